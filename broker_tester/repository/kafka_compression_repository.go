@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+)
+
+var kafkaCompressions = map[domain.CompressionCodec]kafka.Compression{
+	domain.CompressionCodec_None:   0,
+	domain.CompressionCodec_Gzip:   kafka.Gzip,
+	domain.CompressionCodec_Snappy: kafka.Snappy,
+	domain.CompressionCodec_Lz4:    kafka.Lz4,
+	domain.CompressionCodec_Zstd:   kafka.Zstd,
+}
+
+// Client has no DescribeLogDirs API, so its messages are registered for the transport of the client
+func init() {
+	protocol.Register(&describeLogDirsRequest{}, &describeLogDirsResponse{})
+}
+
+type describeLogDirsRequest struct {
+	Topics []describeLogDirsRequestTopic `kafka:"min=v0,max=v1"`
+}
+
+func (r *describeLogDirsRequest) ApiKey() protocol.ApiKey { return protocol.DescribeLogDirs }
+
+type describeLogDirsRequestTopic struct {
+	Topic      string  `kafka:"min=v0,max=v1"`
+	Partitions []int32 `kafka:"min=v0,max=v1"`
+}
+
+type describeLogDirsResponse struct {
+	ThrottleTimeMs int32                           `kafka:"min=v0,max=v1"`
+	Results        []describeLogDirsResponseResult `kafka:"min=v0,max=v1"`
+}
+
+func (r *describeLogDirsResponse) ApiKey() protocol.ApiKey { return protocol.DescribeLogDirs }
+
+type describeLogDirsResponseResult struct {
+	ErrorCode int16                          `kafka:"min=v0,max=v1"`
+	LogDir    string                         `kafka:"min=v0,max=v1"`
+	Topics    []describeLogDirsResponseTopic `kafka:"min=v0,max=v1"`
+}
+
+type describeLogDirsResponseTopic struct {
+	Name       string                             `kafka:"min=v0,max=v1"`
+	Partitions []describeLogDirsResponsePartition `kafka:"min=v0,max=v1"`
+}
+
+type describeLogDirsResponsePartition struct {
+	PartitionIndex int32 `kafka:"min=v0,max=v1"`
+	PartitionSize  int64 `kafka:"min=v0,max=v1"`
+	OffsetLag      int64 `kafka:"min=v0,max=v1"`
+	IsFutureKey    bool  `kafka:"min=v0,max=v1"`
+}
+
+func (r *kafkaBrokerTesterRepository) ProduceCompressed(topic string, messages [][]byte, codec domain.CompressionCodec) error {
+	compression, ok := kafkaCompressions[codec]
+	if !ok {
+		return domain.UNKNOWN_COMPRESSION_CODEC
+	}
+
+	return r.produce(r.getWriter(topic, compression, kafka.RequireAll), messages)
+}
+
+// Partitions logs are described by the broker, which is the only one of the cluster
+func (r *kafkaBrokerTesterRepository) TopicSize(topic string) (int64, error) {
+	conn, err := r.dial()
+	if err != nil {
+		return 0, err
+	}
+	partitions, err := conn.ReadPartitions(topic)
+	conn.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	req := &describeLogDirsRequest{Topics: []describeLogDirsRequestTopic{{Topic: topic}}}
+	for _, p := range partitions {
+		req.Topics[0].Partitions = append(req.Topics[0].Partitions, int32(p.ID))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+	defer cancel()
+
+	msg, err := kafka.DefaultTransport.RoundTrip(ctx, kafka.TCP(r.address), req)
+	if err != nil {
+		return 0, err
+	}
+	res, ok := msg.(*describeLogDirsResponse)
+	if !ok {
+		return 0, domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	var size int64
+	for _, result := range res.Results {
+		if result.ErrorCode != 0 {
+			return 0, kafka.Error(result.ErrorCode)
+		}
+		for _, t := range result.Topics {
+			for _, p := range t.Partitions {
+				// Future logs are the copies of the partitions moved between the log directories
+				if !p.IsFutureKey {
+					size += p.PartitionSize
+				}
+			}
+		}
+	}
+	return size, nil
+}
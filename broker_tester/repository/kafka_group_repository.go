@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/segmentio/kafka-go"
+)
+
+type kafkaGroupMember struct {
+	group      *kafka.ConsumerGroup
+	generation int32
+	// Closed when the member has stopped consuming
+	done chan struct{}
+}
+
+// Partitions are consumed from their committed offsets or from the last ones, so members read only new messages.
+// Offsets are committed when partitions are revoked by the rebalance, so next owners continue from them.
+func (r *kafkaBrokerTesterRepository) JoinGroup(topic string, group string, handler func()) (GroupMember, error) {
+	g, err := kafka.NewConsumerGroup(kafka.ConsumerGroupConfig{
+		ID:          group,
+		Brokers:     []string{r.address},
+		Topics:      []string{topic},
+		StartOffset: kafka.LastOffset,
+		Timeout:     REQUEST_TIMEOUT,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m := new(kafkaGroupMember)
+	m.group = g
+	m.done = make(chan struct{})
+	joined := make(chan struct{})
+	go m.run(r.address, topic, handler, joined)
+
+	select {
+	case <-joined:
+		return m, nil
+	case <-time.After(REQUEST_TIMEOUT):
+		m.Leave()
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+}
+
+func (m *kafkaGroupMember) Generation() int32 {
+	return atomic.LoadInt32(&m.generation)
+}
+
+// Group sends leave request after its generation has been stopped
+func (m *kafkaGroupMember) Leave() error {
+	err := m.group.Close()
+	<-m.done
+	return err
+}
+
+// Method consumes assigned partitions of every generation till the group is closed
+func (m *kafkaGroupMember) run(address string, topic string, handler func(), joined chan<- struct{}) {
+	defer close(m.done)
+
+	for {
+		gen, err := m.group.Next(context.Background())
+		if err == kafka.ErrGroupClosed {
+			return
+		}
+		// Errors of the join are retried by the group
+		if err != nil {
+			continue
+		}
+
+		for _, pa := range gen.Assignments[topic] {
+			pa := pa
+			gen.Start(func(ctx context.Context) { consumeAssignment(ctx, gen, address, topic, pa, handler) })
+		}
+
+		if atomic.SwapInt32(&m.generation, gen.ID) == 0 {
+			close(joined)
+		}
+	}
+}
+
+// Function reads partition till the generation is stopped and commits offset of the next message
+func consumeAssignment(ctx context.Context, gen *kafka.Generation, address string, topic string, pa kafka.PartitionAssignment, handler func()) {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   []string{address},
+		Topic:     topic,
+		Partition: pa.ID,
+		MinBytes:  1,
+		MaxBytes:  KAFKA_MAX_BATCH_BYTES,
+	})
+	defer reader.Close()
+
+	if err := reader.SetOffset(pa.Offset); err != nil {
+		return
+	}
+
+	offset := pa.Offset
+	for {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			break
+		}
+		handler()
+		offset = msg.Offset + 1
+	}
+
+	// Offset is relative one, if nothing was read from the last offset
+	if offset >= 0 {
+		gen.CommitOffsets(map[string]map[int]int64{topic: {pa.ID: offset}})
+	}
+}
@@ -0,0 +1,376 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	REQUEST_TIMEOUT = 60 * time.Second
+	// Produced batch is sent at once, so writer doesn't wait for more messages
+	KAFKA_BATCH_TIMEOUT   = time.Millisecond
+	KAFKA_MAX_BATCH_BYTES = 10 << 20
+)
+
+var kafkaRequiredAcks = map[domain.ProducerAcks]kafka.RequiredAcks{
+	domain.ProducerAcks_None:   kafka.RequireNone,
+	domain.ProducerAcks_Leader: kafka.RequireOne,
+	domain.ProducerAcks_All:    kafka.RequireAll,
+}
+
+type kafkaBrokerTesterRepository struct {
+	address   string
+	batchSize int
+	// Writers of the topics, which keep connections to the partition leaders
+	mu      sync.Mutex
+	writers map[string]*kafka.Writer
+}
+
+// NewKafkaBrokerTesterRepository creates repository of the broker, which is the bootstrap server of the cluster.
+// Batch size is the max count of the produced messages of the single request.
+func NewKafkaBrokerTesterRepository(port uint16, host string, batchSize int) BrokerTesterRepository {
+	return newKafkaBrokerTesterRepository(port, host, batchSize)
+}
+
+func newKafkaBrokerTesterRepository(port uint16, host string, batchSize int) *kafkaBrokerTesterRepository {
+	r := new(kafkaBrokerTesterRepository)
+	r.address = host + ":" + strconv.FormatUint(uint64(port), 10)
+	r.batchSize = batchSize
+	r.writers = make(map[string]*kafka.Writer)
+	return r
+}
+
+// Broker returns cluster metadata only after it has joined the cluster
+func (r *kafkaBrokerTesterRepository) Ping() error {
+	conn, err := r.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Brokers()
+	return err
+}
+
+// Topics are created by the controller of the cluster
+func (r *kafkaBrokerTesterRepository) CreateTopic(name string, partitions int) error {
+	conn, err := r.dialController()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.CreateTopics(kafka.TopicConfig{Topic: name, NumPartitions: partitions, ReplicationFactor: 1})
+}
+
+func (r *kafkaBrokerTesterRepository) DeleteTopic(name string) error {
+	r.mu.Lock()
+	if w, ok := r.writers[name]; ok {
+		w.Close()
+		delete(r.writers, name)
+	}
+	r.mu.Unlock()
+
+	conn, err := r.dialController()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.DeleteTopics(name)
+}
+
+func (r *kafkaBrokerTesterRepository) Produce(topic string, messages [][]byte) error {
+	return r.produce(r.getWriter(topic, 0, kafka.RequireAll), messages)
+}
+
+// Partitions are read concurrently from their first offsets till their last offsets at the call
+func (r *kafkaBrokerTesterRepository) Consume(topic string) (int, error) {
+	conn, err := r.dial()
+	if err != nil {
+		return 0, err
+	}
+	partitions, err := conn.ReadPartitions(topic)
+	conn.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		count    int
+		firstErr error
+	)
+	for _, p := range partitions {
+		wg.Add(1)
+		go func(partition int) {
+			defer wg.Done()
+			n, err := r.consumePartition(topic, partition)
+			mu.Lock()
+			count += n
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			mu.Unlock()
+		}(p.ID)
+	}
+	wg.Wait()
+
+	return count, firstErr
+}
+
+// Partitions are read concurrently from their last offsets at the call, which are resolved before the readers are started
+func (r *kafkaBrokerTesterRepository) Tail(topic string) (<-chan []byte, func(), error) {
+	conn, err := r.dial()
+	if err != nil {
+		return nil, nil, err
+	}
+	partitions, err := conn.ReadPartitions(topic)
+	conn.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	readers := make([]*kafka.Reader, 0, len(partitions))
+	closeReaders := func() {
+		for _, reader := range readers {
+			reader.Close()
+		}
+	}
+	for _, p := range partitions {
+		last, err := r.readLastOffset(topic, p.ID)
+		if err != nil {
+			closeReaders()
+			return nil, nil, err
+		}
+		reader := kafka.NewReader(kafka.ReaderConfig{
+			Brokers:   []string{r.address},
+			Topic:     topic,
+			Partition: p.ID,
+			MinBytes:  1,
+			MaxBytes:  KAFKA_MAX_BATCH_BYTES,
+		})
+		readers = append(readers, reader)
+		if err := reader.SetOffset(last); err != nil {
+			closeReaders()
+			return nil, nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	messages := make(chan []byte)
+	var wg sync.WaitGroup
+	for _, reader := range readers {
+		wg.Add(1)
+		go func(reader *kafka.Reader) {
+			defer wg.Done()
+			for {
+				msg, err := reader.ReadMessage(ctx)
+				if err != nil {
+					return
+				}
+				select {
+				case messages <- msg.Value:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(reader)
+	}
+	go func() {
+		wg.Wait()
+		close(messages)
+	}()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			cancel()
+			wg.Wait()
+			closeReaders()
+		})
+	}
+	return messages, stop, nil
+}
+
+func (r *kafkaBrokerTesterRepository) ProduceWithAcks(topic string, messages [][]byte, acks domain.ProducerAcks) error {
+	requiredAcks, ok := kafkaRequiredAcks[acks]
+	if !ok {
+		return domain.UNKNOWN_PRODUCER_ACKS
+	}
+
+	return r.produce(r.getWriter(topic, 0, requiredAcks), messages)
+}
+
+func (r *kafkaBrokerTesterRepository) Partitions(topic string) (int, error) {
+	conn, err := r.dial()
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions(topic)
+	if err != nil {
+		return 0, err
+	}
+	return len(partitions), nil
+}
+
+// Partitions of all the topics are read from the cluster metadata
+func (r *kafkaBrokerTesterRepository) ListTopics(prefix string) ([]string, error) {
+	conn, err := r.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions()
+	if err != nil {
+		return nil, err
+	}
+
+	var topics []string
+	seen := make(map[string]bool)
+	for _, p := range partitions {
+		if !strings.HasPrefix(p.Topic, prefix) || seen[p.Topic] {
+			continue
+		}
+		seen[p.Topic] = true
+		topics = append(topics, p.Topic)
+	}
+	return topics, nil
+}
+
+func (r *kafkaBrokerTesterRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for name, w := range r.writers {
+		w.Close()
+		delete(r.writers, name)
+	}
+	return nil
+}
+
+func (r *kafkaBrokerTesterRepository) produce(w *kafka.Writer, messages [][]byte) error {
+	msgs := make([]kafka.Message, len(messages))
+	for i, m := range messages {
+		msgs[i] = kafka.Message{Value: m}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+	defer cancel()
+
+	return w.WriteMessages(ctx, msgs...)
+}
+
+func (r *kafkaBrokerTesterRepository) readLastOffset(topic string, partition int) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+	defer cancel()
+
+	conn, err := kafka.DialLeader(ctx, "tcp", r.address, topic, partition)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	return conn.ReadLastOffset()
+}
+
+func (r *kafkaBrokerTesterRepository) consumePartition(topic string, partition int) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+	defer cancel()
+
+	conn, err := kafka.DialLeader(ctx, "tcp", r.address, topic, partition)
+	if err != nil {
+		return 0, err
+	}
+	first, last, err := conn.ReadOffsets()
+	conn.Close()
+	if err != nil {
+		return 0, err
+	}
+	if first >= last {
+		return 0, nil
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   []string{r.address},
+		Topic:     topic,
+		Partition: partition,
+		MinBytes:  1,
+		MaxBytes:  KAFKA_MAX_BATCH_BYTES,
+	})
+	defer reader.Close()
+
+	if err := reader.SetOffset(first); err != nil {
+		return 0, err
+	}
+
+	var count int
+	for {
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			return count, err
+		}
+		count++
+		if msg.Offset >= last-1 {
+			return count, nil
+		}
+	}
+}
+
+// Writer of the topic is created by its first produce, so topic is produced with the single compression and acks
+func (r *kafkaBrokerTesterRepository) getWriter(topic string, compression kafka.Compression, acks kafka.RequiredAcks) *kafka.Writer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.writers[topic]
+	if !ok {
+		w = &kafka.Writer{
+			Addr:         kafka.TCP(r.address),
+			Topic:        topic,
+			Balancer:     &kafka.RoundRobin{},
+			BatchSize:    r.batchSize,
+			BatchBytes:   KAFKA_MAX_BATCH_BYTES,
+			BatchTimeout: KAFKA_BATCH_TIMEOUT,
+			ReadTimeout:  REQUEST_TIMEOUT,
+			WriteTimeout: REQUEST_TIMEOUT,
+			RequiredAcks: acks,
+			Compression:  compression,
+		}
+		r.writers[topic] = w
+	}
+	return w
+}
+
+func (r *kafkaBrokerTesterRepository) dial() (*kafka.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+	defer cancel()
+
+	return kafka.DialContext(ctx, "tcp", r.address)
+}
+
+func (r *kafkaBrokerTesterRepository) dialController() (*kafka.Conn, error) {
+	conn, err := r.dial()
+	if err != nil {
+		return nil, err
+	}
+	controller, err := conn.Controller()
+	conn.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+	defer cancel()
+
+	return kafka.DialContext(ctx, "tcp", controller.Host+":"+strconv.Itoa(controller.Port))
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// Consumer is bound to the stream by its name, so it's deleted with the stream even if unsubscribe fails
+	NATS_CONSUMER_NAME = "cott-consumer"
+	NATS_FETCH_BATCH   = 1000
+	NATS_FETCH_TIMEOUT = 5 * time.Second
+)
+
+// Topic is the JetStream stream of the subject of the same name. Core NATS subjects are published without the streams.
+type natsBrokerTesterRepository struct {
+	url  string
+	opts []nats.Option
+	// Max count of the published messages awaiting acknowledgements
+	maxPending int
+	// Client connects to the server by its creation, so it's created by the first successful ping
+	mu sync.Mutex
+	nc *nats.Conn
+	js nats.JetStreamContext
+}
+
+// NewNatsBrokerTesterRepository creates repository of the server with JetStream enabled. User is empty if authorization is disabled.
+// Max pending is the count of the messages of all the concurrent produce calls, so publishers aren't stalled by each other.
+func NewNatsBrokerTesterRepository(port uint16, host, user, password string, maxPending int) BrokerTesterRepository {
+	r := new(natsBrokerTesterRepository)
+	r.maxPending = maxPending
+	r.url = "nats://" + host + ":" + strconv.FormatUint(uint64(port), 10)
+	r.opts = []nats.Option{nats.Timeout(REQUEST_TIMEOUT)}
+	if user != "" {
+		r.opts = append(r.opts, nats.UserInfo(user, password))
+	}
+	return r
+}
+
+// JetStream account info is requested, because server accepts connections before JetStream is ready
+func (r *natsBrokerTesterRepository) Ping() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nc == nil {
+		nc, err := nats.Connect(r.url, r.opts...)
+		if err != nil {
+			return err
+		}
+		js, err := nc.JetStream(nats.PublishAsyncMaxPending(r.maxPending))
+		if err != nil {
+			nc.Close()
+			return err
+		}
+		r.nc, r.js = nc, js
+	}
+
+	_, err := r.js.AccountInfo()
+	return err
+}
+
+// Stream is persisted to the files and partitions aren't supported
+func (r *natsBrokerTesterRepository) CreateTopic(name string, partitions int) error {
+	_, err := r.js.AddStream(&nats.StreamConfig{Name: name, Subjects: []string{name}, Storage: nats.FileStorage})
+	return err
+}
+
+func (r *natsBrokerTesterRepository) DeleteTopic(name string) error {
+	return r.js.DeleteStream(name)
+}
+
+// Messages are published asynchronously and method awaits acknowledgements of all of them
+func (r *natsBrokerTesterRepository) Produce(topic string, messages [][]byte) error {
+	futures := make([]nats.PubAckFuture, len(messages))
+	for i, m := range messages {
+		f, err := r.js.PublishAsync(topic, m)
+		if err != nil {
+			return err
+		}
+		futures[i] = f
+	}
+
+	timeout := time.After(REQUEST_TIMEOUT)
+	for _, f := range futures {
+		select {
+		case <-f.Ok():
+		case err := <-f.Err():
+			return err
+		case <-timeout:
+			return nats.ErrTimeout
+		}
+	}
+	return nil
+}
+
+// Messages of the stream at the call are fetched by the pull consumer and acknowledged one by one
+func (r *natsBrokerTesterRepository) Consume(topic string) (int, error) {
+	info, err := r.js.StreamInfo(topic)
+	if err != nil {
+		return 0, err
+	}
+	total := int(info.State.Msgs)
+	if total == 0 {
+		return 0, nil
+	}
+
+	sub, err := r.js.PullSubscribe(topic, NATS_CONSUMER_NAME, nats.BindStream(topic), nats.AckExplicit(), nats.DeliverAll())
+	if err != nil {
+		return 0, err
+	}
+	// Consumer created by the subscription is deleted by the unsubscribe
+	defer sub.Unsubscribe()
+
+	var count int
+	for count < total {
+		msgs, err := sub.Fetch(NATS_FETCH_BATCH, nats.MaxWait(NATS_FETCH_TIMEOUT))
+		if err != nil {
+			return count, err
+		}
+		for _, m := range msgs {
+			if err := m.Ack(); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Ephemeral push consumer of the stream delivers messages published after its creation without acknowledgements
+func (r *natsBrokerTesterRepository) Tail(topic string) (<-chan []byte, func(), error) {
+	msgs := make(chan *nats.Msg, NATS_FETCH_BATCH)
+	sub, err := r.js.ChanSubscribe(topic, msgs, nats.BindStream(topic), nats.DeliverNew(), nats.AckNone())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages, stop := forwardNatsMessages(sub, msgs)
+	return messages, stop, nil
+}
+
+func (r *natsBrokerTesterRepository) Subscribe(subject string) (<-chan []byte, func(), error) {
+	msgs := make(chan *nats.Msg, NATS_FETCH_BATCH)
+	sub, err := r.nc.ChanSubscribe(subject, msgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Subscription is registered by the server after the round trip
+	if err := r.nc.FlushTimeout(REQUEST_TIMEOUT); err != nil {
+		sub.Unsubscribe()
+		return nil, nil, err
+	}
+
+	messages, stop := forwardNatsMessages(sub, msgs)
+	return messages, stop, nil
+}
+
+// Function sends data of the subscription messages to the returned channel until stop is called
+func forwardNatsMessages(sub *nats.Subscription, msgs <-chan *nats.Msg) (<-chan []byte, func()) {
+	messages := make(chan []byte)
+	done := make(chan struct{})
+	go func() {
+		defer close(messages)
+		for {
+			select {
+			case m := <-msgs:
+				select {
+				case messages <- m.Data:
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			sub.Unsubscribe()
+			close(done)
+		})
+	}
+	return messages, stop
+}
+
+// Message is flushed, so its latency doesn't include buffering of the client
+func (r *natsBrokerTesterRepository) Publish(subject string, message []byte) error {
+	if err := r.nc.Publish(subject, message); err != nil {
+		return err
+	}
+	return r.nc.Flush()
+}
+
+func (r *natsBrokerTesterRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nc != nil {
+		r.nc.Close()
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Messages are acknowledged by the ranges of the prefetch count, so consumer doesn't wait for the acknowledgement of every message
+const RABBITMQ_PREFETCH_COUNT = 1000
+
+type rabbitMQChannel struct {
+	ch *amqp.Channel
+	// Confirmations of the channel in the confirm mode. Buffer fits the batch, so publisher reads them after the batch is sent.
+	confirms chan amqp.Confirmation
+}
+
+// Topic is the direct exchange with the queue of the same name bound by it
+type rabbitMQBrokerTesterRepository struct {
+	url       string
+	batchSize int
+	mu        sync.Mutex
+	conn      *amqp.Connection
+	// Idle channels of the publishers and consumers, so concurrent callers don't share the channel
+	confirmChannels chan *rabbitMQChannel
+	channels        chan *rabbitMQChannel
+}
+
+// NewRabbitMQBrokerTesterRepository creates repository of the default virtual host. Connection is opened by the first successful ping.
+// Batch size is the max count of the produced messages of the single call, channels are pooled up to poolSize.
+func NewRabbitMQBrokerTesterRepository(port uint16, host, user, password string, batchSize int, poolSize int) BrokerTesterRepository {
+	r := new(rabbitMQBrokerTesterRepository)
+	u := url.URL{Scheme: "amqp", User: url.UserPassword(user, password), Host: host + ":" + strconv.FormatUint(uint64(port), 10), Path: "/"}
+	r.url = u.String()
+	r.batchSize = batchSize
+	r.confirmChannels = make(chan *rabbitMQChannel, poolSize)
+	r.channels = make(chan *rabbitMQChannel, poolSize)
+	return r
+}
+
+func (r *rabbitMQBrokerTesterRepository) Ping() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conn == nil || r.conn.IsClosed() {
+		conn, err := amqp.Dial(r.url)
+		if err != nil {
+			return err
+		}
+		r.conn = conn
+	}
+
+	ch, err := r.conn.Channel()
+	if err != nil {
+		return err
+	}
+	return ch.Close()
+}
+
+// Queue is durable, so published messages are persisted like in the production setups
+func (r *rabbitMQBrokerTesterRepository) CreateTopic(name string, partitions int) error {
+	return r.withChannel(false, func(c *rabbitMQChannel) error {
+		if err := c.ch.ExchangeDeclare(name, amqp.ExchangeDirect, true, false, false, false, nil); err != nil {
+			return err
+		}
+		if _, err := c.ch.QueueDeclare(name, true, false, false, false, nil); err != nil {
+			return err
+		}
+		return c.ch.QueueBind(name, name, name, false, nil)
+	})
+}
+
+func (r *rabbitMQBrokerTesterRepository) DeleteTopic(name string) error {
+	return r.withChannel(false, func(c *rabbitMQChannel) error {
+		if _, err := c.ch.QueueDelete(name, false, false, false); err != nil {
+			return err
+		}
+		return c.ch.ExchangeDelete(name, false, false)
+	})
+}
+
+// Messages are published by the channel in the confirm mode and method awaits confirmations of all of them
+func (r *rabbitMQBrokerTesterRepository) Produce(topic string, messages [][]byte) error {
+	return r.withChannel(true, func(c *rabbitMQChannel) error {
+		if err := r.publish(c, topic, messages); err != nil {
+			return err
+		}
+
+		for range messages {
+			confirm, ok := <-c.confirms
+			if !ok {
+				return domain.CONNECTION_WAS_NOT_ESTABLISHED
+			}
+			if !confirm.Ack {
+				return domain.UNEXPECTED_RESPONSE_STATUS
+			}
+		}
+		return nil
+	})
+}
+
+func (r *rabbitMQBrokerTesterRepository) ProduceUnconfirmed(topic string, messages [][]byte) error {
+	return r.withChannel(false, func(c *rabbitMQChannel) error { return r.publish(c, topic, messages) })
+}
+
+// Messages ready at the call are consumed and acknowledged by the ranges
+func (r *rabbitMQBrokerTesterRepository) Consume(topic string) (int, error) {
+	var count int
+	err := r.withChannel(false, func(c *rabbitMQChannel) error {
+		q, err := c.ch.QueueDeclarePassive(topic, true, false, false, false, nil)
+		if err != nil {
+			return err
+		}
+		if q.Messages == 0 {
+			return nil
+		}
+
+		if err := c.ch.Qos(RABBITMQ_PREFETCH_COUNT, 0, false); err != nil {
+			return err
+		}
+		const consumer = "cott-consumer"
+		deliveries, err := c.ch.Consume(topic, consumer, false, false, false, false, nil)
+		if err != nil {
+			return err
+		}
+		defer c.ch.Cancel(consumer, false)
+
+		for d := range deliveries {
+			count++
+			if count == q.Messages || count%RABBITMQ_PREFETCH_COUNT == 0 {
+				if err := d.Ack(true); err != nil {
+					return err
+				}
+			}
+			if count == q.Messages {
+				return nil
+			}
+		}
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	})
+	return count, err
+}
+
+// Consumer has its own channel, which isn't returned to the pool. Messages are acknowledged by their delivery.
+func (r *rabbitMQBrokerTesterRepository) Tail(topic string) (<-chan []byte, func(), error) {
+	c, err := r.openChannel(false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.ch.Qos(RABBITMQ_PREFETCH_COUNT, 0, false); err != nil {
+		c.ch.Close()
+		return nil, nil, err
+	}
+	// Consumer is registered by the broker before the method returns
+	deliveries, err := c.ch.Consume(topic, "", true, false, false, false, nil)
+	if err != nil {
+		c.ch.Close()
+		return nil, nil, err
+	}
+
+	messages := make(chan []byte)
+	done := make(chan struct{})
+	go func() {
+		defer close(messages)
+		for d := range deliveries {
+			select {
+			case messages <- d.Body:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			close(done)
+			c.ch.Close()
+		})
+	}
+	return messages, stop, nil
+}
+
+func (r *rabbitMQBrokerTesterRepository) Get(topic string) error {
+	return r.withChannel(false, func(c *rabbitMQChannel) error {
+		_, ok, err := c.ch.Get(topic, true)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return domain.DATA_INTEGRITY_VIOLATED
+		}
+		return nil
+	})
+}
+
+func (r *rabbitMQBrokerTesterRepository) Purge(topic string) (int, error) {
+	var count int
+	err := r.withChannel(false, func(c *rabbitMQChannel) error {
+		var err error
+		count, err = c.ch.QueuePurge(topic, false)
+		return err
+	})
+	return count, err
+}
+
+func (r *rabbitMQBrokerTesterRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conn == nil {
+		return nil
+	}
+	// Channels are closed with their connection
+	return r.conn.Close()
+}
+
+func (r *rabbitMQBrokerTesterRepository) publish(c *rabbitMQChannel, topic string, messages [][]byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+	defer cancel()
+
+	for _, m := range messages {
+		if err := c.ch.PublishWithContext(ctx, topic, topic, false, false, amqp.Publishing{DeliveryMode: amqp.Persistent, Body: m}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Method runs function with the idle channel. Channel is closed by the broker on the error, so it isn't reused.
+func (r *rabbitMQBrokerTesterRepository) withChannel(confirm bool, f func(c *rabbitMQChannel) error) error {
+	pool := r.channels
+	if confirm {
+		pool = r.confirmChannels
+	}
+
+	var c *rabbitMQChannel
+	select {
+	case c = <-pool:
+	default:
+		var err error
+		if c, err = r.openChannel(confirm); err != nil {
+			return err
+		}
+	}
+
+	if err := f(c); err != nil {
+		c.ch.Close()
+		return err
+	}
+
+	select {
+	case pool <- c:
+	default:
+		c.ch.Close()
+	}
+	return nil
+}
+
+func (r *rabbitMQBrokerTesterRepository) openChannel(confirm bool) (*rabbitMQChannel, error) {
+	r.mu.Lock()
+	conn := r.conn
+	r.mu.Unlock()
+	if conn == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &rabbitMQChannel{ch: ch}
+	if confirm {
+		if err := ch.Confirm(false); err != nil {
+			ch.Close()
+			return nil, err
+		}
+		c.confirms = ch.NotifyPublish(make(chan amqp.Confirmation, r.batchSize))
+	}
+	return c, nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+const (
+	// Consumer group of the stream, which is created with the stream
+	REDIS_STREAMS_GROUP    = "cott-group"
+	REDIS_STREAMS_CONSUMER = "cott-consumer"
+	REDIS_STREAMS_FIELD    = "value"
+	// Messages count of the single XREADGROUP command draining the group
+	REDIS_STREAMS_READ_COUNT = 1000
+	// Blocking XREAD of the tail is repeated, so stop is noticed in time
+	REDIS_STREAMS_TAIL_BLOCK = 100 * time.Millisecond
+)
+
+// Topic is the stream with the consumer group, which reads messages added after the topic creation
+type redisStreamsBrokerTesterRepository struct {
+	client *redis.Client
+}
+
+// NewRedisStreamsBrokerTesterRepository creates repository of the database number of the server. Password is empty if authentication is disabled.
+func NewRedisStreamsBrokerTesterRepository(port uint16, host, password string, db int, poolSize int) BrokerTesterRepository {
+	r := new(redisStreamsBrokerTesterRepository)
+	r.client = redis.NewClient(&redis.Options{
+		Addr:         host + ":" + strconv.FormatUint(uint64(port), 10),
+		Password:     password,
+		DB:           db,
+		PoolSize:     poolSize,
+		ReadTimeout:  REQUEST_TIMEOUT,
+		WriteTimeout: REQUEST_TIMEOUT,
+	})
+	return r
+}
+
+func (r *redisStreamsBrokerTesterRepository) Ping() error {
+	return r.client.Ping(context.Background()).Err()
+}
+
+// Streams have no partitions
+func (r *redisStreamsBrokerTesterRepository) CreateTopic(name string, partitions int) error {
+	return r.client.XGroupCreateMkStream(context.Background(), name, REDIS_STREAMS_GROUP, "$").Err()
+}
+
+// Consumer group is deleted with the stream
+func (r *redisStreamsBrokerTesterRepository) DeleteTopic(name string) error {
+	return r.client.Del(context.Background(), name).Err()
+}
+
+// XADD commands of the messages are pipelined
+func (r *redisStreamsBrokerTesterRepository) Produce(topic string, messages [][]byte) error {
+	ctx := context.Background()
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, m := range messages {
+			pipe.XAdd(ctx, &redis.XAddArgs{Stream: topic, Values: []interface{}{REDIS_STREAMS_FIELD, m}})
+		}
+		return nil
+	})
+	return err
+}
+
+// Consumer group reads and acknowledges new messages till there are none, so its lag is drained
+func (r *redisStreamsBrokerTesterRepository) Consume(topic string) (int, error) {
+	var count int
+	for {
+		n, err := r.readGroup(topic, REDIS_STREAMS_READ_COUNT)
+		if err != nil {
+			return count, err
+		}
+		if n == 0 {
+			return count, nil
+		}
+		count += n
+	}
+}
+
+func (r *redisStreamsBrokerTesterRepository) ReadGroup(topic string) error {
+	n, err := r.readGroup(topic, 1)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.DATA_INTEGRITY_VIOLATED
+	}
+	return nil
+}
+
+// Messages are read by XREAD after the last ID at the call, because "$" ID is resolved by every blocking read and messages
+// added between the reads would be missed
+func (r *redisStreamsBrokerTesterRepository) Tail(topic string) (<-chan []byte, func(), error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	lastID := "0-0"
+	last, err := r.client.XRevRangeN(ctx, topic, "+", "-", 1).Result()
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	if len(last) != 0 {
+		lastID = last[0].ID
+	}
+
+	messages := make(chan []byte)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(messages)
+		for ctx.Err() == nil {
+			streams, err := r.client.XRead(ctx, &redis.XReadArgs{
+				Streams: []string{topic, lastID},
+				Count:   REDIS_STREAMS_READ_COUNT,
+				Block:   REDIS_STREAMS_TAIL_BLOCK,
+			}).Result()
+			if err == redis.Nil {
+				continue
+			}
+			if err != nil {
+				return
+			}
+
+			for _, stream := range streams {
+				for _, m := range stream.Messages {
+					lastID = m.ID
+					value, _ := m.Values[REDIS_STREAMS_FIELD].(string)
+					select {
+					case messages <- []byte(value):
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			cancel()
+			wg.Wait()
+		})
+	}
+	return messages, stop, nil
+}
+
+// Streams are scanned by their key prefix, so the server isn't blocked by KEYS
+func (r *redisStreamsBrokerTesterRepository) ListTopics(prefix string) ([]string, error) {
+	var topics []string
+	iter := r.client.ScanType(context.Background(), 0, prefix+"*", 0, "stream").Iterator()
+	for iter.Next(context.Background()) {
+		topics = append(topics, iter.Val())
+	}
+	return topics, iter.Err()
+}
+
+func (r *redisStreamsBrokerTesterRepository) Close() error {
+	return r.client.Close()
+}
+
+// Method reads up to count new messages without blocking and acknowledges them. Returns count of the read messages.
+func (r *redisStreamsBrokerTesterRepository) readGroup(topic string, count int) (int, error) {
+	ctx := context.Background()
+	streams, err := r.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    REDIS_STREAMS_GROUP,
+		Consumer: REDIS_STREAMS_CONSUMER,
+		Streams:  []string{topic, ">"},
+		Count:    int64(count),
+		Block:    -1,
+	}).Result()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []string
+	for _, stream := range streams {
+		for _, m := range stream.Messages {
+			ids = append(ids, m.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if err := r.client.XAck(ctx, topic, REDIS_STREAMS_GROUP, ids...).Err(); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
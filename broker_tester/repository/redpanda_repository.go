@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+const REDPANDA_POLL_INTERVAL = 10 * time.Millisecond
+
+// Repository talks Kafka protocol for the messages and topics, but topic is ready only when raft groups of its partitions
+// have elected their leaders, which is reported by the admin API
+type redpandaBrokerTesterRepository struct {
+	*kafkaBrokerTesterRepository
+	adminURL string
+	client   *http.Client
+}
+
+type redpandaPartition struct {
+	LeaderID int `json:"leader_id"`
+}
+
+// NewRedpandaBrokerTesterRepository creates repository of the broker with the admin API of the port
+func NewRedpandaBrokerTesterRepository(port uint16, adminPort uint16, host string, batchSize int) BrokerTesterRepository {
+	r := new(redpandaBrokerTesterRepository)
+	r.kafkaBrokerTesterRepository = newKafkaBrokerTesterRepository(port, host, batchSize)
+	r.adminURL = "http://" + host + ":" + strconv.FormatUint(uint64(adminPort), 10)
+	r.client = &http.Client{Timeout: REQUEST_TIMEOUT}
+	return r
+}
+
+// Broker is ready when it has joined the cluster and its Kafka API is served
+func (r *redpandaBrokerTesterRepository) Ping() error {
+	var status struct {
+		Status string `json:"status"`
+	}
+	if err := r.getAdmin("/v1/status/ready", &status); err != nil {
+		return err
+	}
+	if status.Status != "ready" {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.kafkaBrokerTesterRepository.Ping()
+}
+
+// Topic is created by the Kafka API, then method awaits leaders of all its partitions
+func (r *redpandaBrokerTesterRepository) CreateTopic(name string, partitions int) error {
+	if err := r.kafkaBrokerTesterRepository.CreateTopic(name, partitions); err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	for p := 0; p < partitions; p++ {
+		for {
+			var partition redpandaPartition
+			err := r.getAdmin("/v1/partitions/kafka/"+name+"/"+strconv.Itoa(p), &partition)
+			if err == nil && partition.LeaderID >= 0 {
+				break
+			}
+			if time.Since(startTime) > REQUEST_TIMEOUT {
+				if err != nil {
+					return err
+				}
+				return domain.CONNECTION_WAS_NOT_ESTABLISHED
+			}
+			time.Sleep(REDPANDA_POLL_INTERVAL)
+		}
+	}
+
+	return nil
+}
+
+func (r *redpandaBrokerTesterRepository) getAdmin(path string, result interface{}) error {
+	resp, err := r.client.Get(r.adminURL + path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import "github.com/iakrevetkho/components-tests/cott/domain"
+
+type BrokerTesterRepository interface {
+	Ping() error
+	CreateTopic(name string, partitions int) error
+	DeleteTopic(name string) error
+	// Produce sends messages to the topic by the single batch and awaits them acknowledged
+	Produce(topic string, messages [][]byte) error
+	// Consume reads all the messages of the topic from its beginning and returns their count
+	Consume(topic string) (int, error)
+	// Tail sends new messages of the topic to the channel until stop is called.
+	// Method returns after the consumer is positioned at the end of the topic, so the next messages aren't missed.
+	Tail(topic string) (messages <-chan []byte, stop func(), err error)
+	Close() error
+}
+
+// PartitionedBrokerTesterRepository is implemented by the brokers spreading messages of the topics over their partitions
+type PartitionedBrokerTesterRepository interface {
+	// Partitions returns partitions count of the topic
+	Partitions(topic string) (int, error)
+}
+
+// ListedBrokerTesterRepository is implemented by the brokers which topics could be listed
+type ListedBrokerTesterRepository interface {
+	// ListTopics returns names of the topics with the prefix
+	ListTopics(prefix string) ([]string, error)
+}
+
+// CompressedBrokerTesterRepository is implemented by the brokers storing batches compressed by the producers
+type CompressedBrokerTesterRepository interface {
+	// ProduceCompressed sends messages to the topic by the single batch compressed by the codec and awaits them acknowledged
+	ProduceCompressed(topic string, messages [][]byte, codec domain.CompressionCodec) error
+	// TopicSize returns bytes of the topic stored by the broker
+	TopicSize(topic string) (int64, error)
+}
+
+// AcknowledgedBrokerTesterRepository is implemented by the brokers which producers choose acknowledgements they await
+type AcknowledgedBrokerTesterRepository interface {
+	// ProduceWithAcks sends messages to the topic by the single batch and awaits the acknowledgements of the mode
+	ProduceWithAcks(topic string, messages [][]byte, acks domain.ProducerAcks) error
+}
+
+// QueueBrokerTesterRepository is implemented by the brokers of the queues, which messages are removed by their consumption
+type QueueBrokerTesterRepository interface {
+	// ProduceUnconfirmed sends messages to the topic without awaiting them acknowledged by the broker
+	ProduceUnconfirmed(topic string, messages [][]byte) error
+	// Get reads the single message of the topic by the request
+	Get(topic string) error
+	// Purge removes all the messages of the topic and returns their count
+	Purge(topic string) (int, error)
+}
+
+// PubSubBrokerTesterRepository is implemented by the brokers delivering messages to the current subscribers without persisting them
+type PubSubBrokerTesterRepository interface {
+	// Subscribe sends messages of the subject to the channel until stop is called.
+	// Method returns after the subscription is registered by the broker, so the next messages aren't missed.
+	Subscribe(subject string) (messages <-chan []byte, stop func(), err error)
+	Publish(subject string, message []byte) error
+}
+
+// StreamBrokerTesterRepository is implemented by the brokers of the append only logs read by the consumer groups
+type StreamBrokerTesterRepository interface {
+	// ReadGroup reads the single new message of the topic by the consumer group and acknowledges it
+	ReadGroup(topic string) error
+}
+
+// GroupBrokerTesterRepository is implemented by the brokers assigning partitions of the topics to the members of the consumer groups
+type GroupBrokerTesterRepository interface {
+	// JoinGroup adds member consuming new messages of the topic and calling handler for every message.
+	// Method returns after the member has got its partitions by the rebalance.
+	JoinGroup(topic string, group string, handler func()) (GroupMember, error)
+}
+
+type GroupMember interface {
+	// Generation returns id of the last generation of the group joined by the member
+	Generation() int32
+	// Leave removes member from the group, so the rest members are rebalanced
+	Leave() error
+}
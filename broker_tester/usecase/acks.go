@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/iakrevetkho/components-tests/cott/broker_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// Messages count produced with every acks mode
+const ACKS_MESSAGES_COUNT = 100000
+
+// Method produces messages with every acks mode by their own topics and consumes them back.
+// Latency is the cost of the awaited acknowledgements, lost messages are the produced ones missing in the topic.
+func (bruc *brokerTesterUsecase) testAcks(mcuc metrics_collector.MetricsCollectorUsecase, r repository.BrokerTesterRepository, ar repository.AcknowledgedBrokerTesterRepository, concurrency int) error {
+	countPrefix := strconv.FormatInt(ACKS_MESSAGES_COUNT, 10) + "x"
+
+	for _, acks := range domain.ProducerAcksModes {
+		acks := acks
+		testPrefix := "Acks" + strings.ToUpper(string(acks[:1])) + string(acks[1:])
+		topic := TOPIC_PREFIX + "acks-" + string(acks)
+
+		// Topic could be left by the failed run
+		if err := r.DeleteTopic(topic); err != nil {
+			logrus.WithError(err).Debug("couldn't delete topic")
+		}
+
+		step := &domain.TestCaseStep{Name: "create" + testPrefix + "Topic", StepFunc: func() error { return r.CreateTopic(topic, TOPIC_PARTITIONS) }}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+
+		step = bruc.createProduceStep(countPrefix+testPrefix+"Produce", ACKS_MESSAGES_COUNT, concurrency, func(messages [][]byte) error {
+			return ar.ProduceWithAcks(topic, messages, acks)
+		})
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).WithField("acks", acks).Warn("produce failed")
+		} else {
+			var lost int
+			step = &domain.TestCaseStep{Name: countPrefix + testPrefix + "Consume", StepFunc: func() error {
+				count, err := r.Consume(topic)
+				if err != nil {
+					return err
+				}
+				lost = ACKS_MESSAGES_COUNT - count
+				return nil
+			}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+				tcsra.AddMetric(domain.MetricMeta_LostMessages, float64(lost))
+			}}
+			if err := mcuc.CollectStepMetrics(step); err != nil {
+				logrus.WithError(err).WithField("acks", acks).Warn("consume failed")
+			}
+		}
+
+		step = &domain.TestCaseStep{Name: "delete" + testPrefix + "Topic", StepFunc: func() error { return r.DeleteTopic(topic) }}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
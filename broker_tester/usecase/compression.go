@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/iakrevetkho/components-tests/cott/broker_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Batches of BATCH_SIZE messages produced with every codec
+	COMPRESSION_BATCHES = 100
+	// Topics have the single partition, so every produced batch is stored by the broker as the single record batch
+	COMPRESSION_TOPIC_PARTITIONS = 1
+)
+
+// Method produces the same batches with every codec by their own topics. Throughput is messages per second, transfer rate is
+// uncompressed payload bytes per second and stored batch size is the topic size per produced batch.
+func (bruc *brokerTesterUsecase) testCompression(mcuc metrics_collector.MetricsCollectorUsecase, r repository.BrokerTesterRepository, cr repository.CompressedBrokerTesterRepository, concurrency int) error {
+	batch := generateCompressibleMessages(BATCH_SIZE)
+	var batchBytes int
+	for _, m := range batch {
+		batchBytes += len(m)
+	}
+	messagesCount := COMPRESSION_BATCHES * BATCH_SIZE
+
+	for _, codec := range domain.CompressionCodecs {
+		codec := codec
+		codecName := strings.ToUpper(string(codec[:1])) + string(codec[1:])
+		topic := TOPIC_PREFIX + "compression-" + string(codec)
+
+		// Topic could be left by the failed run
+		if err := r.DeleteTopic(topic); err != nil {
+			logrus.WithError(err).Debug("couldn't delete topic")
+		}
+
+		step := &domain.TestCaseStep{Name: "create" + codecName + "CompressionTopic", StepFunc: func() error { return r.CreateTopic(topic, COMPRESSION_TOPIC_PARTITIONS) }}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+
+		var (
+			lr          *helpers.LoadResult
+			storedBytes int64
+		)
+		step = &domain.TestCaseStep{Name: strconv.FormatInt(int64(messagesCount), 10) + "x" + codecName + "CompressedProduce", StepFunc: func() error {
+			lr = helpers.RunLoad(COMPRESSION_BATCHES, concurrency, func() error { return cr.ProduceCompressed(topic, batch, codec) })
+			if lr.Errors != 0 {
+				return domain.UNEXPECTED_RESPONSE_STATUS
+			}
+
+			size, err := cr.TopicSize(topic)
+			if err != nil {
+				return err
+			}
+			storedBytes = size
+			return nil
+		}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+			tcsra.AddLatencyMetrics(lr.Latencies)
+			tcsra.AddMetric(domain.MetricMeta_Throughput, float64(messagesCount)/lr.Duration.Seconds())
+			tcsra.AddMetric(domain.MetricMeta_TransferRate, float64(COMPRESSION_BATCHES*batchBytes)/lr.Duration.Seconds())
+			tcsra.AddMetric(domain.MetricMeta_StoredBatchSize, float64(storedBytes)/COMPRESSION_BATCHES)
+		}}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).WithField("codec", codec).Warn("compressed produce failed")
+		}
+
+		step = &domain.TestCaseStep{Name: "delete" + codecName + "CompressionTopic", StepFunc: func() error { return r.DeleteTopic(topic) }}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Messages are JSON documents of the same fields with the random values, so they are compressed like the real events
+func generateCompressibleMessages(count int) [][]byte {
+	messages := make([][]byte, count)
+	for i := range messages {
+		messages[i] = []byte(`{"id":` + strconv.Itoa(i) + `,"type":"cott-event","source":"components-tests","value":` + strconv.FormatInt(rand.Int63(), 10) +
+			`,"score":` + strconv.FormatFloat(rand.Float64(), 'f', 6, 64) + `}`)
+	}
+	return messages
+}
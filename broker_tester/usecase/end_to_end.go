@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/broker_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	END_TO_END_TOPIC          = TOPIC_PREFIX + "end-to-end"
+	END_TO_END_MESSAGES_COUNT = 1000
+	// Message starts with the produce timestamp in nanoseconds followed by the sequence number
+	END_TO_END_HEADER_SIZE = 12
+)
+
+// Method produces timestamped messages one by one while the tail consumer reads them. Latency is the time from the produce call
+// till the consumption of the message, so it includes the acknowledgement, replication and the delivery to the consumer.
+// Timestamps are taken by the same clock, so producer and consumer don't need the synchronized ones.
+func (bruc *brokerTesterUsecase) testEndToEnd(mcuc metrics_collector.MetricsCollectorUsecase, r repository.BrokerTesterRepository) error {
+	// Topic could be left by the failed run
+	if err := r.DeleteTopic(END_TO_END_TOPIC); err != nil {
+		logrus.WithError(err).Debug("couldn't delete topic")
+	}
+
+	step := &domain.TestCaseStep{Name: "createEndToEndTopic", StepFunc: func() error { return r.CreateTopic(END_TO_END_TOPIC, TOPIC_PARTITIONS) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	var (
+		latencies []float64
+		lost      int
+	)
+	step = &domain.TestCaseStep{Name: "endToEndLatency", StepFunc: func() error {
+		messages, stop, err := r.Tail(END_TO_END_TOPIC)
+		if err != nil {
+			return err
+		}
+		defer stop()
+
+		produced := make(chan struct{})
+		go func() {
+			defer close(produced)
+			for i := 0; i < END_TO_END_MESSAGES_COUNT; i++ {
+				// Message isn't reused, because it could be sent asynchronously after the produce call
+				message := make([]byte, MESSAGE_SIZE)
+				binary.BigEndian.PutUint32(message[8:], uint32(i))
+				binary.BigEndian.PutUint64(message, uint64(time.Now().UnixNano()))
+				if err := r.Produce(END_TO_END_TOPIC, [][]byte{message}); err != nil {
+					logrus.WithError(err).Debug("couldn't produce message")
+				}
+			}
+		}()
+		defer func() { <-produced }()
+
+		received := make(map[uint32]bool, END_TO_END_MESSAGES_COUNT)
+		for len(received) < END_TO_END_MESSAGES_COUNT {
+			select {
+			case m, ok := <-messages:
+				if !ok {
+					return domain.CONNECTION_WAS_NOT_ESTABLISHED
+				}
+				if len(m) < END_TO_END_HEADER_SIZE {
+					return domain.DATA_INTEGRITY_VIOLATED
+				}
+				producedAt := time.Unix(0, int64(binary.BigEndian.Uint64(m)))
+				latencies = append(latencies, float64(time.Since(producedAt).Microseconds()))
+				received[binary.BigEndian.Uint32(m[8:])] = true
+			case <-time.After(PUBSUB_TIMEOUT):
+				lost = END_TO_END_MESSAGES_COUNT - len(received)
+				if len(received) == 0 {
+					return domain.MESSAGE_DELIVERY_TIMEOUT
+				}
+				return nil
+			}
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(latencies)
+		tcsra.AddMetric(domain.MetricMeta_LostMessages, float64(lost))
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("end-to-end latency failed")
+	}
+
+	step = &domain.TestCaseStep{Name: "deleteEndToEndTopic", StepFunc: func() error { return r.DeleteTopic(END_TO_END_TOPIC) }}
+	return mcuc.CollectStepMetrics(step)
+}
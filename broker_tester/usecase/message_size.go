@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/broker_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Payload bytes of the topics of the message size sweep, so large messages don't exhaust memory of the broker and its clients
+	MESSAGE_SIZE_SWEEP_BYTES          = 50 * 1000 * 1000
+	MESSAGE_SIZE_SWEEP_MESSAGES_COUNT = 10000
+	// Payload bytes of the single produce request, so batches of large messages stay under the max request size of the brokers
+	MESSAGE_SIZE_SWEEP_BATCH_BYTES = 1000 * 1000
+)
+
+// Method creates topic, produces and consumes messages of the size by it and deletes the topic.
+// Messages count is limited by the sweep bytes, so latency and transfer rate are measured by the same payload volume for the large messages.
+func (bruc *brokerTesterUsecase) testMessageSize(mcuc metrics_collector.MetricsCollectorUsecase, r repository.BrokerTesterRepository, concurrency int, messageSize int) error {
+	testPrefix := strconv.FormatInt(int64(messageSize), 10) + "BMessage"
+	topic := TOPIC_PREFIX + "size-" + strconv.FormatInt(int64(messageSize), 10)
+
+	messagesCount := MESSAGE_SIZE_SWEEP_BYTES / messageSize
+	if messagesCount > MESSAGE_SIZE_SWEEP_MESSAGES_COUNT {
+		messagesCount = MESSAGE_SIZE_SWEEP_MESSAGES_COUNT
+	}
+	if messagesCount == 0 {
+		messagesCount = 1
+	}
+	batchSize := MESSAGE_SIZE_SWEEP_BATCH_BYTES / messageSize
+	if batchSize > BATCH_SIZE {
+		batchSize = BATCH_SIZE
+	}
+	if batchSize == 0 {
+		batchSize = 1
+	}
+
+	// Topic could be left by the failed run
+	if err := r.DeleteTopic(topic); err != nil {
+		logrus.WithError(err).Debug("couldn't delete topic")
+	}
+
+	step := &domain.TestCaseStep{Name: "create" + testPrefix + "Topic", StepFunc: func() error { return r.CreateTopic(topic, TOPIC_PARTITIONS) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = bruc.createSizedProduceStep(testPrefix+"Produce", messagesCount, messageSize, batchSize, concurrency, func(messages [][]byte) error { return r.Produce(topic, messages) })
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		r.DeleteTopic(topic)
+		return err
+	}
+
+	step = bruc.createConsumeStep(testPrefix+"Consume", messagesCount, messageSize, func() (int, error) { return r.Consume(topic) })
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		r.DeleteTopic(topic)
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: "delete" + testPrefix + "Topic", StepFunc: func() error { return r.DeleteTopic(topic) }}
+	return mcuc.CollectStepMetrics(step)
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/broker_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// Messages count of the topics of the partitions sweep
+const PARTITIONS_SWEEP_MESSAGES_COUNT = 100000
+
+// Method creates topic of the partitions count, produces and consumes the same messages count by it and deletes the topic.
+// Steps are named by the partitions count like the topic steps are named by the messages count.
+func (bruc *brokerTesterUsecase) testPartitions(mcuc metrics_collector.MetricsCollectorUsecase, r repository.BrokerTesterRepository, pr repository.PartitionedBrokerTesterRepository, concurrency int, partitionsCount int) error {
+	testPrefix := strconv.FormatInt(int64(partitionsCount), 10) + "xPartitions"
+	topic := TOPIC_PREFIX + "partitions-" + strconv.FormatInt(int64(partitionsCount), 10)
+
+	// Topic could be left by the failed run
+	if err := r.DeleteTopic(topic); err != nil {
+		logrus.WithError(err).Debug("couldn't delete topic")
+	}
+
+	step := &domain.TestCaseStep{Name: "create" + testPrefix + "Topic", StepFunc: func() error {
+		if err := r.CreateTopic(topic, partitionsCount); err != nil {
+			return err
+		}
+		count, err := pr.Partitions(topic)
+		if err != nil {
+			return err
+		}
+		if count != partitionsCount {
+			return domain.DATA_INTEGRITY_VIOLATED
+		}
+		return nil
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = bruc.createProduceStep(testPrefix+"Produce", PARTITIONS_SWEEP_MESSAGES_COUNT, concurrency, func(messages [][]byte) error { return r.Produce(topic, messages) })
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		r.DeleteTopic(topic)
+		return err
+	}
+
+	step = bruc.createConsumeStep(testPrefix+"Consume", PARTITIONS_SWEEP_MESSAGES_COUNT, MESSAGE_SIZE, func() (int, error) { return r.Consume(topic) })
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		r.DeleteTopic(topic)
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: "delete" + testPrefix + "Topic", StepFunc: func() error { return r.DeleteTopic(topic) }}
+	return mcuc.CollectStepMetrics(step)
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/broker_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	REBALANCE_TOPIC = TOPIC_PREFIX + "rebalance"
+	GROUP_NAME      = "cott-group"
+	// Every consumer gets its partition, so each join and leave moves partitions between the consumers
+	GROUP_CONSUMERS = TOPIC_PARTITIONS
+	// Messages are produced one by one in the background, so consumption pause of the rebalance is seen by the gaps between them
+	REBALANCE_PRODUCE_INTERVAL = 10 * time.Millisecond
+	// Consumption is observed after the rebalance, so the pause of the partitions resumed by the last consumer is caught
+	REBALANCE_SETTLE_TIME = 5 * time.Second
+	REBALANCE_TIMEOUT     = time.Minute
+)
+
+// Consumption tracker keeps the longest gap between the consumed messages of the group
+type consumptionTracker struct {
+	mu           sync.Mutex
+	lastConsumed time.Time
+	maxPause     time.Duration
+}
+
+func (ct *consumptionTracker) consumed() {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	now := time.Now()
+	if pause := now.Sub(ct.lastConsumed); pause > ct.maxPause {
+		ct.maxPause = pause
+	}
+	ct.lastConsumed = now
+}
+
+func (ct *consumptionTracker) reset() {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	ct.lastConsumed = time.Now()
+	ct.maxPause = 0
+}
+
+// Method returns the longest pause since the reset including the current one, if consumption hasn't been resumed
+func (ct *consumptionTracker) pause() time.Duration {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if pause := time.Since(ct.lastConsumed); pause > ct.maxPause {
+		return pause
+	}
+	return ct.maxPause
+}
+
+// Method joins consumers to the group one by one and then removes them, while messages are produced in the background.
+// Rebalance time is the time till all the members have got the new generation. Consumption pause is the longest gap between
+// the consumed messages of the group from the start of the step till the end of the settle time.
+func (bruc *brokerTesterUsecase) testRebalance(mcuc metrics_collector.MetricsCollectorUsecase, r repository.BrokerTesterRepository, gr repository.GroupBrokerTesterRepository) error {
+	// Topic could be left by the failed run
+	if err := r.DeleteTopic(REBALANCE_TOPIC); err != nil {
+		logrus.WithError(err).Debug("couldn't delete topic")
+	}
+
+	step := &domain.TestCaseStep{Name: "createRebalanceTopic", StepFunc: func() error { return r.CreateTopic(REBALANCE_TOPIC, TOPIC_PARTITIONS) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	tracker := new(consumptionTracker)
+	first, err := gr.JoinGroup(REBALANCE_TOPIC, GROUP_NAME, tracker.consumed)
+	if err != nil {
+		r.DeleteTopic(REBALANCE_TOPIC)
+		return err
+	}
+	members := []repository.GroupMember{first}
+
+	stopProducer := make(chan struct{})
+	producerStopped := make(chan struct{})
+	go func() {
+		defer close(producerStopped)
+
+		message := make([]byte, MESSAGE_SIZE)
+		rand.Read(message)
+		ticker := time.NewTicker(REBALANCE_PRODUCE_INTERVAL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := r.Produce(REBALANCE_TOPIC, [][]byte{message}); err != nil {
+					logrus.WithError(err).Debug("couldn't produce message")
+				}
+			case <-stopProducer:
+				return
+			}
+		}
+	}()
+
+	for len(members) < GROUP_CONSUMERS {
+		var member repository.GroupMember
+		step = bruc.createRebalanceStep("join"+strconv.Itoa(len(members)+1)+"xGroup", tracker, func() error {
+			m, err := gr.JoinGroup(REBALANCE_TOPIC, GROUP_NAME, tracker.consumed)
+			if err != nil {
+				return err
+			}
+			member = m
+			return bruc.awaitGeneration(append(members, m), m.Generation())
+		})
+		err := mcuc.CollectStepMetrics(step)
+		if member != nil {
+			members = append(members, member)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	for len(members) > 1 {
+		left := members[len(members)-1]
+		members = members[:len(members)-1]
+		step = bruc.createRebalanceStep("leave"+strconv.Itoa(len(members)+1)+"xGroup", tracker, func() error {
+			generation := left.Generation()
+			if err := left.Leave(); err != nil {
+				return err
+			}
+			return bruc.awaitGeneration(members, generation+1)
+		})
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).Warn("rebalance failed")
+		}
+	}
+
+	close(stopProducer)
+	<-producerStopped
+	for _, m := range members {
+		m.Leave()
+	}
+
+	step = &domain.TestCaseStep{Name: "deleteRebalanceTopic", StepFunc: func() error { return r.DeleteTopic(REBALANCE_TOPIC) }}
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Method creates step running rebalance and observing consumption for the settle time after it
+func (bruc *brokerTesterUsecase) createRebalanceStep(name string, tracker *consumptionTracker, rebalance func() error) *domain.TestCaseStep {
+	var rebalanceTime, pause time.Duration
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		tracker.reset()
+		startTime := time.Now()
+		if err := rebalance(); err != nil {
+			return err
+		}
+		rebalanceTime = time.Since(startTime)
+
+		time.Sleep(REBALANCE_SETTLE_TIME)
+		pause = tracker.pause()
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_RebalanceTime, float64(rebalanceTime.Microseconds()))
+		tcsra.AddMetric(domain.MetricMeta_ConsumptionPause, float64(pause.Microseconds()))
+	}}
+}
+
+// Method awaits all the members have joined the generation or the later one
+func (bruc *brokerTesterUsecase) awaitGeneration(members []repository.GroupMember, generation int32) error {
+	startTime := time.Now()
+	for time.Since(startTime) < REBALANCE_TIMEOUT {
+		rebalanced := true
+		for _, m := range members {
+			if m.Generation() < generation {
+				rebalanced = false
+				break
+			}
+		}
+		if rebalanced {
+			return nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return domain.REBALANCE_TIMEOUT
+}
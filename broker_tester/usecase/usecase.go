@@ -0,0 +1,376 @@
+package usecase
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/broker_tester/repository"
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TOPIC_PREFIX     = "cott-topic-"
+	TOPIC_PARTITIONS = 4
+	MESSAGE_SIZE     = 100
+	// Messages count of the single produce request
+	BATCH_SIZE = 1000
+	// Messages of the queues got one by one
+	GET_REQUESTS          = 1000
+	PUBSUB_SUBJECT        = "cott-pubsub"
+	PUBSUB_MESSAGES_COUNT = 1000
+	PUBSUB_TIMEOUT        = 10 * time.Second
+)
+
+// Messages counts of the produced batches
+var messagesCounts = []int{1, 100, 10000, 1000000}
+
+type BrokerTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type brokerTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewBrokerTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) BrokerTesterUsecase {
+	bruc := new(brokerTesterUsecase)
+	bruc.cluc = cluc
+	return bruc
+}
+
+func (bruc *brokerTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	r, err := bruc.createBrokerRepository(tcra.TestCase)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, bruc.cluc, containerId)
+
+	// Await for broker ready
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return helpers.AwaitConnection(r.Ping, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	if pr, ok := r.(repository.PubSubBrokerTesterRepository); ok {
+		step = bruc.createPubSubStep(pr)
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).Warn("pub/sub failed")
+		}
+	}
+
+	if err := bruc.testEndToEnd(mcuc, r); err != nil {
+		logrus.WithError(err).Warn("end-to-end latency failed")
+	}
+
+	if gr, ok := r.(repository.GroupBrokerTesterRepository); ok {
+		if err := bruc.testRebalance(mcuc, r, gr); err != nil {
+			logrus.WithError(err).Warn("rebalance failed")
+		}
+	}
+
+	for _, messagesCount := range messagesCounts {
+		if err := bruc.testTopic(mcuc, r, int(tcra.TestCase.GetConcurrency()), messagesCount); err != nil {
+			break
+		}
+	}
+
+	for _, messageSize := range tcra.TestCase.GetMessageSizes() {
+		if err := bruc.testMessageSize(mcuc, r, int(tcra.TestCase.GetConcurrency()), int(messageSize)); err != nil {
+			break
+		}
+	}
+
+	if pr, ok := r.(repository.PartitionedBrokerTesterRepository); ok {
+		for _, partitionsCount := range tcra.TestCase.GetPartitionsCounts() {
+			if err := bruc.testPartitions(mcuc, r, pr, int(tcra.TestCase.GetConcurrency()), int(partitionsCount)); err != nil {
+				break
+			}
+		}
+	}
+
+	if ar, ok := r.(repository.AcknowledgedBrokerTesterRepository); ok {
+		if err := bruc.testAcks(mcuc, r, ar, int(tcra.TestCase.GetConcurrency())); err != nil {
+			logrus.WithError(err).Warn("acks comparison failed")
+		}
+	}
+
+	if cr, ok := r.(repository.CompressedBrokerTesterRepository); ok {
+		if err := bruc.testCompression(mcuc, r, cr, int(tcra.TestCase.GetConcurrency())); err != nil {
+			logrus.WithError(err).Warn("compression failed")
+		}
+	}
+
+	if lr, ok := r.(repository.ListedBrokerTesterRepository); ok {
+		bruc.checkTopicsLeftovers(tcra, lr)
+	}
+
+	return nil
+}
+
+// Method reports topics which were created by the test case but weren't deleted
+func (bruc *brokerTesterUsecase) checkTopicsLeftovers(tcra *domain.TestCaseResultsAccumulator, lr repository.ListedBrokerTesterRepository) {
+	topics, err := lr.ListTopics(TOPIC_PREFIX)
+	if err != nil {
+		logrus.WithError(err).Warn("couldn't list topics")
+		return
+	}
+
+	for _, topic := range topics {
+		logrus.WithField("topic", topic).Warn("topic leftover found")
+		tcra.AddLeftover("topic " + topic)
+	}
+}
+
+func (bruc *brokerTesterUsecase) createBrokerRepository(tc *domain.TestCase) (repository.BrokerTesterRepository, error) {
+	switch tc.ComponentType {
+	case domain.ComponentType_Kafka:
+		return repository.NewKafkaBrokerTesterRepository(tc.Port, "localhost", BATCH_SIZE), nil
+	case domain.ComponentType_Redpanda:
+		// Port is the Kafka API one, admin API port should be published by the extra ports
+		const REDPANDA_ADMIN_PORT = 9644
+
+		return repository.NewRedpandaBrokerTesterRepository(tc.Port, REDPANDA_ADMIN_PORT, "localhost", BATCH_SIZE), nil
+	case domain.ComponentType_RabbitMQ:
+		user, password := tc.User, tc.Password
+		if user == "" {
+			user, password = "guest", "guest"
+		}
+		return repository.NewRabbitMQBrokerTesterRepository(tc.Port, "localhost", user, password, BATCH_SIZE, int(tc.GetConcurrency())), nil
+	// Redis is tested by the broker tester in the broker mode only
+	case domain.ComponentType_Redis:
+		return repository.NewRedisStreamsBrokerTesterRepository(tc.Port, "localhost", tc.Password, 0, int(tc.GetConcurrency())), nil
+	case domain.ComponentType_Nats:
+		return repository.NewNatsBrokerTesterRepository(tc.Port, "localhost", tc.User, tc.Password, BATCH_SIZE*int(tc.GetConcurrency())), nil
+	default:
+		return nil, domain.NewUnknownComponentError(tc.ComponentType)
+	}
+}
+
+// Method creates topic, produces messages by the batches, consumes them back and deletes the topic. Queues are also purged.
+// Topic is created for every messages count, because deleted topic is removed asynchronously and its name can't be reused at once.
+func (bruc *brokerTesterUsecase) testTopic(mcuc metrics_collector.MetricsCollectorUsecase, r repository.BrokerTesterRepository, concurrency int, messagesCount int) error {
+	testPrefix := strconv.FormatInt(int64(messagesCount), 10) + "x"
+	topic := TOPIC_PREFIX + strconv.FormatInt(int64(messagesCount), 10)
+
+	// Topic could be left by the failed run
+	if err := r.DeleteTopic(topic); err != nil {
+		logrus.WithError(err).Debug("couldn't delete topic")
+	}
+
+	step := &domain.TestCaseStep{Name: "create" + testPrefix + "Topic", StepFunc: func() error { return r.CreateTopic(topic, TOPIC_PARTITIONS) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = bruc.createProduceStep(testPrefix+"Produce", messagesCount, concurrency, func(messages [][]byte) error { return r.Produce(topic, messages) })
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		r.DeleteTopic(topic)
+		return err
+	}
+
+	step = bruc.createConsumeStep(testPrefix+"Consume", messagesCount, MESSAGE_SIZE, func() (int, error) { return r.Consume(topic) })
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		r.DeleteTopic(topic)
+		return err
+	}
+
+	if qr, ok := r.(repository.QueueBrokerTesterRepository); ok {
+		if err := bruc.testQueue(mcuc, qr, concurrency, topic, messagesCount); err != nil {
+			r.DeleteTopic(topic)
+			return err
+		}
+	}
+
+	if sr, ok := r.(repository.StreamBrokerTesterRepository); ok {
+		if err := bruc.testStream(mcuc, r, sr, concurrency, topic, messagesCount); err != nil {
+			r.DeleteTopic(topic)
+			return err
+		}
+	}
+
+	step = &domain.TestCaseStep{Name: "delete" + testPrefix + "Topic", StepFunc: func() error { return r.DeleteTopic(topic) }}
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Method produces messages without confirms, gets some of them one by one and purges the rest
+func (bruc *brokerTesterUsecase) testQueue(mcuc metrics_collector.MetricsCollectorUsecase, qr repository.QueueBrokerTesterRepository, concurrency int, topic string, messagesCount int) error {
+	testPrefix := strconv.FormatInt(int64(messagesCount), 10) + "x"
+
+	step := bruc.createProduceStep(testPrefix+"ProduceUnconfirmed", messagesCount, concurrency, func(messages [][]byte) error {
+		return qr.ProduceUnconfirmed(topic, messages)
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	gets := messagesCount
+	if gets > GET_REQUESTS {
+		gets = GET_REQUESTS
+	}
+	var lr *helpers.LoadResult
+	step = &domain.TestCaseStep{Name: strconv.FormatInt(int64(gets), 10) + "xGet" + testPrefix + "Queue", StepFunc: func() error {
+		lr = helpers.RunLoad(gets, concurrency, func() error { return qr.Get(topic) })
+		if lr.Errors != 0 {
+			return domain.UNEXPECTED_RESPONSE_STATUS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: "purge" + testPrefix + "Queue", StepFunc: func() error {
+		count, err := qr.Purge(topic)
+		if err != nil {
+			return err
+		}
+		if count != messagesCount-gets {
+			return domain.DATA_INTEGRITY_VIOLATED
+		}
+		return nil
+	}}
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Method produces messages again and reads some of them one by one by the consumer group. Consume step has drained the lag of
+// all the produced messages, so its duration is the lag drain time.
+func (bruc *brokerTesterUsecase) testStream(mcuc metrics_collector.MetricsCollectorUsecase, r repository.BrokerTesterRepository, sr repository.StreamBrokerTesterRepository, concurrency int, topic string, messagesCount int) error {
+	testPrefix := strconv.FormatInt(int64(messagesCount), 10) + "x"
+
+	reads := messagesCount
+	if reads > GET_REQUESTS {
+		reads = GET_REQUESTS
+	}
+	messages := make([][]byte, reads)
+	for i := range messages {
+		messages[i] = make([]byte, MESSAGE_SIZE)
+		rand.Read(messages[i])
+	}
+	if err := r.Produce(topic, messages); err != nil {
+		return err
+	}
+
+	var lr *helpers.LoadResult
+	step := &domain.TestCaseStep{Name: strconv.FormatInt(int64(reads), 10) + "xReadGroup" + testPrefix + "Stream", StepFunc: func() error {
+		lr = helpers.RunLoad(reads, concurrency, func() error { return sr.ReadGroup(topic) })
+		if lr.Errors != 0 {
+			return domain.UNEXPECTED_RESPONSE_STATUS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+	}}
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Method creates step producing messages of MESSAGE_SIZE by the batches of BATCH_SIZE concurrently
+func (bruc *brokerTesterUsecase) createProduceStep(name string, messagesCount int, concurrency int, produce func(messages [][]byte) error) *domain.TestCaseStep {
+	return bruc.createSizedProduceStep(name, messagesCount, MESSAGE_SIZE, BATCH_SIZE, concurrency, produce)
+}
+
+// Method creates step producing messages by the batches concurrently. Throughput is messages per second, transfer rate is
+// payload bytes per second.
+func (bruc *brokerTesterUsecase) createSizedProduceStep(name string, messagesCount int, messageSize int, batchSize int, concurrency int, produce func(messages [][]byte) error) *domain.TestCaseStep {
+	// Messages share the payload, so 1M messages don't take memory of their own
+	payload := make([]byte, messageSize)
+	rand.Read(payload)
+	batch := make([][]byte, batchSize)
+	for i := range batch {
+		batch[i] = payload
+	}
+
+	var lr *helpers.LoadResult
+	batches := (messagesCount + batchSize - 1) / batchSize
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		var nextBatch int64 = -1
+		lr = helpers.RunLoad(batches, concurrency, func() error {
+			from := int(atomic.AddInt64(&nextBatch, 1)) * batchSize
+			to := from + batchSize
+			if to > messagesCount {
+				to = messagesCount
+			}
+			return produce(batch[:to-from])
+		})
+		if lr.Errors != 0 {
+			return domain.UNEXPECTED_RESPONSE_STATUS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, float64(messagesCount)/lr.Duration.Seconds())
+		tcsra.AddMetric(domain.MetricMeta_TransferRate, float64(messagesCount*messageSize)/lr.Duration.Seconds())
+	}}
+}
+
+// Method creates step consuming all the produced messages. Throughput is messages per second, transfer rate is payload bytes per second.
+func (bruc *brokerTesterUsecase) createConsumeStep(name string, messagesCount int, messageSize int, consume func() (int, error)) *domain.TestCaseStep {
+	var duration time.Duration
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		startTime := time.Now()
+		defer func() { duration = time.Since(startTime) }()
+
+		count, err := consume()
+		if err != nil {
+			return err
+		}
+		if count != messagesCount {
+			return domain.DATA_INTEGRITY_VIOLATED
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_Throughput, float64(messagesCount)/duration.Seconds())
+		tcsra.AddMetric(domain.MetricMeta_TransferRate, float64(messagesCount*messageSize)/duration.Seconds())
+	}}
+}
+
+// Method creates step publishing messages one by one. Latency is time from the publish till the delivery to the subscriber.
+func (bruc *brokerTesterUsecase) createPubSubStep(pr repository.PubSubBrokerTesterRepository) *domain.TestCaseStep {
+	var latencies []float64
+
+	return &domain.TestCaseStep{Name: "pubSubLatency", StepFunc: func() error {
+		messages, stop, err := pr.Subscribe(PUBSUB_SUBJECT)
+		if err != nil {
+			return err
+		}
+		defer stop()
+
+		message := make([]byte, MESSAGE_SIZE)
+		for i := 0; i < PUBSUB_MESSAGES_COUNT; i++ {
+			binary.BigEndian.PutUint32(message, uint32(i))
+			startTime := time.Now()
+			if err := pr.Publish(PUBSUB_SUBJECT, message); err != nil {
+				return err
+			}
+			select {
+			case m := <-messages:
+				if len(m) < 4 || binary.BigEndian.Uint32(m) != uint32(i) {
+					return domain.DATA_INTEGRITY_VIOLATED
+				}
+			case <-time.After(PUBSUB_TIMEOUT):
+				return domain.MESSAGE_DELIVERY_TIMEOUT
+			}
+			latencies = append(latencies, float64(time.Since(startTime).Microseconds()))
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(latencies)
+	}}
+}
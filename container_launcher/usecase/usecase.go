@@ -1,29 +1,69 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io"
+	"os/exec"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
+	"github.com/iakrevetkho/components-tests/cott/domain"
 	"github.com/sirupsen/logrus"
 )
 
 var STOP_CONTAINER_TIMEOUT = 10 * time.Second
 
+const (
+	// Label of the containers launched by cott
+	COTT_CONTAINER_LABEL = "cott"
+	// Prefix of the compose projects launched by cott
+	COTT_COMPOSE_PROJECT_PREFIX = "cott-"
+	COMPOSE_PROJECT_LABEL       = "com.docker.compose.project"
+)
+
 type ContainerLauncherUsecase interface {
-	// Start continer and returns container ID on success
-	LaunchContainer(image string, envVarMap map[string]string, port uint16) (*string, error)
+	// Start continer for the test case and returns container ID on success
+	LaunchContainer(tc *domain.TestCase) (*string, error)
 	StopContainer(id string) error
+	// KillContainer sends SIGKILL to the container without graceful shutdown
+	KillContainer(id string) error
+	// StartContainer starts already created container
+	StartContainer(id string) error
 	RemoveContainer(id string) error
+	// ListContainers returns IDs of all containers launched by cott including stopped ones
+	ListContainers() ([]string, error)
+	// ListContainersCreatedBefore returns IDs of the containers launched by cott before the time including stopped ones
+	ListContainersCreatedBefore(createdBefore time.Time) ([]string, error)
+	// ListComposeNetworks returns IDs of the networks of the cott compose projects created before the time
+	ListComposeNetworks(createdBefore time.Time) ([]string, error)
+	RemoveNetwork(id string) error
+	// ListComposeVolumes returns names of the volumes of the cott compose projects created before the time
+	ListComposeVolumes(createdBefore time.Time) ([]string, error)
+	RemoveVolume(name string) error
+	// ExecInContainer runs command inside the container and returns its stdout on success
+	ExecInContainer(id string, cmd []string) (*string, error)
+	// LaunchCompose starts compose project and returns ID of the service container on success
+	LaunchCompose(filePath string, projectName string, service string) (*string, error)
+	// GetComposeServiceContainer returns ID of the compose service container
+	GetComposeServiceContainer(filePath string, projectName string, service string) (*string, error)
+	// RemoveCompose stops compose project and removes its containers and volumes
+	RemoveCompose(filePath string, projectName string) error
 	// GetContainerStats get channel with container stats and cancel func for stopping receiving container stats
 	GetContainerStats(id string) (*types.StatsJSON, error)
+	// GetContainerStorageSize returns size of the container writable layer and its volumes in bytes
+	GetContainerStorageSize(id string) (uint64, error)
+	// GetContainerStartTimes returns times of the container creation and its last start
+	GetContainerStartTimes(id string) (created time.Time, started time.Time, err error)
 	GetContainerStatsStream(id string) (<-chan *types.Stats, context.CancelFunc, error)
 }
 
@@ -43,8 +83,9 @@ func NewContainerLauncherUsecase() (ContainerLauncherUsecase, error) {
 	return cluc, nil
 }
 
-func (cluc *containerLauncherUsecase) LaunchContainer(image string, envVarMap map[string]string, port uint16) (*string, error) {
-	logrus.WithFields(logrus.Fields{"image": image, "envVarMap": envVarMap, "port": port}).Debug("launch container")
+func (cluc *containerLauncherUsecase) LaunchContainer(tc *domain.TestCase) (*string, error) {
+	image := tc.Image
+	logrus.WithFields(logrus.Fields{"image": image, "envVarMap": tc.EnvVars, "port": tc.Port, "binds": tc.Binds}).Debug("launch container")
 
 	if reader, err := cluc.cli.ImagePull(context.Background(), image, types.ImagePullOptions{}); err != nil {
 		return nil, err
@@ -58,24 +99,34 @@ func (cluc *containerLauncherUsecase) LaunchContainer(image string, envVarMap ma
 	}
 	logrus.WithFields(logrus.Fields{"image": image}).Debug("container image pulled")
 
-	portStr := strconv.FormatUint(uint64(port), 10)
-	containerPort := nat.Port(portStr)
+	exposedPorts := nat.PortSet{}
+	portBindings := nat.PortMap{}
+	for _, port := range append([]uint16{tc.Port}, tc.ExtraPorts...) {
+		portStr := strconv.FormatUint(uint64(port), 10)
+		containerPort := nat.Port(portStr)
+		exposedPorts[containerPort] = struct{}{}
+		portBindings[containerPort] = []nat.PortBinding{
+			nat.PortBinding{
+				HostIP:   "0.0.0.0",
+				HostPort: portStr,
+			},
+		}
+	}
+
 	containerCfg := &container.Config{
-		Image: image,
-		Env:   cluc.convertEnvVarsMapToSlice(envVarMap),
-		ExposedPorts: nat.PortSet{
-			containerPort: struct{}{},
+		Image:        image,
+		Cmd:          tc.Cmd,
+		Env:          cluc.convertEnvVarsMapToSlice(tc.EnvVars),
+		ExposedPorts: exposedPorts,
+		Labels: map[string]string{
+			COTT_CONTAINER_LABEL: "true",
 		},
 	}
 	hostCfg := &container.HostConfig{
-		PortBindings: nat.PortMap{
-			containerPort: []nat.PortBinding{
-				nat.PortBinding{
-					HostIP:   "0.0.0.0",
-					HostPort: portStr,
-				},
-			},
-		},
+		Binds: tc.Binds,
+		// Allow containers to reach services started by cott on the host
+		ExtraHosts:   []string{"host.docker.internal:host-gateway"},
+		PortBindings: portBindings,
 	}
 
 	resp, err := cluc.cli.ContainerCreate(context.Background(), containerCfg, hostCfg, nil, nil, "")
@@ -101,8 +152,26 @@ func (cluc *containerLauncherUsecase) StopContainer(id string) error {
 	return nil
 }
 
+func (cluc *containerLauncherUsecase) KillContainer(id string) error {
+	if err := cluc.cli.ContainerKill(context.Background(), id, "SIGKILL"); err != nil {
+		return err
+	}
+	logrus.WithField("id", id).Debug("container killed")
+
+	return nil
+}
+
+func (cluc *containerLauncherUsecase) StartContainer(id string) error {
+	if err := cluc.cli.ContainerStart(context.Background(), id, types.ContainerStartOptions{}); err != nil {
+		return err
+	}
+	logrus.WithField("id", id).Debug("container started")
+
+	return nil
+}
+
 func (cluc *containerLauncherUsecase) RemoveContainer(id string) error {
-	if err := cluc.cli.ContainerRemove(context.Background(), id, types.ContainerRemoveOptions{}); err != nil {
+	if err := cluc.cli.ContainerRemove(context.Background(), id, types.ContainerRemoveOptions{RemoveVolumes: true}); err != nil {
 		return err
 	}
 	logrus.WithField("id", id).Debug("container removed")
@@ -110,6 +179,154 @@ func (cluc *containerLauncherUsecase) RemoveContainer(id string) error {
 	return nil
 }
 
+func (cluc *containerLauncherUsecase) ListContainers() ([]string, error) {
+	return cluc.ListContainersCreatedBefore(time.Now())
+}
+
+func (cluc *containerLauncherUsecase) ListContainersCreatedBefore(createdBefore time.Time) ([]string, error) {
+	containers, err := cluc.cli.ContainerList(context.Background(), types.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, c := range containers {
+		if time.Unix(c.Created, 0).After(createdBefore) {
+			continue
+		}
+		if _, ok := c.Labels[COTT_CONTAINER_LABEL]; ok {
+			ids = append(ids, c.ID)
+		} else if strings.HasPrefix(c.Labels[COMPOSE_PROJECT_LABEL], COTT_COMPOSE_PROJECT_PREFIX) {
+			ids = append(ids, c.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+func (cluc *containerLauncherUsecase) ListComposeNetworks(createdBefore time.Time) ([]string, error) {
+	networks, err := cluc.cli.NetworkList(context.Background(), types.NetworkListOptions{Filters: filters.NewArgs(filters.Arg("label", COMPOSE_PROJECT_LABEL))})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, n := range networks {
+		if strings.HasPrefix(n.Labels[COMPOSE_PROJECT_LABEL], COTT_COMPOSE_PROJECT_PREFIX) && !n.Created.After(createdBefore) {
+			ids = append(ids, n.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+func (cluc *containerLauncherUsecase) RemoveNetwork(id string) error {
+	if err := cluc.cli.NetworkRemove(context.Background(), id); err != nil {
+		return err
+	}
+	logrus.WithField("id", id).Debug("network removed")
+
+	return nil
+}
+
+func (cluc *containerLauncherUsecase) ListComposeVolumes(createdBefore time.Time) ([]string, error) {
+	resp, err := cluc.cli.VolumeList(context.Background(), filters.NewArgs(filters.Arg("label", COMPOSE_PROJECT_LABEL)))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, v := range resp.Volumes {
+		if !strings.HasPrefix(v.Labels[COMPOSE_PROJECT_LABEL], COTT_COMPOSE_PROJECT_PREFIX) {
+			continue
+		}
+		// Volume without creation time is considered old
+		if createdAt, err := time.Parse(time.RFC3339, v.CreatedAt); err == nil && createdAt.After(createdBefore) {
+			continue
+		}
+		names = append(names, v.Name)
+	}
+
+	return names, nil
+}
+
+func (cluc *containerLauncherUsecase) RemoveVolume(name string) error {
+	if err := cluc.cli.VolumeRemove(context.Background(), name, false); err != nil {
+		return err
+	}
+	logrus.WithField("name", name).Debug("volume removed")
+
+	return nil
+}
+
+func (cluc *containerLauncherUsecase) ExecInContainer(id string, cmd []string) (*string, error) {
+	execResp, err := cluc.cli.ContainerExecCreate(context.Background(), id, types.ExecConfig{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attachResp, err := cluc.cli.ContainerExecAttach(context.Background(), execResp.ID, types.ExecStartCheck{})
+	if err != nil {
+		return nil, err
+	}
+	defer attachResp.Close()
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, attachResp.Reader); err != nil {
+		return nil, err
+	}
+
+	inspectResp, err := cluc.cli.ContainerExecInspect(context.Background(), execResp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if inspectResp.ExitCode != 0 {
+		logrus.WithFields(logrus.Fields{"id": id, "exitCode": inspectResp.ExitCode, "stderr": stderr.String()}).Warn(domain.CONTAINER_COMMAND_FAILED)
+		return nil, domain.CONTAINER_COMMAND_FAILED
+	}
+
+	out := stdout.String()
+	logrus.WithFields(logrus.Fields{"id": id, "cmd": cmd}).Debug("command executed in container")
+
+	return &out, nil
+}
+
+func (cluc *containerLauncherUsecase) LaunchCompose(filePath string, projectName string, service string) (*string, error) {
+	logrus.WithFields(logrus.Fields{"filePath": filePath, "projectName": projectName, "service": service}).Debug("launch compose")
+
+	if _, err := cluc.runCompose(filePath, projectName, "up", "--detach", "--wait"); err != nil {
+		return nil, err
+	}
+	logrus.WithFields(logrus.Fields{"projectName": projectName}).Debug("compose started")
+
+	return cluc.GetComposeServiceContainer(filePath, projectName, service)
+}
+
+func (cluc *containerLauncherUsecase) GetComposeServiceContainer(filePath string, projectName string, service string) (*string, error) {
+	out, err := cluc.runCompose(filePath, projectName, "ps", "--quiet", service)
+	if err != nil {
+		return nil, err
+	}
+
+	id := strings.TrimSpace(out)
+	logrus.WithFields(logrus.Fields{"projectName": projectName, "service": service, "id": id}).Debug("compose service container found")
+
+	return &id, nil
+}
+
+func (cluc *containerLauncherUsecase) RemoveCompose(filePath string, projectName string) error {
+	if _, err := cluc.runCompose(filePath, projectName, "down", "--volumes", "--timeout", strconv.FormatInt(int64(STOP_CONTAINER_TIMEOUT.Seconds()), 10)); err != nil {
+		return err
+	}
+	logrus.WithField("projectName", projectName).Debug("compose removed")
+
+	return nil
+}
+
 func (cluc *containerLauncherUsecase) GetContainerStats(id string) (*types.StatsJSON, error) {
 	statsResponse, err := cluc.cli.ContainerStats(context.Background(), id, false)
 	if err != nil {
@@ -130,6 +347,60 @@ func (cluc *containerLauncherUsecase) GetContainerStats(id string) (*types.Stats
 	return &stats, nil
 }
 
+// Docker API doesn't return size of the single volume, so it's calculated with du in the container
+func (cluc *containerLauncherUsecase) GetContainerStorageSize(id string) (uint64, error) {
+	inspect, _, err := cluc.cli.ContainerInspectWithRaw(context.Background(), id, true)
+	if err != nil {
+		return 0, err
+	}
+
+	var size uint64
+	if inspect.SizeRw != nil {
+		size = uint64(*inspect.SizeRw)
+	}
+
+	for _, m := range inspect.Mounts {
+		if m.Type != mount.TypeVolume {
+			continue
+		}
+
+		out, err := cluc.ExecInContainer(id, []string{"du", "-sk", m.Destination})
+		if err != nil {
+			return 0, err
+		}
+
+		fields := strings.Fields(*out)
+		if len(fields) == 0 {
+			return 0, domain.CONTAINER_COMMAND_FAILED
+		}
+		kb, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		size += kb << 10
+	}
+
+	return size, nil
+}
+
+func (cluc *containerLauncherUsecase) GetContainerStartTimes(id string) (time.Time, time.Time, error) {
+	inspect, err := cluc.cli.ContainerInspect(context.Background(), id)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	created, err := time.Parse(time.RFC3339Nano, inspect.Created)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	started, err := time.Parse(time.RFC3339Nano, inspect.State.StartedAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return created, started, nil
+}
+
 func (cluc *containerLauncherUsecase) GetContainerStatsStream(id string) (<-chan *types.Stats, context.CancelFunc, error) {
 	ctx, ctxCancelFunc := context.WithCancel(context.Background())
 
@@ -177,3 +448,17 @@ func (cluc *containerLauncherUsecase) convertEnvVarsMapToSlice(envVarMap map[str
 	}
 	return envVarsSlice
 }
+
+// Method runs docker compose command for the project and returns its output
+func (cluc *containerLauncherUsecase) runCompose(filePath string, projectName string, args ...string) (string, error) {
+	cmdArgs := append([]string{"compose", "--file", filePath, "--project-name", projectName}, args...)
+
+	out, err := exec.Command("docker", cmdArgs...).CombinedOutput()
+	logrus.WithField("args", cmdArgs).Trace(string(out))
+	if err != nil {
+		logrus.WithError(err).WithField("output", string(out)).Error("docker compose failed")
+		return "", err
+	}
+
+	return string(out), nil
+}
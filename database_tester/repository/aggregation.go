@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"bytes"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+var sqlAggregations = map[domain.Aggregation]string{
+	domain.Aggregation_Count: "COUNT",
+	domain.Aggregation_Sum:   "SUM",
+	domain.Aggregation_Avg:   "AVG",
+}
+
+// Creates select of the aggregated column by the values of the group column or over the whole table if it's empty
+func createAggregationStatement(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) (string, error) {
+	function, ok := sqlAggregations[aggregation]
+	if !ok {
+		return "", domain.UNKNOWN_AGGREGATION
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	if groupColumn != "" {
+		buf.WriteString(groupColumn)
+		buf.WriteByte(',')
+	}
+	buf.WriteString(function)
+	buf.WriteByte('(')
+	buf.WriteString(aggregatedColumn)
+	buf.WriteString(") FROM ")
+	buf.WriteString(tableName)
+	if groupColumn != "" {
+		buf.WriteString(" GROUP BY ")
+		buf.WriteString(groupColumn)
+	}
+
+	return buf.String(), nil
+}
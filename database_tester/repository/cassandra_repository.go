@@ -0,0 +1,670 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+const (
+	CASSANDRA_TIMEOUT = 30 * time.Second
+	// Keyspaces are created for the single node
+	CASSANDRA_REPLICATION_FACTOR = "1"
+	// Rows are inserted by the concurrent single row statements, because batches of the different partitions are coordinated
+	// by the single node and their size is limited
+	CASSANDRA_INSERT_CONCURRENCY = 16
+)
+
+// Column types of the workload tables in CQL. NUMERIC is varint, because driver writes integers into decimal only from inf.Dec.
+var cassandraColumnTypes = map[string]string{
+	"BIGSERIAL":   "bigint",
+	"SERIAL":      "int",
+	"SMALLSERIAL": "smallint",
+	"INTEGER":     "int",
+	"FLOAT":       "double",
+	"REAL":        "float",
+	"NUMERIC":     "varint",
+	"BYTEA":       "blob",
+	"JSONB":       "text",
+	"TIMESTAMPTZ": "timestamp",
+}
+
+// Repository maps databases to the keyspaces. CQL has no sequences and no NOT NULL and DEFAULT column options,
+// so serial columns are filled by the repository and options except the primary key are skipped.
+type cassandraDatabaseTesterRepository struct {
+	session  *gocql.Session
+	port     uint16
+	host     string
+	user     string
+	password string
+	keyspace string
+	// Compaction strategy class of the created tables
+	storageEngine string
+	sequences     *serialSequences
+	// Cluster is customized by the repositories of the compatible databases
+	configureCluster func(cluster *gocql.ClusterConfig)
+	// Setup times of the pool connections aren't available from the session, so they are collected by the observer
+	connectionSetupTimesMu sync.Mutex
+	connectionSetupTimes   []float64
+}
+
+// NewCassandraDatabaseTesterRepository creates repository using gocql. Tables are created with the storage engine
+// as the compaction strategy class if it's not empty.
+func NewCassandraDatabaseTesterRepository(port uint16, host, user, password, storageEngine string) DatabaseTesterRepository {
+	return newCassandraDatabaseTesterRepository(port, host, user, password, storageEngine)
+}
+
+func newCassandraDatabaseTesterRepository(port uint16, host, user, password, storageEngine string) *cassandraDatabaseTesterRepository {
+	r := new(cassandraDatabaseTesterRepository)
+	r.port = port
+	r.host = host
+	r.user = user
+	r.password = password
+	r.keyspace = ""
+	r.storageEngine = storageEngine
+	r.sequences = newSerialSequences()
+	return r
+}
+
+func (r *cassandraDatabaseTesterRepository) Open() error {
+	cluster := gocql.NewCluster(r.host)
+	cluster.Port = int(r.port)
+	cluster.Keyspace = r.keyspace
+	cluster.Consistency = gocql.Quorum
+	cluster.Timeout = CASSANDRA_TIMEOUT
+	cluster.ConnectTimeout = PING_TIMEOUT
+	// Node advertises container address, which isn't reachable from the host
+	cluster.DisableInitialHostLookup = true
+	if r.user != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{Username: r.user, Password: r.password}
+	}
+	cluster.ConnectObserver = r
+	if r.configureCluster != nil {
+		r.configureCluster(cluster)
+	}
+
+	var err error
+	r.session, err = cluster.CreateSession()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Session isn't created until the node accepts CQL connections, so ping opens it too
+func (r *cassandraDatabaseTesterRepository) Ping() error {
+	if r.session == nil {
+		if err := r.Open(); err != nil {
+			return err
+		}
+	}
+
+	ctx, ctxCancelFunc := context.WithTimeout(context.Background(), PING_TIMEOUT)
+	defer ctxCancelFunc()
+	if err := r.session.Query("SELECT release_version FROM system.local").WithContext(ctx).Exec(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *cassandraDatabaseTesterRepository) CreateDatabase(name string) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE KEYSPACE ")
+	buf.WriteString(name)
+	buf.WriteString(" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': ")
+	buf.WriteString(CASSANDRA_REPLICATION_FACTOR)
+	buf.WriteByte('}')
+
+	return r.session.Query(buf.String()).Exec()
+}
+
+func (r *cassandraDatabaseTesterRepository) DropDatabase(name string) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP KEYSPACE IF EXISTS ")
+	buf.WriteString(name)
+
+	return r.session.Query(buf.String()).Exec()
+}
+
+func (r *cassandraDatabaseTesterRepository) SwitchDatabase(name string) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.Close(); err != nil {
+		return err
+	}
+
+	r.keyspace = name
+
+	if err := r.Open(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *cassandraDatabaseTesterRepository) ListDatabases() ([]string, error) {
+	if r.session == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.scanStrings(r.session.Query("SELECT keyspace_name FROM system_schema.keyspaces"))
+}
+
+// Keyspace tables are addressed as "keyspace.table", so schema is created as the keyspace
+func (r *cassandraDatabaseTesterRepository) CreateSchema(name string) error {
+	return r.CreateDatabase(name)
+}
+
+func (r *cassandraDatabaseTesterRepository) DropSchema(name string) error {
+	return r.DropDatabase(name)
+}
+
+func (r *cassandraDatabaseTesterRepository) ListTables() ([]string, error) {
+	if r.session == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.scanStrings(r.session.Query("SELECT table_name FROM system_schema.tables WHERE keyspace_name = ?", r.keyspace))
+}
+
+func (r *cassandraDatabaseTesterRepository) CreateTable(name string, fields []string) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE TABLE ")
+	buf.WriteString(name)
+	buf.WriteString(" (")
+	for i, field := range fields {
+		buf.WriteString(r.convertField(field))
+		if i < len(fields)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(")")
+	if r.storageEngine != "" {
+		buf.WriteString(" WITH compaction = {'class': '")
+		buf.WriteString(r.storageEngine)
+		buf.WriteString("'}")
+	}
+
+	if err := r.session.Query(buf.String()).Exec(); err != nil {
+		return err
+	}
+
+	r.sequences.create(name, fields)
+
+	return nil
+}
+
+// Rows are distributed between nodes by the partition key hash, so range partitions aren't supported
+func (r *cassandraDatabaseTesterRepository) CreatePartitionedTable(name string, fields []string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *cassandraDatabaseTesterRepository) CreateRangePartition(tableName string, partitionName string, from int64, to int64) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *cassandraDatabaseTesterRepository) DropTable(name string) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP TABLE IF EXISTS ")
+	buf.WriteString(name)
+
+	if err := r.session.Query(buf.String()).Exec(); err != nil {
+		return err
+	}
+
+	r.sequences.drop(name)
+
+	return nil
+}
+
+func (r *cassandraDatabaseTesterRepository) AddColumn(tableName string, field string) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" ADD ")
+	buf.WriteString(r.convertField(field))
+
+	return r.session.Query(buf.String()).Exec()
+}
+
+func (r *cassandraDatabaseTesterRepository) DropColumn(tableName string, column string) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" DROP ")
+	buf.WriteString(column)
+
+	return r.session.Query(buf.String()).Exec()
+}
+
+func (r *cassandraDatabaseTesterRepository) SetColumnNotNull(tableName string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+// Column types can't be altered since Cassandra 3.10
+func (r *cassandraDatabaseTesterRepository) AlterColumnType(tableName string, column string, columnType string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+// Secondary index is built in background, so concurrently flag is ignored. Index is created for the single column only.
+func (r *cassandraDatabaseTesterRepository) CreateIndex(tableName string, indexName string, columns []string, concurrently bool) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+	if len(columns) != 1 {
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE INDEX ")
+	buf.WriteString(indexName)
+	buf.WriteString(" ON ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	buf.WriteString(columns[0])
+	buf.WriteByte(')')
+
+	return r.session.Query(buf.String()).Exec()
+}
+
+func (r *cassandraDatabaseTesterRepository) DropIndex(tableName string, indexName string) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP INDEX IF EXISTS ")
+	buf.WriteString(indexName)
+
+	return r.session.Query(buf.String()).Exec()
+}
+
+func (r *cassandraDatabaseTesterRepository) TruncateTable(name string) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("TRUNCATE TABLE ")
+	buf.WriteString(name)
+
+	return r.session.Query(buf.String()).Exec()
+}
+
+func (r *cassandraDatabaseTesterRepository) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	columns, values = r.sequences.fill(tableName, columns, values)
+	statement := r.createInsertStatement(tableName, columns)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		rows     = make(chan map[string]interface{})
+	)
+	for w := 0; w < CASSANDRA_INSERT_CONCURRENCY; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			args := make([]interface{}, len(columns))
+			for v := range rows {
+				for i, column := range columns {
+					args[i] = v[column]
+				}
+				if err := r.session.Query(statement, args...).Exec(); err != nil {
+					errOnce.Do(func() { firstErr = err })
+				}
+			}
+		}()
+	}
+	for _, v := range values {
+		rows <- v
+	}
+	close(rows)
+	wg.Wait()
+
+	return firstErr
+}
+
+// Rows are inserted one by one
+func (r *cassandraDatabaseTesterRepository) MaxInsertRows(columnsCount int) int {
+	return 0
+}
+
+// CQL batches aren't isolated from the concurrent reads
+func (r *cassandraDatabaseTesterRepository) BeginTransaction() (DatabaseTesterTransaction, error) {
+	return nil, domain.UNSUPPORTED_OPERATION
+}
+
+// Insert of the existing primary key overwrites the row
+func (r *cassandraDatabaseTesterRepository) Upsert(tableName string, keyColumns []string, columns []string, values []map[string]interface{}) error {
+	return r.Insert(tableName, append(append([]string{}, keyColumns...), columns...), values)
+}
+
+// Inserts never conflict
+func (r *cassandraDatabaseTesterRepository) IsConflictError(err error) bool {
+	return false
+}
+
+func (r *cassandraDatabaseTesterRepository) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("UPDATE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" SET ")
+	args := make([]interface{}, 0, len(columns)+1)
+	for i, column := range columns {
+		buf.WriteString(column)
+		buf.WriteString("=?")
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+		args = append(args, values[column])
+	}
+	buf.WriteString(" WHERE id=?")
+
+	return r.session.Query(buf.String(), append(args, int64(id))...).Exec()
+}
+
+// Updated rows are addressed by the full primary key only
+func (r *cassandraDatabaseTesterRepository) UpdateByConditions(tableName string, conditions string, columns []string, values map[string]interface{}) (int64, error) {
+	return 0, domain.UNSUPPORTED_OPERATION
+}
+
+func (r *cassandraDatabaseTesterRepository) DeleteById(tableName string, id int) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=?")
+
+	return r.session.Query(buf.String(), int64(id)).Exec()
+}
+
+// Deleted rows are addressed by the full primary key only
+func (r *cassandraDatabaseTesterRepository) DeleteByConditions(tableName string, conditions string) (int64, error) {
+	return 0, domain.UNSUPPORTED_OPERATION
+}
+
+func (r *cassandraDatabaseTesterRepository) DeleteAll(tableName string) (int64, error) {
+	return 0, domain.UNSUPPORTED_OPERATION
+}
+
+func (r *cassandraDatabaseTesterRepository) SelectById(tableName string, id int) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=?")
+
+	return r.session.Query(buf.String(), int64(id)).Iter().Close()
+}
+
+// Conditions of the not key columns are filtered by the full scan
+func (r *cassandraDatabaseTesterRepository) SelectByConditions(tableName string, conditions string) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	parsed, ok := parseConditions(conditions)
+	if !ok {
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	for i, c := range parsed {
+		buf.WriteString(c.column)
+		buf.WriteString(c.operator)
+		buf.WriteString(c.literal)
+		if i < len(parsed)-1 {
+			buf.WriteString(" AND ")
+		}
+	}
+	buf.WriteString(" ALLOW FILTERING")
+
+	return r.session.Query(buf.String()).Iter().Close()
+}
+
+// CQL has no joins, so tables are denormalized instead
+func (r *cassandraDatabaseTesterRepository) SelectJoin(tableName string, column string, joinedTableName string, joinType domain.JoinType) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *cassandraDatabaseTesterRepository) SelectJoinAggregation(tableName string, column string, joinedTableName string, aggregatedColumn string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+// Cassandra groups rows by the primary key columns only, so only the whole table is aggregated
+func (r *cassandraDatabaseTesterRepository) SelectAggregation(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+	if groupColumn != "" {
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	statement, err := createAggregationStatement(tableName, groupColumn, aggregation, aggregatedColumn)
+	if err != nil {
+		return err
+	}
+
+	return r.session.Query(statement).Iter().Close()
+}
+
+// Rows are ordered by the clustering columns within the partition only, so pages ordered by id aren't supported
+func (r *cassandraDatabaseTesterRepository) SelectPage(tableName string, offset int, limit int) (int64, error) {
+	return 0, domain.UNSUPPORTED_OPERATION
+}
+
+func (r *cassandraDatabaseTesterRepository) SelectPageAfterId(tableName string, id int64, limit int) (int64, error) {
+	return 0, domain.UNSUPPORTED_OPERATION
+}
+
+func (r *cassandraDatabaseTesterRepository) SelectColumnById(tableName string, column string, id int64, dest interface{}) error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	buf.WriteString(column)
+	buf.WriteString(" FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=?")
+
+	return r.session.Query(buf.String(), id).Scan(dest)
+}
+
+func (r *cassandraDatabaseTesterRepository) CountRows(tableName string) (int64, error) {
+	if r.session == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COUNT(*) FROM ")
+	buf.WriteString(tableName)
+
+	var count int64
+	if err := r.session.Query(buf.String()).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *cassandraDatabaseTesterRepository) SumColumn(tableName string, column string) (int64, error) {
+	if r.session == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT SUM(")
+	buf.WriteString(column)
+	buf.WriteString(") FROM ")
+	buf.WriteString(tableName)
+
+	var sum int64
+	if err := r.session.Query(buf.String()).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum, nil
+}
+
+func (r *cassandraDatabaseTesterRepository) UpdateInTransaction(tableName string, column string, deltas map[int64]int64, isolationLevel domain.IsolationLevel) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *cassandraDatabaseTesterRepository) WithdrawInTransaction(tableName string, column string, id int64, amount int64, isolationLevel domain.IsolationLevel) (bool, error) {
+	return false, domain.UNSUPPORTED_OPERATION
+}
+
+// Snapshots are restored into the other keyspace by copying SSTables, which can't be done by the single command
+func (r *cassandraDatabaseTesterRepository) BackupCommand(filePath string) []string {
+	return nil
+}
+
+func (r *cassandraDatabaseTesterRepository) RestoreCommand(filePath string, dbname string) []string {
+	return nil
+}
+
+// Cassandra has no database/sql driver
+func (r *cassandraDatabaseTesterRepository) SqlDB() *sql.DB {
+	return nil
+}
+
+func (r *cassandraDatabaseTesterRepository) Close() error {
+	if r.session == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	r.session.Close()
+	r.session = nil
+
+	return nil
+}
+
+func (r *cassandraDatabaseTesterRepository) ObserveConnect(c gocql.ObservedConnect) {
+	if c.Err != nil {
+		return
+	}
+
+	r.connectionSetupTimesMu.Lock()
+	defer r.connectionSetupTimesMu.Unlock()
+	r.connectionSetupTimes = append(r.connectionSetupTimes, float64(c.End.Sub(c.Start).Microseconds()))
+}
+
+func (r *cassandraDatabaseTesterRepository) ConnectionSetupTimes() []float64 {
+	r.connectionSetupTimesMu.Lock()
+	defer r.connectionSetupTimesMu.Unlock()
+	times := r.connectionSetupTimes
+	r.connectionSetupTimes = nil
+	return times
+}
+
+func (r *cassandraDatabaseTesterRepository) scanStrings(q *gocql.Query) ([]string, error) {
+	var (
+		names []string
+		name  string
+	)
+
+	iter := q.Iter()
+	for iter.Scan(&name) {
+		names = append(names, name)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// Method converts Postgres field definition of the workload tables to CQL one. Only PRIMARY KEY option is kept.
+func (r *cassandraDatabaseTesterRepository) convertField(field string) string {
+	name, columnType, options, ok := splitFieldDefinition(field)
+	if !ok {
+		return field
+	}
+
+	dialectType, ok := cassandraColumnTypes[strings.ToUpper(columnType)]
+	if !ok {
+		dialectType = strings.ToLower(columnType)
+	}
+
+	if strings.Contains(strings.ToUpper(options), "PRIMARY KEY") {
+		return name + " " + dialectType + " PRIMARY KEY"
+	}
+	return name + " " + dialectType
+}
+
+func (r *cassandraDatabaseTesterRepository) createInsertStatement(tableName string, columns []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(") VALUES (")
+	for i := range columns {
+		buf.WriteByte('?')
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+
+	return buf.String()
+}
@@ -0,0 +1,855 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/url"
+	"strconv"
+	"strings"
+
+	_ "github.com/ClickHouse/clickhouse-go"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+const CLICKHOUSE_DEFAULT_ENGINE = "MergeTree"
+
+// Column types of the workload tables in ClickHouse. Columns aren't Nullable, because workloads insert all values.
+// NUMERIC has zero scale, because driver writes integers into Decimal64 without scaling.
+var clickhouseColumnTypes = map[string]string{
+	"BIGINT":      "Int64",
+	"BIGSERIAL":   "Int64",
+	"INTEGER":     "Int32",
+	"SERIAL":      "Int32",
+	"SMALLINT":    "Int16",
+	"SMALLSERIAL": "Int16",
+	"BOOLEAN":     "UInt8",
+	"DATE":        "Date",
+	"FLOAT":       "Float64",
+	"REAL":        "Float32",
+	"NUMERIC":     "Decimal(18,0)",
+	"TEXT":        "String",
+	"BYTEA":       "String",
+	"JSONB":       "String",
+	"UUID":        "UUID",
+	"TIMESTAMP":   "DateTime64(6)",
+	"TIMESTAMPTZ": "DateTime64(6)",
+}
+
+type clickhouseDatabaseTesterRepository struct {
+	db       *sqlx.DB
+	port     uint16
+	host     string
+	user     string
+	password string
+	dbname   string
+	// Table engine of the created tables. MergeTree family tables are ordered by the primary key columns.
+	storageEngine string
+	// ClickHouse has no sequences, so serial columns of the tables are filled by the repository
+	sequences *serialSequences
+}
+
+// NewClickHouseDatabaseTesterRepository creates repository using the native protocol of clickhouse-go.
+// Tables are created with the MergeTree engine if storage engine is empty.
+func NewClickHouseDatabaseTesterRepository(port uint16, host, user, password, storageEngine string) DatabaseTesterRepository {
+	r := new(clickhouseDatabaseTesterRepository)
+	r.port = port
+	r.host = host
+	r.user = user
+	r.password = password
+	r.dbname = ""
+	r.storageEngine = storageEngine
+	if r.storageEngine == "" {
+		r.storageEngine = CLICKHOUSE_DEFAULT_ENGINE
+	}
+	r.sequences = newSerialSequences()
+	return r
+}
+
+func (r *clickhouseDatabaseTesterRepository) Open() error {
+	var err error
+	r.db, err = sqlx.Open("clickhouse", r.createConnString(r.port, r.host, r.user, r.password, r.dbname))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) Ping() error {
+	ctx, ctxCancelFunc := context.WithTimeout(context.Background(), PING_TIMEOUT)
+	defer ctxCancelFunc()
+	if err := r.db.PingContext(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) CreateDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE DATABASE ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) DropDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP DATABASE IF EXISTS ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) SwitchDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.Close(); err != nil {
+		return err
+	}
+
+	r.dbname = name
+
+	if err := r.Open(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) ListDatabases() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT name FROM system.databases"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// ClickHouse has no schemas, so schema is created as the database which tables are addressed as "schema.table" in the same way
+func (r *clickhouseDatabaseTesterRepository) CreateSchema(name string) error {
+	return r.CreateDatabase(name)
+}
+
+func (r *clickhouseDatabaseTesterRepository) DropSchema(name string) error {
+	return r.DropDatabase(name)
+}
+
+func (r *clickhouseDatabaseTesterRepository) ListTables() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT name FROM system.tables WHERE database = currentDatabase()"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) CreateTable(name string, fields []string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var primaryKey []string
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE TABLE ")
+	buf.WriteString(name)
+	buf.WriteString(" (")
+	for i, field := range fields {
+		column, _, options, ok := splitFieldDefinition(field)
+		if ok && strings.Contains(strings.ToUpper(options), "PRIMARY KEY") {
+			primaryKey = append(primaryKey, column)
+		}
+		buf.WriteString(r.convertField(field))
+		if i < len(fields)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(") ENGINE=")
+	buf.WriteString(r.storageEngine)
+	if strings.HasSuffix(r.storageEngine, CLICKHOUSE_DEFAULT_ENGINE) {
+		if len(primaryKey) == 0 {
+			buf.WriteString(" ORDER BY tuple()")
+		} else {
+			buf.WriteString(" ORDER BY (")
+			buf.WriteString(strings.Join(primaryKey, ","))
+			buf.WriteByte(')')
+		}
+	}
+
+	if _, err := r.db.Exec(buf.String()); err != nil {
+		return err
+	}
+
+	r.sequences.create(name, fields)
+
+	return nil
+}
+
+// ClickHouse partitions aren't the tables, so rows can't be inserted into them by name
+func (r *clickhouseDatabaseTesterRepository) CreatePartitionedTable(name string, fields []string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *clickhouseDatabaseTesterRepository) CreateRangePartition(tableName string, partitionName string, from int64, to int64) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *clickhouseDatabaseTesterRepository) DropTable(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP TABLE IF EXISTS ")
+	buf.WriteString(name)
+
+	if _, err := r.db.Exec(buf.String()); err != nil {
+		return err
+	}
+
+	r.sequences.drop(name)
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) AddColumn(tableName string, field string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" ADD COLUMN ")
+	buf.WriteString(r.convertField(field))
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) DropColumn(tableName string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" DROP COLUMN IF EXISTS ")
+	buf.WriteString(column)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Columns are created without Nullable, so they are already not null
+func (r *clickhouseDatabaseTesterRepository) SetColumnNotNull(tableName string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) AlterColumnType(tableName string, column string, columnType string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" MODIFY COLUMN ")
+	buf.WriteString(column)
+	buf.WriteByte(' ')
+	buf.WriteString(r.convertType(columnType))
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Index is the minmax data skipping index. It's built for the existing parts by the mutation,
+// which is awaited unless concurrently flag is set.
+func (r *clickhouseDatabaseTesterRepository) CreateIndex(tableName string, indexName string, columns []string, concurrently bool) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" ADD INDEX ")
+	buf.WriteString(indexName)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(") TYPE minmax GRANULARITY 1")
+
+	if _, err := r.db.Exec(buf.String()); err != nil {
+		return err
+	}
+
+	buf.Reset()
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" MATERIALIZE INDEX ")
+	buf.WriteString(indexName)
+	if !concurrently {
+		buf.WriteString(" SETTINGS mutations_sync = 1")
+	}
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) DropIndex(tableName string, indexName string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" DROP INDEX IF EXISTS ")
+	buf.WriteString(indexName)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) TruncateTable(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("TRUNCATE TABLE ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Values are sent to the server as the single block, which is written on commit
+func (r *clickhouseDatabaseTesterRepository) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	columns, values = r.sequences.fill(tableName, columns, values)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(r.createInsertStatement(tableName, columns))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	args := make([]interface{}, len(columns))
+	for _, v := range values {
+		for i, column := range columns {
+			args[i] = v[column]
+		}
+		if _, err := stmt.Exec(args...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Rows of the prepared insert are sent by the blocks
+func (r *clickhouseDatabaseTesterRepository) MaxInsertRows(columnsCount int) int {
+	return 0
+}
+
+// ClickHouse has no transactions
+func (r *clickhouseDatabaseTesterRepository) BeginTransaction() (DatabaseTesterTransaction, error) {
+	return nil, domain.UNSUPPORTED_OPERATION
+}
+
+// Rows with the same key are collapsed by ReplacingMergeTree engine in background only, so upsert isn't supported
+func (r *clickhouseDatabaseTesterRepository) Upsert(tableName string, keyColumns []string, columns []string, values []map[string]interface{}) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+// ClickHouse has no unique constraints
+func (r *clickhouseDatabaseTesterRepository) IsConflictError(err error) bool {
+	return false
+}
+
+// Updates are the mutations rewriting the parts of the rows, so they are awaited to be applied
+func (r *clickhouseDatabaseTesterRepository) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	args := make([]interface{}, 0, len(columns)+1)
+	for _, column := range columns {
+		args = append(args, values[column])
+	}
+
+	if _, err := r.db.Exec(r.createUpdateStatement(tableName, columns, "id=?"), append(args, id)...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Mutations don't report affected rows, so matching rows are counted before the update
+func (r *clickhouseDatabaseTesterRepository) UpdateByConditions(tableName string, conditions string, columns []string, values map[string]interface{}) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT count() FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(conditions)
+
+	var count uint64
+	if err := r.db.Get(&count, buf.String()); err != nil {
+		return 0, err
+	}
+
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		args[i] = values[column]
+	}
+
+	if _, err := r.db.Exec(r.createUpdateStatement(tableName, columns, conditions), args...); err != nil {
+		return 0, err
+	}
+
+	return int64(count), nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) DeleteById(tableName string, id int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if _, err := r.db.Exec(r.createDeleteStatement(tableName, "id=?"), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Mutations don't report affected rows, so matching rows are counted before the delete
+func (r *clickhouseDatabaseTesterRepository) DeleteByConditions(tableName string, conditions string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT count() FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(conditions)
+
+	var count uint64
+	if err := r.db.Get(&count, buf.String()); err != nil {
+		return 0, err
+	}
+
+	if _, err := r.db.Exec(r.createDeleteStatement(tableName, conditions)); err != nil {
+		return 0, err
+	}
+
+	return int64(count), nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) DeleteAll(tableName string) (int64, error) {
+	return r.DeleteByConditions(tableName, "1")
+}
+
+func (r *clickhouseDatabaseTesterRepository) SelectById(tableName string, id int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=?")
+
+	rows, err := r.db.Query(buf.String(), id)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) SelectByConditions(tableName string, conditions string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(conditions)
+
+	rows, err := r.db.Query(buf.String())
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) SelectJoin(tableName string, column string, joinedTableName string, joinType domain.JoinType) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createJoinStatement(tableName, column, joinedTableName, joinType)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(statement)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) SelectJoinAggregation(tableName string, column string, joinedTableName string, aggregatedColumn string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createJoinAggregationStatement(tableName, column, joinedTableName, aggregatedColumn))
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) SelectAggregation(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createAggregationStatement(tableName, groupColumn, aggregation, aggregatedColumn)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(statement)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) SelectPage(tableName string, offset int, limit int) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createOffsetPageStatement(tableName, offset, limit, false))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPageLastId(rows)
+}
+
+func (r *clickhouseDatabaseTesterRepository) SelectPageAfterId(tableName string, id int64, limit int) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createKeysetPageStatement(tableName, id, limit, false))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPageLastId(rows)
+}
+
+func (r *clickhouseDatabaseTesterRepository) SelectColumnById(tableName string, column string, id int64, dest interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	buf.WriteString(column)
+	buf.WriteString(" FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=?")
+
+	if err := r.db.Get(dest, buf.String(), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) CountRows(tableName string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COUNT(*) FROM ")
+	buf.WriteString(tableName)
+
+	var count int64
+	if err := r.db.Get(&count, buf.String()); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) SumColumn(tableName string, column string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT toInt64(SUM(")
+	buf.WriteString(column)
+	buf.WriteString(")) FROM ")
+	buf.WriteString(tableName)
+
+	var sum int64
+	if err := r.db.Get(&sum, buf.String()); err != nil {
+		return 0, err
+	}
+
+	return sum, nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) UpdateInTransaction(tableName string, column string, deltas map[int64]int64, isolationLevel domain.IsolationLevel) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *clickhouseDatabaseTesterRepository) WithdrawInTransaction(tableName string, column string, id int64, amount int64, isolationLevel domain.IsolationLevel) (bool, error) {
+	return false, domain.UNSUPPORTED_OPERATION
+}
+
+// BACKUP needs the backups.allowed_path setting, which the stock image doesn't set, and writes the directory instead of the file
+func (r *clickhouseDatabaseTesterRepository) BackupCommand(filePath string) []string {
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) RestoreCommand(filePath string, dbname string) []string {
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) SqlDB() *sql.DB {
+	if r.db == nil {
+		return nil
+	}
+	return r.db.DB
+}
+
+func (r *clickhouseDatabaseTesterRepository) Close() error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.db.Close(); err != nil {
+		return err
+	}
+
+	r.db = nil
+
+	return nil
+}
+
+func (r *clickhouseDatabaseTesterRepository) createConnString(port uint16, host, user, password, dbname string) string {
+	params := url.Values{}
+	params.Set("username", user)
+	params.Set("password", password)
+	if dbname != "" {
+		params.Set("database", dbname)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("tcp://")
+	buf.WriteString(host)
+	buf.WriteByte(':')
+	buf.WriteString(strconv.FormatUint(uint64(port), 10))
+	buf.WriteByte('?')
+	buf.WriteString(params.Encode())
+
+	return buf.String()
+}
+
+// Method converts Postgres field definition of the workload tables to ClickHouse one.
+// Primary key is set by the ORDER BY of the table and columns are always not null.
+func (r *clickhouseDatabaseTesterRepository) convertField(field string) string {
+	name, columnType, options, ok := splitFieldDefinition(field)
+	if !ok {
+		return field
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(name)
+	buf.WriteByte(' ')
+	buf.WriteString(r.convertType(columnType))
+
+	upperOptions := strings.ToUpper(options)
+	if i := strings.Index(upperOptions, "DEFAULT "); i >= 0 {
+		defaultValue := strings.Fields(options[i+len("DEFAULT "):])
+		if len(defaultValue) > 0 {
+			buf.WriteString(" DEFAULT ")
+			buf.WriteString(defaultValue[0])
+		}
+	}
+
+	return buf.String()
+}
+
+func (r *clickhouseDatabaseTesterRepository) convertType(columnType string) string {
+	if dialectType, ok := clickhouseColumnTypes[strings.ToUpper(columnType)]; ok {
+		return dialectType
+	}
+	return columnType
+}
+
+func (r *clickhouseDatabaseTesterRepository) createInsertStatement(tableName string, columns []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(") VALUES (")
+	for i := range columns {
+		buf.WriteByte('?')
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+
+	return buf.String()
+}
+
+func (r *clickhouseDatabaseTesterRepository) createUpdateStatement(tableName string, columns []string, conditions string) string {
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" UPDATE ")
+	for i, column := range columns {
+		buf.WriteString(column)
+		buf.WriteString("=?")
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(" WHERE ")
+	buf.WriteString(conditions)
+	buf.WriteString(" SETTINGS mutations_sync = 1")
+
+	return buf.String()
+}
+
+// Deletes are the mutations rewriting the parts of the rows, so they are awaited to be applied
+func (r *clickhouseDatabaseTesterRepository) createDeleteStatement(tableName string, conditions string) string {
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" DELETE WHERE ")
+	buf.WriteString(conditions)
+	buf.WriteString(" SETTINGS mutations_sync = 1")
+
+	return buf.String()
+}
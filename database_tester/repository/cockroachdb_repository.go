@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"github.com/cockroachdb/cockroach-go/v2/crdb"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+// Repository talks Postgres protocol, but every statement runs in the serializable transaction, which is aborted
+// if it conflicts with the concurrent one. Such statements and transactions are retried by the crdb retry loop,
+// so the workload measures retries latency instead of the errors.
+type cockroachDBDatabaseTesterRepository struct {
+	*postgresDatabaseTesterRepository
+}
+
+// NewCockroachDBDatabaseTesterRepository creates repository using pgx driver.
+// Table access methods aren't supported by CockroachDB, so tables are created with the default storage.
+func NewCockroachDBDatabaseTesterRepository(port uint16, host, user, password string) DatabaseTesterRepository {
+	r := new(cockroachDBDatabaseTesterRepository)
+	r.postgresDatabaseTesterRepository = newPostgresDatabaseTesterRepository(port, host, user, password, "pgx", "")
+	return r
+}
+
+// Tables are partitioned by the PARTITION BY clause of the primary key instead of the partition tables
+func (r *cockroachDBDatabaseTesterRepository) CreatePartitionedTable(name string, fields []string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *cockroachDBDatabaseTesterRepository) CreateRangePartition(tableName string, partitionName string, from int64, to int64) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *cockroachDBDatabaseTesterRepository) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	return crdb.Execute(func() error {
+		return r.postgresDatabaseTesterRepository.Insert(tableName, columns, values)
+	})
+}
+
+func (r *cockroachDBDatabaseTesterRepository) Upsert(tableName string, keyColumns []string, columns []string, values []map[string]interface{}) error {
+	return crdb.Execute(func() error {
+		return r.postgresDatabaseTesterRepository.Upsert(tableName, keyColumns, columns, values)
+	})
+}
+
+func (r *cockroachDBDatabaseTesterRepository) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	return crdb.Execute(func() error {
+		return r.postgresDatabaseTesterRepository.UpdateById(tableName, id, columns, values)
+	})
+}
+
+func (r *cockroachDBDatabaseTesterRepository) UpdateByConditions(tableName string, conditions string, columns []string, values map[string]interface{}) (int64, error) {
+	var updated int64
+	err := crdb.Execute(func() error {
+		var err error
+		updated, err = r.postgresDatabaseTesterRepository.UpdateByConditions(tableName, conditions, columns, values)
+		return err
+	})
+	return updated, err
+}
+
+func (r *cockroachDBDatabaseTesterRepository) DeleteById(tableName string, id int) error {
+	return crdb.Execute(func() error {
+		return r.postgresDatabaseTesterRepository.DeleteById(tableName, id)
+	})
+}
+
+func (r *cockroachDBDatabaseTesterRepository) DeleteByConditions(tableName string, conditions string) (int64, error) {
+	var deleted int64
+	err := crdb.Execute(func() error {
+		var err error
+		deleted, err = r.postgresDatabaseTesterRepository.DeleteByConditions(tableName, conditions)
+		return err
+	})
+	return deleted, err
+}
+
+func (r *cockroachDBDatabaseTesterRepository) DeleteAll(tableName string) (int64, error) {
+	var deleted int64
+	err := crdb.Execute(func() error {
+		var err error
+		deleted, err = r.postgresDatabaseTesterRepository.DeleteAll(tableName)
+		return err
+	})
+	return deleted, err
+}
+
+func (r *cockroachDBDatabaseTesterRepository) SelectById(tableName string, id int) error {
+	return crdb.Execute(func() error {
+		return r.postgresDatabaseTesterRepository.SelectById(tableName, id)
+	})
+}
+
+func (r *cockroachDBDatabaseTesterRepository) SelectByConditions(tableName string, conditions string) error {
+	return crdb.Execute(func() error {
+		return r.postgresDatabaseTesterRepository.SelectByConditions(tableName, conditions)
+	})
+}
+
+func (r *cockroachDBDatabaseTesterRepository) SelectJoin(tableName string, column string, joinedTableName string, joinType domain.JoinType) error {
+	return crdb.Execute(func() error {
+		return r.postgresDatabaseTesterRepository.SelectJoin(tableName, column, joinedTableName, joinType)
+	})
+}
+
+func (r *cockroachDBDatabaseTesterRepository) SelectJoinAggregation(tableName string, column string, joinedTableName string, aggregatedColumn string) error {
+	return crdb.Execute(func() error {
+		return r.postgresDatabaseTesterRepository.SelectJoinAggregation(tableName, column, joinedTableName, aggregatedColumn)
+	})
+}
+
+func (r *cockroachDBDatabaseTesterRepository) SelectAggregation(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) error {
+	return crdb.Execute(func() error {
+		return r.postgresDatabaseTesterRepository.SelectAggregation(tableName, groupColumn, aggregation, aggregatedColumn)
+	})
+}
+
+func (r *cockroachDBDatabaseTesterRepository) SelectPage(tableName string, offset int, limit int) (int64, error) {
+	var lastId int64
+	err := crdb.Execute(func() (err error) {
+		lastId, err = r.postgresDatabaseTesterRepository.SelectPage(tableName, offset, limit)
+		return err
+	})
+	return lastId, err
+}
+
+func (r *cockroachDBDatabaseTesterRepository) SelectPageAfterId(tableName string, id int64, limit int) (int64, error) {
+	var lastId int64
+	err := crdb.Execute(func() (err error) {
+		lastId, err = r.postgresDatabaseTesterRepository.SelectPageAfterId(tableName, id, limit)
+		return err
+	})
+	return lastId, err
+}
+
+func (r *cockroachDBDatabaseTesterRepository) SelectColumnById(tableName string, column string, id int64, dest interface{}) error {
+	return crdb.Execute(func() error {
+		return r.postgresDatabaseTesterRepository.SelectColumnById(tableName, column, id, dest)
+	})
+}
+
+// Whole transaction is retried, because it's rolled back by the serialization error
+func (r *cockroachDBDatabaseTesterRepository) UpdateInTransaction(tableName string, column string, deltas map[int64]int64, isolationLevel domain.IsolationLevel) error {
+	return crdb.Execute(func() error {
+		return r.postgresDatabaseTesterRepository.UpdateInTransaction(tableName, column, deltas, isolationLevel)
+	})
+}
+
+func (r *cockroachDBDatabaseTesterRepository) WithdrawInTransaction(tableName string, column string, id int64, amount int64, isolationLevel domain.IsolationLevel) (bool, error) {
+	var withdrawn bool
+	err := crdb.Execute(func() error {
+		var err error
+		withdrawn, err = r.postgresDatabaseTesterRepository.WithdrawInTransaction(tableName, column, id, amount, isolationLevel)
+		return err
+	})
+	return withdrawn, err
+}
+
+// Image has no pg_dump, and BACKUP statement writes the directory of the files
+func (r *cockroachDBDatabaseTesterRepository) BackupCommand(filePath string) []string {
+	return nil
+}
+
+func (r *cockroachDBDatabaseTesterRepository) RestoreCommand(filePath string, dbname string) []string {
+	return nil
+}
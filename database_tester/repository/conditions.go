@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+var (
+	conditionsSeparator = regexp.MustCompile(`(?i)\s+AND\s+`)
+	comparisonCondition = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_]*)\s*(>=|<=|<>|!=|=|>|<)\s*(.+?)\s*$`)
+	columnCondition     = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_]*)\s*$`)
+)
+
+// Comparison of the column with the literal
+type condition struct {
+	column   string
+	operator string
+	literal  string
+}
+
+// Parses SQL conditions of the workloads for the databases without SQL. Conditions are the comparisons of the columns
+// with the literals joined by AND, like "f1>1 AND f3", where boolean column is compared with TRUE.
+// Columns are lowercased, because SQL identifiers are case insensitive. Returns false if conditions have another form.
+func parseConditions(conditions string) ([]condition, bool) {
+	var parsed []condition
+	for _, c := range conditionsSeparator.Split(strings.TrimSpace(conditions), -1) {
+		if m := columnCondition.FindStringSubmatch(c); m != nil {
+			parsed = append(parsed, condition{column: strings.ToLower(m[1]), operator: "=", literal: "TRUE"})
+			continue
+		}
+
+		m := comparisonCondition.FindStringSubmatch(c)
+		if m == nil {
+			return nil, false
+		}
+		parsed = append(parsed, condition{column: strings.ToLower(m[1]), operator: m[2], literal: m[3]})
+	}
+
+	return parsed, true
+}
+
+// Writes parsed conditions for the dialects storing booleans as numbers, so TRUE and FALSE literals are written as 1 and 0
+func writeNumericBooleanConditions(buf *bytes.Buffer, parsed []condition) {
+	for i, c := range parsed {
+		buf.WriteString(c.column)
+		buf.WriteString(c.operator)
+		switch strings.ToUpper(c.literal) {
+		case "TRUE":
+			buf.WriteByte('1')
+		case "FALSE":
+			buf.WriteByte('0')
+		default:
+			buf.WriteString(c.literal)
+		}
+		if i < len(parsed)-1 {
+			buf.WriteString(" AND ")
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// Source of the pgx copy, which reads rows from the chunks till the channel is closed
+type chunksCopyFromSource struct {
+	chunks  <-chan []map[string]interface{}
+	columns []string
+	chunk   []map[string]interface{}
+	row     int
+	copied  int64
+}
+
+func newChunksCopyFromSource(chunks <-chan []map[string]interface{}, columns []string) *chunksCopyFromSource {
+	return &chunksCopyFromSource{chunks: chunks, columns: columns, row: -1}
+}
+
+func (s *chunksCopyFromSource) Next() bool {
+	s.row++
+	for s.row >= len(s.chunk) {
+		chunk, ok := <-s.chunks
+		if !ok {
+			return false
+		}
+		s.chunk, s.row = chunk, 0
+	}
+
+	s.copied++
+	return true
+}
+
+func (s *chunksCopyFromSource) Values() ([]interface{}, error) {
+	values := make([]interface{}, len(s.columns))
+	for i, column := range s.columns {
+		values[i] = s.chunk[s.row][column]
+	}
+	return values, nil
+}
+
+func (s *chunksCopyFromSource) Err() error {
+	return nil
+}
+
+// Method splits table name by the schema, because copy quotes the identifiers
+func createCopyIdentifier(tableName string) pgx.Identifier {
+	return pgx.Identifier(strings.Split(tableName, "."))
+}
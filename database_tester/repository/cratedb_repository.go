@@ -0,0 +1,465 @@
+package repository
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+const (
+	// Tables are sharded even on the single node, so writes and selects are spread over the shards like in the cluster
+	CRATEDB_SHARDS = 4
+	// Single node can't allocate replicas, so tables are created without them to stay healthy
+	CRATEDB_REPLICAS = 0
+)
+
+var crateDBColumnTypes = map[string]string{
+	"BIGSERIAL":   "BIGINT",
+	"SERIAL":      "INTEGER",
+	"SMALLSERIAL": "SMALLINT",
+	"FLOAT":       "DOUBLE PRECISION",
+	// Numeric and date values can't be stored, only computed
+	"NUMERIC":     "DOUBLE PRECISION",
+	"DATE":        "TIMESTAMP WITH TIME ZONE",
+	"VARCHAR":     "TEXT",
+	"TIMESTAMPTZ": "TIMESTAMP WITH TIME ZONE",
+	"BYTEA":       "TEXT",
+	"JSONB":       "TEXT",
+}
+
+// Repository talks Postgres protocol, but CrateDB has neither databases nor sequences and transactions.
+// Databases of the workloads are the schemas, which are created with their first table and are the default ones of the
+// connections opened with their names. Writes are visible to the selects only after the table refresh, except the primary key lookups.
+type crateDBDatabaseTesterRepository struct {
+	*postgresDatabaseTesterRepository
+	// CrateDB has no sequences, so serial columns of the tables are filled by the repository
+	sequences *serialSequences
+	// Tables written since their last refresh
+	mu          sync.Mutex
+	dirtyTables map[string]bool
+}
+
+// NewCrateDBDatabaseTesterRepository creates repository using pgx driver
+func NewCrateDBDatabaseTesterRepository(port uint16, host, user, password string) DatabaseTesterRepository {
+	r := new(crateDBDatabaseTesterRepository)
+	r.postgresDatabaseTesterRepository = newPostgresDatabaseTesterRepository(port, host, user, password, "pgx", "")
+	r.sequences = newSerialSequences()
+	r.dirtyTables = make(map[string]bool)
+	return r
+}
+
+// Schema is created by its first table
+func (r *crateDBDatabaseTesterRepository) CreateDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return nil
+}
+
+// Schema is dropped with its last table
+func (r *crateDBDatabaseTesterRepository) DropDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var tables []string
+	if err := r.db.Select(&tables, "SELECT table_name FROM information_schema.tables WHERE table_schema = $1 AND table_type = 'BASE TABLE'", name); err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if err := r.DropTable(name + "." + table); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *crateDBDatabaseTesterRepository) ListDatabases() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT schema_name FROM information_schema.schemata WHERE schema_name NOT IN ('blob', 'information_schema', 'pg_catalog', 'sys')"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (r *crateDBDatabaseTesterRepository) CreateSchema(name string) error {
+	return r.CreateDatabase(name)
+}
+
+func (r *crateDBDatabaseTesterRepository) DropSchema(name string) error {
+	return r.DropDatabase(name)
+}
+
+func (r *crateDBDatabaseTesterRepository) ListTables() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT table_name FROM information_schema.tables WHERE table_schema = CURRENT_SCHEMA AND table_type = 'BASE TABLE'"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (r *crateDBDatabaseTesterRepository) CreateTable(name string, fields []string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE TABLE ")
+	buf.WriteString(name)
+	buf.WriteString(" (")
+	for i, field := range fields {
+		buf.WriteString(r.convertField(field))
+		if i < len(fields)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(") CLUSTERED INTO ")
+	buf.WriteString(strconv.Itoa(CRATEDB_SHARDS))
+	buf.WriteString(" SHARDS WITH (number_of_replicas = ")
+	buf.WriteString(strconv.Itoa(CRATEDB_REPLICAS))
+	buf.WriteByte(')')
+
+	if _, err := r.db.Exec(buf.String()); err != nil {
+		return err
+	}
+
+	r.sequences.create(name, fields)
+
+	return nil
+}
+
+// Tables are partitioned by the values of the column, not by their ranges
+func (r *crateDBDatabaseTesterRepository) CreatePartitionedTable(name string, fields []string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *crateDBDatabaseTesterRepository) CreateRangePartition(tableName string, partitionName string, from int64, to int64) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *crateDBDatabaseTesterRepository) DropTable(name string) error {
+	if err := r.postgresDatabaseTesterRepository.DropTable(name); err != nil {
+		return err
+	}
+
+	r.sequences.drop(name)
+	r.setTableDirty(name, false)
+
+	return nil
+}
+
+func (r *crateDBDatabaseTesterRepository) AddColumn(tableName string, field string) error {
+	return r.postgresDatabaseTesterRepository.AddColumn(tableName, r.convertField(field))
+}
+
+// Columns can only be added to the existing tables
+func (r *crateDBDatabaseTesterRepository) DropColumn(tableName string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *crateDBDatabaseTesterRepository) SetColumnNotNull(tableName string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *crateDBDatabaseTesterRepository) AlterColumnType(tableName string, column string, columnType string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+// All the columns are indexed by default, and there are no secondary indexes
+func (r *crateDBDatabaseTesterRepository) CreateIndex(tableName string, indexName string, columns []string, concurrently bool) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *crateDBDatabaseTesterRepository) DropIndex(tableName string, indexName string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+// TRUNCATE isn't supported, so rows are deleted
+func (r *crateDBDatabaseTesterRepository) TruncateTable(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if _, err := r.db.Exec("DELETE FROM " + name); err != nil {
+		return err
+	}
+
+	r.setTableDirty(name, true)
+
+	return nil
+}
+
+func (r *crateDBDatabaseTesterRepository) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	columns, values = r.sequences.fill(tableName, columns, values)
+	if err := r.postgresDatabaseTesterRepository.Insert(tableName, columns, values); err != nil {
+		return err
+	}
+
+	r.setTableDirty(tableName, true)
+
+	return nil
+}
+
+func (r *crateDBDatabaseTesterRepository) Upsert(tableName string, keyColumns []string, columns []string, values []map[string]interface{}) error {
+	if err := r.postgresDatabaseTesterRepository.Upsert(tableName, keyColumns, columns, values); err != nil {
+		return err
+	}
+
+	r.setTableDirty(tableName, true)
+
+	return nil
+}
+
+func (r *crateDBDatabaseTesterRepository) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	if err := r.postgresDatabaseTesterRepository.UpdateById(tableName, id, columns, values); err != nil {
+		return err
+	}
+
+	r.setTableDirty(tableName, true)
+
+	return nil
+}
+
+// Table is refreshed before the update, so conditions match all the written rows
+func (r *crateDBDatabaseTesterRepository) UpdateByConditions(tableName string, conditions string, columns []string, values map[string]interface{}) (int64, error) {
+	if err := r.refreshTable(tableName); err != nil {
+		return 0, err
+	}
+
+	updated, err := r.postgresDatabaseTesterRepository.UpdateByConditions(tableName, conditions, columns, values)
+	if err != nil {
+		return 0, err
+	}
+
+	r.setTableDirty(tableName, true)
+
+	return updated, nil
+}
+
+func (r *crateDBDatabaseTesterRepository) DeleteById(tableName string, id int) error {
+	if err := r.postgresDatabaseTesterRepository.DeleteById(tableName, id); err != nil {
+		return err
+	}
+
+	r.setTableDirty(tableName, true)
+
+	return nil
+}
+
+// Table is refreshed before the delete, so conditions match all the written rows
+func (r *crateDBDatabaseTesterRepository) DeleteByConditions(tableName string, conditions string) (int64, error) {
+	if err := r.refreshTable(tableName); err != nil {
+		return 0, err
+	}
+
+	deleted, err := r.postgresDatabaseTesterRepository.DeleteByConditions(tableName, conditions)
+	if err != nil {
+		return 0, err
+	}
+
+	r.setTableDirty(tableName, true)
+
+	return deleted, nil
+}
+
+func (r *crateDBDatabaseTesterRepository) DeleteAll(tableName string) (int64, error) {
+	if err := r.refreshTable(tableName); err != nil {
+		return 0, err
+	}
+
+	deleted, err := r.postgresDatabaseTesterRepository.DeleteAll(tableName)
+	if err != nil {
+		return 0, err
+	}
+
+	r.setTableDirty(tableName, true)
+
+	return deleted, nil
+}
+
+// BEGIN and COMMIT are accepted for the compatibility only, so statements aren't isolated and can't be rolled back
+func (r *crateDBDatabaseTesterRepository) BeginTransaction() (DatabaseTesterTransaction, error) {
+	return nil, domain.UNSUPPORTED_OPERATION
+}
+
+func (r *crateDBDatabaseTesterRepository) UpdateInTransaction(tableName string, column string, deltas map[int64]int64, isolationLevel domain.IsolationLevel) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *crateDBDatabaseTesterRepository) WithdrawInTransaction(tableName string, column string, id int64, amount int64, isolationLevel domain.IsolationLevel) (bool, error) {
+	return false, domain.UNSUPPORTED_OPERATION
+}
+
+func (r *crateDBDatabaseTesterRepository) SelectByConditions(tableName string, conditions string) error {
+	if err := r.refreshTable(tableName); err != nil {
+		return err
+	}
+
+	return r.postgresDatabaseTesterRepository.SelectByConditions(tableName, conditions)
+}
+
+func (r *crateDBDatabaseTesterRepository) SelectJoin(tableName string, column string, joinedTableName string, joinType domain.JoinType) error {
+	if err := r.refreshTables(tableName, joinedTableName); err != nil {
+		return err
+	}
+
+	return r.postgresDatabaseTesterRepository.SelectJoin(tableName, column, joinedTableName, joinType)
+}
+
+func (r *crateDBDatabaseTesterRepository) SelectJoinAggregation(tableName string, column string, joinedTableName string, aggregatedColumn string) error {
+	if err := r.refreshTables(tableName, joinedTableName); err != nil {
+		return err
+	}
+
+	return r.postgresDatabaseTesterRepository.SelectJoinAggregation(tableName, column, joinedTableName, aggregatedColumn)
+}
+
+func (r *crateDBDatabaseTesterRepository) SelectAggregation(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) error {
+	if err := r.refreshTable(tableName); err != nil {
+		return err
+	}
+
+	return r.postgresDatabaseTesterRepository.SelectAggregation(tableName, groupColumn, aggregation, aggregatedColumn)
+}
+
+func (r *crateDBDatabaseTesterRepository) SelectPage(tableName string, offset int, limit int) (int64, error) {
+	if err := r.refreshTable(tableName); err != nil {
+		return 0, err
+	}
+
+	return r.postgresDatabaseTesterRepository.SelectPage(tableName, offset, limit)
+}
+
+func (r *crateDBDatabaseTesterRepository) SelectPageAfterId(tableName string, id int64, limit int) (int64, error) {
+	if err := r.refreshTable(tableName); err != nil {
+		return 0, err
+	}
+
+	return r.postgresDatabaseTesterRepository.SelectPageAfterId(tableName, id, limit)
+}
+
+func (r *crateDBDatabaseTesterRepository) CountRows(tableName string) (int64, error) {
+	if err := r.refreshTable(tableName); err != nil {
+		return 0, err
+	}
+
+	return r.postgresDatabaseTesterRepository.CountRows(tableName)
+}
+
+func (r *crateDBDatabaseTesterRepository) SumColumn(tableName string, column string) (int64, error) {
+	if err := r.refreshTable(tableName); err != nil {
+		return 0, err
+	}
+
+	return r.postgresDatabaseTesterRepository.SumColumn(tableName, column)
+}
+
+func (r *crateDBDatabaseTesterRepository) BackupCommand(filePath string) []string {
+	return nil
+}
+
+// Table is marked as written once the insert is prepared, so it's refreshed before the next read after the executed inserts
+func (r *crateDBDatabaseTesterRepository) PrepareInsert(tableName string, columns []string) (PreparedStatement, error) {
+	stmt, err := r.postgresDatabaseTesterRepository.PrepareInsert(tableName, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	r.setTableDirty(tableName, true)
+
+	return stmt, nil
+}
+
+func (r *crateDBDatabaseTesterRepository) PrepareSelectById(tableName string) (PreparedStatement, error) {
+	if err := r.refreshTable(tableName); err != nil {
+		return nil, err
+	}
+
+	return r.postgresDatabaseTesterRepository.PrepareSelectById(tableName)
+}
+
+// CrateDB supports COPY FROM files only
+func (r *crateDBDatabaseTesterRepository) CopyFrom(tableName string, columns []string, chunks <-chan []map[string]interface{}) (int64, error) {
+	return 0, domain.UNSUPPORTED_OPERATION
+}
+
+func (r *crateDBDatabaseTesterRepository) RestoreCommand(filePath string, dbname string) []string {
+	return nil
+}
+
+// Method refreshes table if it was written since the last refresh, so selects see all the written rows
+func (r *crateDBDatabaseTesterRepository) refreshTable(tableName string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	r.mu.Lock()
+	dirty := r.dirtyTables[tableName]
+	r.mu.Unlock()
+	if !dirty {
+		return nil
+	}
+
+	if _, err := r.db.Exec("REFRESH TABLE " + tableName); err != nil {
+		return err
+	}
+
+	r.setTableDirty(tableName, false)
+
+	return nil
+}
+
+func (r *crateDBDatabaseTesterRepository) refreshTables(tableNames ...string) error {
+	for _, tableName := range tableNames {
+		if err := r.refreshTable(tableName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *crateDBDatabaseTesterRepository) setTableDirty(tableName string, dirty bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if dirty {
+		r.dirtyTables[tableName] = true
+	} else {
+		delete(r.dirtyTables, tableName)
+	}
+}
+
+// Primary key and not null options are kept, because they are supported by all the column types
+func (r *crateDBDatabaseTesterRepository) convertField(field string) string {
+	name, columnType, options, ok := splitFieldDefinition(field)
+	if !ok {
+		return field
+	}
+
+	dialectType := columnType
+	if t, ok := crateDBColumnTypes[strings.ToUpper(columnType)]; ok {
+		dialectType = t
+	}
+
+	if options == "" {
+		return name + " " + dialectType
+	}
+	return name + " " + dialectType + " " + options
+}
@@ -0,0 +1,17 @@
+// Package extensions is the directory for the database repositories which aren't the part of the repo.
+//
+// Extension is the file of this package guarded by its own build tag, which registers repository on init:
+//
+//	//go:build cott_mydb
+//
+//	package extensions
+//
+//	func init() {
+//		repository.RegisterDatabaseTesterRepository("mydb", func(tc *domain.TestCase, port uint16) (repository.DatabaseTesterRepository, error) {
+//			return newMyDbRepository(port, "localhost", tc.User, tc.Password), nil
+//		})
+//	}
+//
+// It's compiled in with "go build -tags cott_mydb". The same init function in the main package of the
+// "go build -buildmode=plugin" module registers repository when the plugin is listed in the repositoryplugins config.
+package extensions
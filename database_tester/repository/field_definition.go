@@ -0,0 +1,23 @@
+package repository
+
+import "strings"
+
+// Splits Postgres field definition of the workload tables like "id BIGSERIAL PRIMARY KEY"
+// into column name, type and options. Returns false if definition has no type.
+func splitFieldDefinition(field string) (name string, columnType string, options string, ok bool) {
+	parts := strings.SplitN(field, " ", 3)
+	if len(parts) < 2 {
+		return "", "", "", false
+	}
+
+	if len(parts) == 3 {
+		options = parts[2]
+	}
+
+	return parts[0], parts[1], options, true
+}
+
+// Checks that column of the Postgres type is filled by the sequence
+func isSerialType(columnType string) bool {
+	return strings.HasSuffix(strings.ToUpper(columnType), "SERIAL")
+}
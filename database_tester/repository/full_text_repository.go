@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"bytes"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+// Text search configuration of the indexed and the searched text vectors, which must be the same for the index to be used
+const POSTGRES_TEXT_SEARCH_CONFIG = "'english'"
+
+func (r *postgresDatabaseTesterRepository) CreateFullTextIndex(tableName string, indexName string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE INDEX ")
+	buf.WriteString(indexName)
+	buf.WriteString(" ON ")
+	buf.WriteString(tableName)
+	buf.WriteString(" USING GIN (to_tsvector(")
+	buf.WriteString(POSTGRES_TEXT_SEARCH_CONFIG)
+	buf.WriteByte(',')
+	buf.WriteString(column)
+	buf.WriteString("))")
+
+	_, err := r.db.Exec(buf.String())
+	return err
+}
+
+func (r *postgresDatabaseTesterRepository) SearchText(tableName string, column string, query string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE to_tsvector(")
+	buf.WriteString(POSTGRES_TEXT_SEARCH_CONFIG)
+	buf.WriteByte(',')
+	buf.WriteString(column)
+	buf.WriteString(") @@ plainto_tsquery(")
+	buf.WriteString(POSTGRES_TEXT_SEARCH_CONFIG)
+	buf.WriteString(",$1)")
+
+	rows, err := r.db.Query(buf.String(), query)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+func (r *mysqlDatabaseTesterRepository) CreateFullTextIndex(tableName string, indexName string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE FULLTEXT INDEX ")
+	buf.WriteString(indexName)
+	buf.WriteString(" ON ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	buf.WriteString(column)
+	buf.WriteByte(')')
+
+	_, err := r.db.Exec(buf.String())
+	return err
+}
+
+// MATCH requires the full-text index of the column, so it fails until the index is created
+func (r *mysqlDatabaseTesterRepository) SearchText(tableName string, column string, query string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE MATCH(")
+	buf.WriteString(column)
+	buf.WriteString(") AGAINST (? IN NATURAL LANGUAGE MODE)")
+
+	rows, err := r.db.Query(buf.String(), query)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"bytes"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+var sqlJoins = map[domain.JoinType]string{
+	domain.JoinType_Inner: "INNER JOIN",
+	domain.JoinType_Left:  "LEFT JOIN",
+}
+
+// Creates select of the table rows joined by the column with the ids of the joined table.
+// Tables are aliased without AS keyword, because Oracle doesn't accept it.
+func createJoinStatement(tableName string, column string, joinedTableName string, joinType domain.JoinType) (string, error) {
+	join, ok := sqlJoins[joinType]
+	if !ok {
+		return "", domain.UNKNOWN_JOIN_TYPE
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	writeJoin(&buf, tableName, column, joinedTableName, join)
+
+	return buf.String(), nil
+}
+
+// Creates select of the rows count and the aggregated column sum of the table rows by the ids of the joined table
+func createJoinAggregationStatement(tableName string, column string, joinedTableName string, aggregatedColumn string) string {
+	var buf bytes.Buffer
+	buf.WriteString("SELECT b.id,COUNT(*),SUM(a.")
+	buf.WriteString(aggregatedColumn)
+	buf.WriteString(") FROM ")
+	writeJoin(&buf, tableName, column, joinedTableName, sqlJoins[domain.JoinType_Inner])
+	buf.WriteString(" GROUP BY b.id")
+
+	return buf.String()
+}
+
+func writeJoin(buf *bytes.Buffer, tableName string, column string, joinedTableName string, join string) {
+	buf.WriteString(tableName)
+	buf.WriteString(" a ")
+	buf.WriteString(join)
+	buf.WriteByte(' ')
+	buf.WriteString(joinedTableName)
+	buf.WriteString(" b ON a.")
+	buf.WriteString(column)
+	buf.WriteString("=b.id")
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"bytes"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+func (r *postgresDatabaseTesterRepository) CreateJsonIndex(tableName string, indexName string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE INDEX ")
+	buf.WriteString(indexName)
+	buf.WriteString(" ON ")
+	buf.WriteString(tableName)
+	buf.WriteString(" USING GIN (")
+	buf.WriteString(column)
+	buf.WriteByte(')')
+
+	_, err := r.db.Exec(buf.String())
+	return err
+}
+
+// Documents are passed by their text representation, which is parsed into JSONB by the server
+func (r *postgresDatabaseTesterRepository) SelectJsonContains(tableName string, column string, document string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query("SELECT * FROM "+tableName+" WHERE "+column+" @> $1::jsonb", document)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// Path keys are written as the literals, because they are the constants of the workload
+func (r *postgresDatabaseTesterRepository) SelectJsonPath(tableName string, column string, path []string, value string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(column)
+	for i, key := range path {
+		if i < len(path)-1 {
+			buf.WriteString("->'")
+		} else {
+			buf.WriteString("->>'")
+		}
+		buf.WriteString(key)
+		buf.WriteByte('\'')
+	}
+	buf.WriteString("=$1")
+
+	rows, err := r.db.Query(buf.String(), value)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
@@ -0,0 +1,25 @@
+package repository
+
+// MariaDB has no time zone aware timestamps and keeps microseconds only in the types with explicit precision,
+// so temporal columns are mapped to DATETIME(6) to store the same values as Postgres ones.
+// NUMERIC without precision is DECIMAL(10,0) there, so it's widened to the max precision.
+var mariadbColumnTypes = map[string]string{
+	"BIGSERIAL":   "BIGINT",
+	"SERIAL":      "INTEGER",
+	"SMALLSERIAL": "SMALLINT",
+	"TIMESTAMP":   "DATETIME(6)",
+	"TIMESTAMPTZ": "DATETIME(6)",
+	"NUMERIC":     "DECIMAL(65,30)",
+	"BYTEA":       "LONGBLOB",
+	"JSONB":       "LONGTEXT",
+}
+
+// NewMariaDBDatabaseTesterRepository creates MySQL protocol repository with MariaDB column types and client tools
+func NewMariaDBDatabaseTesterRepository(port uint16, host, user, password, storageEngine string) DatabaseTesterRepository {
+	r := newMySQLDatabaseTesterRepository(port, host, user, password, storageEngine)
+	r.columnTypes = mariadbColumnTypes
+	// mysql named tools aren't shipped by the latest images
+	r.dumpTool = "mariadb-dump"
+	r.clientTool = "mariadb"
+	return r
+}
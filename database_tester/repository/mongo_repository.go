@@ -0,0 +1,770 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+const (
+	// Primary key column is stored as the document ID
+	MONGO_ID_FIELD = "_id"
+	// Array field of the looked up documents of the joined collection
+	MONGO_JOINED_FIELD = "joined"
+)
+
+var mongoOperators = map[string]string{">=": "$gte", "<=": "$lte", "<>": "$ne", "!=": "$ne", "=": "$eq", ">": "$gt", "<": "$lt"}
+
+// Repository maps tables of the workload to the collections and rows to the documents.
+// Collections have no schema, so the column DDL only changes the existing documents or is skipped.
+type mongoDatabaseTesterRepository struct {
+	client   *mongo.Client
+	port     uint16
+	host     string
+	user     string
+	password string
+	dbname   string
+	// Serial primary keys of the collections are filled by the repository
+	sequencesMu sync.Mutex
+	// Collection name to the primary key column and its last serial value
+	primaryKeys map[string]string
+	sequences   map[string]int64
+}
+
+// NewMongoDatabaseTesterRepository creates repository using the official driver. User is authenticated by the admin database if it is set.
+func NewMongoDatabaseTesterRepository(port uint16, host, user, password string) DatabaseTesterRepository {
+	r := new(mongoDatabaseTesterRepository)
+	r.port = port
+	r.host = host
+	r.user = user
+	r.password = password
+	r.dbname = ""
+	r.primaryKeys = make(map[string]string)
+	r.sequences = make(map[string]int64)
+	return r
+}
+
+func (r *mongoDatabaseTesterRepository) Open() error {
+	var err error
+	r.client, err = mongo.Connect(context.Background(), options.Client().ApplyURI(r.createConnUrl(r.host)))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mongoDatabaseTesterRepository) Ping() error {
+	ctx, ctxCancelFunc := context.WithTimeout(context.Background(), PING_TIMEOUT)
+	defer ctxCancelFunc()
+	if err := r.client.Ping(ctx, readpref.Primary()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Database is created by the first write into its collection
+func (r *mongoDatabaseTesterRepository) CreateDatabase(name string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return nil
+}
+
+func (r *mongoDatabaseTesterRepository) DropDatabase(name string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.client.Database(name).Drop(context.Background())
+}
+
+// Client isn't bound to the database, so the connection is kept
+func (r *mongoDatabaseTesterRepository) SwitchDatabase(name string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	r.dbname = name
+
+	return nil
+}
+
+func (r *mongoDatabaseTesterRepository) ListDatabases() ([]string, error) {
+	if r.client == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.client.ListDatabaseNames(context.Background(), bson.D{})
+}
+
+// Collection names could contain dots, so "schema.table" collections are created without the schema itself
+func (r *mongoDatabaseTesterRepository) CreateSchema(name string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return nil
+}
+
+func (r *mongoDatabaseTesterRepository) DropSchema(name string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	names, err := r.ListTables()
+	if err != nil {
+		return err
+	}
+
+	for _, collection := range names {
+		if strings.HasPrefix(collection, name+".") {
+			if err := r.DropTable(collection); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *mongoDatabaseTesterRepository) ListTables() ([]string, error) {
+	if r.client == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.client.Database(r.dbname).ListCollectionNames(context.Background(), bson.D{})
+}
+
+func (r *mongoDatabaseTesterRepository) CreateTable(name string, fields []string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.client.Database(r.dbname).CreateCollection(context.Background(), name); err != nil {
+		return err
+	}
+
+	r.sequencesMu.Lock()
+	defer r.sequencesMu.Unlock()
+	for _, field := range fields {
+		column, columnType, options, ok := splitFieldDefinition(field)
+		if !ok || !strings.Contains(strings.ToUpper(options), "PRIMARY KEY") {
+			continue
+		}
+		r.primaryKeys[name] = column
+		if isSerialType(columnType) {
+			r.sequences[name] = 0
+		}
+	}
+
+	return nil
+}
+
+// Sharding is configured by the cluster, not by the collection DDL
+func (r *mongoDatabaseTesterRepository) CreatePartitionedTable(name string, fields []string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *mongoDatabaseTesterRepository) CreateRangePartition(tableName string, partitionName string, from int64, to int64) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *mongoDatabaseTesterRepository) DropTable(name string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.collection(name).Drop(context.Background()); err != nil {
+		return err
+	}
+
+	r.sequencesMu.Lock()
+	delete(r.primaryKeys, name)
+	delete(r.sequences, name)
+	r.sequencesMu.Unlock()
+
+	return nil
+}
+
+func (r *mongoDatabaseTesterRepository) AddColumn(tableName string, field string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return nil
+}
+
+// Field is removed from every document like the column is removed from every row
+func (r *mongoDatabaseTesterRepository) DropColumn(tableName string, column string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	_, err := r.collection(tableName).UpdateMany(context.Background(), bson.D{}, bson.D{{Key: "$unset", Value: bson.D{{Key: r.fieldName(tableName, column), Value: ""}}}})
+	return err
+}
+
+func (r *mongoDatabaseTesterRepository) SetColumnNotNull(tableName string, column string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return nil
+}
+
+func (r *mongoDatabaseTesterRepository) AlterColumnType(tableName string, column string, columnType string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return nil
+}
+
+// Indexes are built without blocking writes since MongoDB 4.2, so concurrently flag is ignored
+func (r *mongoDatabaseTesterRepository) CreateIndex(tableName string, indexName string, columns []string, concurrently bool) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	keys := bson.D{}
+	for _, column := range columns {
+		keys = append(keys, bson.E{Key: r.fieldName(tableName, column), Value: 1})
+	}
+
+	_, err := r.collection(tableName).Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: keys, Options: options.Index().SetName(indexName)})
+	return err
+}
+
+func (r *mongoDatabaseTesterRepository) DropIndex(tableName string, indexName string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	_, err := r.collection(tableName).Indexes().DropOne(context.Background(), indexName)
+	return err
+}
+
+func (r *mongoDatabaseTesterRepository) TruncateTable(name string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	_, err := r.collection(name).DeleteMany(context.Background(), bson.D{})
+	return err
+}
+
+func (r *mongoDatabaseTesterRepository) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.insert(context.Background(), tableName, columns, values)
+}
+
+// Driver splits documents by the max message size
+func (r *mongoDatabaseTesterRepository) MaxInsertRows(columnsCount int) int {
+	return 0
+}
+
+// Transactions are supported by the replica set members only
+func (r *mongoDatabaseTesterRepository) BeginTransaction() (DatabaseTesterTransaction, error) {
+	if r.client == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	session, err := r.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.StartTransaction(); err != nil {
+		session.EndSession(context.Background())
+		return nil, err
+	}
+
+	return &mongoDatabaseTesterTransaction{session: session, r: r}, nil
+}
+
+func (r *mongoDatabaseTesterRepository) Upsert(tableName string, keyColumns []string, columns []string, values []map[string]interface{}) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	models := make([]mongo.WriteModel, 0, len(values))
+	for _, v := range values {
+		filter := bson.D{}
+		for _, column := range keyColumns {
+			filter = append(filter, bson.E{Key: r.fieldName(tableName, column), Value: v[column]})
+		}
+		update := bson.D{}
+		for _, column := range columns {
+			update = append(update, bson.E{Key: r.fieldName(tableName, column), Value: v[column]})
+		}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(bson.D{{Key: "$set", Value: update}}).SetUpsert(true))
+	}
+
+	_, err := r.collection(tableName).BulkWrite(context.Background(), models)
+	return err
+}
+
+func (r *mongoDatabaseTesterRepository) IsConflictError(err error) bool {
+	return mongo.IsDuplicateKeyError(err)
+}
+
+func (r *mongoDatabaseTesterRepository) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	_, err := r.collection(tableName).UpdateOne(context.Background(), bson.D{{Key: MONGO_ID_FIELD, Value: id}}, r.createSetUpdate(tableName, columns, values))
+	return err
+}
+
+// Documents which values haven't been changed by the update aren't counted
+func (r *mongoDatabaseTesterRepository) UpdateByConditions(tableName string, conditions string, columns []string, values map[string]interface{}) (int64, error) {
+	if r.client == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	filter, err := r.convertConditions(tableName, conditions)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := r.collection(tableName).UpdateMany(context.Background(), filter, r.createSetUpdate(tableName, columns, values))
+	if err != nil {
+		return 0, err
+	}
+
+	return res.ModifiedCount, nil
+}
+
+func (r *mongoDatabaseTesterRepository) DeleteById(tableName string, id int) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	_, err := r.collection(tableName).DeleteOne(context.Background(), bson.D{{Key: MONGO_ID_FIELD, Value: id}})
+	return err
+}
+
+func (r *mongoDatabaseTesterRepository) DeleteByConditions(tableName string, conditions string) (int64, error) {
+	if r.client == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	filter, err := r.convertConditions(tableName, conditions)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := r.collection(tableName).DeleteMany(context.Background(), filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.DeletedCount, nil
+}
+
+func (r *mongoDatabaseTesterRepository) DeleteAll(tableName string) (int64, error) {
+	if r.client == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	res, err := r.collection(tableName).DeleteMany(context.Background(), bson.D{})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.DeletedCount, nil
+}
+
+func (r *mongoDatabaseTesterRepository) SelectById(tableName string, id int) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	err := r.collection(tableName).FindOne(context.Background(), bson.D{{Key: MONGO_ID_FIELD, Value: id}}).Err()
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	return nil
+}
+
+// All found documents are read, because SQL server sends all the rows too.
+func (r *mongoDatabaseTesterRepository) SelectByConditions(tableName string, conditions string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	filter, err := r.convertConditions(tableName, conditions)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	cursor, err := r.collection(tableName).Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+	}
+
+	return cursor.Err()
+}
+
+// Joined documents are looked up by the aggregation pipeline and unwound into the pairs of the documents like the joined rows.
+// Documents without the joined ones are kept by the left join.
+func (r *mongoDatabaseTesterRepository) SelectJoin(tableName string, column string, joinedTableName string, joinType domain.JoinType) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if joinType != domain.JoinType_Inner && joinType != domain.JoinType_Left {
+		return domain.UNKNOWN_JOIN_TYPE
+	}
+
+	pipeline := mongo.Pipeline{
+		r.createLookupStage(tableName, column, joinedTableName),
+		{{Key: "$unwind", Value: bson.D{{Key: "path", Value: "$" + MONGO_JOINED_FIELD}, {Key: "preserveNullAndEmptyArrays", Value: joinType == domain.JoinType_Left}}}},
+	}
+
+	return r.readAggregation(tableName, pipeline)
+}
+
+func (r *mongoDatabaseTesterRepository) SelectJoinAggregation(tableName string, column string, joinedTableName string, aggregatedColumn string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	pipeline := mongo.Pipeline{
+		r.createLookupStage(tableName, column, joinedTableName),
+		{{Key: "$unwind", Value: "$" + MONGO_JOINED_FIELD}},
+		{{Key: "$group", Value: bson.D{
+			{Key: MONGO_ID_FIELD, Value: "$" + MONGO_JOINED_FIELD + "." + MONGO_ID_FIELD},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "sum", Value: bson.D{{Key: "$sum", Value: "$" + r.fieldName(tableName, aggregatedColumn)}}},
+		}}},
+	}
+
+	return r.readAggregation(tableName, pipeline)
+}
+
+func (r *mongoDatabaseTesterRepository) SelectAggregation(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var accumulator bson.D
+	switch aggregation {
+	case domain.Aggregation_Count:
+		accumulator = bson.D{{Key: "$sum", Value: 1}}
+	case domain.Aggregation_Sum:
+		accumulator = bson.D{{Key: "$sum", Value: "$" + r.fieldName(tableName, aggregatedColumn)}}
+	case domain.Aggregation_Avg:
+		accumulator = bson.D{{Key: "$avg", Value: "$" + r.fieldName(tableName, aggregatedColumn)}}
+	default:
+		return domain.UNKNOWN_AGGREGATION
+	}
+
+	// Documents are aggregated into the single group by the null id if the group column isn't set
+	var id interface{}
+	if groupColumn != "" {
+		id = "$" + r.fieldName(tableName, groupColumn)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: MONGO_ID_FIELD, Value: id},
+			{Key: string(aggregation), Value: accumulator},
+		}}},
+	}
+
+	return r.readAggregation(tableName, pipeline)
+}
+
+func (r *mongoDatabaseTesterRepository) SelectPage(tableName string, offset int, limit int) (int64, error) {
+	if r.client == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.readPage(tableName, bson.D{}, options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)))
+}
+
+func (r *mongoDatabaseTesterRepository) SelectPageAfterId(tableName string, id int64, limit int) (int64, error) {
+	if r.client == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.readPage(tableName, bson.D{{Key: MONGO_ID_FIELD, Value: bson.D{{Key: "$gt", Value: id}}}}, options.Find().SetLimit(int64(limit)))
+}
+
+func (r *mongoDatabaseTesterRepository) SelectColumnById(tableName string, column string, id int64, dest interface{}) error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	field := r.fieldName(tableName, column)
+	doc, err := r.collection(tableName).FindOne(context.Background(), bson.D{{Key: MONGO_ID_FIELD, Value: id}}, options.FindOne().SetProjection(bson.D{{Key: field, Value: 1}})).DecodeBytes()
+	if err != nil {
+		return err
+	}
+
+	return doc.Lookup(field).Unmarshal(dest)
+}
+
+func (r *mongoDatabaseTesterRepository) CountRows(tableName string) (int64, error) {
+	if r.client == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.collection(tableName).CountDocuments(context.Background(), bson.D{})
+}
+
+func (r *mongoDatabaseTesterRepository) SumColumn(tableName string, column string) (int64, error) {
+	if r.client == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	ctx := context.Background()
+	pipeline := mongo.Pipeline{{{Key: "$group", Value: bson.D{
+		{Key: MONGO_ID_FIELD, Value: nil},
+		{Key: "sum", Value: bson.D{{Key: "$sum", Value: "$" + r.fieldName(tableName, column)}}},
+	}}}}
+	cursor, err := r.collection(tableName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	// Empty collection has no groups
+	if !cursor.Next(ctx) {
+		return 0, cursor.Err()
+	}
+
+	var sum int64
+	if err := cursor.Current.Lookup("sum").Unmarshal(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum, nil
+}
+
+// MongoDB transactions have the snapshot isolation only, so isolation levels can't be compared
+func (r *mongoDatabaseTesterRepository) UpdateInTransaction(tableName string, column string, deltas map[int64]int64, isolationLevel domain.IsolationLevel) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *mongoDatabaseTesterRepository) WithdrawInTransaction(tableName string, column string, id int64, amount int64, isolationLevel domain.IsolationLevel) (bool, error) {
+	return false, domain.UNSUPPORTED_OPERATION
+}
+
+func (r *mongoDatabaseTesterRepository) BackupCommand(filePath string) []string {
+	return []string{"mongodump", "--uri=" + r.createConnUrl("localhost"), "--db=" + r.dbname, "--archive=" + filePath}
+}
+
+// Collections of the dumped database are renamed into the restored one
+func (r *mongoDatabaseTesterRepository) RestoreCommand(filePath string, dbname string) []string {
+	return []string{"mongorestore", "--uri=" + r.createConnUrl("localhost"), "--archive=" + filePath, "--nsFrom=" + r.dbname + ".*", "--nsTo=" + dbname + ".*"}
+}
+
+// MongoDB has no database/sql driver
+func (r *mongoDatabaseTesterRepository) SqlDB() *sql.DB {
+	return nil
+}
+
+func (r *mongoDatabaseTesterRepository) Close() error {
+	if r.client == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.client.Disconnect(context.Background()); err != nil {
+		return err
+	}
+
+	r.client = nil
+
+	return nil
+}
+
+// Method creates connection URL of the server on the host, tools inside the container use localhost
+func (r *mongoDatabaseTesterRepository) createConnUrl(host string) string {
+	u := url.URL{Scheme: "mongodb", Host: host + ":" + strconv.FormatUint(uint64(r.port), 10), Path: "/"}
+	if r.user != "" {
+		u.User = url.UserPassword(r.user, r.password)
+		u.RawQuery = "authSource=admin"
+	}
+	return u.String()
+}
+
+func (r *mongoDatabaseTesterRepository) collection(name string) *mongo.Collection {
+	return r.client.Database(r.dbname).Collection(name)
+}
+
+// Method returns document field of the column, primary key column is the document ID
+func (r *mongoDatabaseTesterRepository) fieldName(tableName string, column string) string {
+	column = strings.ToLower(column)
+
+	r.sequencesMu.Lock()
+	defer r.sequencesMu.Unlock()
+
+	if primaryKey, ok := r.primaryKeys[tableName]; ok && primaryKey == column {
+		return MONGO_ID_FIELD
+	}
+	return column
+}
+
+func (r *mongoDatabaseTesterRepository) createSetUpdate(tableName string, columns []string, values map[string]interface{}) bson.D {
+	update := make(bson.D, 0, len(columns))
+	for _, column := range columns {
+		update = append(update, bson.E{Key: r.fieldName(tableName, column), Value: values[column]})
+	}
+	return bson.D{{Key: "$set", Value: update}}
+}
+
+// Method creates stage looking up the documents of the joined collection by their IDs into the array field
+func (r *mongoDatabaseTesterRepository) createLookupStage(tableName string, column string, joinedTableName string) bson.D {
+	return bson.D{{Key: "$lookup", Value: bson.D{
+		{Key: "from", Value: joinedTableName},
+		{Key: "localField", Value: r.fieldName(tableName, column)},
+		{Key: "foreignField", Value: MONGO_ID_FIELD},
+		{Key: "as", Value: MONGO_JOINED_FIELD},
+	}}}
+}
+
+// Method reads all the documents of the aggregation, like SQL server sends all the rows
+func (r *mongoDatabaseTesterRepository) readAggregation(tableName string, pipeline mongo.Pipeline) error {
+	ctx := context.Background()
+	cursor, err := r.collection(tableName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+	}
+
+	return cursor.Err()
+}
+
+// Method reads documents of the page sorted by id and returns id of the last one
+func (r *mongoDatabaseTesterRepository) readPage(tableName string, filter bson.D, opts *options.FindOptions) (int64, error) {
+	ctx := context.Background()
+	cursor, err := r.collection(tableName).Find(ctx, filter, opts.SetSort(bson.D{{Key: MONGO_ID_FIELD, Value: 1}}))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var lastId interface{}
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return 0, err
+		}
+		lastId = doc[MONGO_ID_FIELD]
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, err
+	}
+
+	return parsePageId(lastId)
+}
+
+func (r *mongoDatabaseTesterRepository) insert(ctx context.Context, tableName string, columns []string, values []map[string]interface{}) error {
+	fields := make([]string, len(columns))
+	for i, column := range columns {
+		fields[i] = r.fieldName(tableName, column)
+	}
+
+	docs := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		doc := make(bson.D, 0, len(columns)+1)
+		for i, column := range columns {
+			doc = append(doc, bson.E{Key: fields[i], Value: v[column]})
+		}
+		docs = append(docs, doc)
+	}
+
+	// Documents without primary key get the next values of the serial
+	r.sequencesMu.Lock()
+	if _, ok := r.sequences[tableName]; ok && !containsColumn(fields, MONGO_ID_FIELD) {
+		for i := range docs {
+			r.sequences[tableName]++
+			docs[i] = append(bson.D{{Key: MONGO_ID_FIELD, Value: r.sequences[tableName]}}, docs[i].(bson.D)...)
+		}
+	}
+	r.sequencesMu.Unlock()
+
+	_, err := r.collection(tableName).InsertMany(ctx, docs)
+	return err
+}
+
+// Method converts SQL conditions joined by AND into the filter document
+func (r *mongoDatabaseTesterRepository) convertConditions(tableName string, conditions string) (bson.D, error) {
+	parsed, ok := parseConditions(conditions)
+	if !ok {
+		return nil, domain.UNSUPPORTED_OPERATION
+	}
+
+	filter := bson.D{}
+	for _, c := range parsed {
+		filter = append(filter, bson.E{Key: r.fieldName(tableName, c.column), Value: bson.D{{Key: mongoOperators[c.operator], Value: r.convertLiteral(c.literal)}}})
+	}
+
+	return filter, nil
+}
+
+func (r *mongoDatabaseTesterRepository) convertLiteral(literal string) interface{} {
+	if i, err := strconv.ParseInt(literal, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(literal, 64); err == nil {
+		return f
+	}
+	if b, err := strconv.ParseBool(literal); err == nil {
+		return b
+	}
+	return strings.ReplaceAll(strings.Trim(literal, "'"), "''", "'")
+}
+
+type mongoDatabaseTesterTransaction struct {
+	session mongo.Session
+	r       *mongoDatabaseTesterRepository
+}
+
+func (t *mongoDatabaseTesterTransaction) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	return t.r.insert(mongo.NewSessionContext(context.Background(), t.session), tableName, columns, values)
+}
+
+func (t *mongoDatabaseTesterTransaction) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	ctx := mongo.NewSessionContext(context.Background(), t.session)
+	_, err := t.r.collection(tableName).UpdateOne(ctx, bson.D{{Key: MONGO_ID_FIELD, Value: id}}, t.r.createSetUpdate(tableName, columns, values))
+	return err
+}
+
+func (t *mongoDatabaseTesterTransaction) Commit() error {
+	defer t.session.EndSession(context.Background())
+	return t.session.CommitTransaction(context.Background())
+}
+
+func (t *mongoDatabaseTesterTransaction) Rollback() error {
+	defer t.session.EndSession(context.Background())
+	return t.session.AbortTransaction(context.Background())
+}
@@ -0,0 +1,1218 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	mssql "github.com/denisenkom/go-mssqldb"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// Max parameters count of the single request is 2100, one of them is reserved by the driver
+	MSSQL_MAX_PARAMETERS = 2099
+	// Max rows count of the VALUES table value constructor
+	MSSQL_MAX_INSERT_ROWS = 1000
+	// https://docs.microsoft.com/en-us/sql/relational-databases/errors-events/database-engine-events-and-errors
+	MSSQL_UNIQUE_CONSTRAINT_ERROR_NUMBER = 2627
+	MSSQL_UNIQUE_INDEX_ERROR_NUMBER      = 2601
+	// Paths of the image
+	MSSQL_SQLCMD_PATH   = "/opt/mssql-tools/bin/sqlcmd"
+	MSSQL_DATA_DIR_PATH = "/var/opt/mssql/data/"
+)
+
+// Column types of the workload tables which are named differently in T-SQL.
+// NUMERIC without precision is NUMERIC(18,0) there, so it's widened to keep fractional part.
+var mssqlColumnTypes = map[string]string{
+	"BIGSERIAL":   "BIGINT",
+	"SERIAL":      "INT",
+	"SMALLSERIAL": "SMALLINT",
+	"INTEGER":     "INT",
+	"BOOLEAN":     "BIT",
+	"NUMERIC":     "NUMERIC(38,10)",
+	"TEXT":        "NVARCHAR(MAX)",
+	"TIMESTAMP":   "DATETIME2",
+	"TIMESTAMPTZ": "DATETIMEOFFSET",
+	"BYTEA":       "VARBINARY(MAX)",
+	"JSONB":       "NVARCHAR(MAX)",
+}
+
+type mssqlDatabaseTesterRepository struct {
+	db       *sqlx.DB
+	port     uint16
+	host     string
+	user     string
+	password string
+	dbname   string
+	// Explicit values of the IDENTITY columns are inserted only with IDENTITY_INSERT option of the table
+	identityColumnsMu sync.RWMutex
+	identityColumns   map[string]string
+}
+
+// NewMSSQLDatabaseTesterRepository creates repository using go-mssqldb
+func NewMSSQLDatabaseTesterRepository(port uint16, host, user, password string) DatabaseTesterRepository {
+	r := new(mssqlDatabaseTesterRepository)
+	r.port = port
+	r.host = host
+	r.user = user
+	r.password = password
+	r.dbname = ""
+	r.identityColumns = make(map[string]string)
+	return r
+}
+
+func (r *mssqlDatabaseTesterRepository) Open() error {
+	var err error
+	r.db, err = sqlx.Open("sqlserver", r.createConnString(r.port, r.host, r.user, r.password, r.dbname))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) Ping() error {
+	ctx, ctxCancelFunc := context.WithTimeout(context.Background(), PING_TIMEOUT)
+	defer ctxCancelFunc()
+	if err := r.db.PingContext(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) CreateDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE DATABASE ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) DropDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP DATABASE IF EXISTS ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) SwitchDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.Close(); err != nil {
+		return err
+	}
+
+	r.dbname = name
+
+	if err := r.Open(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) ListDatabases() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT name FROM sys.databases"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (r *mssqlDatabaseTesterRepository) CreateSchema(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE SCHEMA ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// T-SQL has no DROP SCHEMA CASCADE, so tables of the schema are dropped before it
+func (r *mssqlDatabaseTesterRepository) DropSchema(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var tables []string
+	if err := r.db.Select(&tables, "SELECT table_name FROM information_schema.tables WHERE table_schema = @p1", name); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if err := r.DropTable(name + "." + table); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP SCHEMA IF EXISTS ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) ListTables() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT table_name FROM information_schema.tables WHERE table_schema = 'dbo' AND table_type = 'BASE TABLE'"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (r *mssqlDatabaseTesterRepository) CreateTable(name string, fields []string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var (
+		buf            bytes.Buffer
+		identityColumn string
+	)
+	buf.WriteString("CREATE TABLE ")
+	buf.WriteString(name)
+	buf.WriteString(" (")
+	for i, field := range fields {
+		definition, isIdentity := r.convertField(field)
+		if isIdentity {
+			identityColumn, _, _, _ = splitFieldDefinition(field)
+		}
+		buf.WriteString(definition)
+		if i < len(fields)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(");")
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	r.identityColumnsMu.Lock()
+	defer r.identityColumnsMu.Unlock()
+	if identityColumn != "" {
+		r.identityColumns[name] = identityColumn
+	} else {
+		delete(r.identityColumns, name)
+	}
+
+	return nil
+}
+
+// T-SQL partitions are defined by the partition function and scheme, and they aren't the tables
+func (r *mssqlDatabaseTesterRepository) CreatePartitionedTable(name string, fields []string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *mssqlDatabaseTesterRepository) CreateRangePartition(tableName string, partitionName string, from int64, to int64) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *mssqlDatabaseTesterRepository) DropTable(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP TABLE IF EXISTS ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	r.identityColumnsMu.Lock()
+	defer r.identityColumnsMu.Unlock()
+	delete(r.identityColumns, name)
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) AddColumn(tableName string, field string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	definition, _ := r.convertField(field)
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" ADD ")
+	buf.WriteString(definition)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) DropColumn(tableName string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" DROP COLUMN ")
+	buf.WriteString(column)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Column is altered with its current type, because T-SQL doesn't change nullability separately
+func (r *mssqlDatabaseTesterRepository) SetColumnNotNull(tableName string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var c struct {
+		DataType  string        `db:"data_type"`
+		Length    sql.NullInt64 `db:"character_maximum_length"`
+		Precision sql.NullInt64 `db:"numeric_precision"`
+		Scale     sql.NullInt64 `db:"numeric_scale"`
+	}
+	if err := r.db.Get(&c, "SELECT data_type, character_maximum_length, numeric_precision, numeric_scale FROM information_schema.columns WHERE table_name = @p1 AND column_name = @p2", tableName, column); err != nil {
+		return err
+	}
+
+	columnType := c.DataType
+	switch {
+	case c.Length.Valid && c.Length.Int64 == -1:
+		columnType += "(MAX)"
+	case c.Length.Valid:
+		columnType += "(" + strconv.FormatInt(c.Length.Int64, 10) + ")"
+	case c.DataType == "decimal" || c.DataType == "numeric":
+		columnType += "(" + strconv.FormatInt(c.Precision.Int64, 10) + "," + strconv.FormatInt(c.Scale.Int64, 10) + ")"
+	}
+
+	return r.alterColumn(tableName, column, columnType+" NOT NULL")
+}
+
+func (r *mssqlDatabaseTesterRepository) AlterColumnType(tableName string, column string, columnType string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.alterColumn(tableName, column, r.convertType(columnType))
+}
+
+// With concurrently flag index is built online, which is supported by the Developer and Enterprise editions
+func (r *mssqlDatabaseTesterRepository) CreateIndex(tableName string, indexName string, columns []string, concurrently bool) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE INDEX ")
+	buf.WriteString(indexName)
+	buf.WriteString(" ON ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+	if concurrently {
+		buf.WriteString(" WITH (ONLINE = ON)")
+	}
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) DropIndex(tableName string, indexName string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP INDEX IF EXISTS ")
+	buf.WriteString(indexName)
+	buf.WriteString(" ON ")
+	buf.WriteString(tableName)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) TruncateTable(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("TRUNCATE TABLE ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Rows are inserted by several statements if they don't fit into the parameters limit
+func (r *mssqlDatabaseTesterRepository) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if !r.hasIdentityColumn(tableName, columns) {
+		return r.insertRows(r.db, tableName, columns, values)
+	}
+
+	// Option is set for the session, so it's set in the transaction keeping the same connection
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.setIdentityInsert(tx, tableName, true); err != nil {
+		return err
+	}
+	if err := r.insertRows(tx, tableName, columns, values); err != nil {
+		return err
+	}
+	if err := r.setIdentityInsert(tx, tableName, false); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Rows are split by the parameters limit on insert
+func (r *mssqlDatabaseTesterRepository) MaxInsertRows(columnsCount int) int {
+	return 0
+}
+
+func (r *mssqlDatabaseTesterRepository) BeginTransaction() (DatabaseTesterTransaction, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	return &mssqlDatabaseTesterTransaction{tx: tx, r: r}, nil
+}
+
+// T-SQL has no INSERT ON CONFLICT, so rows are merged. HOLDLOCK prevents duplicates of the concurrent merges.
+func (r *mssqlDatabaseTesterRepository) Upsert(tableName string, keyColumns []string, columns []string, values []map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	allColumns := append(append([]string{}, keyColumns...), columns...)
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	hasIdentityColumn := r.hasIdentityColumn(tableName, allColumns)
+	if hasIdentityColumn {
+		if err := r.setIdentityInsert(tx, tableName, true); err != nil {
+			return err
+		}
+	}
+
+	for _, chunk := range r.splitRows(allColumns, values) {
+		var buf bytes.Buffer
+		buf.WriteString("MERGE INTO ")
+		buf.WriteString(tableName)
+		buf.WriteString(" WITH (HOLDLOCK) AS target USING (")
+		args := r.writeValues(&buf, allColumns, chunk)
+		buf.WriteString(") AS source (")
+		buf.WriteString(strings.Join(allColumns, ","))
+		buf.WriteString(") ON ")
+		for i, column := range keyColumns {
+			buf.WriteString("target.")
+			buf.WriteString(column)
+			buf.WriteString("=source.")
+			buf.WriteString(column)
+			if i < len(keyColumns)-1 {
+				buf.WriteString(" AND ")
+			}
+		}
+		buf.WriteString(" WHEN MATCHED THEN UPDATE SET ")
+		for i, column := range columns {
+			buf.WriteString(column)
+			buf.WriteString("=source.")
+			buf.WriteString(column)
+			if i < len(columns)-1 {
+				buf.WriteByte(',')
+			}
+		}
+		buf.WriteString(" WHEN NOT MATCHED THEN INSERT (")
+		buf.WriteString(strings.Join(allColumns, ","))
+		buf.WriteString(") VALUES (")
+		for i, column := range allColumns {
+			buf.WriteString("source.")
+			buf.WriteString(column)
+			if i < len(allColumns)-1 {
+				buf.WriteByte(',')
+			}
+		}
+		buf.WriteString(");")
+
+		if _, err := tx.Exec(buf.String(), args...); err != nil {
+			return err
+		}
+	}
+
+	if hasIdentityColumn {
+		if err := r.setIdentityInsert(tx, tableName, false); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *mssqlDatabaseTesterRepository) IsConflictError(err error) bool {
+	var mssqlErr mssql.Error
+	return errors.As(err, &mssqlErr) &&
+		(mssqlErr.Number == MSSQL_UNIQUE_CONSTRAINT_ERROR_NUMBER || mssqlErr.Number == MSSQL_UNIQUE_INDEX_ERROR_NUMBER)
+}
+
+func (r *mssqlDatabaseTesterRepository) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(r.createUpdateStatement(tableName, columns))
+	buf.WriteString("id=@p")
+	buf.WriteString(strconv.Itoa(len(columns) + 1))
+
+	if _, err := r.db.Exec(buf.String(), append(r.createUpdateArgs(columns, values), id)...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Conditions are rewritten with comparisons of the bits like the selects
+func (r *mssqlDatabaseTesterRepository) UpdateByConditions(tableName string, conditions string, columns []string, values map[string]interface{}) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	parsed, ok := parseConditions(conditions)
+	if !ok {
+		return 0, domain.UNSUPPORTED_OPERATION
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(r.createUpdateStatement(tableName, columns))
+	writeNumericBooleanConditions(&buf, parsed)
+
+	res, err := r.db.Exec(buf.String(), r.createUpdateArgs(columns, values)...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *mssqlDatabaseTesterRepository) DeleteById(tableName string, id int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=@p1")
+
+	if _, err := r.db.Exec(buf.String(), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Conditions are rewritten with comparisons of the bits like the selects
+func (r *mssqlDatabaseTesterRepository) DeleteByConditions(tableName string, conditions string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	parsed, ok := parseConditions(conditions)
+	if !ok {
+		return 0, domain.UNSUPPORTED_OPERATION
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	writeNumericBooleanConditions(&buf, parsed)
+
+	res, err := r.db.Exec(buf.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *mssqlDatabaseTesterRepository) DeleteAll(tableName string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	res, err := r.db.Exec("DELETE FROM " + tableName)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *mssqlDatabaseTesterRepository) SelectById(tableName string, id int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=@p1")
+
+	rows, err := r.db.Query(buf.String(), id)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// T-SQL has no boolean expressions of the columns, so conditions are rewritten with comparisons of the bits
+func (r *mssqlDatabaseTesterRepository) SelectByConditions(tableName string, conditions string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	parsed, ok := parseConditions(conditions)
+	if !ok {
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	writeNumericBooleanConditions(&buf, parsed)
+
+	rows, err := r.db.Query(buf.String())
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) SelectJoin(tableName string, column string, joinedTableName string, joinType domain.JoinType) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createJoinStatement(tableName, column, joinedTableName, joinType)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(statement)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) SelectJoinAggregation(tableName string, column string, joinedTableName string, aggregatedColumn string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createJoinAggregationStatement(tableName, column, joinedTableName, aggregatedColumn))
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) SelectAggregation(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createAggregationStatement(tableName, groupColumn, aggregation, aggregatedColumn)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(statement)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) SelectPage(tableName string, offset int, limit int) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createOffsetPageStatement(tableName, offset, limit, true))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPageLastId(rows)
+}
+
+func (r *mssqlDatabaseTesterRepository) SelectPageAfterId(tableName string, id int64, limit int) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createKeysetPageStatement(tableName, id, limit, true))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPageLastId(rows)
+}
+
+func (r *mssqlDatabaseTesterRepository) SelectColumnById(tableName string, column string, id int64, dest interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.db.Get(dest, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) CountRows(tableName string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COUNT_BIG(*) FROM ")
+	buf.WriteString(tableName)
+
+	var count int64
+	if err := r.db.Get(&count, buf.String()); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Sum of the INT column is INT, so column is cast to prevent overflow
+func (r *mssqlDatabaseTesterRepository) SumColumn(tableName string, column string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COALESCE(SUM(CAST(")
+	buf.WriteString(column)
+	buf.WriteString(" AS BIGINT)), 0) FROM ")
+	buf.WriteString(tableName)
+
+	var sum int64
+	if err := r.db.Get(&sum, buf.String()); err != nil {
+		return 0, err
+	}
+
+	return sum, nil
+}
+
+func (r *mssqlDatabaseTesterRepository) UpdateInTransaction(tableName string, column string, deltas map[int64]int64, isolationLevel domain.IsolationLevel) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	txIsolationLevel, err := r.convertIsolationLevel(isolationLevel)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTxx(context.Background(), &sql.TxOptions{Isolation: txIsolationLevel})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Update rows in the same order to prevent deadlocks
+	ids := make([]int64, 0, len(deltas))
+	for id := range deltas {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		var value int64
+		if err := tx.Get(&value, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(r.createUpdateColumnByIdStatement(tableName, column), value+deltas[id], id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *mssqlDatabaseTesterRepository) WithdrawInTransaction(tableName string, column string, id int64, amount int64, isolationLevel domain.IsolationLevel) (bool, error) {
+	if r.db == nil {
+		return false, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	txIsolationLevel, err := r.convertIsolationLevel(isolationLevel)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := r.db.BeginTxx(context.Background(), &sql.TxOptions{Isolation: txIsolationLevel})
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COALESCE(SUM(CAST(")
+	buf.WriteString(column)
+	buf.WriteString(" AS BIGINT)), 0) FROM ")
+	buf.WriteString(tableName)
+
+	var sum int64
+	if err := tx.Get(&sum, buf.String()); err != nil {
+		return false, err
+	}
+	if sum < amount {
+		return false, nil
+	}
+
+	var value int64
+	if err := tx.Get(&value, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(r.createUpdateColumnByIdStatement(tableName, column), value-amount, id); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mssqlDatabaseTesterRepository) BackupCommand(filePath string) []string {
+	return r.createSqlcmdArgs("BACKUP DATABASE " + r.dbname + " TO DISK = '" + filePath + "' WITH INIT")
+}
+
+// Files of the restored database are moved, because the dumped database files still exist
+func (r *mssqlDatabaseTesterRepository) RestoreCommand(filePath string, dbname string) []string {
+	var buf bytes.Buffer
+	buf.WriteString("RESTORE DATABASE ")
+	buf.WriteString(dbname)
+	buf.WriteString(" FROM DISK = '")
+	buf.WriteString(filePath)
+	buf.WriteString("' WITH MOVE '")
+	buf.WriteString(r.dbname)
+	buf.WriteString("' TO '")
+	buf.WriteString(MSSQL_DATA_DIR_PATH + dbname + ".mdf")
+	buf.WriteString("', MOVE '")
+	buf.WriteString(r.dbname)
+	buf.WriteString("_log' TO '")
+	buf.WriteString(MSSQL_DATA_DIR_PATH + dbname + "_log.ldf")
+	buf.WriteByte('\'')
+
+	return r.createSqlcmdArgs(buf.String())
+}
+
+func (r *mssqlDatabaseTesterRepository) PrepareInsert(tableName string, columns []string) (PreparedStatement, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return newSqlPreparedStatement(r.db.DB, createPreparedInsertStatement(tableName, columns, mssqlPlaceholder), false, nil)
+}
+
+func (r *mssqlDatabaseTesterRepository) PrepareSelectById(tableName string) (PreparedStatement, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return newSqlPreparedStatement(r.db.DB, createPreparedSelectByIdStatement(tableName, mssqlPlaceholder), true, nil)
+}
+
+func (r *mssqlDatabaseTesterRepository) SqlDB() *sql.DB {
+	if r.db == nil {
+		return nil
+	}
+	return r.db.DB
+}
+
+func (r *mssqlDatabaseTesterRepository) Close() error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.db.Close(); err != nil {
+		return err
+	}
+
+	r.db = nil
+
+	return nil
+}
+
+func (r *mssqlDatabaseTesterRepository) createConnString(port uint16, host, user, password, dbname string) string {
+	query := url.Values{}
+	if dbname != "" {
+		query.Set("database", dbname)
+	}
+
+	u := &url.URL{
+		Scheme:   "sqlserver",
+		User:     url.UserPassword(user, password),
+		Host:     host + ":" + strconv.FormatUint(uint64(port), 10),
+		RawQuery: query.Encode(),
+	}
+
+	return u.String()
+}
+
+// Method creates arguments of the sqlcmd running inside the container. It exits with error code on the query error.
+func (r *mssqlDatabaseTesterRepository) createSqlcmdArgs(query string) []string {
+	return []string{MSSQL_SQLCMD_PATH, "-b", "-S", "localhost," + strconv.FormatUint(uint64(r.port), 10), "-U", r.user, "-P", r.password, "-Q", query}
+}
+
+func (r *mssqlDatabaseTesterRepository) alterColumn(tableName string, column string, columnDefinition string) error {
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" ALTER COLUMN ")
+	buf.WriteString(column)
+	buf.WriteByte(' ')
+	buf.WriteString(columnDefinition)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Method converts Postgres field definition of the workload tables to the dialect one and returns whether it's IDENTITY.
+// Table can have only one IDENTITY column, so serial of the primary key is auto incremented only.
+func (r *mssqlDatabaseTesterRepository) convertField(field string) (string, bool) {
+	name, columnType, options, ok := splitFieldDefinition(field)
+	if !ok {
+		return field, false
+	}
+
+	dialectType := r.convertType(columnType)
+	isIdentity := isSerialType(columnType) && strings.Contains(strings.ToUpper(options), "PRIMARY KEY")
+	if isIdentity {
+		dialectType += " IDENTITY(1,1)"
+	}
+
+	if options == "" {
+		return name + " " + dialectType, isIdentity
+	}
+	return name + " " + dialectType + " " + options, isIdentity
+}
+
+func (r *mssqlDatabaseTesterRepository) convertType(columnType string) string {
+	if dialectType, ok := mssqlColumnTypes[strings.ToUpper(columnType)]; ok {
+		return dialectType
+	}
+	return columnType
+}
+
+func (r *mssqlDatabaseTesterRepository) hasIdentityColumn(tableName string, columns []string) bool {
+	r.identityColumnsMu.RLock()
+	defer r.identityColumnsMu.RUnlock()
+	identityColumn, ok := r.identityColumns[tableName]
+	return ok && containsColumn(columns, identityColumn)
+}
+
+func (r *mssqlDatabaseTesterRepository) setIdentityInsert(tx *sqlx.Tx, tableName string, on bool) error {
+	var buf bytes.Buffer
+	buf.WriteString("SET IDENTITY_INSERT ")
+	buf.WriteString(tableName)
+	if on {
+		buf.WriteString(" ON")
+	} else {
+		buf.WriteString(" OFF")
+	}
+
+	_, err := tx.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Method inserts rows by the statements fitting into the parameters limit
+func (r *mssqlDatabaseTesterRepository) insertRows(e sqlx.Execer, tableName string, columns []string, values []map[string]interface{}) error {
+	for _, chunk := range r.splitRows(columns, values) {
+		var buf bytes.Buffer
+		buf.WriteString("INSERT INTO ")
+		buf.WriteString(tableName)
+		buf.WriteString(" (")
+		buf.WriteString(strings.Join(columns, ","))
+		buf.WriteString(") ")
+		args := r.writeValues(&buf, columns, chunk)
+
+		if _, err := e.Exec(buf.String(), args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Method splits rows into chunks, which parameters count doesn't exceed the limit
+func (r *mssqlDatabaseTesterRepository) splitRows(columns []string, values []map[string]interface{}) [][]map[string]interface{} {
+	chunkSize := MSSQL_MAX_INSERT_ROWS
+	if len(columns) > 0 && MSSQL_MAX_PARAMETERS/len(columns) < chunkSize {
+		chunkSize = MSSQL_MAX_PARAMETERS / len(columns)
+	}
+
+	var chunks [][]map[string]interface{}
+	for len(values) > chunkSize {
+		chunks = append(chunks, values[:chunkSize])
+		values = values[chunkSize:]
+	}
+	if len(values) > 0 {
+		chunks = append(chunks, values)
+	}
+
+	return chunks
+}
+
+// Method writes VALUES clause of the rows with positional parameters and returns their arguments
+func (r *mssqlDatabaseTesterRepository) writeValues(buf *bytes.Buffer, columns []string, values []map[string]interface{}) []interface{} {
+	args := make([]interface{}, 0, len(columns)*len(values))
+
+	buf.WriteString("VALUES ")
+	for i, v := range values {
+		buf.WriteByte('(')
+		for j, column := range columns {
+			args = append(args, v[column])
+			buf.WriteString("@p")
+			buf.WriteString(strconv.Itoa(len(args)))
+			if j < len(columns)-1 {
+				buf.WriteByte(',')
+			}
+		}
+		buf.WriteByte(')')
+		if i < len(values)-1 {
+			buf.WriteByte(',')
+		}
+	}
+
+	return args
+}
+
+func (r *mssqlDatabaseTesterRepository) createSelectColumnByIdStatement(tableName string, column string) string {
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	buf.WriteString(column)
+	buf.WriteString(" FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=@p1")
+
+	return buf.String()
+}
+
+func (r *mssqlDatabaseTesterRepository) createUpdateColumnByIdStatement(tableName string, column string) string {
+	var buf bytes.Buffer
+	buf.WriteString("UPDATE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" SET ")
+	buf.WriteString(column)
+	buf.WriteString("=@p1 WHERE id=@p2")
+
+	return buf.String()
+}
+
+// Method creates update statement of the columns with @pn placeholders, which is ended by the conditions
+func (r *mssqlDatabaseTesterRepository) createUpdateStatement(tableName string, columns []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("UPDATE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" SET ")
+	for i, column := range columns {
+		buf.WriteString(column)
+		buf.WriteString("=@p")
+		buf.WriteString(strconv.Itoa(i + 1))
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(" WHERE ")
+
+	return buf.String()
+}
+
+func (r *mssqlDatabaseTesterRepository) createUpdateArgs(columns []string, values map[string]interface{}) []interface{} {
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		args[i] = values[column]
+	}
+	return args
+}
+
+func (r *mssqlDatabaseTesterRepository) convertIsolationLevel(isolationLevel domain.IsolationLevel) (sql.IsolationLevel, error) {
+	switch isolationLevel {
+	case domain.IsolationLevel_ReadCommitted:
+		return sql.LevelReadCommitted, nil
+	case domain.IsolationLevel_RepeatableRead:
+		return sql.LevelRepeatableRead, nil
+	case domain.IsolationLevel_Serializable:
+		return sql.LevelSerializable, nil
+	default:
+		return sql.LevelDefault, domain.UNKNOWN_ISOLATION_LEVEL
+	}
+}
+
+type mssqlDatabaseTesterTransaction struct {
+	tx *sqlx.Tx
+	r  *mssqlDatabaseTesterRepository
+}
+
+func (t *mssqlDatabaseTesterTransaction) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	return t.r.insertRows(t.tx, tableName, columns, values)
+}
+
+func (t *mssqlDatabaseTesterTransaction) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	var buf bytes.Buffer
+	buf.WriteString(t.r.createUpdateStatement(tableName, columns))
+	buf.WriteString("id=@p")
+	buf.WriteString(strconv.Itoa(len(columns) + 1))
+
+	if _, err := t.tx.Exec(buf.String(), append(t.r.createUpdateArgs(columns, values), id)...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *mssqlDatabaseTesterTransaction) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *mssqlDatabaseTesterTransaction) Rollback() error {
+	return t.tx.Rollback()
+}
@@ -0,0 +1,1008 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html#error_er_dup_entry
+const (
+	MYSQL_DUPLICATE_ENTRY_ERROR_NUMBER = 1062
+	// Max placeholders count of the prepared statement
+	MYSQL_MAX_PARAMETERS = 65535
+)
+
+// Column types of the workload tables which are named differently in MySQL
+var mysqlColumnTypes = map[string]string{
+	"BIGSERIAL":   "BIGINT",
+	"SERIAL":      "INTEGER",
+	"SMALLSERIAL": "SMALLINT",
+	"TIMESTAMPTZ": "TIMESTAMP",
+	"BYTEA":       "LONGBLOB",
+	"JSONB":       "JSON",
+}
+
+type mysqlDatabaseTesterRepository struct {
+	db       *sqlx.DB
+	port     uint16
+	host     string
+	user     string
+	password string
+	dbname   string
+	// Storage engine of the created tables
+	storageEngine string
+	// Postgres column type to the type of the dialect
+	columnTypes map[string]string
+	// Client tools of the image
+	dumpTool   string
+	clientTool string
+}
+
+// NewMySQLDatabaseTesterRepository creates repository using go-sql-driver.
+// Tables are created with the storage engine if it's not empty, otherwise server's default one is used.
+func NewMySQLDatabaseTesterRepository(port uint16, host, user, password, storageEngine string) DatabaseTesterRepository {
+	return newMySQLDatabaseTesterRepository(port, host, user, password, storageEngine)
+}
+
+func newMySQLDatabaseTesterRepository(port uint16, host, user, password, storageEngine string) *mysqlDatabaseTesterRepository {
+	r := new(mysqlDatabaseTesterRepository)
+	r.port = port
+	r.host = host
+	r.user = user
+	r.password = password
+	r.dbname = ""
+	r.storageEngine = storageEngine
+	r.columnTypes = mysqlColumnTypes
+	r.dumpTool = "mysqldump"
+	r.clientTool = "mysql"
+	return r
+}
+
+func (r *mysqlDatabaseTesterRepository) Open() error {
+	var err error
+	r.db, err = sqlx.Open("mysql", r.createConnString(r.port, r.host, r.user, r.password, r.dbname))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) Ping() error {
+	ctx, ctxCancelFunc := context.WithTimeout(context.Background(), PING_TIMEOUT)
+	defer ctxCancelFunc()
+	if err := r.db.PingContext(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) CreateDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE DATABASE ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) DropDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP DATABASE IF EXISTS ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) SwitchDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.Close(); err != nil {
+		return err
+	}
+
+	r.dbname = name
+
+	if err := r.Open(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) ListDatabases() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT schema_name FROM information_schema.schemata"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// Schema is the synonym of the database in MySQL, so its tables are addressed as "schema.table" in the same way
+func (r *mysqlDatabaseTesterRepository) CreateSchema(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE SCHEMA ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) DropSchema(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP SCHEMA IF EXISTS ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) ListTables() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE()"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (r *mysqlDatabaseTesterRepository) CreateTable(name string, fields []string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE TABLE ")
+	buf.WriteString(name)
+	buf.WriteString(" (")
+	for i, field := range fields {
+		buf.WriteString(r.convertField(field))
+		if i < len(fields)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(")")
+	if r.storageEngine != "" {
+		buf.WriteString(" ENGINE=")
+		buf.WriteString(r.storageEngine)
+	}
+	buf.WriteString(";")
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MySQL partitions aren't the tables, so rows can't be inserted into them by name
+func (r *mysqlDatabaseTesterRepository) CreatePartitionedTable(name string, fields []string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *mysqlDatabaseTesterRepository) CreateRangePartition(tableName string, partitionName string, from int64, to int64) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *mysqlDatabaseTesterRepository) DropTable(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP TABLE IF EXISTS ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) AddColumn(tableName string, field string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" ADD COLUMN ")
+	buf.WriteString(r.convertField(field))
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) DropColumn(tableName string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" DROP COLUMN ")
+	buf.WriteString(column)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Column is modified with its current type, because MySQL doesn't change nullability separately
+func (r *mysqlDatabaseTesterRepository) SetColumnNotNull(tableName string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var columnType string
+	if err := r.db.Get(&columnType, "SELECT column_type FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?", tableName, column); err != nil {
+		return err
+	}
+
+	return r.modifyColumn(tableName, column, columnType+" NOT NULL")
+}
+
+func (r *mysqlDatabaseTesterRepository) AlterColumnType(tableName string, column string, columnType string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.modifyColumn(tableName, column, r.convertType(columnType))
+}
+
+// With concurrently flag InnoDB builds index in place without locking the table
+func (r *mysqlDatabaseTesterRepository) CreateIndex(tableName string, indexName string, columns []string, concurrently bool) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE INDEX ")
+	buf.WriteString(indexName)
+	buf.WriteString(" ON ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+	if concurrently {
+		buf.WriteString(" ALGORITHM=INPLACE LOCK=NONE")
+	}
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) DropIndex(tableName string, indexName string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP INDEX ")
+	buf.WriteString(indexName)
+	buf.WriteString(" ON ")
+	buf.WriteString(tableName)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) TruncateTable(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("TRUNCATE TABLE ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if _, err := r.db.NamedExec(r.createInsertStatement(tableName, columns), values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) MaxInsertRows(columnsCount int) int {
+	if columnsCount == 0 {
+		return 0
+	}
+	return MYSQL_MAX_PARAMETERS / columnsCount
+}
+
+func (r *mysqlDatabaseTesterRepository) BeginTransaction() (DatabaseTesterTransaction, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	return &mysqlDatabaseTesterTransaction{tx: tx, r: r}, nil
+}
+
+func (r *mysqlDatabaseTesterRepository) Upsert(tableName string, keyColumns []string, columns []string, values []map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	allColumns := append(append([]string{}, keyColumns...), columns...)
+
+	// Key columns are resolved by the primary key and unique indexes of the table
+	var buf bytes.Buffer
+	buf.WriteString(r.createInsertStatement(tableName, allColumns))
+	buf.WriteString(" ON DUPLICATE KEY UPDATE ")
+	for i, column := range columns {
+		buf.WriteString(column)
+		buf.WriteString("=VALUES(")
+		buf.WriteString(column)
+		buf.WriteByte(')')
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+
+	if _, err := r.db.NamedExec(buf.String(), values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) IsConflictError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == MYSQL_DUPLICATE_ENTRY_ERROR_NUMBER
+}
+
+func (r *mysqlDatabaseTesterRepository) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	args := make([]interface{}, 0, len(columns)+1)
+	for _, column := range columns {
+		args = append(args, values[column])
+	}
+
+	if _, err := r.db.Exec(r.createUpdateStatement(tableName, columns)+"id=?", append(args, id)...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Rows which values haven't been changed by the update aren't counted
+func (r *mysqlDatabaseTesterRepository) UpdateByConditions(tableName string, conditions string, columns []string, values map[string]interface{}) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		args[i] = values[column]
+	}
+
+	res, err := r.db.Exec(r.createUpdateStatement(tableName, columns)+conditions, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *mysqlDatabaseTesterRepository) DeleteById(tableName string, id int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=?")
+
+	if _, err := r.db.Exec(buf.String(), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) DeleteByConditions(tableName string, conditions string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(conditions)
+
+	res, err := r.db.Exec(buf.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *mysqlDatabaseTesterRepository) DeleteAll(tableName string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	res, err := r.db.Exec("DELETE FROM " + tableName)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *mysqlDatabaseTesterRepository) SelectById(tableName string, id int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=?")
+
+	rows, err := r.db.Query(buf.String(), id)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) SelectByConditions(tableName string, conditions string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(conditions)
+
+	rows, err := r.db.Query(buf.String())
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) SelectJoin(tableName string, column string, joinedTableName string, joinType domain.JoinType) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createJoinStatement(tableName, column, joinedTableName, joinType)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(statement)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) SelectJoinAggregation(tableName string, column string, joinedTableName string, aggregatedColumn string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createJoinAggregationStatement(tableName, column, joinedTableName, aggregatedColumn))
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) SelectAggregation(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createAggregationStatement(tableName, groupColumn, aggregation, aggregatedColumn)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(statement)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) SelectPage(tableName string, offset int, limit int) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createOffsetPageStatement(tableName, offset, limit, false))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPageLastId(rows)
+}
+
+func (r *mysqlDatabaseTesterRepository) SelectPageAfterId(tableName string, id int64, limit int) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createKeysetPageStatement(tableName, id, limit, false))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPageLastId(rows)
+}
+
+func (r *mysqlDatabaseTesterRepository) SelectColumnById(tableName string, column string, id int64, dest interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.db.Get(dest, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) CountRows(tableName string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COUNT(*) FROM ")
+	buf.WriteString(tableName)
+
+	var count int64
+	if err := r.db.Get(&count, buf.String()); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *mysqlDatabaseTesterRepository) SumColumn(tableName string, column string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COALESCE(SUM(")
+	buf.WriteString(column)
+	buf.WriteString("), 0) FROM ")
+	buf.WriteString(tableName)
+
+	var sum int64
+	if err := r.db.Get(&sum, buf.String()); err != nil {
+		return 0, err
+	}
+
+	return sum, nil
+}
+
+func (r *mysqlDatabaseTesterRepository) UpdateInTransaction(tableName string, column string, deltas map[int64]int64, isolationLevel domain.IsolationLevel) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	txIsolationLevel, err := r.convertIsolationLevel(isolationLevel)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTxx(context.Background(), &sql.TxOptions{Isolation: txIsolationLevel})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Update rows in the same order to prevent deadlocks
+	ids := make([]int64, 0, len(deltas))
+	for id := range deltas {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		var value int64
+		if err := tx.Get(&value, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(r.createUpdateColumnByIdStatement(tableName, column), value+deltas[id], id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *mysqlDatabaseTesterRepository) WithdrawInTransaction(tableName string, column string, id int64, amount int64, isolationLevel domain.IsolationLevel) (bool, error) {
+	if r.db == nil {
+		return false, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	txIsolationLevel, err := r.convertIsolationLevel(isolationLevel)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := r.db.BeginTxx(context.Background(), &sql.TxOptions{Isolation: txIsolationLevel})
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COALESCE(SUM(")
+	buf.WriteString(column)
+	buf.WriteString("), 0) FROM ")
+	buf.WriteString(tableName)
+
+	var sum int64
+	if err := tx.Get(&sum, buf.String()); err != nil {
+		return false, err
+	}
+	if sum < amount {
+		return false, nil
+	}
+
+	var value int64
+	if err := tx.Get(&value, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(r.createUpdateColumnByIdStatement(tableName, column), value-amount, id); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mysqlDatabaseTesterRepository) BackupCommand(filePath string) []string {
+	return append(r.createClientArgs(r.dumpTool), "--result-file="+filePath, r.dbname)
+}
+
+// Client executes the source command itself, because container command isn't run by the shell
+func (r *mysqlDatabaseTesterRepository) RestoreCommand(filePath string, dbname string) []string {
+	return append(r.createClientArgs(r.clientTool), "--execute=source "+filePath, dbname)
+}
+
+func (r *mysqlDatabaseTesterRepository) PrepareInsert(tableName string, columns []string) (PreparedStatement, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return newSqlPreparedStatement(r.db.DB, createPreparedInsertStatement(tableName, columns, questionPlaceholder), false, nil)
+}
+
+func (r *mysqlDatabaseTesterRepository) PrepareSelectById(tableName string) (PreparedStatement, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return newSqlPreparedStatement(r.db.DB, createPreparedSelectByIdStatement(tableName, questionPlaceholder), true, nil)
+}
+
+func (r *mysqlDatabaseTesterRepository) SqlDB() *sql.DB {
+	if r.db == nil {
+		return nil
+	}
+	return r.db.DB
+}
+
+func (r *mysqlDatabaseTesterRepository) Close() error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.db.Close(); err != nil {
+		return err
+	}
+
+	r.db = nil
+
+	return nil
+}
+
+func (r *mysqlDatabaseTesterRepository) createConnString(port uint16, host, user, password, dbname string) string {
+	cfg := mysql.NewConfig()
+	cfg.Net = "tcp"
+	cfg.Addr = host + ":" + strconv.FormatUint(uint64(port), 10)
+	cfg.User = user
+	cfg.Passwd = password
+	cfg.DBName = dbname
+	cfg.ParseTime = true
+
+	return cfg.FormatDSN()
+}
+
+// Method creates arguments of the client tools running inside the container
+func (r *mysqlDatabaseTesterRepository) createClientArgs(tool string) []string {
+	return []string{tool, "--host=127.0.0.1", "--port=" + strconv.FormatUint(uint64(r.port), 10), "--user=" + r.user, "--password=" + r.password}
+}
+
+func (r *mysqlDatabaseTesterRepository) modifyColumn(tableName string, column string, columnDefinition string) error {
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" MODIFY COLUMN ")
+	buf.WriteString(column)
+	buf.WriteByte(' ')
+	buf.WriteString(columnDefinition)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Method converts Postgres field definition of the workload tables to the dialect one.
+// Table can have only one auto increment column, so serial of the primary key is auto incremented only.
+func (r *mysqlDatabaseTesterRepository) convertField(field string) string {
+	name, columnType, options, ok := splitFieldDefinition(field)
+	if !ok {
+		return field
+	}
+
+	dialectType := r.convertType(columnType)
+	if isSerialType(columnType) && strings.Contains(strings.ToUpper(options), "PRIMARY KEY") {
+		dialectType += " AUTO_INCREMENT"
+	}
+
+	if options == "" {
+		return name + " " + dialectType
+	}
+	return name + " " + dialectType + " " + options
+}
+
+func (r *mysqlDatabaseTesterRepository) convertType(columnType string) string {
+	if dialectType, ok := r.columnTypes[strings.ToUpper(columnType)]; ok {
+		return dialectType
+	}
+	return columnType
+}
+
+func (r *mysqlDatabaseTesterRepository) createInsertStatement(tableName string, columns []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(") VALUES (")
+
+	for i, column := range columns {
+		buf.WriteByte(':')
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+
+	return buf.String()
+}
+
+func (r *mysqlDatabaseTesterRepository) createSelectColumnByIdStatement(tableName string, column string) string {
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	buf.WriteString(column)
+	buf.WriteString(" FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=?")
+
+	return buf.String()
+}
+
+func (r *mysqlDatabaseTesterRepository) createUpdateColumnByIdStatement(tableName string, column string) string {
+	var buf bytes.Buffer
+	buf.WriteString("UPDATE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" SET ")
+	buf.WriteString(column)
+	buf.WriteString("=? WHERE id=?")
+
+	return buf.String()
+}
+
+// Method creates update statement of the columns, which is ended by the conditions
+func (r *mysqlDatabaseTesterRepository) createUpdateStatement(tableName string, columns []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("UPDATE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" SET ")
+	for i, column := range columns {
+		buf.WriteString(column)
+		buf.WriteString("=?")
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(" WHERE ")
+
+	return buf.String()
+}
+
+func (r *mysqlDatabaseTesterRepository) convertIsolationLevel(isolationLevel domain.IsolationLevel) (sql.IsolationLevel, error) {
+	switch isolationLevel {
+	case domain.IsolationLevel_ReadCommitted:
+		return sql.LevelReadCommitted, nil
+	case domain.IsolationLevel_RepeatableRead:
+		return sql.LevelRepeatableRead, nil
+	case domain.IsolationLevel_Serializable:
+		return sql.LevelSerializable, nil
+	default:
+		return sql.LevelDefault, domain.UNKNOWN_ISOLATION_LEVEL
+	}
+}
+
+type mysqlDatabaseTesterTransaction struct {
+	tx *sqlx.Tx
+	r  *mysqlDatabaseTesterRepository
+}
+
+func (t *mysqlDatabaseTesterTransaction) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	if _, err := t.tx.NamedExec(t.r.createInsertStatement(tableName, columns), values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *mysqlDatabaseTesterTransaction) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	args := make([]interface{}, 0, len(columns)+1)
+	for _, column := range columns {
+		args = append(args, values[column])
+	}
+
+	if _, err := t.tx.Exec(t.r.createUpdateStatement(tableName, columns)+"id=?", append(args, id)...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *mysqlDatabaseTesterTransaction) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *mysqlDatabaseTesterTransaction) Rollback() error {
+	return t.tx.Rollback()
+}
@@ -0,0 +1,1087 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/jmoiron/sqlx"
+	go_ora "github.com/sijms/go-ora/v2"
+	"github.com/sijms/go-ora/v2/network"
+)
+
+const (
+	// Pluggable database of the Express Edition images
+	ORACLE_SERVICE_NAME = "XEPDB1"
+	// Tablespace of the users data
+	ORACLE_TABLESPACE = "USERS"
+	// https://docs.oracle.com/en/database/oracle/oracle-database/21/errmg/
+	ORACLE_UNIQUE_CONSTRAINT_ERROR_CODE = 1
+	ORACLE_TABLE_NOT_EXIST_ERROR_CODE   = 942
+	ORACLE_INDEX_NOT_EXIST_ERROR_CODE   = 1418
+)
+
+// Column types of the workload tables which are named differently in Oracle
+var oracleColumnTypes = map[string]string{
+	"BIGINT":      "NUMBER(19)",
+	"INTEGER":     "NUMBER(10)",
+	"INT":         "NUMBER(10)",
+	"SMALLINT":    "NUMBER(5)",
+	"BIGSERIAL":   "NUMBER(19)",
+	"SERIAL":      "NUMBER(10)",
+	"SMALLSERIAL": "NUMBER(5)",
+	"BOOLEAN":     "NUMBER(1)",
+	"NUMERIC":     "NUMBER",
+	"FLOAT":       "BINARY_DOUBLE",
+	"REAL":        "BINARY_FLOAT",
+	"TEXT":        "CLOB",
+	"VARCHAR":     "VARCHAR2(4000)",
+	"TIMESTAMP":   "TIMESTAMP(6)",
+	"TIMESTAMPTZ": "TIMESTAMP(6) WITH TIME ZONE",
+	"BYTEA":       "BLOB",
+	"JSONB":       "CLOB",
+}
+
+// Databases of the workloads are the schemas of the users without authentication, because Oracle instance serves single database.
+// Connection is opened by the admin user, and the current schema is switched for every connection of the pool.
+type oracleDatabaseTesterRepository struct {
+	db       *sqlx.DB
+	port     uint16
+	host     string
+	user     string
+	password string
+	dbname   string
+}
+
+// NewOracleDatabaseTesterRepository creates repository using go-ora
+func NewOracleDatabaseTesterRepository(port uint16, host, user, password string) DatabaseTesterRepository {
+	r := new(oracleDatabaseTesterRepository)
+	r.port = port
+	r.host = host
+	r.user = user
+	r.password = password
+	r.dbname = ""
+	return r
+}
+
+func (r *oracleDatabaseTesterRepository) Open() error {
+	connector, err := new(go_ora.OracleDriver).OpenConnector(go_ora.BuildUrl(r.host, int(r.port), ORACLE_SERVICE_NAME, r.user, r.password, nil))
+	if err != nil {
+		return err
+	}
+
+	r.db = sqlx.NewDb(sql.OpenDB(&oracleSchemaConnector{Connector: connector, schema: r.dbname}), "oracle")
+
+	return nil
+}
+
+func (r *oracleDatabaseTesterRepository) Ping() error {
+	ctx, ctxCancelFunc := context.WithTimeout(context.Background(), PING_TIMEOUT)
+	defer ctxCancelFunc()
+	if err := r.db.PingContext(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// User owns the schema, so database is created as the user which can't log in
+func (r *oracleDatabaseTesterRepository) CreateDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE USER ")
+	buf.WriteString(name)
+	buf.WriteString(" NO AUTHENTICATION DEFAULT TABLESPACE ")
+	buf.WriteString(ORACLE_TABLESPACE)
+	buf.WriteString(" QUOTA UNLIMITED ON ")
+	buf.WriteString(ORACLE_TABLESPACE)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Oracle has no DROP USER IF EXISTS, so user is checked before
+func (r *oracleDatabaseTesterRepository) DropDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var count int64
+	if err := r.db.Get(&count, "SELECT COUNT(*) FROM all_users WHERE username = UPPER(:1)", name); err != nil {
+		return err
+	}
+	if count == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP USER ")
+	buf.WriteString(name)
+	buf.WriteString(" CASCADE")
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *oracleDatabaseTesterRepository) SwitchDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.Close(); err != nil {
+		return err
+	}
+
+	r.dbname = name
+
+	if err := r.Open(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *oracleDatabaseTesterRepository) ListDatabases() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT LOWER(username) FROM all_users"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// Schema is the user too, so its tables are addressed as "schema.table"
+func (r *oracleDatabaseTesterRepository) CreateSchema(name string) error {
+	return r.CreateDatabase(name)
+}
+
+func (r *oracleDatabaseTesterRepository) DropSchema(name string) error {
+	return r.DropDatabase(name)
+}
+
+func (r *oracleDatabaseTesterRepository) ListTables() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT LOWER(table_name) FROM all_tables WHERE owner = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (r *oracleDatabaseTesterRepository) CreateTable(name string, fields []string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE TABLE ")
+	buf.WriteString(name)
+	buf.WriteString(" (")
+	for i, field := range fields {
+		buf.WriteString(r.convertField(field))
+		if i < len(fields)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Partitioning is the option of the Enterprise Edition only
+func (r *oracleDatabaseTesterRepository) CreatePartitionedTable(name string, fields []string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *oracleDatabaseTesterRepository) CreateRangePartition(tableName string, partitionName string, from int64, to int64) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+// Oracle has no DROP TABLE IF EXISTS, so error of the missing table is ignored. Table is purged to release its space.
+func (r *oracleDatabaseTesterRepository) DropTable(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP TABLE ")
+	buf.WriteString(name)
+	buf.WriteString(" PURGE")
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil && !isOracleError(err, ORACLE_TABLE_NOT_EXIST_ERROR_CODE) {
+		return err
+	}
+
+	return nil
+}
+
+func (r *oracleDatabaseTesterRepository) AddColumn(tableName string, field string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.alterTable(tableName, "ADD", r.convertField(field))
+}
+
+func (r *oracleDatabaseTesterRepository) DropColumn(tableName string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.alterTable(tableName, "DROP", column)
+}
+
+func (r *oracleDatabaseTesterRepository) SetColumnNotNull(tableName string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.alterTable(tableName, "MODIFY", column+" NOT NULL")
+}
+
+func (r *oracleDatabaseTesterRepository) AlterColumnType(tableName string, column string, columnType string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.alterTable(tableName, "MODIFY", column+" "+r.convertType(columnType))
+}
+
+// With concurrently flag index is built online, which doesn't lock the table for DML
+func (r *oracleDatabaseTesterRepository) CreateIndex(tableName string, indexName string, columns []string, concurrently bool) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE INDEX ")
+	buf.WriteString(indexName)
+	buf.WriteString(" ON ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+	if concurrently {
+		buf.WriteString(" ONLINE")
+	}
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Index names are unique in the schema, so table isn't used
+func (r *oracleDatabaseTesterRepository) DropIndex(tableName string, indexName string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP INDEX ")
+	buf.WriteString(indexName)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil && !isOracleError(err, ORACLE_INDEX_NOT_EXIST_ERROR_CODE) {
+		return err
+	}
+
+	return nil
+}
+
+func (r *oracleDatabaseTesterRepository) TruncateTable(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("TRUNCATE TABLE ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Oracle has no multi-row VALUES, so rows are inserted by the single statement with array binding of the columns
+func (r *oracleDatabaseTesterRepository) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	columnValues := make([][]driver.Value, len(columns))
+	for i, column := range columns {
+		columnValues[i] = make([]driver.Value, len(values))
+		for j, v := range values {
+			columnValues[i][j] = r.convertValue(v[column])
+		}
+	}
+
+	conn, err := r.db.Conn(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn interface{}) error {
+		oracleConn, ok := driverConn.(*go_ora.Connection)
+		if !ok {
+			return domain.UNSUPPORTED_OPERATION
+		}
+
+		_, err := oracleConn.BulkInsert(r.createInsertStatement(tableName, columns), len(values), columnValues...)
+		return err
+	})
+}
+
+// Rows are bound by the column arrays, so their count isn't limited by the parameters
+func (r *oracleDatabaseTesterRepository) MaxInsertRows(columnsCount int) int {
+	return 0
+}
+
+func (r *oracleDatabaseTesterRepository) BeginTransaction() (DatabaseTesterTransaction, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	return &oracleDatabaseTesterTransaction{tx: tx, r: r}, nil
+}
+
+// Oracle has no INSERT ON CONFLICT, so rows are merged one by one in the transaction
+func (r *oracleDatabaseTesterRepository) Upsert(tableName string, keyColumns []string, columns []string, values []map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	allColumns := append(append([]string{}, keyColumns...), columns...)
+
+	var buf bytes.Buffer
+	buf.WriteString("MERGE INTO ")
+	buf.WriteString(tableName)
+	buf.WriteString(" target USING (SELECT ")
+	for i, column := range allColumns {
+		buf.WriteByte(':')
+		buf.WriteString(strconv.Itoa(i + 1))
+		buf.WriteString(" AS ")
+		buf.WriteString(column)
+		if i < len(allColumns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(" FROM dual) source ON (")
+	for i, column := range keyColumns {
+		buf.WriteString("target.")
+		buf.WriteString(column)
+		buf.WriteString("=source.")
+		buf.WriteString(column)
+		if i < len(keyColumns)-1 {
+			buf.WriteString(" AND ")
+		}
+	}
+	buf.WriteString(") WHEN MATCHED THEN UPDATE SET ")
+	for i, column := range columns {
+		buf.WriteString("target.")
+		buf.WriteString(column)
+		buf.WriteString("=source.")
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(" WHEN NOT MATCHED THEN INSERT (")
+	buf.WriteString(strings.Join(allColumns, ","))
+	buf.WriteString(") VALUES (")
+	for i, column := range allColumns {
+		buf.WriteString("source.")
+		buf.WriteString(column)
+		if i < len(allColumns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, v := range values {
+		if _, err := tx.Exec(buf.String(), r.createArgs(allColumns, v)...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *oracleDatabaseTesterRepository) IsConflictError(err error) bool {
+	return isOracleError(err, ORACLE_UNIQUE_CONSTRAINT_ERROR_CODE)
+}
+
+func (r *oracleDatabaseTesterRepository) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(r.createUpdateStatement(tableName, columns))
+	buf.WriteString("id=:")
+	buf.WriteString(strconv.Itoa(len(columns) + 1))
+
+	if _, err := r.db.Exec(buf.String(), append(r.createArgs(columns, values), id)...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Conditions are rewritten with comparisons of the numbers like the selects
+func (r *oracleDatabaseTesterRepository) UpdateByConditions(tableName string, conditions string, columns []string, values map[string]interface{}) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	parsed, ok := parseConditions(conditions)
+	if !ok {
+		return 0, domain.UNSUPPORTED_OPERATION
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(r.createUpdateStatement(tableName, columns))
+	writeNumericBooleanConditions(&buf, parsed)
+
+	res, err := r.db.Exec(buf.String(), r.createArgs(columns, values)...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *oracleDatabaseTesterRepository) DeleteById(tableName string, id int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=:1")
+
+	if _, err := r.db.Exec(buf.String(), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Conditions are rewritten with comparisons of the numbers like the selects
+func (r *oracleDatabaseTesterRepository) DeleteByConditions(tableName string, conditions string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	parsed, ok := parseConditions(conditions)
+	if !ok {
+		return 0, domain.UNSUPPORTED_OPERATION
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	writeNumericBooleanConditions(&buf, parsed)
+
+	res, err := r.db.Exec(buf.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *oracleDatabaseTesterRepository) DeleteAll(tableName string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	res, err := r.db.Exec("DELETE FROM " + tableName)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *oracleDatabaseTesterRepository) SelectById(tableName string, id int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=:1")
+
+	rows, err := r.db.Query(buf.String(), id)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Booleans are stored as numbers, so conditions are rewritten with comparisons of the numbers
+func (r *oracleDatabaseTesterRepository) SelectByConditions(tableName string, conditions string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	parsed, ok := parseConditions(conditions)
+	if !ok {
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	writeNumericBooleanConditions(&buf, parsed)
+
+	rows, err := r.db.Query(buf.String())
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *oracleDatabaseTesterRepository) SelectJoin(tableName string, column string, joinedTableName string, joinType domain.JoinType) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createJoinStatement(tableName, column, joinedTableName, joinType)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(statement)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *oracleDatabaseTesterRepository) SelectJoinAggregation(tableName string, column string, joinedTableName string, aggregatedColumn string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createJoinAggregationStatement(tableName, column, joinedTableName, aggregatedColumn))
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *oracleDatabaseTesterRepository) SelectAggregation(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createAggregationStatement(tableName, groupColumn, aggregation, aggregatedColumn)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(statement)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *oracleDatabaseTesterRepository) SelectPage(tableName string, offset int, limit int) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createOffsetPageStatement(tableName, offset, limit, true))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPageLastId(rows)
+}
+
+func (r *oracleDatabaseTesterRepository) SelectPageAfterId(tableName string, id int64, limit int) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createKeysetPageStatement(tableName, id, limit, true))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPageLastId(rows)
+}
+
+func (r *oracleDatabaseTesterRepository) SelectColumnById(tableName string, column string, id int64, dest interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.db.Get(dest, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *oracleDatabaseTesterRepository) CountRows(tableName string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COUNT(*) FROM ")
+	buf.WriteString(tableName)
+
+	var count int64
+	if err := r.db.Get(&count, buf.String()); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *oracleDatabaseTesterRepository) SumColumn(tableName string, column string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var sum int64
+	if err := r.db.Get(&sum, r.createSumColumnStatement(tableName, column)); err != nil {
+		return 0, err
+	}
+
+	return sum, nil
+}
+
+func (r *oracleDatabaseTesterRepository) UpdateInTransaction(tableName string, column string, deltas map[int64]int64, isolationLevel domain.IsolationLevel) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	tx, err := r.beginTx(isolationLevel)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Update rows in the same order to prevent deadlocks
+	ids := make([]int64, 0, len(deltas))
+	for id := range deltas {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		var value int64
+		if err := tx.Get(&value, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(r.createUpdateColumnByIdStatement(tableName, column), value+deltas[id], id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *oracleDatabaseTesterRepository) WithdrawInTransaction(tableName string, column string, id int64, amount int64, isolationLevel domain.IsolationLevel) (bool, error) {
+	if r.db == nil {
+		return false, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	tx, err := r.beginTx(isolationLevel)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var sum int64
+	if err := tx.Get(&sum, r.createSumColumnStatement(tableName, column)); err != nil {
+		return false, err
+	}
+	if sum < amount {
+		return false, nil
+	}
+
+	var value int64
+	if err := tx.Get(&value, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(r.createUpdateColumnByIdStatement(tableName, column), value-amount, id); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Data Pump writes dumps only into the directory objects created by DBA, so backup isn't supported
+func (r *oracleDatabaseTesterRepository) BackupCommand(filePath string) []string {
+	return nil
+}
+
+func (r *oracleDatabaseTesterRepository) RestoreCommand(filePath string, dbname string) []string {
+	return nil
+}
+
+func (r *oracleDatabaseTesterRepository) PrepareInsert(tableName string, columns []string) (PreparedStatement, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return newSqlPreparedStatement(r.db.DB, createPreparedInsertStatement(tableName, columns, oraclePlaceholder), false, r.convertValue)
+}
+
+func (r *oracleDatabaseTesterRepository) PrepareSelectById(tableName string) (PreparedStatement, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return newSqlPreparedStatement(r.db.DB, createPreparedSelectByIdStatement(tableName, oraclePlaceholder), true, r.convertValue)
+}
+
+func (r *oracleDatabaseTesterRepository) SqlDB() *sql.DB {
+	if r.db == nil {
+		return nil
+	}
+	return r.db.DB
+}
+
+func (r *oracleDatabaseTesterRepository) Close() error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.db.Close(); err != nil {
+		return err
+	}
+
+	r.db = nil
+
+	return nil
+}
+
+// Oracle supports only READ COMMITTED and SERIALIZABLE levels, and the driver doesn't set them by the options.
+// So level is set by the first statement of the transaction.
+func (r *oracleDatabaseTesterRepository) beginTx(isolationLevel domain.IsolationLevel) (*sqlx.Tx, error) {
+	var statement string
+	switch isolationLevel {
+	case domain.IsolationLevel_ReadCommitted:
+		statement = "SET TRANSACTION ISOLATION LEVEL READ COMMITTED"
+	case domain.IsolationLevel_RepeatableRead:
+		return nil, domain.UNSUPPORTED_OPERATION
+	case domain.IsolationLevel_Serializable:
+		statement = "SET TRANSACTION ISOLATION LEVEL SERIALIZABLE"
+	default:
+		return nil, domain.UNKNOWN_ISOLATION_LEVEL
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(statement); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func (r *oracleDatabaseTesterRepository) alterTable(tableName string, action string, definition string) error {
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteByte(' ')
+	buf.WriteString(action)
+	buf.WriteString(" (")
+	buf.WriteString(definition)
+	buf.WriteByte(')')
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Method converts Postgres field definition of the workload tables to the dialect one.
+// Serial of the primary key is the identity column, which accepts explicit values too.
+func (r *oracleDatabaseTesterRepository) convertField(field string) string {
+	name, columnType, options, ok := splitFieldDefinition(field)
+	if !ok {
+		return field
+	}
+
+	dialectType := r.convertType(columnType)
+	if isSerialType(columnType) && strings.Contains(strings.ToUpper(options), "PRIMARY KEY") {
+		dialectType += " GENERATED BY DEFAULT AS IDENTITY"
+	}
+
+	if options == "" {
+		return name + " " + dialectType
+	}
+	return name + " " + dialectType + " " + options
+}
+
+func (r *oracleDatabaseTesterRepository) convertType(columnType string) string {
+	if dialectType, ok := oracleColumnTypes[strings.ToUpper(columnType)]; ok {
+		return dialectType
+	}
+	return columnType
+}
+
+// Driver doesn't bind booleans, so they are passed as numbers
+func (r *oracleDatabaseTesterRepository) convertValue(value interface{}) interface{} {
+	if b, ok := value.(bool); ok {
+		if b {
+			return 1
+		}
+		return 0
+	}
+	return value
+}
+
+func (r *oracleDatabaseTesterRepository) createArgs(columns []string, v map[string]interface{}) []interface{} {
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		args[i] = r.convertValue(v[column])
+	}
+	return args
+}
+
+func (r *oracleDatabaseTesterRepository) createInsertStatement(tableName string, columns []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	buf.WriteString(strings.Join(columns, ","))
+	buf.WriteString(") VALUES (")
+	for i := range columns {
+		buf.WriteByte(':')
+		buf.WriteString(strconv.Itoa(i + 1))
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+
+	return buf.String()
+}
+
+func (r *oracleDatabaseTesterRepository) createSelectColumnByIdStatement(tableName string, column string) string {
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	buf.WriteString(column)
+	buf.WriteString(" FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=:1")
+
+	return buf.String()
+}
+
+func (r *oracleDatabaseTesterRepository) createUpdateColumnByIdStatement(tableName string, column string) string {
+	var buf bytes.Buffer
+	buf.WriteString("UPDATE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" SET ")
+	buf.WriteString(column)
+	buf.WriteString("=:1 WHERE id=:2")
+
+	return buf.String()
+}
+
+// Method creates update statement of the columns with :n placeholders, which is ended by the conditions
+func (r *oracleDatabaseTesterRepository) createUpdateStatement(tableName string, columns []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("UPDATE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" SET ")
+	for i, column := range columns {
+		buf.WriteString(column)
+		buf.WriteString("=:")
+		buf.WriteString(strconv.Itoa(i + 1))
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(" WHERE ")
+
+	return buf.String()
+}
+
+func (r *oracleDatabaseTesterRepository) createSumColumnStatement(tableName string, column string) string {
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COALESCE(SUM(")
+	buf.WriteString(column)
+	buf.WriteString("), 0) FROM ")
+	buf.WriteString(tableName)
+
+	return buf.String()
+}
+
+func isOracleError(err error, code int) bool {
+	var oracleErr *network.OracleError
+	return errors.As(err, &oracleErr) && oracleErr.ErrCode == code
+}
+
+// Connector sets current schema of every opened connection, so tables are addressed without schema
+type oracleSchemaConnector struct {
+	driver.Connector
+	schema string
+}
+
+func (c *oracleSchemaConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.schema == "" {
+		return conn, nil
+	}
+
+	execer, ok := conn.(driver.ExecerContext)
+	if !ok {
+		conn.Close()
+		return nil, domain.UNSUPPORTED_OPERATION
+	}
+	if _, err := execer.ExecContext(ctx, "ALTER SESSION SET CURRENT_SCHEMA = "+c.schema, nil); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+type oracleDatabaseTesterTransaction struct {
+	tx *sqlx.Tx
+	r  *oracleDatabaseTesterRepository
+}
+
+// Array binding isn't available for the transaction connection, so rows are inserted by the prepared statement
+func (t *oracleDatabaseTesterTransaction) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	stmt, err := t.tx.Prepare(t.r.createInsertStatement(tableName, columns))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, v := range values {
+		if _, err := stmt.Exec(t.r.createArgs(columns, v)...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *oracleDatabaseTesterTransaction) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	var buf bytes.Buffer
+	buf.WriteString(t.r.createUpdateStatement(tableName, columns))
+	buf.WriteString("id=:")
+	buf.WriteString(strconv.Itoa(len(columns) + 1))
+
+	if _, err := t.tx.Exec(buf.String(), append(t.r.createArgs(columns, values), id)...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *oracleDatabaseTesterTransaction) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *oracleDatabaseTesterTransaction) Rollback() error {
+	return t.tx.Rollback()
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"bytes"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/jackc/pgx/v4"
+)
+
+// Creates select of the page of rows ordered by id, which skips offset rows.
+// Page is limited by the OFFSET FETCH clause if fetch is set, because MSSQL and Oracle don't support LIMIT.
+func createOffsetPageStatement(tableName string, offset int, limit int, fetch bool) string {
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	writePageLimit(&buf, offset, limit, fetch)
+
+	return buf.String()
+}
+
+// Creates select of the page of rows ordered by id, which ids are greater than the last id of the previous page
+func createKeysetPageStatement(tableName string, id int64, limit int, fetch bool) string {
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id>")
+	buf.WriteString(strconv.FormatInt(id, 10))
+	writePageLimit(&buf, 0, limit, fetch)
+
+	return buf.String()
+}
+
+func writePageLimit(buf *bytes.Buffer, offset int, limit int, fetch bool) {
+	buf.WriteString(" ORDER BY id")
+	if fetch {
+		buf.WriteString(" OFFSET ")
+		buf.WriteString(strconv.Itoa(offset))
+		buf.WriteString(" ROWS FETCH NEXT ")
+		buf.WriteString(strconv.Itoa(limit))
+		buf.WriteString(" ROWS ONLY")
+		return
+	}
+
+	buf.WriteString(" LIMIT ")
+	buf.WriteString(strconv.Itoa(limit))
+	if offset != 0 {
+		buf.WriteString(" OFFSET ")
+		buf.WriteString(strconv.Itoa(offset))
+	}
+}
+
+// Method reads all the rows of the page and returns id of the last one
+func readPageLastId(rows *sql.Rows) (int64, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	idIndex := -1
+	for i, column := range columns {
+		// Oracle returns column names in upper case
+		if strings.EqualFold(column, "id") {
+			idIndex = i
+		}
+	}
+	if idIndex < 0 {
+		return 0, domain.UNSUPPORTED_OPERATION
+	}
+
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	var lastId interface{}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return 0, err
+		}
+		lastId = values[idIndex]
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	return parsePageId(lastId)
+}
+
+func readPgxPageLastId(rows pgx.Rows) (int64, error) {
+	defer rows.Close()
+
+	idIndex := -1
+	for i, field := range rows.FieldDescriptions() {
+		if string(field.Name) == "id" {
+			idIndex = i
+		}
+	}
+	if idIndex < 0 {
+		return 0, domain.UNSUPPORTED_OPERATION
+	}
+
+	var lastId interface{}
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return 0, err
+		}
+		lastId = values[idIndex]
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	return parsePageId(lastId)
+}
+
+// Method converts id of the driver type. Empty page has no last id, so it's 0.
+func parsePageId(id interface{}) (int64, error) {
+	switch v := id.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return v, nil
+	case int32:
+		return int64(v), nil
+	case int:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, domain.UNSUPPORTED_OPERATION
+	}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+const (
+	PGVECTOR_INDEX_METHOD_HNSW    = "hnsw"
+	PGVECTOR_INDEX_METHOD_IVFFLAT = "ivfflat"
+	// IVFFlat lists count is rows/PGVECTOR_ROWS_PER_LIST as recommended for the tables up to 1M rows
+	PGVECTOR_ROWS_PER_LIST = 1000
+)
+
+// Extension isn't shipped with Postgres, so the server image must have it installed
+func (r *postgresDatabaseTesterRepository) CreateVectorExtension() error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	_, err := r.db.Exec("CREATE EXTENSION IF NOT EXISTS vector")
+	return err
+}
+
+// Vectors are passed by their text representation, so neither driver has to know the extension type
+func (r *postgresDatabaseTesterRepository) VectorValue(v []float32) interface{} {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, x := range v {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(strconv.FormatFloat(float64(x), 'g', -1, 32))
+	}
+	buf.WriteByte(']')
+	return buf.String()
+}
+
+func (r *postgresDatabaseTesterRepository) CreateVectorIndex(tableName string, indexName string, column string, method string, rowsCount int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE INDEX ")
+	buf.WriteString(indexName)
+	buf.WriteString(" ON ")
+	buf.WriteString(tableName)
+	buf.WriteString(" USING ")
+	buf.WriteString(method)
+	buf.WriteString(" (")
+	buf.WriteString(column)
+	buf.WriteString(" vector_cosine_ops)")
+
+	switch method {
+	case PGVECTOR_INDEX_METHOD_HNSW:
+		// Default m and ef_construction are used
+	case PGVECTOR_INDEX_METHOD_IVFFLAT:
+		lists := rowsCount / PGVECTOR_ROWS_PER_LIST
+		if lists < 1 {
+			lists = 1
+		}
+		buf.WriteString(" WITH (lists = ")
+		buf.WriteString(strconv.Itoa(lists))
+		buf.WriteByte(')')
+	default:
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	_, err := r.db.Exec(buf.String())
+	return err
+}
+
+func (r *postgresDatabaseTesterRepository) SearchVectors(tableName string, column string, query []float32, k int) ([]int, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var ids []int
+	if err := r.db.Select(&ids, "SELECT id FROM "+tableName+" ORDER BY "+column+" <=> $1 LIMIT $2", r.VectorValue(query), k); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Repository runs the benchmarked operations with the pgx native interface.
+// Other operations are done with database/sql over pgx, because they don't depend on the driver performance.
+type pgxPostgresDatabaseTesterRepository struct {
+	*postgresDatabaseTesterRepository
+	pool *pgxpool.Pool
+}
+
+func newPgxPostgresDatabaseTesterRepository(port uint16, host, user, password, storageEngine string) *pgxPostgresDatabaseTesterRepository {
+	r := new(pgxPostgresDatabaseTesterRepository)
+	r.postgresDatabaseTesterRepository = newPostgresDatabaseTesterRepository(port, host, user, password, "pgx", storageEngine)
+	return r
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) Open() error {
+	if err := r.postgresDatabaseTesterRepository.Open(); err != nil {
+		return err
+	}
+
+	var err error
+	r.pool, err = pgxpool.Connect(context.Background(), r.createConnString(r.port, r.host, r.user, r.password, r.dbname))
+	if err != nil {
+		r.postgresDatabaseTesterRepository.Close()
+		return err
+	}
+
+	return nil
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) SwitchDatabase(name string) error {
+	if r.pool == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.Close(); err != nil {
+		return err
+	}
+
+	r.dbname = name
+
+	if err := r.Open(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	if r.pool == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	args := make([]interface{}, 0, len(columns)*len(values))
+	for _, v := range values {
+		for _, column := range columns {
+			args = append(args, v[column])
+		}
+	}
+
+	if _, err := r.pool.Exec(context.Background(), r.createPositionalInsertStatement(tableName, columns, len(values)), args...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) CopyFrom(tableName string, columns []string, chunks <-chan []map[string]interface{}) (int64, error) {
+	if r.pool == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.pool.CopyFrom(context.Background(), createCopyIdentifier(tableName), columns, newChunksCopyFromSource(chunks, columns))
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	if r.pool == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(r.createUpdateStatement(tableName, columns))
+	buf.WriteString("id=$")
+	buf.WriteString(strconv.Itoa(len(columns) + 1))
+
+	args := make([]interface{}, 0, len(columns)+1)
+	for _, column := range columns {
+		args = append(args, values[column])
+	}
+
+	if _, err := r.pool.Exec(context.Background(), buf.String(), append(args, id)...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) UpdateByConditions(tableName string, conditions string, columns []string, values map[string]interface{}) (int64, error) {
+	if r.pool == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		args[i] = values[column]
+	}
+
+	tag, err := r.pool.Exec(context.Background(), r.createUpdateStatement(tableName, columns)+conditions, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) DeleteById(tableName string, id int) error {
+	if r.pool == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=$1")
+
+	if _, err := r.pool.Exec(context.Background(), buf.String(), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) DeleteByConditions(tableName string, conditions string) (int64, error) {
+	if r.pool == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(conditions)
+
+	tag, err := r.pool.Exec(context.Background(), buf.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) DeleteAll(tableName string) (int64, error) {
+	if r.pool == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	tag, err := r.pool.Exec(context.Background(), "DELETE FROM "+tableName)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) SelectById(tableName string, id int) error {
+	if r.pool == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=$1")
+
+	rows, err := r.pool.Query(context.Background(), buf.String(), id)
+	if err != nil {
+		return err
+	}
+	rows.Close()
+
+	return rows.Err()
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) SelectByConditions(tableName string, conditions string) error {
+	if r.pool == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(conditions)
+
+	rows, err := r.pool.Query(context.Background(), buf.String())
+	if err != nil {
+		return err
+	}
+	rows.Close()
+
+	return rows.Err()
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) SelectJoin(tableName string, column string, joinedTableName string, joinType domain.JoinType) error {
+	if r.pool == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createJoinStatement(tableName, column, joinedTableName, joinType)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.pool.Query(context.Background(), statement)
+	if err != nil {
+		return err
+	}
+	rows.Close()
+
+	return rows.Err()
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) SelectJoinAggregation(tableName string, column string, joinedTableName string, aggregatedColumn string) error {
+	if r.pool == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.pool.Query(context.Background(), createJoinAggregationStatement(tableName, column, joinedTableName, aggregatedColumn))
+	if err != nil {
+		return err
+	}
+	rows.Close()
+
+	return rows.Err()
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) SelectAggregation(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) error {
+	if r.pool == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createAggregationStatement(tableName, groupColumn, aggregation, aggregatedColumn)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.pool.Query(context.Background(), statement)
+	if err != nil {
+		return err
+	}
+	rows.Close()
+
+	return rows.Err()
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) SelectPage(tableName string, offset int, limit int) (int64, error) {
+	if r.pool == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.pool.Query(context.Background(), createOffsetPageStatement(tableName, offset, limit, false))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPgxPageLastId(rows)
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) SelectPageAfterId(tableName string, id int64, limit int) (int64, error) {
+	if r.pool == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.pool.Query(context.Background(), createKeysetPageStatement(tableName, id, limit, false))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPgxPageLastId(rows)
+}
+
+func (r *pgxPostgresDatabaseTesterRepository) Close() error {
+	if r.pool == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	r.pool.Close()
+	r.pool = nil
+
+	return r.postgresDatabaseTesterRepository.Close()
+}
+
+// Method creates multi row insert statement with $n placeholders, because pgx doesn't support named ones
+func (r *pgxPostgresDatabaseTesterRepository) createPositionalInsertStatement(tableName string, columns []string, rowsCount int) string {
+	var buf bytes.Buffer
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(") VALUES ")
+
+	n := 1
+	for row := 0; row < rowsCount; row++ {
+		buf.WriteByte('(')
+		for i := range columns {
+			buf.WriteByte('$')
+			buf.WriteString(strconv.FormatInt(int64(n), 10))
+			n++
+			if i < len(columns)-1 {
+				buf.WriteByte(',')
+			}
+		}
+		buf.WriteByte(')')
+		if row < rowsCount-1 {
+			buf.WriteByte(',')
+		}
+	}
+
+	return buf.String()
+}
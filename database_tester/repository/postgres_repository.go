@@ -3,16 +3,27 @@ package repository
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4/stdlib"
 	"github.com/jmoiron/sqlx"
-
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
-const PING_TIMEOUT = 5 * time.Second
+const (
+	PING_TIMEOUT = 5 * time.Second
+	// https://www.postgresql.org/docs/current/errcodes-appendix.html
+	POSTGRES_UNIQUE_VIOLATION_CODE = "23505"
+	// Bind message keeps parameters count in int16
+	POSTGRES_MAX_PARAMETERS = 65535
+)
 
 type postgresDatabaseTesterRepository struct {
 	db       *sqlx.DB
@@ -21,21 +32,42 @@ type postgresDatabaseTesterRepository struct {
 	user     string
 	password string
 	dbname   string
+	// database/sql driver name
+	driverName string
+	// Table access method of the created tables
+	storageEngine string
+}
+
+// NewPostgresDatabaseTesterRepository creates repository using the driver.
+// Tables are created with the storage engine as the table access method if it's not empty.
+func NewPostgresDatabaseTesterRepository(port uint16, host, user, password string, driver domain.Driver, storageEngine string) (DatabaseTesterRepository, error) {
+	switch driver {
+	case domain.Driver_LibPq:
+		return newPostgresDatabaseTesterRepository(port, host, user, password, "postgres", storageEngine), nil
+	case domain.Driver_PgxStdlib:
+		return newPostgresDatabaseTesterRepository(port, host, user, password, "pgx", storageEngine), nil
+	case domain.Driver_Pgx:
+		return newPgxPostgresDatabaseTesterRepository(port, host, user, password, storageEngine), nil
+	default:
+		return nil, domain.UNKNOWN_DRIVER
+	}
 }
 
-func NewPostgresDatabaseTesterRepository(port uint16, host, user, password string) DatabaseTesterRepository {
+func newPostgresDatabaseTesterRepository(port uint16, host, user, password, driverName, storageEngine string) *postgresDatabaseTesterRepository {
 	r := new(postgresDatabaseTesterRepository)
 	r.port = port
 	r.host = host
 	r.user = user
 	r.password = password
 	r.dbname = ""
+	r.driverName = driverName
+	r.storageEngine = storageEngine
 	return r
 }
 
 func (r *postgresDatabaseTesterRepository) Open() error {
 	var err error
-	r.db, err = sqlx.Open("postgres", r.createConnString(r.port, r.host, r.user, r.password, r.dbname))
+	r.db, err = sqlx.Open(r.driverName, r.createConnString(r.port, r.host, r.user, r.password, r.dbname))
 	if err != nil {
 		return err
 	}
@@ -105,6 +137,67 @@ func (r *postgresDatabaseTesterRepository) SwitchDatabase(name string) error {
 	return nil
 }
 
+func (r *postgresDatabaseTesterRepository) ListDatabases() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT datname FROM pg_database WHERE NOT datistemplate"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (r *postgresDatabaseTesterRepository) CreateSchema(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE SCHEMA ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) DropSchema(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP SCHEMA IF EXISTS ")
+	buf.WriteString(name)
+	buf.WriteString(" CASCADE")
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) ListTables() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT tablename FROM pg_tables WHERE schemaname = 'public'"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
 func (r *postgresDatabaseTesterRepository) CreateTable(name string, fields []string) error {
 	if r.db == nil {
 		return domain.CONNECTION_WAS_NOT_ESTABLISHED
@@ -120,6 +213,39 @@ func (r *postgresDatabaseTesterRepository) CreateTable(name string, fields []str
 			buf.WriteByte(',')
 		}
 	}
+	buf.WriteString(")")
+	if r.storageEngine != "" {
+		buf.WriteString(" USING ")
+		buf.WriteString(r.storageEngine)
+	}
+	buf.WriteString(";")
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Partitioned table can't have access method, so storage engine is applied to its partitions only
+func (r *postgresDatabaseTesterRepository) CreatePartitionedTable(name string, fields []string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE TABLE ")
+	buf.WriteString(name)
+	buf.WriteString(" (")
+	for i, field := range fields {
+		buf.WriteString(field)
+		if i < len(fields)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(") PARTITION BY RANGE (")
+	buf.WriteString(column)
 	buf.WriteString(");")
 
 	_, err := r.db.Exec(buf.String())
@@ -130,6 +256,35 @@ func (r *postgresDatabaseTesterRepository) CreateTable(name string, fields []str
 	return nil
 }
 
+func (r *postgresDatabaseTesterRepository) CreateRangePartition(tableName string, partitionName string, from int64, to int64) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE TABLE ")
+	buf.WriteString(partitionName)
+	buf.WriteString(" PARTITION OF ")
+	buf.WriteString(tableName)
+	buf.WriteString(" FOR VALUES FROM (")
+	buf.WriteString(strconv.FormatInt(from, 10))
+	buf.WriteString(") TO (")
+	buf.WriteString(strconv.FormatInt(to, 10))
+	buf.WriteString(")")
+	if r.storageEngine != "" {
+		buf.WriteString(" USING ")
+		buf.WriteString(r.storageEngine)
+	}
+	buf.WriteString(";")
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *postgresDatabaseTesterRepository) DropTable(name string) error {
 	if r.db == nil {
 		return domain.CONNECTION_WAS_NOT_ESTABLISHED
@@ -147,14 +302,16 @@ func (r *postgresDatabaseTesterRepository) DropTable(name string) error {
 	return nil
 }
 
-func (r *postgresDatabaseTesterRepository) TruncateTable(name string) error {
+func (r *postgresDatabaseTesterRepository) AddColumn(tableName string, field string) error {
 	if r.db == nil {
 		return domain.CONNECTION_WAS_NOT_ESTABLISHED
 	}
 
 	var buf bytes.Buffer
-	buf.WriteString("TRUNCATE TABLE ")
-	buf.WriteString(name)
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" ADD COLUMN ")
+	buf.WriteString(field)
 
 	_, err := r.db.Exec(buf.String())
 	if err != nil {
@@ -164,116 +321,817 @@ func (r *postgresDatabaseTesterRepository) TruncateTable(name string) error {
 	return nil
 }
 
-func (r *postgresDatabaseTesterRepository) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+func (r *postgresDatabaseTesterRepository) DropColumn(tableName string, column string) error {
 	if r.db == nil {
 		return domain.CONNECTION_WAS_NOT_ESTABLISHED
 	}
 
-	if _, err := r.db.NamedExec(r.createInsertStatement(tableName, columns), values); err != nil {
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" DROP COLUMN IF EXISTS ")
+	buf.WriteString(column)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (r *postgresDatabaseTesterRepository) SelectById(tableName string, id int) error {
+func (r *postgresDatabaseTesterRepository) SetColumnNotNull(tableName string, column string) error {
 	if r.db == nil {
 		return domain.CONNECTION_WAS_NOT_ESTABLISHED
 	}
 
 	var buf bytes.Buffer
-	buf.WriteString("SELECT * FROM ")
+	buf.WriteString("ALTER TABLE ")
 	buf.WriteString(tableName)
-	buf.WriteString(" WHERE id=$1")
+	buf.WriteString(" ALTER COLUMN ")
+	buf.WriteString(column)
+	buf.WriteString(" SET NOT NULL")
 
-	rows, err := r.db.Query(buf.String(), id)
+	_, err := r.db.Exec(buf.String())
 	if err != nil {
 		return err
 	}
-	if err := rows.Close(); err != nil {
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) AlterColumnType(tableName string, column string, columnType string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" ALTER COLUMN ")
+	buf.WriteString(column)
+	buf.WriteString(" TYPE ")
+	buf.WriteString(columnType)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (r *postgresDatabaseTesterRepository) SelectByConditions(tableName string, conditions string) error {
+func (r *postgresDatabaseTesterRepository) CreateIndex(tableName string, indexName string, columns []string, concurrently bool) error {
 	if r.db == nil {
 		return domain.CONNECTION_WAS_NOT_ESTABLISHED
 	}
 
 	var buf bytes.Buffer
-	buf.WriteString("SELECT * FROM ")
+	buf.WriteString("CREATE INDEX ")
+	if concurrently {
+		buf.WriteString("CONCURRENTLY ")
+	}
+	buf.WriteString(indexName)
+	buf.WriteString(" ON ")
 	buf.WriteString(tableName)
-	buf.WriteString(" WHERE ")
-	buf.WriteString(conditions)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
 
-	rows, err := r.db.Query(buf.String())
+	_, err := r.db.Exec(buf.String())
 	if err != nil {
 		return err
 	}
-	if err := rows.Close(); err != nil {
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) DropIndex(tableName string, indexName string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP INDEX IF EXISTS ")
+	buf.WriteString(indexName)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (r *postgresDatabaseTesterRepository) Close() error {
+func (r *postgresDatabaseTesterRepository) TruncateTable(name string) error {
 	if r.db == nil {
 		return domain.CONNECTION_WAS_NOT_ESTABLISHED
 	}
 
-	if err := r.db.Close(); err != nil {
+	var buf bytes.Buffer
+	buf.WriteString("TRUNCATE TABLE ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
 		return err
 	}
 
-	r.db = nil
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if _, err := r.db.NamedExec(r.createInsertStatement(tableName, columns), values); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (r *postgresDatabaseTesterRepository) createConnString(port uint16, host, user, password, dbname string) string {
-	var buf bytes.Buffer
+func (r *postgresDatabaseTesterRepository) MaxInsertRows(columnsCount int) int {
+	if columnsCount == 0 {
+		return 0
+	}
+	return POSTGRES_MAX_PARAMETERS / columnsCount
+}
 
-	buf.WriteString("host=")
-	buf.WriteString(host)
-	buf.WriteString(" port=")
-	buf.WriteString(strconv.FormatUint(uint64(port), 10))
-	buf.WriteString(" user=")
-	buf.WriteString(user)
-	buf.WriteString(" password=")
-	buf.WriteString(password)
-	if dbname != "" {
-		buf.WriteString(" dbname=")
-		buf.WriteString(dbname)
+// Method copies rows by the driver copy interface, which is CopyIn statement of lib/pq or CopyFrom of the pgx connection
+func (r *postgresDatabaseTesterRepository) CopyFrom(tableName string, columns []string, chunks <-chan []map[string]interface{}) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
 	}
-	buf.WriteString(" sslmode=disable")
 
-	return buf.String()
+	if r.driverName == "pgx" {
+		return r.copyFromPgx(tableName, columns, chunks)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	identifier := createCopyIdentifier(tableName)
+	statement := pq.CopyIn(tableName, columns...)
+	if len(identifier) > 1 {
+		statement = pq.CopyInSchema(identifier[0], identifier[1], columns...)
+	}
+	stmt, err := tx.Prepare(statement)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var copied int64
+	args := make([]interface{}, len(columns))
+	for chunk := range chunks {
+		for _, v := range chunk {
+			for i, column := range columns {
+				args[i] = v[column]
+			}
+			if _, err := stmt.Exec(args...); err != nil {
+				return 0, err
+			}
+			copied++
+		}
+	}
+	// Buffered rows are flushed by the exec without arguments
+	if _, err := stmt.Exec(); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return copied, nil
 }
 
-func (r *postgresDatabaseTesterRepository) createInsertStatement(tableName string, columns []string) string {
+func (r *postgresDatabaseTesterRepository) copyFromPgx(tableName string, columns []string, chunks <-chan []map[string]interface{}) (int64, error) {
+	conn, err := r.db.Conn(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	var copied int64
+	err = conn.Raw(func(driverConn interface{}) error {
+		copied, err = driverConn.(*stdlib.Conn).Conn().CopyFrom(context.Background(), createCopyIdentifier(tableName), columns, newChunksCopyFromSource(chunks, columns))
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return copied, nil
+}
+
+func (r *postgresDatabaseTesterRepository) BeginTransaction() (DatabaseTesterTransaction, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresDatabaseTesterTransaction{tx: tx, r: r}, nil
+}
+
+func (r *postgresDatabaseTesterRepository) Upsert(tableName string, keyColumns []string, columns []string, values []map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	allColumns := append(append([]string{}, keyColumns...), columns...)
+
 	var buf bytes.Buffer
-	buf.WriteString("INSERT INTO ")
-	buf.WriteString(tableName)
-	buf.WriteString(" (")
-	for i, column := range columns {
+	buf.WriteString(r.createInsertStatement(tableName, allColumns))
+	buf.WriteString(" ON CONFLICT (")
+	for i, column := range keyColumns {
 		buf.WriteString(column)
-		if i < len(columns)-1 {
+		if i < len(keyColumns)-1 {
 			buf.WriteByte(',')
 		}
 	}
-	buf.WriteString(") VALUES (")
-
+	buf.WriteString(") DO UPDATE SET ")
 	for i, column := range columns {
-		buf.WriteByte(':')
+		buf.WriteString(column)
+		buf.WriteString("=EXCLUDED.")
 		buf.WriteString(column)
 		if i < len(columns)-1 {
 			buf.WriteByte(',')
 		}
 	}
-	buf.WriteByte(')')
 
-	return buf.String()
+	if _, err := r.db.NamedExec(buf.String(), values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) IsConflictError(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == POSTGRES_UNIQUE_VIOLATION_CODE
+	}
+
+	// pgx driver error
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == POSTGRES_UNIQUE_VIOLATION_CODE
+}
+
+func (r *postgresDatabaseTesterRepository) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(r.createUpdateStatement(tableName, columns))
+	buf.WriteString("id=$")
+	buf.WriteString(strconv.Itoa(len(columns) + 1))
+
+	args := make([]interface{}, 0, len(columns)+1)
+	for _, column := range columns {
+		args = append(args, values[column])
+	}
+
+	if _, err := r.db.Exec(buf.String(), append(args, id)...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) UpdateByConditions(tableName string, conditions string, columns []string, values map[string]interface{}) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		args[i] = values[column]
+	}
+
+	res, err := r.db.Exec(r.createUpdateStatement(tableName, columns)+conditions, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *postgresDatabaseTesterRepository) DeleteById(tableName string, id int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=$1")
+
+	if _, err := r.db.Exec(buf.String(), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) DeleteByConditions(tableName string, conditions string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(conditions)
+
+	res, err := r.db.Exec(buf.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *postgresDatabaseTesterRepository) DeleteAll(tableName string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	res, err := r.db.Exec("DELETE FROM " + tableName)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *postgresDatabaseTesterRepository) SelectById(tableName string, id int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=$1")
+
+	rows, err := r.db.Query(buf.String(), id)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) SelectByConditions(tableName string, conditions string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(conditions)
+
+	rows, err := r.db.Query(buf.String())
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) SelectJoin(tableName string, column string, joinedTableName string, joinType domain.JoinType) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createJoinStatement(tableName, column, joinedTableName, joinType)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(statement)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) SelectJoinAggregation(tableName string, column string, joinedTableName string, aggregatedColumn string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createJoinAggregationStatement(tableName, column, joinedTableName, aggregatedColumn))
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) SelectAggregation(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createAggregationStatement(tableName, groupColumn, aggregation, aggregatedColumn)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(statement)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) SelectPage(tableName string, offset int, limit int) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createOffsetPageStatement(tableName, offset, limit, false))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPageLastId(rows)
+}
+
+func (r *postgresDatabaseTesterRepository) SelectPageAfterId(tableName string, id int64, limit int) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createKeysetPageStatement(tableName, id, limit, false))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPageLastId(rows)
+}
+
+func (r *postgresDatabaseTesterRepository) SelectColumnById(tableName string, column string, id int64, dest interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.db.Get(dest, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) CountRows(tableName string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COUNT(*) FROM ")
+	buf.WriteString(tableName)
+
+	var count int64
+	if err := r.db.Get(&count, buf.String()); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *postgresDatabaseTesterRepository) SumColumn(tableName string, column string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COALESCE(SUM(")
+	buf.WriteString(column)
+	buf.WriteString("), 0) FROM ")
+	buf.WriteString(tableName)
+
+	var sum int64
+	if err := r.db.Get(&sum, buf.String()); err != nil {
+		return 0, err
+	}
+
+	return sum, nil
+}
+
+func (r *postgresDatabaseTesterRepository) UpdateInTransaction(tableName string, column string, deltas map[int64]int64, isolationLevel domain.IsolationLevel) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	txIsolationLevel, err := r.convertIsolationLevel(isolationLevel)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTxx(context.Background(), &sql.TxOptions{Isolation: txIsolationLevel})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Update rows in the same order to prevent deadlocks
+	ids := make([]int64, 0, len(deltas))
+	for id := range deltas {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		var value int64
+		if err := tx.Get(&value, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(r.createUpdateColumnByIdStatement(tableName, column), value+deltas[id], id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *postgresDatabaseTesterRepository) WithdrawInTransaction(tableName string, column string, id int64, amount int64, isolationLevel domain.IsolationLevel) (bool, error) {
+	if r.db == nil {
+		return false, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	txIsolationLevel, err := r.convertIsolationLevel(isolationLevel)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := r.db.BeginTxx(context.Background(), &sql.TxOptions{Isolation: txIsolationLevel})
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COALESCE(SUM(")
+	buf.WriteString(column)
+	buf.WriteString("), 0) FROM ")
+	buf.WriteString(tableName)
+
+	var sum int64
+	if err := tx.Get(&sum, buf.String()); err != nil {
+		return false, err
+	}
+	if sum < amount {
+		return false, nil
+	}
+
+	var value int64
+	if err := tx.Get(&value, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(r.createUpdateColumnByIdStatement(tableName, column), value-amount, id); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *postgresDatabaseTesterRepository) BackupCommand(filePath string) []string {
+	return []string{"pg_dump", "--format=custom", "--file=" + filePath, "--dbname=" + r.createConnUrl(r.dbname)}
+}
+
+func (r *postgresDatabaseTesterRepository) RestoreCommand(filePath string, dbname string) []string {
+	return []string{"pg_restore", "--dbname=" + r.createConnUrl(dbname), filePath}
+}
+
+func (r *postgresDatabaseTesterRepository) PrepareInsert(tableName string, columns []string) (PreparedStatement, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return newSqlPreparedStatement(r.db.DB, createPreparedInsertStatement(tableName, columns, dollarPlaceholder), false, nil)
+}
+
+func (r *postgresDatabaseTesterRepository) PrepareSelectById(tableName string) (PreparedStatement, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return newSqlPreparedStatement(r.db.DB, createPreparedSelectByIdStatement(tableName, dollarPlaceholder), true, nil)
+}
+
+func (r *postgresDatabaseTesterRepository) SqlDB() *sql.DB {
+	if r.db == nil {
+		return nil
+	}
+	return r.db.DB
+}
+
+func (r *postgresDatabaseTesterRepository) Close() error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.db.Close(); err != nil {
+		return err
+	}
+
+	r.db = nil
+
+	return nil
+}
+
+func (r *postgresDatabaseTesterRepository) createConnString(port uint16, host, user, password, dbname string) string {
+	var buf bytes.Buffer
+
+	buf.WriteString("host=")
+	buf.WriteString(host)
+	buf.WriteString(" port=")
+	buf.WriteString(strconv.FormatUint(uint64(port), 10))
+	buf.WriteString(" user=")
+	buf.WriteString(user)
+	// Empty value would take the next parameter
+	if password != "" {
+		buf.WriteString(" password=")
+		buf.WriteString(password)
+	}
+	if dbname != "" {
+		buf.WriteString(" dbname=")
+		buf.WriteString(dbname)
+	}
+	buf.WriteString(" sslmode=disable")
+
+	return buf.String()
+}
+
+// Method creates connection URL for the tools running inside the container
+func (r *postgresDatabaseTesterRepository) createConnUrl(dbname string) string {
+	var buf bytes.Buffer
+
+	buf.WriteString("postgresql://")
+	buf.WriteString(url.UserPassword(r.user, r.password).String())
+	buf.WriteString("@localhost:")
+	buf.WriteString(strconv.FormatUint(uint64(r.port), 10))
+	buf.WriteByte('/')
+	buf.WriteString(dbname)
+
+	return buf.String()
+}
+
+func (r *postgresDatabaseTesterRepository) createInsertStatement(tableName string, columns []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(") VALUES (")
+
+	for i, column := range columns {
+		buf.WriteByte(':')
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+
+	return buf.String()
+}
+
+func (r *postgresDatabaseTesterRepository) createSelectColumnByIdStatement(tableName string, column string) string {
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	buf.WriteString(column)
+	buf.WriteString(" FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=$1")
+
+	return buf.String()
+}
+
+func (r *postgresDatabaseTesterRepository) createUpdateColumnByIdStatement(tableName string, column string) string {
+	var buf bytes.Buffer
+	buf.WriteString("UPDATE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" SET ")
+	buf.WriteString(column)
+	buf.WriteString("=$1 WHERE id=$2")
+
+	return buf.String()
+}
+
+// Method creates update statement of the columns with $n placeholders, which is ended by the conditions
+func (r *postgresDatabaseTesterRepository) createUpdateStatement(tableName string, columns []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("UPDATE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" SET ")
+	for i, column := range columns {
+		buf.WriteString(column)
+		buf.WriteString("=$")
+		buf.WriteString(strconv.Itoa(i + 1))
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(" WHERE ")
+
+	return buf.String()
+}
+
+func (r *postgresDatabaseTesterRepository) convertIsolationLevel(isolationLevel domain.IsolationLevel) (sql.IsolationLevel, error) {
+	switch isolationLevel {
+	case domain.IsolationLevel_ReadCommitted:
+		return sql.LevelReadCommitted, nil
+	case domain.IsolationLevel_RepeatableRead:
+		return sql.LevelRepeatableRead, nil
+	case domain.IsolationLevel_Serializable:
+		return sql.LevelSerializable, nil
+	default:
+		return sql.LevelDefault, domain.UNKNOWN_ISOLATION_LEVEL
+	}
+}
+
+type postgresDatabaseTesterTransaction struct {
+	tx *sqlx.Tx
+	r  *postgresDatabaseTesterRepository
+}
+
+func (t *postgresDatabaseTesterTransaction) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	if _, err := t.tx.NamedExec(t.r.createInsertStatement(tableName, columns), values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *postgresDatabaseTesterTransaction) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	var buf bytes.Buffer
+	buf.WriteString(t.r.createUpdateStatement(tableName, columns))
+	buf.WriteString("id=$")
+	buf.WriteString(strconv.Itoa(len(columns) + 1))
+
+	args := make([]interface{}, 0, len(columns)+1)
+	for _, column := range columns {
+		args = append(args, values[column])
+	}
+
+	if _, err := t.tx.Exec(buf.String(), append(args, id)...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *postgresDatabaseTesterTransaction) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *postgresDatabaseTesterTransaction) Rollback() error {
+	return t.tx.Rollback()
 }
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"bytes"
+	"database/sql"
+	"strconv"
+)
+
+// Statement prepared by database/sql on every connection of the pool, where it's executed first
+type sqlPreparedStatement struct {
+	stmt *sql.Stmt
+	// Selects return rows, which are read like by the not prepared selects
+	query bool
+	// Converts arguments for the dialect, if it's set
+	convertValue func(value interface{}) interface{}
+}
+
+func newSqlPreparedStatement(db *sql.DB, statement string, query bool, convertValue func(value interface{}) interface{}) (PreparedStatement, error) {
+	stmt, err := db.Prepare(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlPreparedStatement{stmt: stmt, query: query, convertValue: convertValue}, nil
+}
+
+func (s *sqlPreparedStatement) Exec(args ...interface{}) error {
+	if s.convertValue != nil {
+		converted := make([]interface{}, len(args))
+		for i, arg := range args {
+			converted[i] = s.convertValue(arg)
+		}
+		args = converted
+	}
+
+	if !s.query {
+		_, err := s.stmt.Exec(args...)
+		return err
+	}
+
+	rows, err := s.stmt.Query(args...)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+func (s *sqlPreparedStatement) Close() error {
+	return s.stmt.Close()
+}
+
+// Creates single row insert with the placeholders of the dialect, which are numbered from 1
+func createPreparedInsertStatement(tableName string, columns []string, placeholder func(n int) string) string {
+	var buf bytes.Buffer
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(") VALUES (")
+	for i := range columns {
+		buf.WriteString(placeholder(i + 1))
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+
+	return buf.String()
+}
+
+func createPreparedSelectByIdStatement(tableName string, placeholder func(n int) string) string {
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=")
+	buf.WriteString(placeholder(1))
+
+	return buf.String()
+}
+
+func dollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+func questionPlaceholder(int) string {
+	return "?"
+}
+
+func mssqlPlaceholder(n int) string {
+	return "@p" + strconv.Itoa(n)
+}
+
+func oraclePlaceholder(n int) string {
+	return ":" + strconv.Itoa(n)
+}
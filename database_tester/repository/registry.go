@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"plugin"
+	"sort"
+	"sync"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+// DatabaseTesterRepositoryFactory creates repository of the registered component for the test case.
+// Port is passed separately, because replicas are listening on the other ports.
+type DatabaseTesterRepositoryFactory func(tc *domain.TestCase, port uint16) (DatabaseTesterRepository, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[domain.ComponentType]DatabaseTesterRepositoryFactory)
+)
+
+// RegisterDatabaseTesterRepository adds repository of the component type implemented outside of the repository package.
+// It's called from init functions of the extensions and the Go plugins.
+func RegisterDatabaseTesterRepository(ct domain.ComponentType, factory DatabaseTesterRepositoryFactory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	factories[ct] = factory
+}
+
+func LookupDatabaseTesterRepository(ct domain.ComponentType) (DatabaseTesterRepositoryFactory, bool) {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	factory, ok := factories[ct]
+	return factory, ok
+}
+
+// RegisteredComponentTypes returns sorted component types of the registered repositories
+func RegisteredComponentTypes() []domain.ComponentType {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	cts := make([]domain.ComponentType, 0, len(factories))
+	for ct := range factories {
+		cts = append(cts, ct)
+	}
+	sort.Slice(cts, func(i, j int) bool { return cts[i] < cts[j] })
+	return cts
+}
+
+// LoadRepositoryPlugin opens Go plugin which registers its repositories on init.
+// Plugin has to be built by the same Go version with the same versions of the shared packages.
+func LoadRepositoryPlugin(path string) error {
+	_, err := plugin.Open(path)
+	return err
+}
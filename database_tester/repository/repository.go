@@ -1,16 +1,162 @@
 package repository
 
+import (
+	"database/sql"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
 type DatabaseTesterRepository interface {
 	Open() error
 	Ping() error
 	CreateDatabase(name string) error
 	DropDatabase(name string) error
 	SwitchDatabase(name string) error
+	ListDatabases() ([]string, error)
+	// CreateSchema creates namespace in the current database, its tables are addressed as "schema.table"
+	CreateSchema(name string) error
+	// DropSchema drops namespace with all its tables
+	DropSchema(name string) error
+	// ListTables returns tables of the current database
+	ListTables() ([]string, error)
 	CreateTable(name string, fields []string) error
+	// CreatePartitionedTable creates table which rows are stored in the range partitions by the column
+	CreatePartitionedTable(name string, fields []string, column string) error
+	// CreateRangePartition creates partition of the table for the column values in [from, to) range
+	CreateRangePartition(tableName string, partitionName string, from int64, to int64) error
 	TruncateTable(name string) error
 	DropTable(name string) error
+	AddColumn(tableName string, field string) error
+	DropColumn(tableName string, column string) error
+	SetColumnNotNull(tableName string, column string) error
+	AlterColumnType(tableName string, column string, columnType string) error
+	// CreateIndex creates index on the columns. With concurrently flag index is built without blocking writes if engine supports it.
+	CreateIndex(tableName string, indexName string, columns []string, concurrently bool) error
+	DropIndex(tableName string, indexName string) error
 	Insert(tableName string, columns []string, values []map[string]interface{}) error
+	// MaxInsertRows returns max rows count of the single insert of the columns count, which is 0 if repository splits rows itself
+	MaxInsertRows(columnsCount int) int
+	// Upsert inserts values or updates columns of the rows with the same key columns
+	Upsert(tableName string, keyColumns []string, columns []string, values []map[string]interface{}) error
+	// IsConflictError checks that error is caused by unique constraint violation
+	IsConflictError(err error) bool
+	// UpdateById sets columns of the row by id to the values
+	UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error
+	// UpdateByConditions sets columns of the rows matching conditions to the values and returns count of the updated rows
+	UpdateByConditions(tableName string, conditions string, columns []string, values map[string]interface{}) (int64, error)
+	DeleteById(tableName string, id int) error
+	// DeleteByConditions deletes rows matching conditions and returns count of the deleted rows
+	DeleteByConditions(tableName string, conditions string) (int64, error)
+	// DeleteAll deletes all the rows one by one unlike the truncate and returns count of the deleted rows
+	DeleteAll(tableName string) (int64, error)
 	SelectById(tableName string, id int) error
 	SelectByConditions(tableName string, conditions string) error
+	// SelectJoin selects rows of the table joined by the column with the ids of the joined table
+	SelectJoin(tableName string, column string, joinedTableName string, joinType domain.JoinType) error
+	// SelectJoinAggregation selects count of the table rows and sum of their aggregated column by the ids of the joined table
+	SelectJoinAggregation(tableName string, column string, joinedTableName string, aggregatedColumn string) error
+	// SelectAggregation selects aggregation of the column values by the group column or over the whole table if it's empty
+	SelectAggregation(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) error
+	// SelectPage selects limit rows ordered by id after offset rows and returns id of the last selected row
+	SelectPage(tableName string, offset int, limit int) (int64, error)
+	// SelectPageAfterId selects limit rows ordered by id, which ids are greater than the id, and returns id of the last selected row
+	SelectPageAfterId(tableName string, id int64, limit int) (int64, error)
+	// SelectColumnById scans column value of the row into the dest pointer
+	SelectColumnById(tableName string, column string, id int64, dest interface{}) error
+	CountRows(tableName string) (int64, error)
+	SumColumn(tableName string, column string) (int64, error)
+	// BeginTransaction starts transaction for the operations which are committed or rolled back together
+	BeginTransaction() (DatabaseTesterTransaction, error)
+	// UpdateInTransaction reads column values by ids and writes them back increased by deltas in one transaction
+	UpdateInTransaction(tableName string, column string, deltas map[int64]int64, isolationLevel domain.IsolationLevel) error
+	// WithdrawInTransaction decreases column value by id only if sum of the column stays non negative.
+	// Returns false if withdraw wasn't allowed.
+	WithdrawInTransaction(tableName string, column string, id int64, amount int64, isolationLevel domain.IsolationLevel) (bool, error)
+	// BackupCommand returns engine's native command to dump the current database into the file inside the container
+	BackupCommand(filePath string) []string
+	// RestoreCommand returns engine's native command to restore the dump file into the database inside the container
+	RestoreCommand(filePath string, dbname string) []string
+	// SqlDB returns connection pool of the current database for the workloads comparing client libraries
+	SqlDB() *sql.DB
+	Close() error
+}
+
+// ConnectionSetupReporter is implemented by the repositories which drivers open connection to every node or shard
+type ConnectionSetupReporter interface {
+	// ConnectionSetupTimes returns setup times of the connections opened since the previous call in microseconds
+	ConnectionSetupTimes() []float64
+}
+
+// BootstrapReporter is implemented by the repositories of the databases which accept connections before their storage is ready
+type BootstrapReporter interface {
+	// BootstrapTime returns time since the first accepted connection till the successful ping in microseconds or 0 if ping didn't succeed
+	BootstrapTime() float64
+}
+
+// HypertableRepository is implemented by the repositories of the time series databases storing tables in the time chunks
+type HypertableRepository interface {
+	// CreateHypertable creates table which rows are stored in the chunks by the time column ranges of the interval
+	CreateHypertable(name string, fields []string, timeColumn string, chunkInterval time.Duration) error
+	// CountChunks returns count of the chunks of the hypertable
+	CountChunks(tableName string) (int64, error)
+	// SelectTimeBuckets aggregates column of the rows in [from, to) time range by the time buckets. It works for the plain tables too.
+	SelectTimeBuckets(tableName string, timeColumn string, column string, bucket time.Duration, from time.Time, to time.Time) error
+}
+
+// VectorRepository is implemented by the repositories of the databases storing vectors by the extension
+type VectorRepository interface {
+	// CreateVectorExtension creates extension of the vector column type in the current database
+	CreateVectorExtension() error
+	// VectorValue converts vector into the inserted value of the vector column
+	VectorValue(v []float32) interface{}
+	// CreateVectorIndex creates ANN index of the method on the cosine distance of the column. Rows count is the size hint of the clustered indexes.
+	CreateVectorIndex(tableName string, indexName string, column string, method string, rowsCount int) error
+	// SearchVectors returns ids of k rows nearest to the query by the cosine distance
+	SearchVectors(tableName string, column string, query []float32, k int) ([]int, error)
+}
+
+// JsonRepository is implemented by the repositories of the databases storing JSON documents in the JSONB columns
+type JsonRepository interface {
+	// CreateJsonIndex creates GIN index of the document column, which is used by the containment selects
+	CreateJsonIndex(tableName string, indexName string, column string) error
+	// SelectJsonContains selects rows, which documents contain the document
+	SelectJsonContains(tableName string, column string, document string) error
+	// SelectJsonPath selects rows, which documents have the text value by the path of the keys
+	SelectJsonPath(tableName string, column string, path []string, value string) error
+}
+
+// FullTextSearchRepository is implemented by the repositories of the databases searching words of the text columns by the index
+type FullTextSearchRepository interface {
+	// CreateFullTextIndex creates full-text index of the text column
+	CreateFullTextIndex(tableName string, indexName string, column string) error
+	// SearchText selects rows, which texts of the column match words of the query
+	SearchText(tableName string, column string, query string) error
+}
+
+// PreparedStatementRepository is implemented by the repositories of the databases, which parse and plan the prepared statements once
+type PreparedStatementRepository interface {
+	// PrepareInsert prepares insert of the single row, which values of the columns are the arguments of the statement
+	PrepareInsert(tableName string, columns []string) (PreparedStatement, error)
+	// PrepareSelectById prepares select of the row, which id is the argument of the statement
+	PrepareSelectById(tableName string) (PreparedStatement, error)
+}
+
+type PreparedStatement interface {
+	// Exec runs statement with the arguments and reads all its rows
+	Exec(args ...interface{}) error
 	Close() error
 }
+
+// CopyRepository is implemented by the repositories of the databases, which load rows by the COPY protocol
+type CopyRepository interface {
+	// CopyFrom copies rows of the chunks till the channel is closed and returns count of the copied rows
+	CopyFrom(tableName string, columns []string, chunks <-chan []map[string]interface{}) (int64, error)
+}
+
+type DatabaseTesterTransaction interface {
+	Insert(tableName string, columns []string, values []map[string]interface{}) error
+	UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error
+	Commit() error
+	Rollback() error
+}
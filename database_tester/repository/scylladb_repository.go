@@ -0,0 +1,15 @@
+package repository
+
+import "github.com/gocql/gocql"
+
+// NewScyllaDBDatabaseTesterRepository creates CQL repository routing queries to the replicas owning the partition.
+// Shard aware routing is done by the scylladb/gocql fork with the same API, which is plugged by the replace directive
+// of the module. The fork opens connection to every shard of the node, so connection setup times are reported per shard then.
+// Upstream driver opens the pool of the connections per node.
+func NewScyllaDBDatabaseTesterRepository(port uint16, host, user, password, storageEngine string) DatabaseTesterRepository {
+	r := newCassandraDatabaseTesterRepository(port, host, user, password, storageEngine)
+	r.configureCluster = func(cluster *gocql.ClusterConfig) {
+		cluster.PoolConfig.HostSelectionPolicy = gocql.TokenAwareHostPolicy(gocql.RoundRobinHostPolicy())
+	}
+	return r
+}
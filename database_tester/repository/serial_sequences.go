@@ -0,0 +1,70 @@
+package repository
+
+import "sync"
+
+// Sequences of the serial columns for the databases without them, which are filled by the repository on insert
+type serialSequences struct {
+	mu sync.Mutex
+	// Table name to the serial columns and their last values
+	values map[string]map[string]int64
+}
+
+func newSerialSequences() *serialSequences {
+	s := new(serialSequences)
+	s.values = make(map[string]map[string]int64)
+	return s
+}
+
+// Method creates sequences of the serial fields of the table
+func (s *serialSequences) create(tableName string, fields []string) {
+	serials := make(map[string]int64)
+	for _, field := range fields {
+		if column, columnType, _, ok := splitFieldDefinition(field); ok && isSerialType(columnType) {
+			serials[column] = 0
+		}
+	}
+
+	s.mu.Lock()
+	s.values[tableName] = serials
+	s.mu.Unlock()
+}
+
+func (s *serialSequences) drop(tableName string) {
+	s.mu.Lock()
+	delete(s.values, tableName)
+	s.mu.Unlock()
+}
+
+// Method adds serial columns which values aren't set to the inserted values with the next values of their sequences
+func (s *serialSequences) fill(tableName string, columns []string, values []map[string]interface{}) ([]string, []map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	serials, ok := s.values[tableName]
+	if !ok {
+		return columns, values
+	}
+
+	for column := range serials {
+		if containsColumn(columns, column) {
+			continue
+		}
+
+		columns = append(append([]string{}, columns...), column)
+		for _, v := range values {
+			serials[column]++
+			v[column] = serials[column]
+		}
+	}
+
+	return columns, values
+}
+
+func containsColumn(columns []string, column string) bool {
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+-- name: InsertRow :exec
+INSERT INTO orm_overhead_table (id, name, value, created_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetRow :one
+SELECT id, name, value, created_at FROM orm_overhead_table
+WHERE id = $1;
+
+-- name: ListRowsByValue :many
+SELECT id, name, value, created_at FROM orm_overhead_table
+WHERE value >= $1 AND value < $2
+ORDER BY id
+LIMIT $3;
@@ -0,0 +1,6 @@
+CREATE TABLE orm_overhead_table (
+  id         BIGINT    PRIMARY KEY,
+  name       TEXT      NOT NULL,
+  value      BIGINT    NOT NULL,
+  created_at TIMESTAMP NOT NULL
+);
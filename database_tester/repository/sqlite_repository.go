@@ -0,0 +1,978 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	SQLITE_DATABASE_FILE_EXTENSION = ".db"
+	// Default SQLITE_MAX_VARIABLE_NUMBER since SQLite 3.32.0
+	SQLITE_MAX_PARAMETERS = 32766
+)
+
+// Column types of the workload tables which are named differently in SQLite.
+// Only INTEGER PRIMARY KEY column is the alias of the auto incremented rowid, so serials are mapped to INTEGER.
+var sqliteColumnTypes = map[string]string{
+	"BIGSERIAL":   "INTEGER",
+	"SERIAL":      "INTEGER",
+	"SMALLSERIAL": "INTEGER",
+	"TIMESTAMPTZ": "TIMESTAMP",
+	"BYTEA":       "BLOB",
+	"JSONB":       "TEXT",
+}
+
+// Repository maps databases to the files of the directory and schemas to the attached databases.
+// SQLite serializes writers anyway, so the single connection is used, which keeps attached schemas
+// and in-memory database visible to all statements.
+type sqliteDatabaseTesterRepository struct {
+	db *sqlx.DB
+	// Directory of the database files. In-memory databases are used if it's empty.
+	directory string
+	dbname    string
+}
+
+// NewSQLiteDatabaseTesterRepository creates embedded repository using go-sqlite3.
+// Databases are kept in memory if the directory is empty.
+func NewSQLiteDatabaseTesterRepository(directory string) DatabaseTesterRepository {
+	r := new(sqliteDatabaseTesterRepository)
+	r.directory = directory
+	r.dbname = ""
+	return r
+}
+
+func (r *sqliteDatabaseTesterRepository) Open() error {
+	var err error
+	r.db, err = sqlx.Open("sqlite3", r.createConnString(r.dbname))
+	if err != nil {
+		return err
+	}
+	r.db.SetMaxOpenConns(1)
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) Ping() error {
+	ctx, ctxCancelFunc := context.WithTimeout(context.Background(), PING_TIMEOUT)
+	defer ctxCancelFunc()
+	if err := r.db.PingContext(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In-memory database is created on connection
+func (r *sqliteDatabaseTesterRepository) CreateDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+	if r.directory == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(r.createFilePath(name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// In-memory database is removed on connection close
+func (r *sqliteDatabaseTesterRepository) DropDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+	if r.directory == "" {
+		return nil
+	}
+
+	return r.removeFiles(r.createFilePath(name))
+}
+
+func (r *sqliteDatabaseTesterRepository) SwitchDatabase(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.Close(); err != nil {
+		return err
+	}
+
+	r.dbname = name
+
+	if err := r.Open(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) ListDatabases() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if r.directory == "" {
+		if r.dbname == "" {
+			return nil, nil
+		}
+		return []string{r.dbname}, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(r.directory, "*"+SQLITE_DATABASE_FILE_EXTENSION))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(paths))
+	for _, path := range paths {
+		names = append(names, strings.TrimSuffix(filepath.Base(path), SQLITE_DATABASE_FILE_EXTENSION))
+	}
+
+	return names, nil
+}
+
+// Schema is the attached database, so its tables are addressed as "schema.table"
+func (r *sqliteDatabaseTesterRepository) CreateSchema(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	path := ":memory:"
+	if r.directory != "" {
+		path = r.createFilePath(r.dbname + "_" + name)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ATTACH DATABASE '")
+	buf.WriteString(path)
+	buf.WriteString("' AS ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) DropSchema(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var attached int
+	if err := r.db.Get(&attached, "SELECT COUNT(*) FROM pragma_database_list WHERE name = ?", name); err != nil {
+		return err
+	}
+
+	if attached > 0 {
+		var buf bytes.Buffer
+		buf.WriteString("DETACH DATABASE ")
+		buf.WriteString(name)
+
+		if _, err := r.db.Exec(buf.String()); err != nil {
+			return err
+		}
+	}
+
+	if r.directory == "" {
+		return nil
+	}
+	return r.removeFiles(r.createFilePath(r.dbname + "_" + name))
+}
+
+func (r *sqliteDatabaseTesterRepository) ListTables() ([]string, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var names []string
+	if err := r.db.Select(&names, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'"); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (r *sqliteDatabaseTesterRepository) CreateTable(name string, fields []string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE TABLE ")
+	buf.WriteString(name)
+	buf.WriteString(" (")
+	for i, field := range fields {
+		buf.WriteString(r.convertField(field))
+		if i < len(fields)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(");")
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SQLite has no partitions
+func (r *sqliteDatabaseTesterRepository) CreatePartitionedTable(name string, fields []string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *sqliteDatabaseTesterRepository) CreateRangePartition(tableName string, partitionName string, from int64, to int64) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *sqliteDatabaseTesterRepository) DropTable(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP TABLE IF EXISTS ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) AddColumn(tableName string, field string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" ADD COLUMN ")
+	buf.WriteString(r.convertField(field))
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) DropColumn(tableName string, column string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" DROP COLUMN ")
+	buf.WriteString(column)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Column constraints and types can be changed only by the table rebuild
+func (r *sqliteDatabaseTesterRepository) SetColumnNotNull(tableName string, column string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+func (r *sqliteDatabaseTesterRepository) AlterColumnType(tableName string, column string, columnType string) error {
+	return domain.UNSUPPORTED_OPERATION
+}
+
+// Index is always built under the write lock, so concurrently flag is ignored
+func (r *sqliteDatabaseTesterRepository) CreateIndex(tableName string, indexName string, columns []string, concurrently bool) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE INDEX ")
+	buf.WriteString(indexName)
+	buf.WriteString(" ON ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) DropIndex(tableName string, indexName string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DROP INDEX IF EXISTS ")
+	buf.WriteString(indexName)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SQLite has no TRUNCATE, but DELETE without WHERE clause drops all pages of the table at once
+func (r *sqliteDatabaseTesterRepository) TruncateTable(name string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(name)
+
+	_, err := r.db.Exec(buf.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if _, err := r.db.NamedExec(r.createInsertStatement(tableName, columns), values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) MaxInsertRows(columnsCount int) int {
+	if columnsCount == 0 {
+		return 0
+	}
+	return SQLITE_MAX_PARAMETERS / columnsCount
+}
+
+func (r *sqliteDatabaseTesterRepository) BeginTransaction() (DatabaseTesterTransaction, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteDatabaseTesterTransaction{tx: tx, r: r}, nil
+}
+
+func (r *sqliteDatabaseTesterRepository) Upsert(tableName string, keyColumns []string, columns []string, values []map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	allColumns := append(append([]string{}, keyColumns...), columns...)
+
+	var buf bytes.Buffer
+	buf.WriteString(r.createInsertStatement(tableName, allColumns))
+	buf.WriteString(" ON CONFLICT (")
+	for i, column := range keyColumns {
+		buf.WriteString(column)
+		if i < len(keyColumns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(") DO UPDATE SET ")
+	for i, column := range columns {
+		buf.WriteString(column)
+		buf.WriteString("=excluded.")
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+
+	if _, err := r.db.NamedExec(buf.String(), values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) IsConflictError(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) &&
+		(sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey || sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique)
+}
+
+func (r *sqliteDatabaseTesterRepository) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	args := make([]interface{}, 0, len(columns)+1)
+	for _, column := range columns {
+		args = append(args, values[column])
+	}
+
+	if _, err := r.db.Exec(r.createUpdateStatement(tableName, columns)+"id=?", append(args, id)...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) UpdateByConditions(tableName string, conditions string, columns []string, values map[string]interface{}) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		args[i] = values[column]
+	}
+
+	res, err := r.db.Exec(r.createUpdateStatement(tableName, columns)+conditions, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *sqliteDatabaseTesterRepository) DeleteById(tableName string, id int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=?")
+
+	if _, err := r.db.Exec(buf.String(), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) DeleteByConditions(tableName string, conditions string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(conditions)
+
+	res, err := r.db.Exec(buf.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *sqliteDatabaseTesterRepository) DeleteAll(tableName string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	res, err := r.db.Exec("DELETE FROM " + tableName)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *sqliteDatabaseTesterRepository) SelectById(tableName string, id int) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=?")
+
+	rows, err := r.db.Query(buf.String(), id)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) SelectByConditions(tableName string, conditions string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(conditions)
+
+	rows, err := r.db.Query(buf.String())
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) SelectJoin(tableName string, column string, joinedTableName string, joinType domain.JoinType) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createJoinStatement(tableName, column, joinedTableName, joinType)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(statement)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) SelectJoinAggregation(tableName string, column string, joinedTableName string, aggregatedColumn string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createJoinAggregationStatement(tableName, column, joinedTableName, aggregatedColumn))
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) SelectAggregation(tableName string, groupColumn string, aggregation domain.Aggregation, aggregatedColumn string) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	statement, err := createAggregationStatement(tableName, groupColumn, aggregation, aggregatedColumn)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(statement)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) SelectPage(tableName string, offset int, limit int) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createOffsetPageStatement(tableName, offset, limit, false))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPageLastId(rows)
+}
+
+func (r *sqliteDatabaseTesterRepository) SelectPageAfterId(tableName string, id int64, limit int) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.db.Query(createKeysetPageStatement(tableName, id, limit, false))
+	if err != nil {
+		return 0, err
+	}
+
+	return readPageLastId(rows)
+}
+
+func (r *sqliteDatabaseTesterRepository) SelectColumnById(tableName string, column string, id int64, dest interface{}) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.db.Get(dest, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) CountRows(tableName string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COUNT(*) FROM ")
+	buf.WriteString(tableName)
+
+	var count int64
+	if err := r.db.Get(&count, buf.String()); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *sqliteDatabaseTesterRepository) SumColumn(tableName string, column string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COALESCE(SUM(")
+	buf.WriteString(column)
+	buf.WriteString("), 0) FROM ")
+	buf.WriteString(tableName)
+
+	var sum int64
+	if err := r.db.Get(&sum, buf.String()); err != nil {
+		return 0, err
+	}
+
+	return sum, nil
+}
+
+// Transactions of the single connection are serial, so isolation level is only validated
+func (r *sqliteDatabaseTesterRepository) UpdateInTransaction(tableName string, column string, deltas map[int64]int64, isolationLevel domain.IsolationLevel) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.validateIsolationLevel(isolationLevel); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	ids := make([]int64, 0, len(deltas))
+	for id := range deltas {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		var value int64
+		if err := tx.Get(&value, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(r.createUpdateColumnByIdStatement(tableName, column), value+deltas[id], id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *sqliteDatabaseTesterRepository) WithdrawInTransaction(tableName string, column string, id int64, amount int64, isolationLevel domain.IsolationLevel) (bool, error) {
+	if r.db == nil {
+		return false, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.validateIsolationLevel(isolationLevel); err != nil {
+		return false, err
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT COALESCE(SUM(")
+	buf.WriteString(column)
+	buf.WriteString("), 0) FROM ")
+	buf.WriteString(tableName)
+
+	var sum int64
+	if err := tx.Get(&sum, buf.String()); err != nil {
+		return false, err
+	}
+	if sum < amount {
+		return false, nil
+	}
+
+	var value int64
+	if err := tx.Get(&value, r.createSelectColumnByIdStatement(tableName, column), id); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(r.createUpdateColumnByIdStatement(tableName, column), value-amount, id); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Embedded database has no container to run the tools in
+func (r *sqliteDatabaseTesterRepository) BackupCommand(filePath string) []string {
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) RestoreCommand(filePath string, dbname string) []string {
+	return nil
+}
+
+func (r *sqliteDatabaseTesterRepository) PrepareInsert(tableName string, columns []string) (PreparedStatement, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return newSqlPreparedStatement(r.db.DB, createPreparedInsertStatement(tableName, columns, questionPlaceholder), false, nil)
+}
+
+func (r *sqliteDatabaseTesterRepository) PrepareSelectById(tableName string) (PreparedStatement, error) {
+	if r.db == nil {
+		return nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return newSqlPreparedStatement(r.db.DB, createPreparedSelectByIdStatement(tableName, questionPlaceholder), true, nil)
+}
+
+func (r *sqliteDatabaseTesterRepository) SqlDB() *sql.DB {
+	if r.db == nil {
+		return nil
+	}
+	return r.db.DB
+}
+
+func (r *sqliteDatabaseTesterRepository) Close() error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.db.Close(); err != nil {
+		return err
+	}
+
+	r.db = nil
+
+	return nil
+}
+
+// Method creates DSN of the database file. Database without name is the temporary one.
+func (r *sqliteDatabaseTesterRepository) createConnString(dbname string) string {
+	if r.directory == "" {
+		return "file:" + dbname + "?mode=memory"
+	}
+	if dbname == "" {
+		return "file:?_journal_mode=WAL"
+	}
+	return "file:" + r.createFilePath(dbname) + "?_journal_mode=WAL"
+}
+
+func (r *sqliteDatabaseTesterRepository) createFilePath(dbname string) string {
+	return filepath.Join(r.directory, dbname+SQLITE_DATABASE_FILE_EXTENSION)
+}
+
+// Method removes database file with its journals
+func (r *sqliteDatabaseTesterRepository) removeFiles(path string) error {
+	for _, suffix := range []string{"", "-wal", "-shm", "-journal"} {
+		if err := os.Remove(path + suffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Method converts Postgres field definition of the workload tables to the dialect one.
+// Serial primary key becomes the rowid alias, which requires exactly INTEGER type.
+func (r *sqliteDatabaseTesterRepository) convertField(field string) string {
+	name, columnType, options, ok := splitFieldDefinition(field)
+	if !ok {
+		return field
+	}
+
+	dialectType, ok := sqliteColumnTypes[strings.ToUpper(columnType)]
+	if !ok {
+		dialectType = columnType
+	}
+
+	if options == "" {
+		return name + " " + dialectType
+	}
+	return name + " " + dialectType + " " + options
+}
+
+func (r *sqliteDatabaseTesterRepository) createInsertStatement(tableName string, columns []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(tableName)
+	buf.WriteString(" (")
+	for i, column := range columns {
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(") VALUES (")
+
+	for i, column := range columns {
+		buf.WriteByte(':')
+		buf.WriteString(column)
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte(')')
+
+	return buf.String()
+}
+
+func (r *sqliteDatabaseTesterRepository) createSelectColumnByIdStatement(tableName string, column string) string {
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	buf.WriteString(column)
+	buf.WriteString(" FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE id=?")
+
+	return buf.String()
+}
+
+func (r *sqliteDatabaseTesterRepository) createUpdateColumnByIdStatement(tableName string, column string) string {
+	var buf bytes.Buffer
+	buf.WriteString("UPDATE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" SET ")
+	buf.WriteString(column)
+	buf.WriteString("=? WHERE id=?")
+
+	return buf.String()
+}
+
+// Method creates update statement of the columns, which is ended by the conditions
+func (r *sqliteDatabaseTesterRepository) createUpdateStatement(tableName string, columns []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("UPDATE ")
+	buf.WriteString(tableName)
+	buf.WriteString(" SET ")
+	for i, column := range columns {
+		buf.WriteString(column)
+		buf.WriteString("=?")
+		if i < len(columns)-1 {
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteString(" WHERE ")
+
+	return buf.String()
+}
+
+func (r *sqliteDatabaseTesterRepository) validateIsolationLevel(isolationLevel domain.IsolationLevel) error {
+	switch isolationLevel {
+	case domain.IsolationLevel_ReadCommitted, domain.IsolationLevel_RepeatableRead, domain.IsolationLevel_Serializable:
+		return nil
+	default:
+		return domain.UNKNOWN_ISOLATION_LEVEL
+	}
+}
+
+type sqliteDatabaseTesterTransaction struct {
+	tx *sqlx.Tx
+	r  *sqliteDatabaseTesterRepository
+}
+
+func (t *sqliteDatabaseTesterTransaction) Insert(tableName string, columns []string, values []map[string]interface{}) error {
+	if _, err := t.tx.NamedExec(t.r.createInsertStatement(tableName, columns), values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *sqliteDatabaseTesterTransaction) UpdateById(tableName string, id int, columns []string, values map[string]interface{}) error {
+	args := make([]interface{}, 0, len(columns)+1)
+	for _, column := range columns {
+		args = append(args, values[column])
+	}
+
+	if _, err := t.tx.Exec(t.r.createUpdateStatement(tableName, columns)+"id=?", append(args, id)...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *sqliteDatabaseTesterTransaction) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *sqliteDatabaseTesterTransaction) Rollback() error {
+	return t.tx.Rollback()
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+// Repository is Postgres one with TimescaleDB extension, so plain tables and hypertables are tested by the same server
+type timescaleDBDatabaseTesterRepository struct {
+	*postgresDatabaseTesterRepository
+}
+
+// NewTimescaleDBDatabaseTesterRepository creates repository using pgx driver
+func NewTimescaleDBDatabaseTesterRepository(port uint16, host, user, password string) DatabaseTesterRepository {
+	r := new(timescaleDBDatabaseTesterRepository)
+	r.postgresDatabaseTesterRepository = newPostgresDatabaseTesterRepository(port, host, user, password, "pgx", "")
+	return r
+}
+
+// Extension is created in the database, so time bucket functions are available for the plain tables too
+func (r *timescaleDBDatabaseTesterRepository) SwitchDatabase(name string) error {
+	if err := r.postgresDatabaseTesterRepository.SwitchDatabase(name); err != nil {
+		return err
+	}
+
+	if name == "" {
+		return nil
+	}
+
+	_, err := r.db.Exec("CREATE EXTENSION IF NOT EXISTS timescaledb")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *timescaleDBDatabaseTesterRepository) CreateHypertable(name string, fields []string, timeColumn string, chunkInterval time.Duration) error {
+	if err := r.CreateTable(name, fields); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec("SELECT create_hypertable($1, $2, chunk_time_interval => $3::interval)", name, timeColumn, r.createInterval(chunkInterval))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *timescaleDBDatabaseTesterRepository) CountChunks(tableName string) (int64, error) {
+	if r.db == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var count int64
+	if err := r.db.Get(&count, "SELECT COUNT(*) FROM show_chunks($1::regclass)", tableName); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *timescaleDBDatabaseTesterRepository) SelectTimeBuckets(tableName string, timeColumn string, column string, bucket time.Duration, from time.Time, to time.Time) error {
+	if r.db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT time_bucket($1::interval, ")
+	buf.WriteString(timeColumn)
+	buf.WriteString(") AS bucket, AVG(")
+	buf.WriteString(column)
+	buf.WriteString(") FROM ")
+	buf.WriteString(tableName)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(timeColumn)
+	buf.WriteString(" >= $2 AND ")
+	buf.WriteString(timeColumn)
+	buf.WriteString(" < $3 GROUP BY bucket ORDER BY bucket")
+
+	rows, err := r.db.Query(buf.String(), r.createInterval(bucket), from, to)
+	if err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *timescaleDBDatabaseTesterRepository) createInterval(d time.Duration) string {
+	return strconv.FormatInt(d.Microseconds(), 10) + " microseconds"
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+// Repository talks Postgres protocol to the YSQL port, so the workload compares distributed SQL with the vanilla Postgres
+type yugabyteDBDatabaseTesterRepository struct {
+	*postgresDatabaseTesterRepository
+	// Time of the first connection accepted by YSQL and time since it till the tablet server ready in microseconds
+	connectedAt   time.Time
+	bootstrapTime float64
+}
+
+// NewYugabyteDBDatabaseTesterRepository creates repository using pgx driver
+func NewYugabyteDBDatabaseTesterRepository(port uint16, host, user, password string) DatabaseTesterRepository {
+	r := new(yugabyteDBDatabaseTesterRepository)
+	r.postgresDatabaseTesterRepository = newPostgresDatabaseTesterRepository(port, host, user, password, "pgx", "")
+	return r
+}
+
+// YSQL accepts connections before the tablet server has registered at the master and bootstrapped its tablets,
+// so database is ready when the server is listed by yb_servers()
+func (r *yugabyteDBDatabaseTesterRepository) Ping() error {
+	if err := r.postgresDatabaseTesterRepository.Ping(); err != nil {
+		return err
+	}
+
+	if r.connectedAt.IsZero() {
+		r.connectedAt = time.Now()
+	}
+
+	var count int
+	if err := r.db.Get(&count, "SELECT COUNT(*) FROM yb_servers()"); err != nil {
+		return err
+	}
+	if count == 0 {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if r.bootstrapTime == 0 {
+		r.bootstrapTime = float64(time.Since(r.connectedAt).Microseconds())
+	}
+
+	return nil
+}
+
+func (r *yugabyteDBDatabaseTesterRepository) BootstrapTime() float64 {
+	return r.bootstrapTime
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const BACKUP_FILE_PATH = "/tmp/cott.dump"
+
+// Method dumps populated database with engine's native tool and restores it into the new database
+func (dtuc *databaseTesterUsecase) testBackupRestore(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, containerId string, testPrefix string) error {
+	// Engine has no native tool dumping the single database
+	if r.BackupCommand(BACKUP_FILE_PATH) == nil {
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	restoreDatabaseName := dtuc.databaseName + "_restore"
+
+	step := &domain.TestCaseStep{Name: "backup" + testPrefix + "Table", StepFunc: func() error {
+		_, err := dtuc.cluc.ExecInContainer(containerId, r.BackupCommand(BACKUP_FILE_PATH))
+		return err
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		out, err := dtuc.cluc.ExecInContainer(containerId, []string{"stat", "-c", "%s", BACKUP_FILE_PATH})
+		if err != nil {
+			tcsra.AddError(err.Error())
+			return
+		}
+		size, err := strconv.ParseFloat(strings.TrimSpace(*out), 64)
+		if err != nil {
+			tcsra.AddError(err.Error())
+			return
+		}
+		tcsra.AddMetric(domain.MetricMeta_BackupSize, size)
+	}}
+	// Backup file is removed even if backup failed half way, so it doesn't skew backup size of the next runs in the container
+	defer func() {
+		if _, err := dtuc.cluc.ExecInContainer(containerId, []string{"rm", "-f", BACKUP_FILE_PATH}); err != nil {
+			logrus.WithError(err).Debug("couldn't remove backup file")
+		}
+	}()
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	if err := r.DropDatabase(restoreDatabaseName); err != nil {
+		return err
+	}
+	if err := r.CreateDatabase(restoreDatabaseName); err != nil {
+		return err
+	}
+	defer func() {
+		if err := r.DropDatabase(restoreDatabaseName); err != nil {
+			logrus.WithError(err).Debug("couldn't drop restored database")
+		}
+	}()
+
+	step = &domain.TestCaseStep{Name: "restore" + testPrefix + "Table", StepFunc: func() error {
+		_, err := dtuc.cluc.ExecInContainer(containerId, r.RestoreCommand(BACKUP_FILE_PATH, restoreDatabaseName))
+		return err
+	}}
+	return mcuc.CollectStepMetrics(step)
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"database/sql"
+	"math"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+var nullableIntColumnEqual = func(expected interface{}, dest interface{}) bool {
+	v := dest.(*sql.NullInt64)
+	if expected == nil {
+		return !v.Valid
+	}
+	return v.Valid && v.Int64 == expected.(int64)
+}
+
+var boundaryValuesTests = []struct {
+	stepName string
+	column   roundTripColumn
+	cases    []roundTripCase
+}{
+	{
+		stepName: "bigintBoundaries",
+		column: roundTripColumn{
+			Type:    "BIGINT",
+			NewDest: func() interface{} { return new(sql.NullInt64) },
+			Equal:   nullableIntColumnEqual,
+		},
+		cases: []roundTripCase{
+			{Name: "null", Value: nil},
+			{Name: "zero", Value: int64(0)},
+			{Name: "min", Value: int64(math.MinInt64)},
+			{Name: "max", Value: int64(math.MaxInt64)},
+		},
+	},
+	{
+		stepName: "integerBoundaries",
+		column: roundTripColumn{
+			Type:    "INTEGER",
+			NewDest: func() interface{} { return new(sql.NullInt64) },
+			Equal:   nullableIntColumnEqual,
+		},
+		cases: []roundTripCase{
+			{Name: "null", Value: nil},
+			{Name: "min", Value: int64(math.MinInt32)},
+			{Name: "max", Value: int64(math.MaxInt32)},
+			{Name: "overflow", Value: int64(math.MaxInt32) + 1},
+		},
+	},
+	{
+		stepName: "smallintBoundaries",
+		column: roundTripColumn{
+			Type:    "SMALLINT",
+			NewDest: func() interface{} { return new(sql.NullInt64) },
+			Equal:   nullableIntColumnEqual,
+		},
+		cases: []roundTripCase{
+			{Name: "null", Value: nil},
+			{Name: "min", Value: int64(math.MinInt16)},
+			{Name: "max", Value: int64(math.MaxInt16)},
+			{Name: "overflow", Value: int64(math.MaxInt16) + 1},
+		},
+	},
+	{
+		stepName: "floatBoundaries",
+		column: roundTripColumn{
+			Type:    "DOUBLE PRECISION",
+			NewDest: func() interface{} { return new(sql.NullFloat64) },
+			Equal: func(expected interface{}, dest interface{}) bool {
+				v := dest.(*sql.NullFloat64)
+				if expected == nil {
+					return !v.Valid
+				}
+				e := expected.(float64)
+				if math.IsNaN(e) {
+					return v.Valid && math.IsNaN(v.Float64)
+				}
+				return v.Valid && v.Float64 == e && math.Signbit(v.Float64) == math.Signbit(e)
+			},
+		},
+		cases: []roundTripCase{
+			{Name: "null", Value: nil},
+			{Name: "nan", Value: math.NaN()},
+			{Name: "positiveInf", Value: math.Inf(1)},
+			{Name: "negativeInf", Value: math.Inf(-1)},
+			{Name: "negativeZero", Value: math.Copysign(0, -1)},
+			{Name: "max", Value: math.MaxFloat64},
+			{Name: "smallestNonzero", Value: math.SmallestNonzeroFloat64},
+		},
+	},
+	{
+		stepName: "dateBoundaries",
+		column: roundTripColumn{
+			Type:    "DATE",
+			NewDest: func() interface{} { return new(sql.NullTime) },
+			Equal: func(expected interface{}, dest interface{}) bool {
+				v := dest.(*sql.NullTime)
+				if expected == nil {
+					return !v.Valid
+				}
+				ey, em, ed := expected.(time.Time).Date()
+				y, m, d := v.Time.Date()
+				return v.Valid && ey == y && em == m && ed == d
+			},
+		},
+		cases: []roundTripCase{
+			{Name: "null", Value: nil},
+			{Name: "zero", Value: time.Time{}},
+			{Name: "unixEpoch", Value: time.Unix(0, 0).UTC()},
+			{Name: "leapDay", Value: time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC)},
+			{Name: "max", Value: time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		},
+	},
+	{
+		stepName: "textNulls",
+		column: roundTripColumn{
+			Type:    "TEXT",
+			NewDest: func() interface{} { return new(sql.NullString) },
+			Equal: func(expected interface{}, dest interface{}) bool {
+				v := dest.(*sql.NullString)
+				if expected == nil {
+					return !v.Valid
+				}
+				return v.Valid && v.String == expected.(string)
+			},
+		},
+		cases: []roundTripCase{
+			{Name: "null", Value: nil},
+			{Name: "empty", Value: ""},
+			{Name: "nullString", Value: "NULL"},
+		},
+	},
+}
+
+// Method checks NULLs, min/max integers, NaN/Inf floats and boundary dates round-trips
+func (dtuc *databaseTesterUsecase) testBoundaryValues(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) {
+	for _, t := range boundaryValuesTests {
+		if err := dtuc.testRoundTrip(mcuc, r, t.stepName, t.column, t.cases); err != nil {
+			logrus.WithError(err).WithField("step", t.stepName).Warn("couldn't test boundary values")
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	CLUSTER_TOPOLOGY_TABLE_NAME = "cluster_topology_table"
+	// Rows inserted on the leader and selected on every follower
+	CLUSTER_TOPOLOGY_ROWS_COUNT = 1000
+)
+
+// Method writes on the leader node and reads on every follower node by their own connections.
+// Metrics of every step are collected from the container of the node it's run on, and followers report their catch up time.
+func (dtuc *databaseTesterUsecase) testClusterTopology(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	tc := tcra.TestCase
+	leaders := tc.GetNodes(domain.NodeRole_Leader)
+	if len(leaders) != 1 {
+		return domain.NO_CLUSTER_LEADER
+	}
+
+	leader, err := dtuc.openNode(tc, &leaders[0])
+	if err != nil {
+		return err
+	}
+	defer leader.Close()
+
+	tableName := dtuc.tableName(CLUSTER_TOPOLOGY_TABLE_NAME)
+	if err := leader.CreateTable(tableName, tenantTableFields); err != nil {
+		return err
+	}
+	defer leader.DropTable(tableName)
+
+	var next int64 = -1
+	step := dtuc.createNodeLoadStep(strconv.FormatInt(CLUSTER_TOPOLOGY_ROWS_COUNT, 10)+"xInsertOnLeader "+leaders[0].Service, tc, func() error {
+		id := atomic.AddInt64(&next, 1)
+		return leader.Insert(tableName, []string{"id", "f1"}, []map[string]interface{}{{"id": id, "f1": dtuc.rand.Int63()}})
+	})
+	if err := dtuc.nodeMetricsCollector(tcra, &leaders[0], containerId).CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	for _, n := range tc.GetNodes(domain.NodeRole_Follower) {
+		n := n
+		if err := dtuc.testFollower(tcra, &n, containerId, tableName); err != nil {
+			logrus.WithError(err).WithField("node", n).Warn("couldn't test follower node")
+		}
+	}
+
+	return nil
+}
+
+func (dtuc *databaseTesterUsecase) testFollower(tcra *domain.TestCaseResultsAccumulator, n *domain.ClusterNode, containerId string, tableName string) error {
+	follower, err := dtuc.openNode(tcra.TestCase, n)
+	if err != nil {
+		return err
+	}
+	defer follower.Close()
+
+	mcuc := dtuc.nodeMetricsCollector(tcra, n, containerId)
+
+	// Duration of the step is the time follower needs to catch up the leader after its load
+	step := &domain.TestCaseStep{Name: "catchUpLeader " + n.Service, StepFunc: func() error {
+		startTime := time.Now()
+		for {
+			count, err := follower.CountRows(tableName)
+			if err == nil && count >= CLUSTER_TOPOLOGY_ROWS_COUNT {
+				return nil
+			}
+			if time.Since(startTime) > REPLICATION_LAG_TIMEOUT {
+				return domain.REPLICATION_LAG_TIMEOUT
+			}
+			time.Sleep(REPLICATION_LAG_POLL_PERIOD)
+		}
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = dtuc.createNodeLoadStep(strconv.FormatInt(CLUSTER_TOPOLOGY_ROWS_COUNT, 10)+"xSelectOnFollower "+n.Service, tcra.TestCase, func() error {
+		return follower.SelectById(tableName, dtuc.rand.Intn(CLUSTER_TOPOLOGY_ROWS_COUNT))
+	})
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Method connects to the node and switches to the test case database, which appears on followers by replication
+func (dtuc *databaseTesterUsecase) openNode(tc *domain.TestCase, n *domain.ClusterNode) (repository.DatabaseTesterRepository, error) {
+	r, err := dtuc.createDatabaseRepository(tc, n.Port)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.Open(); err != nil {
+		return nil, err
+	}
+	if err := r.SwitchDatabase(dtuc.databaseName); err != nil {
+		r.Close()
+		return nil, err
+	}
+	if err := dtuc.awaitDatabaseReady(r); err != nil {
+		r.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Method returns metrics collector of the node container. Tested container is used if node container isn't found.
+func (dtuc *databaseTesterUsecase) nodeMetricsCollector(tcra *domain.TestCaseResultsAccumulator, n *domain.ClusterNode, containerId string) metrics_collector.MetricsCollectorUsecase {
+	if id, ok := tcra.GetNodeContainerId(n.Service); ok {
+		containerId = id
+	}
+	return metrics_collector.NewMetricsCollectorUsecase(tcra, dtuc.cluc, containerId)
+}
+
+func (dtuc *databaseTesterUsecase) createNodeLoadStep(name string, tc *domain.TestCase, operation func() error) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = helpers.RunLoad(CLUSTER_TOPOLOGY_ROWS_COUNT, int(tc.GetConcurrency()), operation)
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"math"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+const (
+	CONCURRENT_CLIENTS_TABLE_NAME = "concurrent_clients_table"
+	// Rows selected by id, which are inserted before the clients are started
+	CONCURRENT_CLIENTS_ROWS_COUNT = 10000
+	// Operations run by every client
+	CONCURRENT_CLIENTS_OPERATIONS = 1000
+	// Share of the single row inserts in the mix, other operations are selects by id
+	CONCURRENT_CLIENTS_INSERT_SHARE = 0.2
+)
+
+var (
+	concurrentClientsTableFields = []string{"id BIGINT PRIMARY KEY", "f1 BIGINT"}
+	concurrentClientsColumns     = []string{"id", "f1"}
+)
+
+// Method runs the insert and select mix by the concurrent clients sharing the connection pool.
+// Throughput and latencies are the aggregate ones of all the clients, and the spread of the clients p99 latencies shows
+// how fairly database serves them.
+func (dtuc *databaseTesterUsecase) testConcurrentClients(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase) error {
+	clients := int(tc.GetConcurrentClients())
+	tableName := dtuc.tableName(CONCURRENT_CLIENTS_TABLE_NAME)
+
+	if err := r.CreateTable(tableName, concurrentClientsTableFields); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	for offset := 0; offset < CONCURRENT_CLIENTS_ROWS_COUNT; offset += INSERT_CHUNK_SIZE {
+		values := make([]map[string]interface{}, 0, INSERT_CHUNK_SIZE)
+		for id := offset; id < offset+INSERT_CHUNK_SIZE && id < CONCURRENT_CLIENTS_ROWS_COUNT; id++ {
+			values = append(values, map[string]interface{}{"id": id, "f1": dtuc.rand.Int63()})
+		}
+		if err := r.Insert(tableName, concurrentClientsColumns, values); err != nil {
+			return err
+		}
+	}
+
+	var (
+		lastId    int64 = CONCURRENT_CLIENTS_ROWS_COUNT - 1
+		lr        *helpers.LoadResult
+		clientLrs []*helpers.LoadResult
+	)
+	step := &domain.TestCaseStep{Name: strconv.Itoa(clients) + "xClientsInsertSelect", StepFunc: func() error {
+		lr, clientLrs = helpers.RunWorkersLoad(CONCURRENT_CLIENTS_OPERATIONS, clients, func(int) error {
+			if dtuc.rand.Float64() < CONCURRENT_CLIENTS_INSERT_SHARE {
+				id := atomic.AddInt64(&lastId, 1)
+				return r.Insert(tableName, concurrentClientsColumns, []map[string]interface{}{{"id": id, "f1": dtuc.rand.Int63()}})
+			}
+			return r.SelectById(tableName, dtuc.rand.Intn(CONCURRENT_CLIENTS_ROWS_COUNT))
+		})
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+
+		minP99, maxP99 := math.Inf(1), 0.0
+		for _, clientLr := range clientLrs {
+			p99 := clientLr.Quantile(0.99)
+			minP99 = math.Min(minP99, p99)
+			maxP99 = math.Max(maxP99, p99)
+		}
+		tcsra.AddMetric(domain.MetricMeta_WorkerLatencyP99Min, minP99)
+		tcsra.AddMetric(domain.MetricMeta_WorkerLatencyP99Max, maxP99)
+	}}
+	return mcuc.CollectStepMetrics(step)
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+const COPY_FROM_TABLE_NAME = "copy_from_table"
+
+var (
+	copyFromRowsCounts  = []int{10000, 100000, 1000000}
+	copyFromTableFields = []string{"id BIGINT PRIMARY KEY", "f1 BIGINT", "f2 TEXT", "f3 FLOAT"}
+	copyFromColumns     = []string{"id", "f1", "f2", "f3"}
+)
+
+// Method compares bulk load of the rows by the multi-row inserts with the COPY protocol.
+// Both steps stream the same generated rows and report the load rate.
+func (dtuc *databaseTesterUsecase) testCopyFrom(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) error {
+	cr, ok := r.(repository.CopyRepository)
+	if !ok {
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	tableName := dtuc.tableName(COPY_FROM_TABLE_NAME)
+
+	if err := r.CreateTable(tableName, copyFromTableFields); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	chunkSize := dtuc.insertChunkSize(r, len(copyFromColumns))
+	for _, rowsCount := range copyFromRowsCounts {
+		testPrefix := strconv.Itoa(rowsCount) + "x"
+
+		step := dtuc.createCopyFromStep(testPrefix+"MultiRowInsert", rowsCount, chunkSize, func(chunks <-chan []map[string]interface{}) error {
+			for chunk := range chunks {
+				if err := r.Insert(tableName, copyFromColumns, chunk); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+		if err := dtuc.checkRowsCount(r, tableName, int64(rowsCount)); err != nil {
+			return err
+		}
+		if err := r.TruncateTable(tableName); err != nil {
+			return err
+		}
+
+		step = dtuc.createCopyFromStep(testPrefix+"CopyFrom", rowsCount, chunkSize, func(chunks <-chan []map[string]interface{}) error {
+			_, err := cr.CopyFrom(tableName, copyFromColumns, chunks)
+			return err
+		})
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+		if err := dtuc.checkRowsCount(r, tableName, int64(rowsCount)); err != nil {
+			return err
+		}
+		if err := r.TruncateTable(tableName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Method creates step loading rows streamed by the chunks of the size by the load function
+func (dtuc *databaseTesterUsecase) createCopyFromStep(name string, rowsCount int, chunkSize int, load func(chunks <-chan []map[string]interface{}) error) *domain.TestCaseStep {
+	var duration time.Duration
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		done := make(chan struct{})
+		defer close(done)
+
+		chunks := dtuc.streamRows(done, rowsCount, chunkSize, func(n int) map[string]interface{} {
+			return map[string]interface{}{"id": n, "f1": dtuc.rand.Int63(), "f2": "row" + strconv.Itoa(n), "f3": dtuc.rand.Float64()}
+		})
+
+		startTime := time.Now()
+		if err := load(chunks); err != nil {
+			return err
+		}
+		duration = time.Since(startTime)
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_LoadRate, float64(rowsCount)/duration.Seconds())
+	}}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const CRASH_RECOVERY_ROWS_COUNT = 10000
+
+// Method populates table, hard-kills container, starts it again and checks that data survived
+func (dtuc *databaseTesterUsecase) testCrashRecovery(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tcra *domain.TestCaseResultsAccumulator, containerId string) {
+	if tcra.TestCase.IsEmbedded() {
+		logrus.Warn("crash recovery workload is skipped, because embedded database has no container to kill")
+		return
+	}
+
+	var (
+		tableName   = dtuc.tableName("crash_recovery_table")
+		tableFields = tcra.TestCase.GetTableFields()
+	)
+
+	if err := r.CreateTable(tableName, tableFields); err != nil {
+		logrus.WithError(err).Debug("couldn't create crash recovery table")
+		return
+	}
+	defer r.DropTable(tableName)
+
+	step := &domain.TestCaseStep{Name: "crashRecoveryPopulate", StepFunc: func() error {
+		return dtuc.insertTableData(r, tableName, tableFields, CRASH_RECOVERY_ROWS_COUNT)
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return
+	}
+
+	// Kill is timed without container stats, because stats of the killed container are zeros
+	tcsra := tcra.GetTestCaseStepResultsAccumulator(&domain.TestCaseStep{Name: "crashRecoveryKill"})
+	startTime := time.Now()
+	if err := dtuc.cluc.KillContainer(containerId); err != nil {
+		logrus.WithError(err).Warn("couldn't kill container")
+		tcsra.AddError(err.Error())
+		return
+	}
+	tcsra.AddMetric(domain.MetricMeta_Duration, float64(time.Since(startTime).Microseconds()))
+
+	// Start container without metrics collection, because stats for killed container are not available
+	if err := dtuc.cluc.StartContainer(containerId); err != nil {
+		logrus.WithError(err).Error("couldn't start container after kill")
+		return
+	}
+
+	// Duration of this step is the time until engine accepts queries again
+	step = &domain.TestCaseStep{Name: "crashRecoveryRestart", StepFunc: func() error { return dtuc.awaitDatabaseReady(r) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return
+	}
+
+	step = &domain.TestCaseStep{Name: "crashRecoveryVerifyIntegrity", StepFunc: func() error {
+		count, err := r.CountRows(tableName)
+		if err != nil {
+			return err
+		}
+		if count != CRASH_RECOVERY_ROWS_COUNT {
+			logrus.WithFields(logrus.Fields{"expected": CRASH_RECOVERY_ROWS_COUNT, "actual": count}).Warn(domain.DATA_INTEGRITY_VIOLATED)
+			return domain.DATA_INTEGRITY_VIOLATED
+		}
+		return nil
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+)
+
+// Rows count of the single insert of the workloads with their own tables. Postgres bulk insert supports max 65535 params.
+const INSERT_CHUNK_SIZE = 1000
+
+// Method streams generated rows by chunks of chunkSize, so only the inserted chunk and the next one are kept in memory
+// regardless of the rows count. Generation stops when done channel is closed.
+func (dtuc *databaseTesterUsecase) streamTableData(done <-chan struct{}, fields []string, count int, chunkSize int) <-chan []map[string]interface{} {
+	return dtuc.streamRows(done, count, chunkSize, func(int) map[string]interface{} { return dtuc.generateTableRow(fields) })
+}
+
+// Method streams rows created by the generator from their numbers like streamTableData
+func (dtuc *databaseTesterUsecase) streamRows(done <-chan struct{}, count int, chunkSize int, generateRow func(n int) map[string]interface{}) <-chan []map[string]interface{} {
+	chunks := make(chan []map[string]interface{}, 1)
+
+	go func() {
+		defer close(chunks)
+
+		for offset := 0; offset < count; offset += chunkSize {
+			size := chunkSize
+			if count-offset < size {
+				size = count - offset
+			}
+
+			chunk := make([]map[string]interface{}, 0, size)
+			for i := 0; i < size; i++ {
+				chunk = append(chunk, generateRow(offset+i))
+			}
+
+			select {
+			case chunks <- chunk:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return chunks
+}
+
+// Method inserts count generated rows of the table fields by chunks while the next chunk is generated
+func (dtuc *databaseTesterUsecase) insertTableData(r repository.DatabaseTesterRepository, tableName string, fields []string, count int) error {
+	done := make(chan struct{})
+	defer close(done)
+
+	columns := dtuc.tableColumns(fields)
+	for chunk := range dtuc.streamTableData(done, fields, count, dtuc.insertChunkSize(r, len(columns))) {
+		if err := r.Insert(tableName, columns, chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Method returns configured rows count of the single insert, which is decreased to the max rows count of the repository
+func (dtuc *databaseTesterUsecase) insertChunkSize(r repository.DatabaseTesterRepository, columnsCount int) int {
+	chunkSize := dtuc.insertBatchSize
+	if chunkSize == 0 {
+		chunkSize = INSERT_CHUNK_SIZE
+	}
+
+	if maxRows := r.MaxInsertRows(columnsCount); maxRows > 0 && maxRows < chunkSize {
+		return maxRows
+	}
+	return chunkSize
+}
+
+// Source of the rand.Rand shared by the goroutines generating rows, which isn't safe for concurrent use itself
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+// Function creates rand.Rand safe for concurrent use, which generates the same sequence for the same seed
+func newLockedRand(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewSource(seed).(rand.Source64)})
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+const (
+	FULL_TEXT_TABLE_NAME  = "full_text_table"
+	FULL_TEXT_COLUMN      = "body"
+	FULL_TEXT_INDEX_NAME  = "full_text_table_body_idx"
+	FULL_TEXT_ROWS_COUNT  = 100000
+	FULL_TEXT_SEARCHES    = 100
+	FULL_TEXT_WORDS_COUNT = 1000
+	// Sentences have 12 of the words, so the searched word matches about 1/80 of the rows
+	FULL_TEXT_SENTENCE_WORDS = 12
+)
+
+// Method inserts generated sentences and compares full-text search of the word by the index with the LIKE scan.
+// Search steps report their latency difference with the scan, so the dedicated search engines could be compared by the same steps.
+func (dtuc *databaseTesterUsecase) testFullTextSearch(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) error {
+	fr, ok := r.(repository.FullTextSearchRepository)
+	if !ok {
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	testPrefix := strconv.Itoa(FULL_TEXT_ROWS_COUNT) + "x"
+	tableName := dtuc.tableName(FULL_TEXT_TABLE_NAME)
+
+	if err := r.CreateTable(tableName, []string{"id BIGINT PRIMARY KEY", FULL_TEXT_COLUMN + " TEXT"}); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	var duration time.Duration
+	step := &domain.TestCaseStep{Name: testPrefix + "InsertText", StepFunc: func() error {
+		done := make(chan struct{})
+		defer close(done)
+
+		chunks := dtuc.streamRows(done, FULL_TEXT_ROWS_COUNT, INSERT_CHUNK_SIZE, func(n int) map[string]interface{} {
+			return map[string]interface{}{"id": n, FULL_TEXT_COLUMN: dtuc.generateSentence()}
+		})
+
+		startTime := time.Now()
+		for chunk := range chunks {
+			if err := r.Insert(tableName, []string{"id", FULL_TEXT_COLUMN}, chunk); err != nil {
+				return err
+			}
+		}
+		duration = time.Since(startTime)
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_LoadRate, FULL_TEXT_ROWS_COUNT/duration.Seconds())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	// Words are numbered, so the spaces keep the searched word from matching the longer ones
+	scanLr, err := dtuc.collectComparedStep(mcuc, "likeSearch"+testPrefix+"Table", FULL_TEXT_SEARCHES, nil, func() error {
+		return r.SelectByConditions(tableName, FULL_TEXT_COLUMN+" LIKE '% "+dtuc.generateWord()+" %'")
+	})
+	if err != nil {
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: "createFullTextIndex" + testPrefix + "Table", StepFunc: func() error {
+		return fr.CreateFullTextIndex(tableName, FULL_TEXT_INDEX_NAME, FULL_TEXT_COLUMN)
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+	defer r.DropIndex(tableName, FULL_TEXT_INDEX_NAME)
+
+	_, err = dtuc.collectComparedStep(mcuc, "fullTextSearch"+testPrefix+"Table", FULL_TEXT_SEARCHES, scanLr, func() error {
+		return fr.SearchText(tableName, FULL_TEXT_COLUMN, dtuc.generateWord())
+	})
+	return err
+}
+
+func (dtuc *databaseTesterUsecase) generateSentence() string {
+	words := make([]string, FULL_TEXT_SENTENCE_WORDS)
+	for i := range words {
+		words[i] = dtuc.generateWord()
+	}
+
+	return strings.Join(words, " ") + "."
+}
+
+// Words have 5 and more letters, because shorter words aren't indexed by MySQL by default
+func (dtuc *databaseTesterUsecase) generateWord() string {
+	return "term" + strconv.Itoa(dtuc.rand.Intn(FULL_TEXT_WORDS_COUNT))
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+const (
+	GIANT_TRANSACTION_TABLE_NAME = "giant_transaction_table"
+	GIANT_TRANSACTION_BATCH_SIZE = 1000
+)
+
+var (
+	giantTransactionTableFields = []string{"id BIGINT PRIMARY KEY", "f1 BIGINT", "f2 TEXT"}
+	giantTransactionColumns     = []string{"id", "f1", "f2"}
+)
+
+// Method inserts rows in the single transaction and measures its commit, then does the same with rollback.
+// Memory usage diff of the insert steps is the memory growth of the uncommitted transaction.
+func (dtuc *databaseTesterUsecase) testGiantTransaction(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase) error {
+	rowsCount := int(tc.GetGiantTransactionRowsCount())
+	testPrefix := strconv.FormatInt(int64(rowsCount), 10) + "x"
+	tableName := dtuc.tableName(GIANT_TRANSACTION_TABLE_NAME)
+
+	if err := r.CreateTable(tableName, giantTransactionTableFields); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	tx, err := dtuc.insertInGiantTransaction(mcuc, r, testPrefix+"InsertInTransaction", tableName, rowsCount)
+	if err != nil {
+		return err
+	}
+	step := &domain.TestCaseStep{Name: "commitGiantTransaction", StepFunc: func() error { return tx.Commit() }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+	if err := dtuc.checkRowsCount(r, tableName, int64(rowsCount)); err != nil {
+		return err
+	}
+
+	if err := r.TruncateTable(tableName); err != nil {
+		return err
+	}
+
+	tx, err = dtuc.insertInGiantTransaction(mcuc, r, testPrefix+"InsertInTransactionForRollback", tableName, rowsCount)
+	if err != nil {
+		return err
+	}
+	step = &domain.TestCaseStep{Name: "rollbackGiantTransaction", StepFunc: func() error { return tx.Rollback() }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+	return dtuc.checkRowsCount(r, tableName, 0)
+}
+
+// Method returns open transaction with inserted rows. Transaction is rolled back if the insert step fails.
+func (dtuc *databaseTesterUsecase) insertInGiantTransaction(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, name string, tableName string, rowsCount int) (repository.DatabaseTesterTransaction, error) {
+	var tx repository.DatabaseTesterTransaction
+
+	step := &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		var err error
+		if tx, err = r.BeginTransaction(); err != nil {
+			return err
+		}
+
+		for offset := 0; offset < rowsCount; offset += GIANT_TRANSACTION_BATCH_SIZE {
+			values := make([]map[string]interface{}, 0, GIANT_TRANSACTION_BATCH_SIZE)
+			for id := offset; id < offset+GIANT_TRANSACTION_BATCH_SIZE && id < rowsCount; id++ {
+				values = append(values, map[string]interface{}{"id": id, "f1": dtuc.rand.Int63(), "f2": "row" + strconv.FormatInt(int64(id), 10)})
+			}
+			if err := tx.Insert(tableName, giantTransactionColumns, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		if tx != nil {
+			tx.Rollback()
+		}
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func (dtuc *databaseTesterUsecase) checkRowsCount(r repository.DatabaseTesterRepository, tableName string, expected int64) error {
+	count, err := r.CountRows(tableName)
+	if err != nil {
+		return err
+	}
+	if count != expected {
+		return domain.DATA_INTEGRITY_VIOLATED
+	}
+	return nil
+}
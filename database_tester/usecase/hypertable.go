@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+const (
+	HYPERTABLE_TABLE_NAME     = "hypertable_table"
+	HYPERTABLE_ROWS_COUNT     = 200000
+	HYPERTABLE_ROWS_INTERVAL  = time.Second
+	HYPERTABLE_CHUNK_INTERVAL = 6 * time.Hour
+	HYPERTABLE_BUCKET         = time.Hour
+	HYPERTABLE_SELECTS_COUNT  = 100
+	// Every select aggregates rows of the single chunk interval
+	HYPERTABLE_SELECT_RANGE = HYPERTABLE_CHUNK_INTERVAL
+)
+
+var (
+	// Hypertable unique indexes must include time column, so table has no primary key
+	hypertableTableFields = []string{"ts TIMESTAMPTZ NOT NULL", "device_id BIGINT", "value FLOAT"}
+	hypertableColumns     = []string{"ts", "device_id", "value"}
+	hypertableStartTime   = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Method runs the same time series workload for the plain table and hypertable, so their step metrics are compared by the prefixes
+func (dtuc *databaseTesterUsecase) testHypertable(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) error {
+	hr, ok := r.(repository.HypertableRepository)
+	if !ok {
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	tableName := dtuc.tableName(HYPERTABLE_TABLE_NAME)
+
+	if err := r.CreateTable(tableName, hypertableTableFields); err != nil {
+		return err
+	}
+	if err := dtuc.runTimeSeriesSteps(mcuc, r, hr, tableName, "plainTable", nil); err != nil {
+		r.DropTable(tableName)
+		return err
+	}
+	if err := r.DropTable(tableName); err != nil {
+		return err
+	}
+
+	if err := hr.CreateHypertable(tableName, hypertableTableFields, "ts", HYPERTABLE_CHUNK_INTERVAL); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	return dtuc.runTimeSeriesSteps(mcuc, r, hr, tableName, "hypertable", func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		if chunks, err := hr.CountChunks(tableName); err == nil {
+			tcsra.AddMetric(domain.MetricMeta_Chunks, float64(chunks))
+		}
+	})
+}
+
+// Method inserts time ordered rows by chunks and selects time buckets of the random ranges. Insert step reports chunksMetricsFunc metrics too.
+func (dtuc *databaseTesterUsecase) runTimeSeriesSteps(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, hr repository.HypertableRepository, tableName string, prefix string, chunksMetricsFunc func(tcsra *domain.TestCaseStepResultsAccumulator)) error {
+	var duration time.Duration
+
+	step := &domain.TestCaseStep{Name: prefix + "Insert", StepFunc: func() error {
+		startTime := time.Now()
+		defer func() { duration = time.Since(startTime) }()
+
+		for offset := 0; offset < HYPERTABLE_ROWS_COUNT; offset += INSERT_CHUNK_SIZE {
+			values := make([]map[string]interface{}, 0, INSERT_CHUNK_SIZE)
+			for i := offset; i < offset+INSERT_CHUNK_SIZE && i < HYPERTABLE_ROWS_COUNT; i++ {
+				values = append(values, map[string]interface{}{
+					"ts":        hypertableStartTime.Add(time.Duration(i) * HYPERTABLE_ROWS_INTERVAL),
+					"device_id": dtuc.rand.Int63n(100),
+					"value":     dtuc.rand.Float64(),
+				})
+			}
+			if err := r.Insert(tableName, hypertableColumns, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_LoadRate, float64(HYPERTABLE_ROWS_COUNT)/duration.Seconds())
+		if chunksMetricsFunc != nil {
+			chunksMetricsFunc(tcsra)
+		}
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+	if err := dtuc.checkRowsCount(r, tableName, HYPERTABLE_ROWS_COUNT); err != nil {
+		return err
+	}
+
+	maxOffset := int64(HYPERTABLE_ROWS_COUNT*HYPERTABLE_ROWS_INTERVAL - HYPERTABLE_SELECT_RANGE)
+	step = &domain.TestCaseStep{Name: prefix + "TimeBucketSelect", StepFunc: func() error {
+		startTime := time.Now()
+		defer func() { duration = time.Since(startTime) }()
+
+		for i := 0; i < HYPERTABLE_SELECTS_COUNT; i++ {
+			from := hypertableStartTime.Add(time.Duration(dtuc.rand.Int63n(maxOffset)))
+			if err := hr.SelectTimeBuckets(tableName, "ts", "value", HYPERTABLE_BUCKET, from, from.Add(HYPERTABLE_SELECT_RANGE)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_Throughput, float64(HYPERTABLE_SELECTS_COUNT)/duration.Seconds())
+	}}
+	return mcuc.CollectStepMetrics(step)
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	JSONB_TABLE_NAME      = "jsonb_table"
+	JSONB_COLUMN          = "doc"
+	JSONB_INDEX_NAME      = "jsonb_table_doc_idx"
+	JSONB_DOCUMENTS_COUNT = 100000
+	// Selects are sequential full scans before the index is built, so their count is kept small
+	JSONB_SELECTS_COUNT = 20
+	// Documents have 2 of the tags and one of the cities, so selects match about 1/50 and 1/100 of the rows
+	JSONB_TAGS_COUNT   = 100
+	JSONB_CITIES_COUNT = 100
+)
+
+var jsonbCityPath = []string{"address", "city"}
+
+type jsonbDocument struct {
+	Name    string               `json:"name"`
+	Score   float64              `json:"score"`
+	Tags    []string             `json:"tags"`
+	Address jsonbDocumentAddress `json:"address"`
+}
+
+type jsonbDocumentAddress struct {
+	City string `json:"city"`
+	Zip  int    `json:"zip"`
+}
+
+// Method inserts generated JSON documents and compares containment and path selects before and after the GIN index is built.
+// Indexed selects report their latency difference with the not indexed ones, so it's negative if the index speeds selects up.
+func (dtuc *databaseTesterUsecase) testJsonb(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) error {
+	jr, ok := r.(repository.JsonRepository)
+	if !ok {
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	testPrefix := strconv.Itoa(JSONB_DOCUMENTS_COUNT) + "x"
+	tableName := dtuc.tableName(JSONB_TABLE_NAME)
+
+	if err := r.CreateTable(tableName, []string{"id BIGINT PRIMARY KEY", JSONB_COLUMN + " JSONB"}); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	var duration time.Duration
+	step := &domain.TestCaseStep{Name: testPrefix + "InsertJsonDocument", StepFunc: func() error {
+		done := make(chan struct{})
+		defer close(done)
+
+		chunks := dtuc.streamRows(done, JSONB_DOCUMENTS_COUNT, INSERT_CHUNK_SIZE, func(n int) map[string]interface{} {
+			return map[string]interface{}{"id": n, JSONB_COLUMN: dtuc.generateJsonbDocument(n)}
+		})
+
+		startTime := time.Now()
+		for chunk := range chunks {
+			if err := r.Insert(tableName, []string{"id", JSONB_COLUMN}, chunk); err != nil {
+				return err
+			}
+		}
+		duration = time.Since(startTime)
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_LoadRate, JSONB_DOCUMENTS_COUNT/duration.Seconds())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	selectContains := func() error {
+		return jr.SelectJsonContains(tableName, JSONB_COLUMN, `{"tags":["tag`+strconv.Itoa(dtuc.rand.Intn(JSONB_TAGS_COUNT))+`"]}`)
+	}
+	selectPath := func() error {
+		return jr.SelectJsonPath(tableName, JSONB_COLUMN, jsonbCityPath, "city"+strconv.Itoa(dtuc.rand.Intn(JSONB_CITIES_COUNT)))
+	}
+
+	containsLr, err := dtuc.collectComparedStep(mcuc, "jsonContains"+testPrefix+"Table", JSONB_SELECTS_COUNT, nil, selectContains)
+	if err != nil {
+		return err
+	}
+	pathLr, err := dtuc.collectComparedStep(mcuc, "jsonPath"+testPrefix+"Table", JSONB_SELECTS_COUNT, nil, selectPath)
+	if err != nil {
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: "createJsonIndex" + testPrefix + "Table", StepFunc: func() error {
+		return jr.CreateJsonIndex(tableName, JSONB_INDEX_NAME, JSONB_COLUMN)
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+	defer r.DropIndex(tableName, JSONB_INDEX_NAME)
+
+	if _, err := dtuc.collectComparedStep(mcuc, "jsonContains"+testPrefix+"IndexedTable", JSONB_SELECTS_COUNT, containsLr, selectContains); err != nil {
+		logrus.WithError(err).Warn("couldn't select json documents by containment")
+	}
+	// GIN index doesn't support the path operators, so the path select is expected to stay a full scan
+	if _, err := dtuc.collectComparedStep(mcuc, "jsonPath"+testPrefix+"IndexedTable", JSONB_SELECTS_COUNT, pathLr, selectPath); err != nil {
+		logrus.WithError(err).Warn("couldn't select json documents by path")
+	}
+
+	return nil
+}
+
+// Method generates JSON document of the row, which is inserted by its text representation
+func (dtuc *databaseTesterUsecase) generateJsonbDocument(n int) string {
+	doc := jsonbDocument{
+		Name:  "user" + strconv.Itoa(n),
+		Score: dtuc.rand.Float64(),
+		Tags:  []string{"tag" + strconv.Itoa(dtuc.rand.Intn(JSONB_TAGS_COUNT)), "tag" + strconv.Itoa(dtuc.rand.Intn(JSONB_TAGS_COUNT))},
+		Address: jsonbDocumentAddress{
+			City: "city" + strconv.Itoa(dtuc.rand.Intn(JSONB_CITIES_COUNT)),
+			Zip:  dtuc.rand.Intn(100000),
+		},
+	}
+
+	data, _ := json.Marshal(doc)
+	return string(data)
+}
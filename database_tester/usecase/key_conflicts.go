@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	KEY_CONFLICTS_EXISTING_ROWS_COUNT = 1000
+	KEY_CONFLICTS_OPERATIONS_COUNT    = 1000
+)
+
+// Method inserts rows where configured share of keys already exists and compares abort-and-retry against upsert
+func (dtuc *databaseTesterUsecase) testKeyConflicts(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, conflictRate float64) error {
+	var (
+		tableName   = dtuc.tableName("key_conflicts_table")
+		tableFields = []string{"id BIGINT PRIMARY KEY", "f1 BIGINT"}
+		testPrefix  = strconv.FormatFloat(conflictRate*100, 'f', -1, 64) + "PercentConflicts"
+	)
+
+	if err := r.CreateTable(tableName, tableFields); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	// On conflict pick the next new key and retry the insert
+	var retries int64
+	step := dtuc.createKeyConflictsStep("abortRetry"+testPrefix, conflictRate, func(id int64, nextId func() int64) error {
+		for {
+			err := r.Insert(tableName, []string{"id", "f1"}, []map[string]interface{}{{"id": id, "f1": dtuc.rand.Intn(255)}})
+			if err == nil {
+				return nil
+			}
+			if !r.IsConflictError(err) {
+				return err
+			}
+			retries++
+			id = nextId()
+		}
+	}, &retries)
+	if err := dtuc.populateKeyConflictsTable(r, tableName); err != nil {
+		return err
+	}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	if err := r.TruncateTable(tableName); err != nil {
+		return err
+	}
+
+	step = dtuc.createKeyConflictsStep("upsert"+testPrefix, conflictRate, func(id int64, nextId func() int64) error {
+		return r.Upsert(tableName, []string{"id"}, []string{"f1"}, []map[string]interface{}{{"id": id, "f1": dtuc.rand.Intn(255)}})
+	}, nil)
+	if err := dtuc.populateKeyConflictsTable(r, tableName); err != nil {
+		return err
+	}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Method creates step which writes existing keys with conflict rate probability and new keys otherwise.
+// Conflicts are taken from the counter if it's set or planned conflicts are reported.
+func (dtuc *databaseTesterUsecase) createKeyConflictsStep(name string, conflictRate float64, write func(id int64, nextId func() int64) error, conflicts *int64) *domain.TestCaseStep {
+	var (
+		duration         time.Duration
+		plannedConflicts int64
+	)
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		newId := int64(KEY_CONFLICTS_EXISTING_ROWS_COUNT)
+		nextId := func() int64 {
+			newId++
+			return newId
+		}
+
+		startTime := time.Now()
+		for i := 0; i < KEY_CONFLICTS_OPERATIONS_COUNT; i++ {
+			var id int64
+			if dtuc.rand.Float64() < conflictRate {
+				plannedConflicts++
+				id = dtuc.rand.Int63n(KEY_CONFLICTS_EXISTING_ROWS_COUNT) + 1
+			} else {
+				id = nextId()
+			}
+			if err := write(id, nextId); err != nil {
+				return err
+			}
+		}
+		duration = time.Since(startTime)
+
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_Throughput, KEY_CONFLICTS_OPERATIONS_COUNT/duration.Seconds())
+		if conflicts != nil {
+			tcsra.AddMetric(domain.MetricMeta_Conflicts, float64(*conflicts))
+		} else {
+			tcsra.AddMetric(domain.MetricMeta_Conflicts, float64(plannedConflicts))
+		}
+	}}
+}
+
+func (dtuc *databaseTesterUsecase) populateKeyConflictsTable(r repository.DatabaseTesterRepository, tableName string) error {
+	var values []map[string]interface{}
+	for i := 1; i <= KEY_CONFLICTS_EXISTING_ROWS_COUNT; i++ {
+		values = append(values, map[string]interface{}{"id": i, "f1": dtuc.rand.Intn(255)})
+	}
+
+	if err := r.Insert(tableName, []string{"id", "f1"}, values); err != nil {
+		logrus.WithError(err).Debug("couldn't populate key conflicts table")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+const (
+	LARGE_BINARY_TABLE_NAME = "large_binary_table"
+	// Bytes written by every payload size, so the small payloads are written by more rows
+	LARGE_BINARY_BYTES_PER_SIZE = 64 << 20
+	LARGE_BINARY_MIN_ROWS_COUNT = 10
+	LARGE_BINARY_MAX_ROWS_COUNT = 1000
+)
+
+var largeBinaryPayloadSizes = []int{10 << 10, 100 << 10, 1 << 20, 10 << 20}
+
+// Method inserts rows with the binary payloads of every size and reads them back by id.
+// Payloads are random, so they aren't shrunk by the compression and the transfer rate is the rate of the payload bytes.
+func (dtuc *databaseTesterUsecase) testLargeBinary(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) error {
+	tableName := dtuc.tableName(LARGE_BINARY_TABLE_NAME)
+
+	if err := r.CreateTable(tableName, []string{"id BIGINT PRIMARY KEY", "payload BYTEA"}); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	for _, size := range largeBinaryPayloadSizes {
+		rowsCount := LARGE_BINARY_BYTES_PER_SIZE / size
+		if rowsCount < LARGE_BINARY_MIN_ROWS_COUNT {
+			rowsCount = LARGE_BINARY_MIN_ROWS_COUNT
+		} else if rowsCount > LARGE_BINARY_MAX_ROWS_COUNT {
+			rowsCount = LARGE_BINARY_MAX_ROWS_COUNT
+		}
+		testPrefix := strconv.Itoa(rowsCount) + "x" + dtuc.formatPayloadSize(size)
+
+		payload := make([]byte, size)
+		dtuc.rand.Read(payload)
+
+		var id int64
+		step := dtuc.createLargeBinaryStep(testPrefix+"InsertBinary", rowsCount, size, func() error {
+			id++
+			return r.Insert(tableName, []string{"id", "payload"}, []map[string]interface{}{{"id": id, "payload": payload}})
+		})
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+
+		step = dtuc.createLargeBinaryStep(testPrefix+"SelectBinary", rowsCount, size, func() error {
+			var dest []byte
+			if err := r.SelectColumnById(tableName, "payload", dtuc.rand.Int63n(int64(rowsCount))+1, &dest); err != nil {
+				return err
+			}
+			if len(dest) != size {
+				return domain.DATA_INTEGRITY_VIOLATED
+			}
+			return nil
+		})
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+
+		if err := r.TruncateTable(tableName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Method creates step running operations sequentially, which reports their latencies and rate of the payload bytes
+func (dtuc *databaseTesterUsecase) createLargeBinaryStep(name string, rowsCount int, size int, operation func() error) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = helpers.RunLoad(rowsCount, 1, operation)
+		if lr.Errors != 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_TransferRate, float64(rowsCount*size)/lr.Duration.Seconds())
+	}}
+}
+
+func (dtuc *databaseTesterUsecase) formatPayloadSize(size int) string {
+	if size < 1<<20 {
+		return strconv.Itoa(size>>10) + "KB"
+	}
+	return strconv.Itoa(size>>20) + "MB"
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TENANT_TABLE_NAME = "tenant_table"
+	// Rows inserted and selected by every tenant
+	TENANT_ROWS_COUNT = 100
+	// Tenants loaded at the same time, so connections count stays below the server limit
+	TENANTS_MAX_CONCURRENCY = 32
+)
+
+// Tenants count for every step group. Database and schema based tenancy use the same counts to be comparable.
+var tenantsCounts = []int{1, 10, 100}
+
+var tenantTableFields = []string{"id BIGINT PRIMARY KEY", "f1 BIGINT"}
+
+// Method creates many databases on the server and runs light workload in each of them concurrently.
+// Resources usage of the steps shows how per database overhead scales.
+func (dtuc *databaseTesterUsecase) testMultiDatabase(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase) {
+	for _, count := range tenantsCounts {
+		if err := dtuc.testDatabasesCount(mcuc, r, tc, count); err != nil {
+			logrus.WithError(err).WithField("databasesCount", count).Warn("couldn't test multiple databases")
+			return
+		}
+	}
+}
+
+func (dtuc *databaseTesterUsecase) testDatabasesCount(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase, count int) error {
+	testPrefix := strconv.FormatInt(int64(count), 10) + "x"
+
+	names := make([]string, count)
+	for i := range names {
+		names[i] = dtuc.databaseName + "_" + strconv.FormatInt(int64(i+1), 10)
+	}
+
+	step := &domain.TestCaseStep{Name: testPrefix + "CreateDatabase", StepFunc: func() error {
+		for _, name := range names {
+			if err := r.CreateDatabase(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+	isDropped := false
+	// Some databases could be created before the error
+	defer func() {
+		if !isDropped {
+			dtuc.dropDatabases(r, names)
+		}
+	}()
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = dtuc.createTenantsLoadStep(testPrefix+"DatabasesConcurrentLoad", len(names), func(i int) ([]float64, error) {
+		return dtuc.runDatabaseTenantWorkload(tc, names[i])
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: testPrefix + "DropDatabase", StepFunc: func() error { return dtuc.dropDatabases(r, names) }}
+	err := mcuc.CollectStepMetrics(step)
+	isDropped = err == nil
+	return err
+}
+
+// Method creates step running workload of every tenant concurrently and reporting latencies of the tenants operations
+func (dtuc *databaseTesterUsecase) createTenantsLoadStep(name string, count int, workload func(i int) ([]float64, error)) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = dtuc.loadTenants(count, workload)
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+}
+
+// Method runs workload of every tenant by limited workers and collects latencies of its operations
+func (dtuc *databaseTesterUsecase) loadTenants(count int, workload func(i int) ([]float64, error)) *helpers.LoadResult {
+	var (
+		lr      = new(helpers.LoadResult)
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		next    int64 = -1
+		workers       = count
+	)
+	if workers > TENANTS_MAX_CONCURRENCY {
+		workers = TENANTS_MAX_CONCURRENCY
+	}
+
+	startTime := time.Now()
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := atomic.AddInt64(&next, 1); i < int64(count); i = atomic.AddInt64(&next, 1) {
+				latencies, err := workload(int(i))
+				if err != nil {
+					logrus.WithError(err).WithField("tenant", i).Debug("tenant workload failed")
+					atomic.AddInt64(&lr.Errors, 1)
+				}
+
+				mu.Lock()
+				lr.Latencies = append(lr.Latencies, latencies...)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	lr.Duration = time.Since(startTime)
+
+	return lr
+}
+
+// Method connects to the tenant database by the own connection and creates table for the tenant operations
+func (dtuc *databaseTesterUsecase) runDatabaseTenantWorkload(tc *domain.TestCase, name string) ([]float64, error) {
+	r, err := dtuc.createDatabaseRepository(tc, tc.Port)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Open(); err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	if err := r.SwitchDatabase(name); err != nil {
+		return nil, err
+	}
+	tableName := dtuc.tableName(TENANT_TABLE_NAME)
+	if err := r.CreateTable(tableName, tenantTableFields); err != nil {
+		return nil, err
+	}
+
+	return dtuc.runTenantOperations(r, tableName)
+}
+
+// Method inserts rows into the tenant table one by one and selects them by id
+func (dtuc *databaseTesterUsecase) runTenantOperations(r repository.DatabaseTesterRepository, tableName string) ([]float64, error) {
+	var latencies []float64
+	measure := func(op func() error) error {
+		startTime := time.Now()
+		if err := op(); err != nil {
+			return err
+		}
+		latencies = append(latencies, float64(time.Since(startTime).Microseconds()))
+		return nil
+	}
+
+	for i := 0; i < TENANT_ROWS_COUNT; i++ {
+		i := i
+		if err := measure(func() error {
+			return r.Insert(tableName, []string{"id", "f1"}, []map[string]interface{}{{"id": i, "f1": dtuc.rand.Intn(255)}})
+		}); err != nil {
+			return latencies, err
+		}
+	}
+	for i := 0; i < TENANT_ROWS_COUNT; i++ {
+		if err := measure(func() error { return r.SelectById(tableName, dtuc.rand.Intn(TENANT_ROWS_COUNT)) }); err != nil {
+			return latencies, err
+		}
+	}
+
+	return latencies, nil
+}
+
+func (dtuc *databaseTesterUsecase) dropDatabases(r repository.DatabaseTesterRepository, names []string) error {
+	var firstErr error
+	for _, name := range names {
+		if err := r.DropDatabase(name); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const TENANT_SCHEMA_PREFIX = "cott_tenant_"
+
+// Method creates schema with table for every tenant in one database and runs the same workload as the multiDatabase one.
+// Steps with the same tenants count are compared with the database based tenancy ones.
+func (dtuc *databaseTesterUsecase) testMultiSchema(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) {
+	for _, count := range tenantsCounts {
+		if err := dtuc.testSchemasCount(mcuc, r, count); err != nil {
+			logrus.WithError(err).WithField("schemasCount", count).Warn("couldn't test multiple schemas")
+			return
+		}
+	}
+}
+
+func (dtuc *databaseTesterUsecase) testSchemasCount(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, count int) error {
+	testPrefix := strconv.FormatInt(int64(count), 10) + "x"
+
+	tables := make([]string, count)
+	schemas := make([]string, count)
+	for i := range schemas {
+		schemas[i] = TENANT_SCHEMA_PREFIX + strconv.FormatInt(int64(i+1), 10)
+		tables[i] = schemas[i] + "." + dtuc.tableName(TENANT_TABLE_NAME)
+	}
+
+	step := &domain.TestCaseStep{Name: testPrefix + "CreateSchema", StepFunc: func() error {
+		for i, schema := range schemas {
+			if err := r.CreateSchema(schema); err != nil {
+				return err
+			}
+			if err := r.CreateTable(tables[i], tenantTableFields); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+	isDropped := false
+	// Some schemas could be created before the error
+	defer func() {
+		if !isDropped {
+			dtuc.dropSchemas(r, schemas)
+		}
+	}()
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	// Tenants share the connection pool of the database
+	step = dtuc.createTenantsLoadStep(testPrefix+"SchemasConcurrentLoad", count, func(i int) ([]float64, error) {
+		return dtuc.runTenantOperations(r, tables[i])
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	// Every tenant table is filtered by the ids of the first tenant
+	step = &domain.TestCaseStep{Name: testPrefix + "CrossSchemaSelect", StepFunc: func() error {
+		for _, table := range tables {
+			if err := r.SelectByConditions(table, "id IN (SELECT id FROM "+tables[0]+" WHERE f1 < 16)"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: testPrefix + "DropSchema", StepFunc: func() error { return dtuc.dropSchemas(r, schemas) }}
+	err := mcuc.CollectStepMetrics(step)
+	isDropped = err == nil
+	return err
+}
+
+func (dtuc *databaseTesterUsecase) dropSchemas(r repository.DatabaseTesterRepository, schemas []string) error {
+	var firstErr error
+	for _, schema := range schemas {
+		if err := r.DropSchema(schema); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
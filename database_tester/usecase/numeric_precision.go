@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"math/big"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// Values are passed as strings to exclude precision loss on the client side float conversion
+var numericPrecisionCases = []roundTripCase{
+	{Name: "integer", Value: "12345678901234567890"},
+	{Name: "fraction", Value: "0.1"},
+	{Name: "smallFraction", Value: "0.000000000000000001"},
+	{Name: "negative", Value: "-98765432109876543210.123456789012345678"},
+	{Name: "maxScale", Value: "1.123456789012345678"},
+	{Name: "overScale", Value: "1.1234567890123456789"},
+	{Name: "trailingZeros", Value: "100.100000000000000000"},
+	{Name: "wide", Value: "12345678901234567890.123456789012345678"},
+}
+
+var numericPrecisionColumnTypes = map[string]string{
+	"numericPrecision":          "NUMERIC",
+	"numeric38Scale18Precision": "NUMERIC(38,18)",
+}
+
+// Method checks that high-precision NUMERIC values are returned exactly as they were inserted
+func (dtuc *databaseTesterUsecase) testNumericPrecision(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) {
+	for stepName, columnType := range numericPrecisionColumnTypes {
+		column := roundTripColumn{
+			Type:    columnType,
+			NewDest: func() interface{} { return new(string) },
+			Equal: func(expected interface{}, dest interface{}) bool {
+				e, ok := new(big.Rat).SetString(expected.(string))
+				if !ok {
+					return false
+				}
+				v, ok := new(big.Rat).SetString(*dest.(*string))
+				if !ok {
+					return false
+				}
+				return e.Cmp(v) == 0
+			},
+		}
+
+		if err := dtuc.testRoundTrip(mcuc, r, stepName, column, numericPrecisionCases); err != nil {
+			logrus.WithError(err).WithField("step", stepName).Warn("couldn't test numeric precision")
+		}
+	}
+}
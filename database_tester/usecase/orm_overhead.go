@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository/sqlc"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	// Table name isn't prefixed, because it's fixed in the sqlc generated queries
+	ORM_OVERHEAD_TABLE_NAME = "orm_overhead_table"
+	ORM_OVERHEAD_ROWS_COUNT = 1000
+	ORM_OVERHEAD_RANGE_SIZE = 100
+	// Values are lower than it, so range select returns about range size rows
+	ORM_OVERHEAD_MAX_VALUE = ORM_OVERHEAD_ROWS_COUNT
+)
+
+// Table is the same as in the sqlc schema.sql
+var ormOverheadTableFields = []string{"id BIGINT PRIMARY KEY", "name TEXT NOT NULL", "value BIGINT NOT NULL", "created_at TIMESTAMP NOT NULL"}
+
+type ormOverheadRow struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	Value     int64
+	CreatedAt time.Time
+}
+
+func (ormOverheadRow) TableName() string {
+	return ORM_OVERHEAD_TABLE_NAME
+}
+
+// Client which runs the same operations as the others
+type ormOverheadClient struct {
+	name        string
+	insert      func(row *ormOverheadRow) error
+	selectById  func(id int64) (*ormOverheadRow, error)
+	selectRange func(from int64, to int64) ([]ormOverheadRow, error)
+}
+
+// Method runs the same inserts and selects through raw SQL, sqlc generated code and GORM over the same connection pool.
+// Difference of the latencies with raw SQL is the library overhead.
+func (dtuc *databaseTesterUsecase) testOrmOverhead(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) error {
+	db := r.SqlDB()
+	if db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	// Default GORM config is used, because implicit transactions are the part of its overhead
+	gormDb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return err
+	}
+
+	if err := r.CreateTable(ORM_OVERHEAD_TABLE_NAME, ormOverheadTableFields); err != nil {
+		return err
+	}
+	defer r.DropTable(ORM_OVERHEAD_TABLE_NAME)
+
+	clients := []*ormOverheadClient{
+		dtuc.createRawSqlClient(db),
+		dtuc.createSqlcClient(sqlc.New(db)),
+		dtuc.createGormClient(gormDb),
+	}
+
+	rows := make([]*ormOverheadRow, ORM_OVERHEAD_ROWS_COUNT)
+	for i := range rows {
+		rows[i] = &ormOverheadRow{ID: int64(i), Name: "row" + strconv.FormatInt(int64(i), 10), Value: dtuc.rand.Int63n(ORM_OVERHEAD_MAX_VALUE), CreatedAt: dtuc.generateDate()}
+	}
+	countPrefix := strconv.FormatInt(ORM_OVERHEAD_ROWS_COUNT, 10) + "x"
+
+	var rawLr *helpers.LoadResult
+	for i, c := range clients {
+		c := c
+		if err := r.TruncateTable(ORM_OVERHEAD_TABLE_NAME); err != nil {
+			return err
+		}
+
+		next := 0
+		lr, err := dtuc.collectOrmOverheadStep(mcuc, countPrefix+"InsertRow"+c.name, rawLr, func() error {
+			row := rows[next]
+			next++
+			return c.insert(row)
+		})
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			rawLr = lr
+		}
+	}
+
+	rawLr = nil
+	for i, c := range clients {
+		c := c
+		next := 0
+		lr, err := dtuc.collectOrmOverheadStep(mcuc, countPrefix+"SelectRowById"+c.name, rawLr, func() error {
+			row, err := c.selectById(int64(next))
+			if err != nil {
+				return err
+			}
+			if row.Name != rows[next].Name {
+				return domain.DATA_INTEGRITY_VIOLATED
+			}
+			next++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			rawLr = lr
+		}
+	}
+
+	rawLr = nil
+	for i, c := range clients {
+		c := c
+		lr, err := dtuc.collectOrmOverheadStep(mcuc, countPrefix+"SelectRowsRange"+c.name, rawLr, func() error {
+			from := dtuc.rand.Int63n(ORM_OVERHEAD_MAX_VALUE - ORM_OVERHEAD_MAX_VALUE/10)
+			_, err := c.selectRange(from, from+ORM_OVERHEAD_MAX_VALUE/10)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			rawLr = lr
+		}
+	}
+
+	return nil
+}
+
+// Method runs operation sequentially and adds latency difference with raw SQL if it's set
+func (dtuc *databaseTesterUsecase) collectOrmOverheadStep(mcuc metrics_collector.MetricsCollectorUsecase, name string, rawLr *helpers.LoadResult, operation func() error) (*helpers.LoadResult, error) {
+	var lr *helpers.LoadResult
+
+	step := &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = helpers.RunLoad(ORM_OVERHEAD_ROWS_COUNT, 1, operation)
+		if lr.Errors != 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		if rawLr != nil {
+			tcsra.AddMetric(domain.MetricMeta_AddedLatencyP50, lr.Quantile(0.5)-rawLr.Quantile(0.5))
+			tcsra.AddMetric(domain.MetricMeta_AddedLatencyP99, lr.Quantile(0.99)-rawLr.Quantile(0.99))
+		}
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil, err
+	}
+
+	return lr, nil
+}
+
+func (dtuc *databaseTesterUsecase) createRawSqlClient(db *sql.DB) *ormOverheadClient {
+	return &ormOverheadClient{
+		name: "Raw",
+		insert: func(row *ormOverheadRow) error {
+			_, err := db.Exec("INSERT INTO "+ORM_OVERHEAD_TABLE_NAME+" (id, name, value, created_at) VALUES ($1, $2, $3, $4)", row.ID, row.Name, row.Value, row.CreatedAt)
+			return err
+		},
+		selectById: func(id int64) (*ormOverheadRow, error) {
+			row := new(ormOverheadRow)
+			err := db.QueryRow("SELECT id, name, value, created_at FROM "+ORM_OVERHEAD_TABLE_NAME+" WHERE id = $1", id).Scan(&row.ID, &row.Name, &row.Value, &row.CreatedAt)
+			return row, err
+		},
+		selectRange: func(from int64, to int64) ([]ormOverheadRow, error) {
+			rows, err := db.Query("SELECT id, name, value, created_at FROM "+ORM_OVERHEAD_TABLE_NAME+" WHERE value >= $1 AND value < $2 ORDER BY id LIMIT $3", from, to, ORM_OVERHEAD_RANGE_SIZE)
+			if err != nil {
+				return nil, err
+			}
+			defer rows.Close()
+
+			var result []ormOverheadRow
+			for rows.Next() {
+				var row ormOverheadRow
+				if err := rows.Scan(&row.ID, &row.Name, &row.Value, &row.CreatedAt); err != nil {
+					return nil, err
+				}
+				result = append(result, row)
+			}
+			return result, rows.Err()
+		},
+	}
+}
+
+func (dtuc *databaseTesterUsecase) createSqlcClient(q *sqlc.Queries) *ormOverheadClient {
+	return &ormOverheadClient{
+		name: "Sqlc",
+		insert: func(row *ormOverheadRow) error {
+			return q.InsertRow(context.Background(), sqlc.InsertRowParams{ID: row.ID, Name: row.Name, Value: row.Value, CreatedAt: row.CreatedAt})
+		},
+		selectById: func(id int64) (*ormOverheadRow, error) {
+			row, err := q.GetRow(context.Background(), id)
+			if err != nil {
+				return nil, err
+			}
+			return &ormOverheadRow{ID: row.ID, Name: row.Name, Value: row.Value, CreatedAt: row.CreatedAt}, nil
+		},
+		selectRange: func(from int64, to int64) ([]ormOverheadRow, error) {
+			rows, err := q.ListRowsByValue(context.Background(), sqlc.ListRowsByValueParams{Value: from, Value_2: to, Limit: ORM_OVERHEAD_RANGE_SIZE})
+			if err != nil {
+				return nil, err
+			}
+
+			result := make([]ormOverheadRow, len(rows))
+			for i, row := range rows {
+				result[i] = ormOverheadRow{ID: row.ID, Name: row.Name, Value: row.Value, CreatedAt: row.CreatedAt}
+			}
+			return result, nil
+		},
+	}
+}
+
+func (dtuc *databaseTesterUsecase) createGormClient(db *gorm.DB) *ormOverheadClient {
+	return &ormOverheadClient{
+		name: "Gorm",
+		insert: func(row *ormOverheadRow) error {
+			return db.Create(row).Error
+		},
+		selectById: func(id int64) (*ormOverheadRow, error) {
+			row := new(ormOverheadRow)
+			err := db.First(row, id).Error
+			return row, err
+		},
+		selectRange: func(from int64, to int64) ([]ormOverheadRow, error) {
+			var result []ormOverheadRow
+			err := db.Where("value >= ? AND value < ?", from, to).Order("id").Limit(ORM_OVERHEAD_RANGE_SIZE).Find(&result).Error
+			return result, err
+		},
+	}
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	PGVECTOR_TABLE_NAME     = "pgvector_table"
+	PGVECTOR_COLUMN         = "embedding"
+	PGVECTOR_SEARCH_QUERIES = 200
+)
+
+// Index methods are built one after another on the same rows, so their build time, latency and recall are compared by the step names
+var pgVectorIndexMethods = []string{repository.PGVECTOR_INDEX_METHOD_HNSW, repository.PGVECTOR_INDEX_METHOD_IVFFLAT}
+
+// Method inserts vectors of the test case dimension and count, builds every ANN index and measures top k search latency and recall
+// against exact search
+func (dtuc *databaseTesterUsecase) testPgVector(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase) error {
+	vr, ok := r.(repository.VectorRepository)
+	if !ok {
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	step := &domain.TestCaseStep{Name: "createVectorExtension", StepFunc: func() error { return vr.CreateVectorExtension() }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	tableName := dtuc.tableName(PGVECTOR_TABLE_NAME)
+	fields := []string{"id BIGINT PRIMARY KEY", PGVECTOR_COLUMN + " vector(" + strconv.Itoa(int(tc.GetVectorDimension())) + ")"}
+	if err := r.CreateTable(tableName, fields); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	vectors := helpers.GenerateVectors(int(tc.GetVectorsCount()), int(tc.GetVectorDimension()))
+	testPrefix := strconv.FormatInt(int64(len(vectors)), 10) + "x"
+
+	var duration time.Duration
+	step = &domain.TestCaseStep{Name: testPrefix + "VectorInsert", StepFunc: func() error {
+		startTime := time.Now()
+		defer func() { duration = time.Since(startTime) }()
+
+		for offset := 0; offset < len(vectors); offset += INSERT_CHUNK_SIZE {
+			values := make([]map[string]interface{}, 0, INSERT_CHUNK_SIZE)
+			for i := offset; i < offset+INSERT_CHUNK_SIZE && i < len(vectors); i++ {
+				values = append(values, map[string]interface{}{"id": i, PGVECTOR_COLUMN: vr.VectorValue(vectors[i])})
+			}
+			if err := r.Insert(tableName, []string{"id", PGVECTOR_COLUMN}, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_LoadRate, float64(len(vectors))/duration.Seconds())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	queries := helpers.GenerateVectors(PGVECTOR_SEARCH_QUERIES, int(tc.GetVectorDimension()))
+	// Exact results are calculated before the steps not to affect their duration
+	expected := make([][]int, len(queries))
+	for i, q := range queries {
+		expected[i] = helpers.ExactTopK(vectors, q, int(tc.GetTopK()))
+	}
+
+	for _, method := range pgVectorIndexMethods {
+		indexName := tableName + "_" + method
+		methodName := strings.ToUpper(method[:1]) + method[1:]
+
+		step = &domain.TestCaseStep{Name: "create" + methodName + "Index", StepFunc: func() error {
+			return vr.CreateVectorIndex(tableName, indexName, PGVECTOR_COLUMN, method, len(vectors))
+		}}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).WithField("method", method).Warn("couldn't create vector index")
+			continue
+		}
+
+		step = dtuc.createVectorSearchStep(vr, "top"+strconv.Itoa(int(tc.GetTopK()))+"Search"+methodName+"Index", tableName, queries, expected, int(tc.GetConcurrency()))
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).WithField("method", method).Warn("vector search failed")
+		}
+
+		if err := r.DropIndex(tableName, indexName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Method creates step running top k queries concurrently and reporting latency and mean recall
+func (dtuc *databaseTesterUsecase) createVectorSearchStep(vr repository.VectorRepository, name string, tableName string, queries [][]float32, expected [][]int, concurrency int) *domain.TestCaseStep {
+	var (
+		lr     *helpers.LoadResult
+		mu     sync.Mutex
+		recall float64
+		found  int
+	)
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		var nextQuery int64 = -1
+		lr = helpers.RunLoad(len(queries), concurrency, func() error {
+			i := int(atomic.AddInt64(&nextQuery, 1))
+			ids, err := vr.SearchVectors(tableName, PGVECTOR_COLUMN, queries[i], len(expected[i]))
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			recall += helpers.Recall(expected[i], ids)
+			found++
+			mu.Unlock()
+			return nil
+		})
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+		tcsra.AddMetric(domain.MetricMeta_Recall, 100*recall/float64(found))
+	}}
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+const (
+	PREPARED_STATEMENTS_TABLE_NAME = "prepared_statements_table"
+	// Statements are run sequentially, so latencies show parsing and planning costs without the contention
+	PREPARED_STATEMENTS_COUNT = 1000
+)
+
+var (
+	preparedStatementsTableFields = []string{"id BIGINT PRIMARY KEY", "f1 BIGINT"}
+	preparedStatementsColumns     = []string{"id", "f1"}
+)
+
+// Method compares single row inserts and selects by id, which are sent as the ad-hoc statements, with the same ones
+// prepared once and reused. Prepared steps report their latency difference with the ad-hoc ones, so it's negative
+// if the database or the driver caches plans of the prepared statements only.
+func (dtuc *databaseTesterUsecase) testPreparedStatements(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) error {
+	pr, ok := r.(repository.PreparedStatementRepository)
+	if !ok {
+		return domain.UNSUPPORTED_OPERATION
+	}
+
+	testPrefix := strconv.Itoa(PREPARED_STATEMENTS_COUNT) + "x"
+	tableName := dtuc.tableName(PREPARED_STATEMENTS_TABLE_NAME)
+
+	if err := r.CreateTable(tableName, preparedStatementsTableFields); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	// Both inserts write the same ids, so the table is truncated between them
+	id := 0
+	insertLr, err := dtuc.collectComparedStep(mcuc, testPrefix+"InsertRow", PREPARED_STATEMENTS_COUNT, nil, func() error {
+		id++
+		return r.Insert(tableName, preparedStatementsColumns, []map[string]interface{}{{"id": id, "f1": dtuc.rand.Int63()}})
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.TruncateTable(tableName); err != nil {
+		return err
+	}
+
+	// Statements are prepared before the steps, so only their reuse is measured
+	insert, err := pr.PrepareInsert(tableName, preparedStatementsColumns)
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	id = 0
+	if _, err := dtuc.collectComparedStep(mcuc, testPrefix+"InsertRowPrepared", PREPARED_STATEMENTS_COUNT, insertLr, func() error {
+		id++
+		return insert.Exec(id, dtuc.rand.Int63())
+	}); err != nil {
+		return err
+	}
+	if err := dtuc.checkRowsCount(r, tableName, PREPARED_STATEMENTS_COUNT); err != nil {
+		return err
+	}
+
+	selectLr, err := dtuc.collectComparedStep(mcuc, testPrefix+"SelectRowById", PREPARED_STATEMENTS_COUNT, nil, func() error {
+		return r.SelectById(tableName, dtuc.rand.Intn(PREPARED_STATEMENTS_COUNT)+1)
+	})
+	if err != nil {
+		return err
+	}
+
+	selectById, err := pr.PrepareSelectById(tableName)
+	if err != nil {
+		return err
+	}
+	defer selectById.Close()
+
+	_, err = dtuc.collectComparedStep(mcuc, testPrefix+"SelectRowByIdPrepared", PREPARED_STATEMENTS_COUNT, selectLr, func() error {
+		return selectById.Exec(dtuc.rand.Intn(PREPARED_STATEMENTS_COUNT) + 1)
+	})
+	return err
+}
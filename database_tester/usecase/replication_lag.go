@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+	"gonum.org/v1/gonum/stat"
+)
+
+const (
+	REPLICATION_LAG_PROBES_COUNT = 50
+	REPLICATION_LAG_TIMEOUT      = 30 * time.Second
+	REPLICATION_LAG_POLL_PERIOD  = time.Millisecond
+)
+
+// Background writers count for every load level
+var replicationLagWritersCounts = []int{0, 1, 4, 16}
+
+// Method writes probe rows on the primary and polls replica until they appear under increasing background write load
+func (dtuc *databaseTesterUsecase) testReplicationLag(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase) error {
+	var (
+		probeTableName = dtuc.tableName("replication_lag_probe")
+		loadTableName  = dtuc.tableName("replication_lag_load")
+		probeFields    = []string{"id BIGSERIAL PRIMARY KEY", "f1 BIGINT"}
+		loadFields     = tc.GetTableFields()
+	)
+
+	if tc.ReplicaPort == 0 {
+		return domain.NO_REPLICA_PORT
+	}
+
+	replica, err := dtuc.createDatabaseRepository(tc, tc.ReplicaPort)
+	if err != nil {
+		return err
+	}
+
+	if err := r.CreateTable(probeTableName, probeFields); err != nil {
+		return err
+	}
+	defer r.DropTable(probeTableName)
+	if err := r.CreateTable(loadTableName, loadFields); err != nil {
+		return err
+	}
+	defer r.DropTable(loadTableName)
+
+	if err := replica.Open(); err != nil {
+		return err
+	}
+	defer replica.Close()
+	if err := replica.SwitchDatabase(dtuc.databaseName); err != nil {
+		return err
+	}
+	// Database and tables appear on the replica with the lag too
+	if err := dtuc.awaitDatabaseReady(replica); err != nil {
+		return err
+	}
+
+	for _, writersCount := range replicationLagWritersCounts {
+		var lags []float64
+
+		step := &domain.TestCaseStep{Name: strconv.FormatInt(int64(writersCount), 10) + "xWritersReplicationLag", StepFunc: func() error {
+			stopCh := make(chan struct{})
+			var wg sync.WaitGroup
+			for i := 0; i < writersCount; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						select {
+						case <-stopCh:
+							return
+						default:
+							if err := dtuc.insertTableData(r, loadTableName, loadFields, 100); err != nil {
+								logrus.WithError(err).Debug("couldn't insert replication load")
+							}
+						}
+					}
+				}()
+			}
+			defer func() {
+				close(stopCh)
+				wg.Wait()
+			}()
+
+			var err error
+			lags, err = dtuc.probeReplicationLag(r, replica, probeTableName)
+			return err
+		}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+			sort.Float64s(lags)
+			tcsra.AddMetric(domain.MetricMeta_ReplicationLagP50, stat.Quantile(0.5, stat.Empirical, lags, nil))
+			tcsra.AddMetric(domain.MetricMeta_ReplicationLagP90, stat.Quantile(0.9, stat.Empirical, lags, nil))
+			tcsra.AddMetric(domain.MetricMeta_ReplicationLagP99, stat.Quantile(0.99, stat.Empirical, lags, nil))
+		}}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+
+		if err := r.TruncateTable(loadTableName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Method returns lags in microseconds between probe row insert on primary and its appearance on the replica
+func (dtuc *databaseTesterUsecase) probeReplicationLag(primary repository.DatabaseTesterRepository, replica repository.DatabaseTesterRepository, tableName string) ([]float64, error) {
+	var lags []float64
+
+	expectedCount, err := primary.CountRows(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < REPLICATION_LAG_PROBES_COUNT; i++ {
+		if err := primary.Insert(tableName, []string{"f1"}, []map[string]interface{}{{"f1": i}}); err != nil {
+			return nil, err
+		}
+		startTime := time.Now()
+		expectedCount++
+
+		for {
+			count, err := replica.CountRows(tableName)
+			if err == nil && count >= expectedCount {
+				break
+			}
+			if time.Since(startTime) > REPLICATION_LAG_TIMEOUT {
+				return nil, domain.REPLICATION_LAG_TIMEOUT
+			}
+			time.Sleep(REPLICATION_LAG_POLL_PERIOD)
+		}
+
+		lags = append(lags, float64(time.Since(startTime).Microseconds()))
+	}
+
+	return lags, nil
+}
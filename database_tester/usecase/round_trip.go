@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+type roundTripCase struct {
+	Name  string
+	Value interface{}
+}
+
+type roundTripColumn struct {
+	// Column type in the table DDL
+	Type string
+	// NewDest returns pointer for scanning the column value
+	NewDest func() interface{}
+	// Equal compares inserted value with the scanned dest
+	Equal func(expected interface{}, dest interface{}) bool
+}
+
+// Method inserts every case value, reads it back and compares.
+// Insert errors and mismatches don't fail the step and are reported as step errors and mismatches metric,
+// because they show engines' and drivers' divergent behavior.
+func (dtuc *databaseTesterUsecase) testRoundTrip(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, stepName string, column roundTripColumn, cases []roundTripCase) error {
+	tableName := dtuc.tableName("round_trip_table")
+
+	if err := r.CreateTable(tableName, []string{"id BIGINT PRIMARY KEY", "value " + column.Type}); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	var mismatches []string
+	step := &domain.TestCaseStep{Name: stepName, StepFunc: func() error {
+		for i, c := range cases {
+			id := int64(i + 1)
+
+			if err := r.Insert(tableName, []string{"id", "value"}, []map[string]interface{}{{"id": id, "value": c.Value}}); err != nil {
+				mismatches = append(mismatches, dtuc.formatRoundTripMismatch(c.Name, "insert", err.Error()))
+				continue
+			}
+
+			dest := column.NewDest()
+			if err := r.SelectColumnById(tableName, "value", id, dest); err != nil {
+				mismatches = append(mismatches, dtuc.formatRoundTripMismatch(c.Name, "select", err.Error()))
+				continue
+			}
+
+			if !column.Equal(c.Value, dest) {
+				mismatches = append(mismatches, dtuc.formatRoundTripMismatch(c.Name, "diff", fmt.Sprintf("%.64v", dest)))
+			}
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_RoundTripMismatches, float64(len(mismatches)))
+		for _, m := range mismatches {
+			tcsra.AddError(m)
+		}
+	}}
+
+	return mcuc.CollectStepMetrics(step)
+}
+
+func (dtuc *databaseTesterUsecase) formatRoundTripMismatch(caseName string, stage string, details string) string {
+	var buf bytes.Buffer
+	buf.WriteString(caseName)
+	buf.WriteString(": ")
+	buf.WriteString(stage)
+	buf.WriteString(": ")
+	buf.WriteString(details)
+	return buf.String()
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"sync/atomic"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+const (
+	SATURATION_TABLE_NAME  = "saturation_table"
+	SATURATION_ROWS_COUNT  = 10000
+	SATURATION_BATCH_SIZE  = 1000
+	SATURATION_FIELD_RANGE = 255
+)
+
+// Method ramps concurrency of the single row inserts and selects by id till the latency SLO is violated
+func (dtuc *databaseTesterUsecase) testSaturation(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase) error {
+	tableName := dtuc.tableName(SATURATION_TABLE_NAME)
+	if err := r.CreateTable(tableName, []string{"id BIGINT PRIMARY KEY", "f1 BIGINT"}); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	var next int64 = -1
+	step := helpers.NewSaturationStep("saturationInsertRow", tc, func() error {
+		id := atomic.AddInt64(&next, 1)
+		return r.Insert(tableName, []string{"id", "f1"}, []map[string]interface{}{{"id": id, "f1": dtuc.rand.Intn(SATURATION_FIELD_RANGE)}})
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	if err := r.TruncateTable(tableName); err != nil {
+		return err
+	}
+	for i := 0; i < SATURATION_ROWS_COUNT; i += SATURATION_BATCH_SIZE {
+		values := make([]map[string]interface{}, SATURATION_BATCH_SIZE)
+		for j := range values {
+			values[j] = map[string]interface{}{"id": i + j, "f1": dtuc.rand.Intn(SATURATION_FIELD_RANGE)}
+		}
+		if err := r.Insert(tableName, []string{"id", "f1"}, values); err != nil {
+			return err
+		}
+	}
+
+	step = helpers.NewSaturationStep("saturationSelectById", tc, func() error {
+		return r.SelectById(tableName, dtuc.rand.Intn(SATURATION_ROWS_COUNT))
+	})
+	return mcuc.CollectStepMetrics(step)
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"sort"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+	"gonum.org/v1/gonum/stat"
+)
+
+const (
+	MIGRATION_PROBE_PERIOD            = 10 * time.Millisecond
+	MIGRATION_PROBE_BLOCKED_THRESHOLD = 100 * time.Millisecond
+)
+
+// Method runs common migrations on the populated table and measures their duration and how they block concurrent queries
+func (dtuc *databaseTesterUsecase) testSchemaMigrations(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tableName string, tableFields []string, testPrefix string) error {
+	const (
+		indexName = "migration_f1_idx"
+	)
+
+	// Revert migrations to keep table schema for the next data count
+	defer func() {
+		if err := r.DropIndex(tableName, indexName); err != nil {
+			logrus.WithError(err).Debug("couldn't drop migration index")
+		}
+		if err := r.AlterColumnType(tableName, "f7", "INTEGER"); err != nil {
+			logrus.WithError(err).Debug("couldn't revert migration column type")
+		}
+		if err := r.DropColumn(tableName, "f12"); err != nil {
+			logrus.WithError(err).Debug("couldn't drop migration column")
+		}
+	}()
+
+	steps := []*domain.TestCaseStep{
+		{Name: "addColumnWithDefault" + testPrefix + "Table", StepFunc: func() error { return r.AddColumn(tableName, "f12 BIGINT DEFAULT 0") }},
+		{Name: "setNotNull" + testPrefix + "Table", StepFunc: func() error { return r.SetColumnNotNull(tableName, "f12") }},
+		{Name: "createIndexConcurrently" + testPrefix + "Table", StepFunc: func() error { return r.CreateIndex(tableName, indexName, []string{"f1"}, true) }},
+		{Name: "changeColumnType" + testPrefix + "Table", StepFunc: func() error { return r.AlterColumnType(tableName, "f7", "BIGINT") }},
+	}
+
+	for _, step := range steps {
+		dtuc.addMigrationProbe(step, r, tableName, tableFields)
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Method wraps step to run concurrent insert and select probes while step is executing
+func (dtuc *databaseTesterUsecase) addMigrationProbe(step *domain.TestCaseStep, r repository.DatabaseTesterRepository, tableName string, tableFields []string) {
+	var latencies []float64
+	stepFunc := step.StepFunc
+
+	step.StepFunc = func() error {
+		stopCh := make(chan struct{})
+		doneCh := make(chan struct{})
+
+		go func() {
+			defer close(doneCh)
+			for {
+				select {
+				case <-stopCh:
+					return
+				default:
+					startTime := time.Now()
+					if err := dtuc.insertTableData(r, tableName, tableFields, 1); err != nil {
+						logrus.WithError(err).Debug("migration probe insert failed")
+					}
+					if err := r.SelectById(tableName, 1); err != nil {
+						logrus.WithError(err).Debug("migration probe select failed")
+					}
+					latencies = append(latencies, float64(time.Since(startTime).Microseconds()))
+					time.Sleep(MIGRATION_PROBE_PERIOD)
+				}
+			}
+		}()
+
+		err := stepFunc()
+		close(stopCh)
+		<-doneCh
+
+		return err
+	}
+
+	step.MetricsFunc = func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		if len(latencies) == 0 {
+			return
+		}
+
+		sort.Float64s(latencies)
+
+		var blocked float64
+		for _, v := range latencies {
+			if v > float64(MIGRATION_PROBE_BLOCKED_THRESHOLD.Microseconds()) {
+				blocked++
+			}
+		}
+
+		tcsra.AddMetric(domain.MetricMeta_ProbeLatencyP99, stat.Quantile(0.99, stat.Empirical, latencies, nil))
+		tcsra.AddMetric(domain.MetricMeta_ProbeLatencyMax, latencies[len(latencies)-1])
+		tcsra.AddMetric(domain.MetricMeta_BlockedProbes, blocked)
+	}
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+	"go.starlark.net/starlark"
+)
+
+// Function of the script which is called on every accumulation
+const SCRIPT_ENTRY_FUNCTION = "main"
+
+// Method runs Starlark script of the test case. Script defines main function which calls repository builtins
+// and wraps measured parts into step("name", fn, args...) calls.
+func (dtuc *databaseTesterUsecase) testScript(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase) error {
+	thread := &starlark.Thread{Name: tc.ScriptPath, Print: func(_ *starlark.Thread, msg string) {
+		logrus.WithField("script", tc.ScriptPath).Info(msg)
+	}}
+
+	params := starlark.NewDict(len(tc.ScriptParams))
+	for k, v := range tc.ScriptParams {
+		if err := params.SetKey(starlark.String(k), starlark.String(v)); err != nil {
+			return err
+		}
+	}
+
+	globals, err := starlark.ExecFile(thread, tc.ScriptPath, nil, dtuc.createScriptBuiltins(mcuc, r, params))
+	if err != nil {
+		return err
+	}
+
+	entry, ok := globals[SCRIPT_ENTRY_FUNCTION]
+	if !ok {
+		return domain.NO_SCRIPT_ENTRY_FUNCTION
+	}
+	_, err = starlark.Call(thread, entry, nil, nil)
+	return err
+}
+
+func (dtuc *databaseTesterUsecase) createScriptBuiltins(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, params *starlark.Dict) starlark.StringDict {
+	// Builtins taking only the table name
+	tableBuiltin := func(name string, f func(tableName string) error) *starlark.Builtin {
+		return starlark.NewBuiltin(name, func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var tableName string
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "table", &tableName); err != nil {
+				return nil, err
+			}
+			return starlark.None, f(tableName)
+		})
+	}
+
+	return starlark.StringDict{
+		"params":         params,
+		"drop_table":     tableBuiltin("drop_table", r.DropTable),
+		"truncate_table": tableBuiltin("truncate_table", r.TruncateTable),
+
+		// step(name, fn, *args) runs fn as the measured step and returns whether it succeeded
+		"step": starlark.NewBuiltin("step", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			if len(args) < 2 {
+				return nil, domain.INVALID_SCRIPT_ARGUMENTS
+			}
+			name, ok := starlark.AsString(args[0])
+			if !ok {
+				return nil, domain.INVALID_SCRIPT_ARGUMENTS
+			}
+
+			step := &domain.TestCaseStep{Name: name, StepFunc: func() error {
+				_, err := starlark.Call(thread, args[1], args[2:], kwargs)
+				return err
+			}}
+			return starlark.Bool(mcuc.CollectStepMetrics(step) == nil), nil
+		}),
+
+		"create_table": starlark.NewBuiltin("create_table", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var (
+				tableName string
+				fields    *starlark.List
+			)
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "table", &tableName, "fields", &fields); err != nil {
+				return nil, err
+			}
+
+			var goFields []string
+			for i := 0; i < fields.Len(); i++ {
+				field, ok := starlark.AsString(fields.Index(i))
+				if !ok {
+					return nil, domain.INVALID_SCRIPT_ARGUMENTS
+				}
+				goFields = append(goFields, field)
+			}
+			return starlark.None, r.CreateTable(tableName, goFields)
+		}),
+
+		// insert(table, rows) inserts list of dicts, columns are taken from the first row
+		"insert": starlark.NewBuiltin("insert", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var (
+				tableName string
+				rows      *starlark.List
+			)
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "table", &tableName, "rows", &rows); err != nil {
+				return nil, err
+			}
+			if rows.Len() == 0 {
+				return starlark.None, nil
+			}
+
+			var (
+				columns []string
+				values  []map[string]interface{}
+			)
+			for i := 0; i < rows.Len(); i++ {
+				row, ok := rows.Index(i).(*starlark.Dict)
+				if !ok {
+					return nil, domain.INVALID_SCRIPT_ARGUMENTS
+				}
+
+				value := make(map[string]interface{})
+				for _, item := range row.Items() {
+					column, ok := starlark.AsString(item[0])
+					if !ok {
+						return nil, domain.INVALID_SCRIPT_ARGUMENTS
+					}
+					v, err := dtuc.convertScriptValue(item[1])
+					if err != nil {
+						return nil, err
+					}
+					value[column] = v
+					if i == 0 {
+						columns = append(columns, column)
+					}
+				}
+				values = append(values, value)
+			}
+			return starlark.None, r.Insert(tableName, columns, values)
+		}),
+
+		"select_by_id": starlark.NewBuiltin("select_by_id", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var (
+				tableName string
+				id        int
+			)
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "table", &tableName, "id", &id); err != nil {
+				return nil, err
+			}
+			return starlark.None, r.SelectById(tableName, id)
+		}),
+
+		"select_by_conditions": starlark.NewBuiltin("select_by_conditions", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var tableName, conditions string
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "table", &tableName, "conditions", &conditions); err != nil {
+				return nil, err
+			}
+			return starlark.None, r.SelectByConditions(tableName, conditions)
+		}),
+
+		"count_rows": starlark.NewBuiltin("count_rows", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var tableName string
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "table", &tableName); err != nil {
+				return nil, err
+			}
+			count, err := r.CountRows(tableName)
+			if err != nil {
+				return nil, err
+			}
+			return starlark.MakeInt64(count), nil
+		}),
+	}
+}
+
+func (dtuc *databaseTesterUsecase) convertScriptValue(v starlark.Value) (interface{}, error) {
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.Int:
+		if i, ok := v.Int64(); ok {
+			return i, nil
+		}
+		return nil, domain.INVALID_SCRIPT_ARGUMENTS
+	case starlark.Float:
+		return float64(v), nil
+	case starlark.String:
+		return string(v), nil
+	default:
+		return nil, domain.INVALID_SCRIPT_ARGUMENTS
+	}
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+const SHARDED_INSERT_TABLE_NAME = "sharded_insert_table"
+
+var (
+	shardedInsertTableFields = []string{"id BIGINT PRIMARY KEY", "f1 BIGINT", "f2 TEXT"}
+	shardedInsertColumns     = []string{"id", "f1", "f2"}
+)
+
+// Method partitions rows by id ranges between concurrent shards like ETL ingestion does and reports aggregate load rate.
+// With partitions every shard inserts directly into the range partition of its rows, so routing by the parent table is skipped.
+func (dtuc *databaseTesterUsecase) testShardedInsert(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase) error {
+	rowsCount := int(tc.GetShardedInsertRowsCount())
+	shards := int(tc.GetInsertShards())
+	testPrefix := strconv.FormatInt(int64(rowsCount), 10) + "x"
+	shardsSuffix := strconv.FormatInt(int64(shards), 10) + "Shards"
+	tableName := dtuc.tableName(SHARDED_INSERT_TABLE_NAME)
+
+	// Shard i inserts ids in [bounds[i], bounds[i+1])
+	bounds := make([]int64, shards+1)
+	for i := range bounds {
+		bounds[i] = int64(rowsCount * i / shards)
+	}
+
+	var partitions []string
+	if tc.ShardedInsertPartitions {
+		if err := r.CreatePartitionedTable(tableName, shardedInsertTableFields, "id"); err != nil {
+			return err
+		}
+		defer r.DropTable(tableName)
+
+		for i := 0; i < shards; i++ {
+			partition := tableName + "_p" + strconv.FormatInt(int64(i), 10)
+			if err := r.CreateRangePartition(tableName, partition, bounds[i], bounds[i+1]); err != nil {
+				return err
+			}
+			partitions = append(partitions, partition)
+		}
+	} else {
+		if err := r.CreateTable(tableName, shardedInsertTableFields); err != nil {
+			return err
+		}
+		defer r.DropTable(tableName)
+	}
+
+	step := dtuc.createShardedInsertStep(testPrefix+"InsertBy"+shardsSuffix, r, bounds, func(int) string { return tableName })
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+	if err := dtuc.checkRowsCount(r, tableName, int64(rowsCount)); err != nil {
+		return err
+	}
+
+	if partitions == nil {
+		return nil
+	}
+
+	if err := r.TruncateTable(tableName); err != nil {
+		return err
+	}
+	step = dtuc.createShardedInsertStep(testPrefix+"InsertBy"+shardsSuffix+"IntoPartitions", r, bounds, func(i int) string { return partitions[i] })
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+	return dtuc.checkRowsCount(r, tableName, int64(rowsCount))
+}
+
+// Method creates step inserting rows of every shard by its own connection into the table returned by shardTable
+func (dtuc *databaseTesterUsecase) createShardedInsertStep(name string, r repository.DatabaseTesterRepository, bounds []int64, shardTable func(i int) string) *domain.TestCaseStep {
+	var duration time.Duration
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		var (
+			wg       sync.WaitGroup
+			errOnce  sync.Once
+			firstErr error
+		)
+
+		startTime := time.Now()
+		for i := 0; i < len(bounds)-1; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if err := dtuc.insertShard(r, shardTable(i), bounds[i], bounds[i+1]); err != nil {
+					errOnce.Do(func() { firstErr = err })
+				}
+			}(i)
+		}
+		wg.Wait()
+		duration = time.Since(startTime)
+
+		return firstErr
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_LoadRate, float64(bounds[len(bounds)-1])/duration.Seconds())
+	}}
+}
+
+// Method inserts rows with ids in [from, to) range by chunks
+func (dtuc *databaseTesterUsecase) insertShard(r repository.DatabaseTesterRepository, tableName string, from int64, to int64) error {
+	for offset := from; offset < to; offset += INSERT_CHUNK_SIZE {
+		values := make([]map[string]interface{}, 0, INSERT_CHUNK_SIZE)
+		for id := offset; id < offset+INSERT_CHUNK_SIZE && id < to; id++ {
+			values = append(values, map[string]interface{}{"id": id, "f1": dtuc.rand.Int63(), "f2": "row" + strconv.FormatInt(id, 10)})
+		}
+		if err := r.Insert(tableName, shardedInsertColumns, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// Method runs SQL steps in their order, so the first ones could prepare tables for the imported benchmarks
+func (dtuc *databaseTesterUsecase) testSqlSteps(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase) error {
+	for i := range tc.SqlSteps {
+		if err := mcuc.CollectStepMetrics(dtuc.createSqlStep(r, &tc.SqlSteps[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Method creates step executing the statements as the single transaction of every client.
+// Variables are generated for every transaction and statements are executed by the same connection, so scripts could use BEGIN and END.
+func (dtuc *databaseTesterUsecase) createSqlStep(r repository.DatabaseTesterRepository, s *domain.SqlStep) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+
+	operation := func() error {
+		db := r.SqlDB()
+		if db == nil {
+			return domain.CONNECTION_WAS_NOT_ESTABLISHED
+		}
+
+		conn, err := db.Conn(context.Background())
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		values := make(map[string]string, len(s.Variables))
+		for _, v := range s.Variables {
+			value := v.Min
+			if v.Max > v.Min {
+				value += dtuc.rand.Int63n(v.Max - v.Min + 1)
+			}
+			values[v.Name] = strconv.FormatInt(value, 10)
+		}
+		lookup := func(name string) (string, bool) {
+			value, ok := values[name]
+			return value, ok
+		}
+
+		for _, statement := range s.Statements {
+			if _, err := conn.ExecContext(context.Background(), domain.ExpandSqlVariables(statement, lookup)); err != nil {
+				// Connection is returned to the pool, so failed transaction shouldn't be left open on it
+				if _, rbErr := conn.ExecContext(context.Background(), "ROLLBACK"); rbErr != nil {
+					logrus.WithError(rbErr).Debug("couldn't rollback failed SQL step transaction")
+				}
+				return err
+			}
+		}
+		return nil
+	}
+
+	return &domain.TestCaseStep{Name: s.Name, StepFunc: func() error {
+		lr = helpers.RunLoad(int(s.GetTransactions()), int(s.GetClients()), operation)
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+}
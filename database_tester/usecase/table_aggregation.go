@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// Method aggregates filter column of the populated test table over the whole table and by the groups.
+// Group column values are random in [0, 255), so rows are aggregated into 255 groups.
+func (dtuc *databaseTesterUsecase) testTableAggregation(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tableName string, columns *workloadColumns, testPrefix string) {
+	for _, aggregation := range domain.Aggregations {
+		aggregation := aggregation
+
+		step := &domain.TestCaseStep{Name: string(aggregation) + testPrefix + "Table", StepFunc: func() error {
+			return r.SelectAggregation(tableName, "", aggregation, columns.filter)
+		}}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).WithField("aggregation", aggregation).Warn("couldn't select table aggregation")
+		}
+
+		step = &domain.TestCaseStep{Name: string(aggregation) + "GroupBy" + testPrefix + "Table", StepFunc: func() error {
+			return r.SelectAggregation(tableName, columns.group, aggregation, columns.filter)
+		}}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).WithField("aggregation", aggregation).Warn("couldn't select grouped aggregation")
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import "strings"
+
+// Integer column values are random in [0, 255), so conditions on them match the known share of the rows
+var integerColumnTypes = map[string]bool{
+	"SMALLINT": true,
+	"INT2":     true,
+	"INTEGER":  true,
+	"INT":      true,
+	"INT4":     true,
+	"BIGINT":   true,
+	"INT8":     true,
+}
+
+var floatColumnTypes = map[string]bool{
+	"FLOAT":  true,
+	"REAL":   true,
+	"DOUBLE": true,
+}
+
+// Columns of the table workload used by the index, update, delete, aggregation and join steps.
+// For the default schema they are f1 filtered, indexed and aggregated, f9 grouping and joining rows, f7 aggregated by the join, and f5 and f7 updated.
+type workloadColumns struct {
+	// Integer column filtered by the conditions, indexed and aggregated
+	filter string
+	// Integer column grouping rows and referencing the related rows
+	group string
+	// Integer column aggregated over the joined rows
+	joinAggregated string
+	// Not key and not serial columns, so they are updated by all the engines
+	update []string
+}
+
+// Method picks columns of the table workload steps from the table fields. Returns false if the fields have no integer column.
+func (dtuc *databaseTesterUsecase) findWorkloadColumns(fields []string) (*workloadColumns, bool) {
+	var integers, floats []string
+	for _, field := range fields {
+		column, columnType, ok := dtuc.splitTableField(field)
+		if !ok || strings.Contains(strings.ToUpper(field), "PRIMARY KEY") {
+			continue
+		}
+		if i := strings.IndexByte(columnType, '('); i >= 0 {
+			columnType = columnType[:i]
+		}
+
+		switch columnType = strings.ToUpper(columnType); {
+		case integerColumnTypes[columnType]:
+			integers = append(integers, column)
+		case floatColumnTypes[columnType]:
+			floats = append(floats, column)
+		}
+	}
+	if len(integers) == 0 {
+		return nil, false
+	}
+
+	wc := &workloadColumns{filter: integers[0], group: integers[len(integers)-1], joinAggregated: integers[0]}
+	if len(integers) > 2 {
+		wc.joinAggregated = integers[1]
+	}
+	if len(floats) > 0 {
+		wc.update = append(wc.update, floats[0])
+	}
+	wc.update = append(wc.update, wc.joinAggregated)
+
+	return wc, true
+}
+
+// Method returns conditions matching about half of the rows, because filter column values are random in [0, 255)
+func (wc *workloadColumns) halfRowsConditions() string {
+	return wc.filter + ">128"
+}
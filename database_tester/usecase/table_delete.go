@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// Method deletes the single row by id, the rows matching conditions and then all the left rows of the populated table.
+// Delete by conditions is skipped if the table has no filter column.
+// Deleted rows are inserted back after the delete of all the rows, so the following truncate step empties the table of the same size.
+// Load rates of the mass deletes are the deleted rows per second.
+func (dtuc *databaseTesterUsecase) testTableDelete(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tableName string, tableFields []string, columns *workloadColumns, testPrefix string, dataCount int) {
+	step := &domain.TestCaseStep{Name: "deleteById" + testPrefix + "Table", StepFunc: func() error { return r.DeleteById(tableName, dataCount/2) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("couldn't delete row by id")
+	}
+
+	if columns != nil {
+		step = dtuc.createMassDeleteStep("deleteByConditions"+testPrefix+"Table", func() (int64, error) {
+			return r.DeleteByConditions(tableName, columns.halfRowsConditions())
+		})
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).Warn("couldn't delete rows by conditions")
+		}
+	}
+
+	var deleted int64
+	step = dtuc.createMassDeleteStep("deleteAll"+testPrefix+"Table", func() (int64, error) {
+		n, err := r.DeleteAll(tableName)
+		deleted = n
+		return n, err
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("couldn't delete all rows")
+		return
+	}
+
+	if err := dtuc.insertTableData(r, tableName, tableFields, int(deleted)); err != nil {
+		logrus.WithError(err).Warn("couldn't insert deleted rows back")
+	}
+}
+
+// Method creates step running the delete and reporting the deleted rows per second
+func (dtuc *databaseTesterUsecase) createMassDeleteStep(name string, deleteRows func() (int64, error)) *domain.TestCaseStep {
+	var (
+		deleted  int64
+		duration time.Duration
+	)
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		startTime := time.Now()
+		n, err := deleteRows()
+		if err != nil {
+			return err
+		}
+		duration = time.Since(startTime)
+		deleted = n
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_LoadRate, float64(deleted)/duration.Seconds())
+	}}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	INDEX_NAME = "test_table_filter_idx"
+	// Selects are sequential full scans before the index is built, so their count is kept small for the largest tables
+	INDEX_SELECTS_COUNT = 20
+)
+
+// Method compares select by filter column value before and after the index on it is built. Build time is the duration of the index step.
+// Indexed select reports its latency difference with the not indexed one, so it's negative if the index speeds selects up.
+func (dtuc *databaseTesterUsecase) testTableIndex(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tableName string, columns *workloadColumns, testPrefix string) {
+	// Filter column values are random in [0, 255), so every select matches about 1/255 of the rows
+	selectByFilter := func() error {
+		return r.SelectByConditions(tableName, columns.filter+"="+strconv.Itoa(dtuc.rand.Intn(255)))
+	}
+	column := strings.ToUpper(columns.filter[:1]) + columns.filter[1:]
+
+	scanLr, err := dtuc.collectComparedStep(mcuc, "selectBy"+column+testPrefix+"Table", INDEX_SELECTS_COUNT, nil, selectByFilter)
+	if err != nil {
+		logrus.WithError(err).Warn("couldn't select by not indexed column")
+		return
+	}
+
+	step := &domain.TestCaseStep{Name: "create" + column + "Index" + testPrefix + "Table", StepFunc: func() error {
+		return r.CreateIndex(tableName, INDEX_NAME, []string{columns.filter}, false)
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("couldn't create index")
+		return
+	}
+
+	if _, err := dtuc.collectComparedStep(mcuc, "selectBy"+column+testPrefix+"IndexedTable", INDEX_SELECTS_COUNT, scanLr, selectByFilter); err != nil {
+		logrus.WithError(err).Warn("couldn't select by indexed column")
+	}
+
+	step = &domain.TestCaseStep{Name: "drop" + column + "Index" + testPrefix + "Table", StepFunc: func() error { return r.DropIndex(tableName, INDEX_NAME) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("couldn't drop index")
+	}
+}
+
+// Method runs operations sequentially and adds latency difference with the base operations if they are set
+func (dtuc *databaseTesterUsecase) collectComparedStep(mcuc metrics_collector.MetricsCollectorUsecase, name string, requestsCount int, baseLr *helpers.LoadResult, operation func() error) (*helpers.LoadResult, error) {
+	var lr *helpers.LoadResult
+
+	step := &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = helpers.RunLoad(requestsCount, 1, operation)
+		if lr.Errors != 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		if baseLr != nil {
+			tcsra.AddMetric(domain.MetricMeta_AddedLatencyP50, lr.Quantile(0.5)-baseLr.Quantile(0.5))
+			tcsra.AddMetric(domain.MetricMeta_AddedLatencyP99, lr.Quantile(0.99)-baseLr.Quantile(0.99))
+		}
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil, err
+	}
+
+	return lr, nil
+}
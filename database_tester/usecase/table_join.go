@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	RELATED_TABLE_NAME = "test_related_table"
+	// Rows of the test table reference related rows by group column values, which are random in [0, 255),
+	// so about half of them have no related row and are kept only by the left join
+	RELATED_TABLE_ROWS_COUNT = 128
+)
+
+var relatedTableFields = []string{
+	"id BIGINT PRIMARY KEY",
+	"weight INTEGER",
+}
+
+// Method creates related table, which rows are referenced by the rows of the test table
+func (dtuc *databaseTesterUsecase) createRelatedTable(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) error {
+	tableName := dtuc.tableName(RELATED_TABLE_NAME)
+
+	step := &domain.TestCaseStep{Name: "createRelatedTable", StepFunc: func() error { return r.CreateTable(tableName, relatedTableFields) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	values := make([]map[string]interface{}, RELATED_TABLE_ROWS_COUNT)
+	for i := range values {
+		values[i] = map[string]interface{}{"id": int64(i), "weight": i}
+	}
+	step = &domain.TestCaseStep{Name: strconv.Itoa(RELATED_TABLE_ROWS_COUNT) + "xInsertRelatedTable", StepFunc: func() error {
+		return r.Insert(tableName, []string{"id", "weight"}, values)
+	}}
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Method selects rows of the populated test table joined with the related table and aggregated by the related rows
+func (dtuc *databaseTesterUsecase) testTableJoin(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tableName string, columns *workloadColumns, testPrefix string) {
+	relatedTableName := dtuc.tableName(RELATED_TABLE_NAME)
+
+	for _, joinType := range domain.JoinTypes {
+		joinType := joinType
+		step := &domain.TestCaseStep{Name: string(joinType) + "Join" + testPrefix + "Table", StepFunc: func() error {
+			return r.SelectJoin(tableName, columns.group, relatedTableName, joinType)
+		}}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).WithField("joinType", joinType).Warn("couldn't select joined rows")
+		}
+	}
+
+	step := &domain.TestCaseStep{Name: "joinAggregation" + testPrefix + "Table", StepFunc: func() error {
+		return r.SelectJoinAggregation(tableName, columns.group, relatedTableName, columns.joinAggregated)
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("couldn't select joined rows aggregation")
+	}
+}
+
+// Method drops related table of the test table
+func (dtuc *databaseTesterUsecase) dropRelatedTable(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) error {
+	step := &domain.TestCaseStep{Name: "dropRelatedTable", StepFunc: func() error { return r.DropTable(dtuc.tableName(RELATED_TABLE_NAME)) }}
+	return mcuc.CollectStepMetrics(step)
+}
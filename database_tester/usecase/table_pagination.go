@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Offset pages are read by skipping all the previous rows, so they are compared on the large tables only
+	PAGINATION_MIN_ROWS_COUNT = 100000
+	PAGE_SIZE                 = 100
+)
+
+// Method compares selects of the middle and the last pages of the populated test table by OFFSET and by the last id of the previous page
+func (dtuc *databaseTesterUsecase) testTablePagination(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tableName string, testPrefix string, dataCount int) {
+	if dataCount < PAGINATION_MIN_ROWS_COUNT {
+		return
+	}
+
+	dtuc.testTablePage(mcuc, r, tableName, "Middle"+testPrefix+"Table", dataCount/2)
+	dtuc.testTablePage(mcuc, r, tableName, "Last"+testPrefix+"Table", dataCount-PAGE_SIZE)
+}
+
+func (dtuc *databaseTesterUsecase) testTablePage(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tableName string, name string, offset int) {
+	var offsetLastId, keysetLastId int64
+
+	step := &domain.TestCaseStep{Name: "offsetPage" + name, StepFunc: func() (err error) {
+		offsetLastId, err = r.SelectPage(tableName, offset, PAGE_SIZE)
+		return err
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).WithField("offset", offset).Warn("couldn't select page by offset")
+		return
+	}
+
+	// Client keeps the last id of the previous page, so it's selected before the measured step
+	previousLastId, err := r.SelectPage(tableName, offset-PAGE_SIZE, PAGE_SIZE)
+	if err != nil {
+		logrus.WithError(err).WithField("offset", offset).Warn("couldn't select previous page")
+		return
+	}
+
+	step = &domain.TestCaseStep{Name: "keysetPage" + name, StepFunc: func() (err error) {
+		keysetLastId, err = r.SelectPageAfterId(tableName, previousLastId, PAGE_SIZE)
+		return err
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).WithField("offset", offset).Warn("couldn't select page by last id")
+		return
+	}
+
+	// Both pages end with the same row, if rows ids aren't changed between the selects
+	if keysetLastId != offsetLastId {
+		logrus.WithFields(logrus.Fields{"offset": offsetLastId, "keyset": keysetLastId}).Warn(domain.DATA_INTEGRITY_VIOLATED)
+	}
+}
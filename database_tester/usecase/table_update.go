@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// Method updates the single row by id and the rows matching conditions of the populated table.
+// Load rate of the bulk update is the updated rows per second.
+func (dtuc *databaseTesterUsecase) testTableUpdate(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tableName string, tableFields []string, columns *workloadColumns, testPrefix string, dataCount int) {
+	step := &domain.TestCaseStep{Name: "updateById" + testPrefix + "Table", StepFunc: func() error {
+		return r.UpdateById(tableName, dataCount/2, columns.update, dtuc.generateTableRow(tableFields))
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("couldn't update row by id")
+	}
+
+	var (
+		updated  int64
+		duration time.Duration
+	)
+	step = &domain.TestCaseStep{Name: "updateByConditions" + testPrefix + "Table", StepFunc: func() error {
+		startTime := time.Now()
+		n, err := r.UpdateByConditions(tableName, columns.halfRowsConditions(), columns.update, dtuc.generateTableRow(tableFields))
+		if err != nil {
+			return err
+		}
+		duration = time.Since(startTime)
+		updated = n
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_LoadRate, float64(updated)/duration.Seconds())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("couldn't update rows by conditions")
+	}
+}
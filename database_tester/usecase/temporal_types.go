@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const TEMPORAL_ROWS_COUNT = 10000
+
+var (
+	// Generated temporal values are in range [temporalRangeStart, temporalRangeStart + temporalRangeYears)
+	temporalRangeStart = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	temporalRangeYears = 30
+)
+
+var temporalTableFields = []string{
+	"id BIGSERIAL PRIMARY KEY",
+	"d DATE",
+	"ts TIMESTAMP",
+	"tstz TIMESTAMPTZ",
+	"iv INTERVAL",
+}
+
+var temporalSelectConditions = map[string]string{
+	"Date":        "d BETWEEN '2010-01-01' AND '2015-01-01'",
+	"Timestamp":   "ts BETWEEN '2010-01-01 00:00:00' AND '2010-02-01 00:00:00'",
+	"TimestampTz": "tstz BETWEEN '2010-01-01 00:00:00+00' AND '2010-02-01 00:00:00+00'",
+	"Interval":    "iv > INTERVAL '10 days'",
+}
+
+var timestampTzColumn = roundTripColumn{
+	Type:    "TIMESTAMPTZ",
+	NewDest: func() interface{} { return new(time.Time) },
+	Equal: func(expected interface{}, dest interface{}) bool {
+		return dest.(*time.Time).Equal(expected.(time.Time))
+	},
+}
+
+// Wall clock is expected to be kept as is, because TIMESTAMP doesn't store time zone
+var timestampColumn = roundTripColumn{
+	Type:    "TIMESTAMP",
+	NewDest: func() interface{} { return new(time.Time) },
+	Equal: func(expected interface{}, dest interface{}) bool {
+		e := expected.(time.Time)
+		return dest.(*time.Time).Equal(time.Date(e.Year(), e.Month(), e.Day(), e.Hour(), e.Minute(), e.Second(), e.Nanosecond(), time.UTC))
+	},
+}
+
+var timeZonesCases = []roundTripCase{
+	{Name: "utc", Value: time.Date(2021, time.March, 28, 1, 30, 0, 0, time.UTC)},
+	{Name: "positiveOffset", Value: time.Date(2021, time.March, 28, 1, 30, 0, 0, time.FixedZone("UTC+5:45", 5*3600+45*60))},
+	{Name: "negativeOffset", Value: time.Date(2021, time.March, 28, 1, 30, 0, 0, time.FixedZone("UTC-9:30", -(9*3600+30*60)))},
+	{Name: "dayBoundary", Value: time.Date(2021, time.December, 31, 23, 59, 59, 0, time.FixedZone("UTC+14", 14*3600))},
+	{Name: "microseconds", Value: time.Date(2021, time.June, 1, 12, 0, 0, 123456000, time.FixedZone("UTC+3", 3*3600))},
+}
+
+// Method benchmarks inserts and range selects on temporal columns and verifies time zones round-trips
+func (dtuc *databaseTesterUsecase) testTemporalTypes(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) {
+	tableName := dtuc.tableName("temporal_table")
+
+	if err := dtuc.testRoundTrip(mcuc, r, "timestampTzRoundTrip", timestampTzColumn, timeZonesCases); err != nil {
+		logrus.WithError(err).Warn("couldn't test timestamp with time zone round trip")
+	}
+	if err := dtuc.testRoundTrip(mcuc, r, "timestampRoundTrip", timestampColumn, timeZonesCases); err != nil {
+		logrus.WithError(err).Warn("couldn't test timestamp round trip")
+	}
+
+	step := &domain.TestCaseStep{Name: "createTemporalTable", StepFunc: func() error { return r.CreateTable(tableName, temporalTableFields) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return
+	}
+	defer r.DropTable(tableName)
+
+	step = &domain.TestCaseStep{Name: "insertTemporalTable", StepFunc: func() error {
+		for i := TEMPORAL_ROWS_COUNT / 1000; i > 0; i-- {
+			if err := r.Insert(tableName, []string{"d", "ts", "tstz", "iv"}, dtuc.generateTemporalData(1000)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return
+	}
+
+	for name, conditions := range temporalSelectConditions {
+		conditions := conditions
+		step = &domain.TestCaseStep{Name: "selectRangeBy" + name, StepFunc: func() error { return r.SelectByConditions(tableName, conditions) }}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return
+		}
+	}
+}
+
+func (dtuc *databaseTesterUsecase) generateTemporalData(count int) []map[string]interface{} {
+	var values []map[string]interface{}
+
+	for i := 0; i < count; i++ {
+		values = append(values, map[string]interface{}{
+			"d":    dtuc.generateDate(),
+			"ts":   dtuc.generateTimestamp(),
+			"tstz": dtuc.generateTimestampTz(),
+			"iv":   dtuc.generateInterval(),
+		})
+	}
+
+	return values
+}
+
+// Method generates date without time part
+func (dtuc *databaseTesterUsecase) generateDate() time.Time {
+	return temporalRangeStart.AddDate(0, 0, dtuc.rand.Intn(temporalRangeYears*365))
+}
+
+// Method generates timestamp with microseconds precision without time zone
+func (dtuc *databaseTesterUsecase) generateTimestamp() time.Time {
+	return temporalRangeStart.Add(time.Duration(dtuc.rand.Int63n(int64(temporalRangeYears) * 365 * 24 * int64(time.Hour)))).Truncate(time.Microsecond)
+}
+
+// Method generates timestamp in random time zone with quarter hour offset
+func (dtuc *databaseTesterUsecase) generateTimestampTz() time.Time {
+	offset := (dtuc.rand.Intn(26*4+1) - 12*4) * 15 * 60
+	return dtuc.generateTimestamp().In(time.FixedZone("", offset))
+}
+
+// Method generates interval in the postgres format
+func (dtuc *databaseTesterUsecase) generateInterval() string {
+	seconds := dtuc.rand.Intn(24 * 3600)
+	return fmt.Sprintf("%d days %02d:%02d:%02d", dtuc.rand.Intn(30), seconds/3600, seconds%3600/60, seconds%60)
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	ANOMALIES_WORKERS_COUNT         = 8
+	ANOMALIES_OPERATIONS_PER_WORKER = 50
+	ANOMALIES_ACCOUNTS_COUNT        = 10
+	ANOMALIES_ACCOUNT_BALANCE       = 1000
+)
+
+var anomaliesTableFields = []string{
+	"id BIGINT PRIMARY KEY",
+	"value BIGINT",
+}
+
+// Method runs Jepsen-lite workloads under every isolation level and reports found anomalies as metrics
+func (dtuc *databaseTesterUsecase) testTransactionAnomalies(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) {
+	for _, isolationLevel := range domain.IsolationLevels {
+		suffix := strings.ToUpper(string(isolationLevel[:1])) + string(isolationLevel[1:])
+
+		if err := dtuc.testConcurrentCounters(mcuc, r, isolationLevel, suffix); err != nil {
+			logrus.WithError(err).WithField("isolationLevel", isolationLevel).Debug("concurrent counters test failed")
+		}
+		if err := dtuc.testBankTransfers(mcuc, r, isolationLevel, suffix); err != nil {
+			logrus.WithError(err).WithField("isolationLevel", isolationLevel).Debug("bank transfers test failed")
+		}
+		if err := dtuc.testWriteSkew(mcuc, r, isolationLevel, suffix); err != nil {
+			logrus.WithError(err).WithField("isolationLevel", isolationLevel).Debug("write skew test failed")
+		}
+	}
+}
+
+// Concurrent read-modify-write increments of the single counter. Every committed increment missing in the result is a lost update.
+func (dtuc *databaseTesterUsecase) testConcurrentCounters(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, isolationLevel domain.IsolationLevel, suffix string) error {
+	tableName := dtuc.tableName("anomalies_counters")
+
+	if err := dtuc.createAnomaliesTable(r, tableName, 1, 0); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	var committed, aborted int64
+	step := &domain.TestCaseStep{Name: "concurrentCounters" + suffix, StepFunc: func() error {
+		dtuc.runAnomaliesWorkers(func(rnd *rand.Rand) {
+			if err := r.UpdateInTransaction(tableName, "value", map[int64]int64{1: 1}, isolationLevel); err != nil {
+				atomic.AddInt64(&aborted, 1)
+			} else {
+				atomic.AddInt64(&committed, 1)
+			}
+		})
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		value, err := r.SumColumn(tableName, "value")
+		if err != nil {
+			tcsra.AddError(err.Error())
+			return
+		}
+		tcsra.AddMetric(domain.MetricMeta_LostUpdates, float64(committed-value))
+		tcsra.AddMetric(domain.MetricMeta_AbortedTransactions, float64(aborted))
+	}}
+
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Concurrent transfers between accounts. Total balance must stay the same.
+func (dtuc *databaseTesterUsecase) testBankTransfers(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, isolationLevel domain.IsolationLevel, suffix string) error {
+	tableName := dtuc.tableName("anomalies_accounts")
+
+	if err := dtuc.createAnomaliesTable(r, tableName, ANOMALIES_ACCOUNTS_COUNT, ANOMALIES_ACCOUNT_BALANCE); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	var aborted int64
+	step := &domain.TestCaseStep{Name: "bankTransfers" + suffix, StepFunc: func() error {
+		dtuc.runAnomaliesWorkers(func(rnd *rand.Rand) {
+			from := rnd.Int63n(ANOMALIES_ACCOUNTS_COUNT) + 1
+			to := rnd.Int63n(ANOMALIES_ACCOUNTS_COUNT-1) + 1
+			if to >= from {
+				to++
+			}
+			amount := rnd.Int63n(100) + 1
+
+			if err := r.UpdateInTransaction(tableName, "value", map[int64]int64{from: -amount, to: amount}, isolationLevel); err != nil {
+				atomic.AddInt64(&aborted, 1)
+			}
+		})
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		total, err := r.SumColumn(tableName, "value")
+		if err != nil {
+			tcsra.AddError(err.Error())
+			return
+		}
+		var violations float64
+		if total != ANOMALIES_ACCOUNTS_COUNT*ANOMALIES_ACCOUNT_BALANCE {
+			violations = 1
+		}
+		tcsra.AddMetric(domain.MetricMeta_InvariantViolations, violations)
+		tcsra.AddMetric(domain.MetricMeta_AbortedTransactions, float64(aborted))
+	}}
+
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Two accounts share the constraint that their total is non negative.
+// Concurrent withdrawals from different accounts which both see enough total produce write skew.
+func (dtuc *databaseTesterUsecase) testWriteSkew(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, isolationLevel domain.IsolationLevel, suffix string) error {
+	tableName := dtuc.tableName("anomalies_write_skew")
+
+	if err := r.CreateTable(tableName, anomaliesTableFields); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	var skews, aborted int64
+	step := &domain.TestCaseStep{Name: "writeSkew" + suffix, StepFunc: func() error {
+		for i := 0; i < ANOMALIES_OPERATIONS_PER_WORKER; i++ {
+			if err := r.TruncateTable(tableName); err != nil {
+				return err
+			}
+			if err := r.Insert(tableName, []string{"id", "value"}, dtuc.generateAnomaliesData(2, ANOMALIES_ACCOUNT_BALANCE/2)); err != nil {
+				return err
+			}
+
+			var wg sync.WaitGroup
+			start := make(chan struct{})
+			for id := int64(1); id <= 2; id++ {
+				wg.Add(1)
+				go func(id int64) {
+					defer wg.Done()
+					<-start
+					if _, err := r.WithdrawInTransaction(tableName, "value", id, ANOMALIES_ACCOUNT_BALANCE, isolationLevel); err != nil {
+						atomic.AddInt64(&aborted, 1)
+					}
+				}(id)
+			}
+			close(start)
+			wg.Wait()
+
+			total, err := r.SumColumn(tableName, "value")
+			if err != nil {
+				return err
+			}
+			if total < 0 {
+				skews++
+			}
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_WriteSkews, float64(skews))
+		tcsra.AddMetric(domain.MetricMeta_AbortedTransactions, float64(aborted))
+	}}
+
+	return mcuc.CollectStepMetrics(step)
+}
+
+func (dtuc *databaseTesterUsecase) createAnomaliesTable(r repository.DatabaseTesterRepository, tableName string, rowsCount int, value int64) error {
+	if err := r.CreateTable(tableName, anomaliesTableFields); err != nil {
+		return err
+	}
+	return r.Insert(tableName, []string{"id", "value"}, dtuc.generateAnomaliesData(rowsCount, value))
+}
+
+func (dtuc *databaseTesterUsecase) generateAnomaliesData(count int, value int64) []map[string]interface{} {
+	var values []map[string]interface{}
+	for i := 1; i <= count; i++ {
+		values = append(values, map[string]interface{}{"id": i, "value": value})
+	}
+	return values
+}
+
+// Method runs operation concurrently in the workers and waits for all of them
+func (dtuc *databaseTesterUsecase) runAnomaliesWorkers(operation func(rnd *rand.Rand)) {
+	var wg sync.WaitGroup
+	for w := 0; w < ANOMALIES_WORKERS_COUNT; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for i := 0; i < ANOMALIES_OPERATIONS_PER_WORKER; i++ {
+				operation(rnd)
+			}
+		}(dtuc.rand.Int63())
+	}
+	wg.Wait()
+}
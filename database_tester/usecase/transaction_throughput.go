@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"strconv"
+	"sync/atomic"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+const TRANSACTION_THROUGHPUT_TABLE_NAME = "transaction_throughput_table"
+
+var (
+	transactionThroughputTableFields = []string{"id BIGINT PRIMARY KEY", "f1 BIGINT"}
+	transactionThroughputColumns     = []string{"id", "f1"}
+)
+
+// Method runs small transactions inserting the row and updating it, which are committed and then rolled back.
+// Throughput is transactions per second, so it shows the commit overhead compared with the autocommit statements.
+func (dtuc *databaseTesterUsecase) testTransactionThroughput(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase) error {
+	transactionsCount := int(tc.GetTransactionsCount())
+	testPrefix := strconv.FormatInt(int64(transactionsCount), 10) + "x"
+	tableName := dtuc.tableName(TRANSACTION_THROUGHPUT_TABLE_NAME)
+
+	if err := r.CreateTable(tableName, transactionThroughputTableFields); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	// Ids are shared by the concurrent clients, so every transaction inserts its own row
+	var lastId int64
+	step := dtuc.createTransactionsStep(testPrefix+"CommittedTransaction", tableName, r, transactionsCount, int(tc.GetConcurrency()), &lastId, func(tx repository.DatabaseTesterTransaction) error {
+		return tx.Commit()
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+	if err := dtuc.checkRowsCount(r, tableName, int64(transactionsCount)); err != nil {
+		return err
+	}
+
+	step = dtuc.createTransactionsStep(testPrefix+"RolledBackTransaction", tableName, r, transactionsCount, int(tc.GetConcurrency()), &lastId, func(tx repository.DatabaseTesterTransaction) error {
+		return tx.Rollback()
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+	return dtuc.checkRowsCount(r, tableName, int64(transactionsCount))
+}
+
+// Method creates step running transactions, which insert the row, update it and are finished by the end function
+func (dtuc *databaseTesterUsecase) createTransactionsStep(name string, tableName string, r repository.DatabaseTesterRepository, transactionsCount int, concurrency int, lastId *int64,
+	end func(tx repository.DatabaseTesterTransaction) error) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = helpers.RunLoad(transactionsCount, concurrency, func() error {
+			id := int(atomic.AddInt64(lastId, 1))
+
+			tx, err := r.BeginTransaction()
+			if err != nil {
+				return err
+			}
+			if err := tx.Insert(tableName, transactionThroughputColumns, []map[string]interface{}{{"id": id, "f1": dtuc.rand.Int63()}}); err != nil {
+				tx.Rollback()
+				return err
+			}
+			if err := tx.UpdateById(tableName, id, []string{"f1"}, map[string]interface{}{"f1": dtuc.rand.Int63()}); err != nil {
+				tx.Rollback()
+				return err
+			}
+			return end(tx)
+		})
+		if lr.Errors != 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+	}}
+}
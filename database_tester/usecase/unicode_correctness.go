@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+var unicodeCases = []roundTripCase{
+	{Name: "ascii", Value: "plain ascii text"},
+	{Name: "emoji", Value: "😀🚀👨‍👩‍👧‍👦🏳️‍🌈"},
+	{Name: "cyrillic", Value: "Съешь же ещё этих мягких французских булок"},
+	{Name: "cjk", Value: "日本語の文字列と中文字符串와 한국어"},
+	{Name: "rtl", Value: "שלום עולם مرحبا بالعالم"},
+	{Name: "combining", Value: "é ä ñ"},
+	{Name: "supplementaryPlanes", Value: "𝄞𐍈𠜎"},
+	{Name: "controlChars", Value: "tab\there\nnew line\rcarriage\x01\x1f\x7f"},
+	{Name: "nullByte", Value: "before\x00after"},
+	{Name: "quotes", Value: `'single' "double" \backslash`},
+	{Name: "empty", Value: ""},
+	{Name: "long", Value: strings.Repeat("long string ✓ ", 1<<16)},
+}
+
+var unicodeColumn = roundTripColumn{
+	Type:    "TEXT",
+	NewDest: func() interface{} { return new(string) },
+	Equal: func(expected interface{}, dest interface{}) bool {
+		return *dest.(*string) == expected.(string)
+	},
+}
+
+// Method checks that multi-byte, very long and control characters strings are returned as they were inserted
+func (dtuc *databaseTesterUsecase) testUnicodeCorrectness(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) error {
+	return dtuc.testRoundTrip(mcuc, r, "unicodeRoundTrip", unicodeColumn, unicodeCases)
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+const (
+	UPSERT_TABLE_NAME = "upsert_table"
+	// Rows written by the upsert step, which conflicting ones update the existing rows
+	UPSERT_ROWS_COUNT = 10000
+)
+
+var (
+	upsertConflictRates = []float64{0, 0.1, 0.5, 1}
+	upsertTableFields   = []string{"id BIGINT PRIMARY KEY", "f1 BIGINT"}
+)
+
+// Method upserts rows by the batches, where share of the keys exists in the table, for every conflict rate.
+// Table is populated with the existing rows again before every step, so steps differ by the conflict rate only.
+func (dtuc *databaseTesterUsecase) testUpsert(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository) error {
+	tableName := dtuc.tableName(UPSERT_TABLE_NAME)
+
+	if err := r.CreateTable(tableName, upsertTableFields); err != nil {
+		return err
+	}
+	defer r.DropTable(tableName)
+
+	for _, conflictRate := range upsertConflictRates {
+		if err := r.TruncateTable(tableName); err != nil {
+			return err
+		}
+		existingIds := make([]int64, UPSERT_ROWS_COUNT)
+		for i := range existingIds {
+			existingIds[i] = int64(i)
+		}
+		if err := dtuc.writeUpsertRows(existingIds, func(values []map[string]interface{}) error {
+			return r.Insert(tableName, []string{"id", "f1"}, values)
+		}); err != nil {
+			return err
+		}
+
+		// Batch can't contain the same key twice, so conflicting keys are the first existing ids and other keys are new
+		conflicts := int(conflictRate * UPSERT_ROWS_COUNT)
+		ids := dtuc.createUpsertIds(conflicts)
+
+		var duration time.Duration
+		step := &domain.TestCaseStep{Name: strconv.FormatFloat(conflictRate*100, 'f', -1, 64) + "PercentConflictsUpsert", StepFunc: func() error {
+			startTime := time.Now()
+			if err := dtuc.writeUpsertRows(ids, func(values []map[string]interface{}) error {
+				return r.Upsert(tableName, []string{"id"}, []string{"f1"}, values)
+			}); err != nil {
+				return err
+			}
+			duration = time.Since(startTime)
+			return nil
+		}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+			tcsra.AddMetric(domain.MetricMeta_LoadRate, UPSERT_ROWS_COUNT/duration.Seconds())
+			tcsra.AddMetric(domain.MetricMeta_Conflicts, float64(conflicts))
+		}}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+		if err := dtuc.checkRowsCount(r, tableName, int64(2*UPSERT_ROWS_COUNT-conflicts)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Method creates shuffled ids of the upserted rows, where conflicts ids exist in the table populated by ids from 0
+func (dtuc *databaseTesterUsecase) createUpsertIds(conflicts int) []int64 {
+	ids := make([]int64, UPSERT_ROWS_COUNT)
+	for i := range ids {
+		if i < conflicts {
+			ids[i] = int64(i)
+		} else {
+			ids[i] = int64(UPSERT_ROWS_COUNT + i)
+		}
+	}
+	dtuc.rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+
+	return ids
+}
+
+// Method writes rows of the ids by the chunks
+func (dtuc *databaseTesterUsecase) writeUpsertRows(ids []int64, write func(values []map[string]interface{}) error) error {
+	for offset := 0; offset < len(ids); offset += INSERT_CHUNK_SIZE {
+		end := offset + INSERT_CHUNK_SIZE
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		values := make([]map[string]interface{}, 0, end-offset)
+		for _, id := range ids[offset:end] {
+			values = append(values, map[string]interface{}{"id": id, "f1": dtuc.rand.Int63()})
+		}
+		if err := write(values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
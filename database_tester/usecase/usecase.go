@@ -1,33 +1,44 @@
 package usecase
 
 import (
+	"context"
 	"math/rand"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
 	"github.com/iakrevetkho/components-tests/cott/database_tester/repository"
 	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
 	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
 	"github.com/sirupsen/logrus"
 )
 
-const (
-	DATABASE_NAME = "cott_db"
-)
-
 type DatabaseTesterUsecase interface {
 	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
 }
 
 type databaseTesterUsecase struct {
+	// Names of the current test case
 	databaseName string
-	cluc         container_launcher.ContainerLauncherUsecase
+	tablePrefix  string
+	nameSuffix   string
+	// Rows count of the single insert of the table data of the current test case
+	insertBatchSize int
+	// Source of the data generated by all the workloads of the current test case, which is seeded by the test case seed
+	rand *rand.Rand
+	// Unique ID of the run, which is appended to the names of the test cases with unique names
+	runId string
+	// Time to await database responding on ping after the start of the current test case
+	startupTimeout time.Duration
+	cluc           container_launcher.ContainerLauncherUsecase
 }
 
 func NewDatabaseTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) DatabaseTesterUsecase {
 	dtuc := new(databaseTesterUsecase)
-	dtuc.databaseName = DATABASE_NAME
+	dtuc.runId = strconv.FormatInt(time.Now().UnixNano(), 36)
 	dtuc.cluc = cluc
 	return dtuc
 }
@@ -35,7 +46,18 @@ func NewDatabaseTesterUsecase(cluc container_launcher.ContainerLauncherUsecase)
 func (dtuc *databaseTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
 	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
 
-	r, err := dtuc.createDatabaseRepository(tcra.TestCase)
+	dtuc.setNames(tcra.TestCase)
+	dtuc.insertBatchSize = int(tcra.TestCase.GetInsertBatchSize())
+	seed := tcra.TestCase.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	dtuc.rand = newLockedRand(seed)
+	tcra.SetSeed(seed)
+	logrus.WithField("seed", seed).Info("generate table data")
+	dtuc.startupTimeout = tcra.TestCase.GetStartupTimeout()
+
+	r, err := dtuc.createDatabaseRepository(tcra.TestCase, tcra.TestCase.Port)
 	if err != nil {
 		return err
 	}
@@ -48,17 +70,9 @@ func (dtuc *databaseTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulat
 	}
 
 	// Await for DB ready
-	step = &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
-		// await 30 second
-		for i := 0; i < 300; i++ {
-			if err := r.Ping(); err != nil {
-				time.Sleep(100 * time.Millisecond)
-			} else {
-				// Success
-				return nil
-			}
-		}
-		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	step = &domain.TestCaseStep{Name: "startUp", StepFunc: func() error { return dtuc.awaitDatabaseReady(r) }, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		dtuc.addConnectionSetupMetrics(tcsra, r)
+		dtuc.addBootstrapMetrics(tcsra, r)
 	}}
 	if err := mcuc.CollectStepMetrics(step); err != nil {
 		logrus.WithError(err).Debug("couldn't ping database")
@@ -79,7 +93,160 @@ func (dtuc *databaseTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulat
 		return nil
 	}
 
-	dtuc.testTable(mcuc, r, containerId)
+	dtuc.testTable(mcuc, r, tcra.TestCase, containerId)
+
+	if tcra.TestCase.HasWorkload(domain.Workload_CrashRecovery) {
+		dtuc.testCrashRecovery(mcuc, r, tcra, containerId)
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_TransactionAnomalies) {
+		dtuc.testTransactionAnomalies(mcuc, r)
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_UnicodeCorrectness) {
+		if err := dtuc.testUnicodeCorrectness(mcuc, r); err != nil {
+			logrus.WithError(err).Warn("couldn't test unicode correctness")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_BoundaryValues) {
+		dtuc.testBoundaryValues(mcuc, r)
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_TemporalTypes) {
+		dtuc.testTemporalTypes(mcuc, r)
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_NumericPrecision) {
+		dtuc.testNumericPrecision(mcuc, r)
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_KeyConflicts) {
+		if err := dtuc.testKeyConflicts(mcuc, r, tcra.TestCase.GetConflictRate()); err != nil {
+			logrus.WithError(err).Warn("couldn't test key conflicts")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_OrmOverhead) {
+		// Table name is fixed in the sqlc generated queries, so concurrent runs would clobber it
+		if tcra.TestCase.UniqueNames {
+			logrus.Warn("ORM overhead workload is skipped, because its table name can't be unique")
+		} else if err := dtuc.testOrmOverhead(mcuc, r); err != nil {
+			logrus.WithError(err).Warn("couldn't test ORM overhead")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_MultiDatabase) {
+		dtuc.testMultiDatabase(mcuc, r, tcra.TestCase)
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_MultiSchema) {
+		dtuc.testMultiSchema(mcuc, r)
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_GiantTransaction) {
+		if err := dtuc.testGiantTransaction(mcuc, r, tcra.TestCase); err != nil {
+			logrus.WithError(err).Warn("couldn't test giant transaction")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_TransactionThroughput) {
+		if err := dtuc.testTransactionThroughput(mcuc, r, tcra.TestCase); err != nil {
+			logrus.WithError(err).Warn("couldn't test transaction throughput")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_ConcurrentClients) {
+		if err := dtuc.testConcurrentClients(mcuc, r, tcra.TestCase); err != nil {
+			logrus.WithError(err).Warn("couldn't test concurrent clients")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_PreparedStatements) {
+		if err := dtuc.testPreparedStatements(mcuc, r); err != nil {
+			logrus.WithError(err).Warn("couldn't test prepared statements")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_CopyFrom) {
+		if err := dtuc.testCopyFrom(mcuc, r); err != nil {
+			logrus.WithError(err).Warn("couldn't test copy from")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_Upsert) {
+		if err := dtuc.testUpsert(mcuc, r); err != nil {
+			logrus.WithError(err).Warn("couldn't test upsert")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_Jsonb) {
+		if err := dtuc.testJsonb(mcuc, r); err != nil {
+			logrus.WithError(err).Warn("couldn't test jsonb")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_FullTextSearch) {
+		if err := dtuc.testFullTextSearch(mcuc, r); err != nil {
+			logrus.WithError(err).Warn("couldn't test full-text search")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_LargeBinary) {
+		if err := dtuc.testLargeBinary(mcuc, r); err != nil {
+			logrus.WithError(err).Warn("couldn't test large binary")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_ShardedInsert) {
+		if err := dtuc.testShardedInsert(mcuc, r, tcra.TestCase); err != nil {
+			logrus.WithError(err).Warn("couldn't test sharded insert")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_ClusterTopology) {
+		if err := dtuc.testClusterTopology(tcra, containerId); err != nil {
+			logrus.WithError(err).Warn("couldn't test cluster topology")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_Saturation) {
+		if err := dtuc.testSaturation(mcuc, r, tcra.TestCase); err != nil {
+			logrus.WithError(err).Warn("couldn't test saturation")
+		}
+	}
+
+	if len(tcra.TestCase.SqlSteps) > 0 {
+		if err := dtuc.testSqlSteps(mcuc, r, tcra.TestCase); err != nil {
+			logrus.WithError(err).Warn("couldn't run SQL steps")
+		}
+	}
+
+	if tcra.TestCase.ScriptPath != "" {
+		if err := dtuc.testScript(mcuc, r, tcra.TestCase); err != nil {
+			logrus.WithError(err).Warn("couldn't run script")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_ReplicationLag) {
+		if err := dtuc.testReplicationLag(mcuc, r, tcra.TestCase); err != nil {
+			logrus.WithError(err).Warn("couldn't test replication lag")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_Hypertable) {
+		if err := dtuc.testHypertable(mcuc, r); err != nil {
+			logrus.WithError(err).Warn("couldn't test hypertable")
+		}
+	}
+
+	if tcra.TestCase.HasWorkload(domain.Workload_PgVector) {
+		if err := dtuc.testPgVector(mcuc, r, tcra.TestCase); err != nil {
+			logrus.WithError(err).Warn("couldn't test pgvector")
+		}
+	}
+
+	dtuc.checkTablesLeftovers(tcra, r)
 
 	if err := r.SwitchDatabase(""); err != nil {
 		return err
@@ -90,6 +257,8 @@ func (dtuc *databaseTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulat
 		return nil
 	}
 
+	dtuc.checkDatabasesLeftovers(tcra, r)
+
 	step = &domain.TestCaseStep{Name: "closeConnection", StepFunc: func() error { return r.Close() }}
 	if err := mcuc.CollectStepMetrics(step); err != nil {
 		return nil
@@ -98,57 +267,311 @@ func (dtuc *databaseTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulat
 	return nil
 }
 
-func (dtuc *databaseTesterUsecase) createDatabaseRepository(tc *domain.TestCase) (repository.DatabaseTesterRepository, error) {
+// Method sets database name and table name affixes of the test case.
+// Run ID suffix lets concurrent runs share the same server without clobbering each other.
+func (dtuc *databaseTesterUsecase) setNames(tc *domain.TestCase) {
+	dtuc.nameSuffix = ""
+	if tc.UniqueNames {
+		dtuc.nameSuffix = "_" + dtuc.runId
+	}
+	dtuc.databaseName = tc.GetDatabaseName() + dtuc.nameSuffix
+	dtuc.tablePrefix = tc.TablePrefix
+}
+
+// Method returns name of the table created by the test case
+func (dtuc *databaseTesterUsecase) tableName(name string) string {
+	return dtuc.tablePrefix + name + dtuc.nameSuffix
+}
+
+// Method reports tables which were created by the test case but weren't dropped
+func (dtuc *databaseTesterUsecase) checkTablesLeftovers(tcra *domain.TestCaseResultsAccumulator, r repository.DatabaseTesterRepository) {
+	tables, err := r.ListTables()
+	if err != nil {
+		logrus.WithError(err).Warn("couldn't list tables")
+		return
+	}
+
+	for _, table := range tables {
+		if !strings.HasPrefix(table, dtuc.tablePrefix) || !strings.HasSuffix(table, dtuc.nameSuffix) {
+			continue
+		}
+		logrus.WithField("table", table).Warn("table leftover found")
+		tcra.AddLeftover("table " + table)
+	}
+}
+
+// Method reports databases which were created by the test case but weren't dropped
+func (dtuc *databaseTesterUsecase) checkDatabasesLeftovers(tcra *domain.TestCaseResultsAccumulator, r repository.DatabaseTesterRepository) {
+	databases, err := r.ListDatabases()
+	if err != nil {
+		logrus.WithError(err).Warn("couldn't list databases")
+		return
+	}
+
+	for _, database := range databases {
+		if strings.HasPrefix(database, dtuc.databaseName) {
+			logrus.WithField("database", database).Warn("database leftover found")
+			tcra.AddLeftover("database " + database)
+		}
+	}
+}
+
+// Method awaits startup timeout until database will respond on ping
+func (dtuc *databaseTesterUsecase) awaitDatabaseReady(r repository.DatabaseTesterRepository) error {
+	return helpers.AwaitConnection(r.Ping, dtuc.startupTimeout)
+}
+
+// Method reports setup times of the connections opened by the driver to every node or shard
+func (dtuc *databaseTesterUsecase) addConnectionSetupMetrics(tcsra *domain.TestCaseStepResultsAccumulator, r repository.DatabaseTesterRepository) {
+	csr, ok := r.(repository.ConnectionSetupReporter)
+	if !ok {
+		return
+	}
+
+	times := csr.ConnectionSetupTimes()
+	if len(times) == 0 {
+		return
+	}
+	sort.Float64s(times)
+
+	tcsra.AddMetric(domain.MetricMeta_Connections, float64(len(times)))
+	tcsra.AddMetric(domain.MetricMeta_ConnectionSetupP50, times[len(times)/2])
+	tcsra.AddMetric(domain.MetricMeta_ConnectionSetupMax, times[len(times)-1])
+}
+
+// Method reports time of the storage bootstrap after the database has accepted the first connection
+func (dtuc *databaseTesterUsecase) addBootstrapMetrics(tcsra *domain.TestCaseStepResultsAccumulator, r repository.DatabaseTesterRepository) {
+	br, ok := r.(repository.BootstrapReporter)
+	if !ok {
+		return
+	}
+
+	if bootstrapTime := br.BootstrapTime(); bootstrapTime != 0 {
+		tcsra.AddMetric(domain.MetricMeta_TabletBootstrapTime, bootstrapTime)
+	}
+}
+
+// ProbeService executes readiness probe query by the new connection, so the probe doesn't depend on the test case connection
+func (dtuc *databaseTesterUsecase) ProbeService(tc *domain.TestCase) error {
+	r, err := dtuc.createDatabaseRepository(tc, tc.Port)
+	if err != nil {
+		return err
+	}
+
+	if err := r.Open(); err != nil {
+		return err
+	}
+	defer r.Close()
+
+	db := r.SqlDB()
+	if db == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	ctx, ctxCancelFunc := context.WithTimeout(context.Background(), helpers.PROBE_ATTEMPT_TIMEOUT)
+	defer ctxCancelFunc()
+	_, err = db.ExecContext(ctx, tc.ReadinessProbe.GetQuery())
+	return err
+}
+
+func (dtuc *databaseTesterUsecase) createDatabaseRepository(tc *domain.TestCase, port uint16) (repository.DatabaseTesterRepository, error) {
 	switch tc.ComponentType {
 
 	case domain.ComponentType_Postgres:
+		user, password, err := dtuc.getPostgresCredentials(tc)
+		if err != nil {
+			return nil, err
+		}
+
+		return repository.NewPostgresDatabaseTesterRepository(port, "localhost", user, password, tc.GetDriver(), tc.StorageEngine)
+
+	case domain.ComponentType_MySQL:
+		const MYSQL_ROOT_PASSWORD_ENV_VAR = "MYSQL_ROOT_PASSWORD"
+
+		// Root user is used, because the image's MYSQL_USER can't create databases
+		password, ok := tc.EnvVars[MYSQL_ROOT_PASSWORD_ENV_VAR]
+		if !ok {
+			err := domain.NewRequiredEnvVarError(MYSQL_ROOT_PASSWORD_ENV_VAR)
+			logrus.WithError(err).Error("couldn't create database repository")
+			return nil, err
+		}
+
+		return repository.NewMySQLDatabaseTesterRepository(port, "localhost", "root", password, tc.StorageEngine), nil
+
+	case domain.ComponentType_MariaDB:
+		const (
+			MARIADB_ROOT_PASSWORD_ENV_VAR = "MARIADB_ROOT_PASSWORD"
+			MYSQL_ROOT_PASSWORD_ENV_VAR   = "MYSQL_ROOT_PASSWORD"
+		)
+
+		// Image accepts MySQL env vars too
+		password, ok := tc.EnvVars[MARIADB_ROOT_PASSWORD_ENV_VAR]
+		if !ok {
+			password, ok = tc.EnvVars[MYSQL_ROOT_PASSWORD_ENV_VAR]
+		}
+		if !ok {
+			err := domain.NewRequiredEnvVarError(MARIADB_ROOT_PASSWORD_ENV_VAR)
+			logrus.WithError(err).Error("couldn't create database repository")
+			return nil, err
+		}
+
+		return repository.NewMariaDBDatabaseTesterRepository(port, "localhost", "root", password, tc.StorageEngine), nil
+
+	case domain.ComponentType_ClickHouse:
 		const (
-			POSTGRES_USER_ENV_VAR     = "POSTGRES_USER"
-			POSTGRES_PASSWORD_ENV_VAR = "POSTGRES_PASSWORD"
+			CLICKHOUSE_USER_ENV_VAR     = "CLICKHOUSE_USER"
+			CLICKHOUSE_PASSWORD_ENV_VAR = "CLICKHOUSE_PASSWORD"
 		)
 
-		// Get user from env vars
-		user, ok := tc.EnvVars[POSTGRES_USER_ENV_VAR]
+		// Image creates default user without password if env vars aren't set
+		user, ok := tc.EnvVars[CLICKHOUSE_USER_ENV_VAR]
 		if !ok {
-			logrus.WithField("envVarName", POSTGRES_USER_ENV_VAR).Error(domain.NO_REQUIRED_ENV_VAR_KEY)
-			return nil, domain.NO_REQUIRED_ENV_VAR_KEY
+			user = "default"
 		}
-		// Get password from env vars
-		password, ok := tc.EnvVars[POSTGRES_PASSWORD_ENV_VAR]
+		password := tc.EnvVars[CLICKHOUSE_PASSWORD_ENV_VAR]
+
+		return repository.NewClickHouseDatabaseTesterRepository(port, "localhost", user, password, tc.StorageEngine), nil
+
+	case domain.ComponentType_Mongo:
+		const (
+			MONGO_USER_ENV_VAR     = "MONGO_INITDB_ROOT_USERNAME"
+			MONGO_PASSWORD_ENV_VAR = "MONGO_INITDB_ROOT_PASSWORD"
+		)
+
+		// Image doesn't enable authentication if root user isn't set
+		return repository.NewMongoDatabaseTesterRepository(port, "localhost", tc.EnvVars[MONGO_USER_ENV_VAR], tc.EnvVars[MONGO_PASSWORD_ENV_VAR]), nil
+
+	case domain.ComponentType_Cassandra:
+		// Image doesn't enable authentication, so credentials are set only for the customized images
+		return repository.NewCassandraDatabaseTesterRepository(port, "localhost", tc.User, tc.Password, tc.StorageEngine), nil
+
+	case domain.ComponentType_ScyllaDB:
+		return repository.NewScyllaDBDatabaseTesterRepository(port, "localhost", tc.User, tc.Password, tc.StorageEngine), nil
+
+	case domain.ComponentType_CockroachDB:
+		// Insecure single node accepts root user without password
+		user := tc.User
+		if user == "" {
+			user = "root"
+		}
+
+		return repository.NewCockroachDBDatabaseTesterRepository(port, "localhost", user, tc.Password), nil
+
+	case domain.ComponentType_SQLite:
+		return repository.NewSQLiteDatabaseTesterRepository(tc.DataDir), nil
+
+	case domain.ComponentType_MSSQL:
+		const (
+			MSSQL_SA_PASSWORD_ENV_VAR = "MSSQL_SA_PASSWORD"
+			SA_PASSWORD_ENV_VAR       = "SA_PASSWORD"
+		)
+
+		// Image accepts deprecated env var too
+		password, ok := tc.EnvVars[MSSQL_SA_PASSWORD_ENV_VAR]
 		if !ok {
-			logrus.WithField("envVarName", POSTGRES_PASSWORD_ENV_VAR).Error(domain.NO_REQUIRED_ENV_VAR_KEY)
-			return nil, domain.NO_REQUIRED_ENV_VAR_KEY
+			password, ok = tc.EnvVars[SA_PASSWORD_ENV_VAR]
 		}
+		if !ok {
+			err := domain.NewRequiredEnvVarError(MSSQL_SA_PASSWORD_ENV_VAR)
+			logrus.WithError(err).Error("couldn't create database repository")
+			return nil, err
+		}
+
+		return repository.NewMSSQLDatabaseTesterRepository(port, "localhost", "sa", password), nil
+
+	case domain.ComponentType_Oracle:
+		const ORACLE_PASSWORD_ENV_VAR = "ORACLE_PASSWORD"
+
+		// Image sets password of the SYS and SYSTEM users
+		password, ok := tc.EnvVars[ORACLE_PASSWORD_ENV_VAR]
+		if !ok {
+			err := domain.NewRequiredEnvVarError(ORACLE_PASSWORD_ENV_VAR)
+			logrus.WithError(err).Error("couldn't create database repository")
+			return nil, err
+		}
+
+		return repository.NewOracleDatabaseTesterRepository(port, "localhost", "system", password), nil
 
-		return repository.NewPostgresDatabaseTesterRepository(tc.Port, "localhost", user, password), nil
+	case domain.ComponentType_TimescaleDB:
+		// Image is based on the Postgres one
+		user, password, err := dtuc.getPostgresCredentials(tc)
+		if err != nil {
+			return nil, err
+		}
+
+		return repository.NewTimescaleDBDatabaseTesterRepository(port, "localhost", user, password), nil
+
+	case domain.ComponentType_YugabyteDB:
+		// Image creates default user without password if authentication isn't enabled
+		user := tc.User
+		if user == "" {
+			user = "yugabyte"
+		}
+
+		return repository.NewYugabyteDBDatabaseTesterRepository(port, "localhost", user, tc.Password), nil
+
+	case domain.ComponentType_CrateDB:
+		// Superuser of the image authenticates by trust from any host
+		user := tc.User
+		if user == "" {
+			user = "crate"
+		}
+
+		return repository.NewCrateDBDatabaseTesterRepository(port, "localhost", user, tc.Password), nil
 
 	default:
-		return nil, domain.UNKNOWN_COMPONENT_FOR_TESTING
+		if factory, ok := repository.LookupDatabaseTesterRepository(tc.ComponentType); ok {
+			return factory(tc, port)
+		}
+		return nil, domain.NewUnknownComponentError(tc.ComponentType)
 	}
 }
 
-func (dtuc *databaseTesterUsecase) testTable(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, containerId string) {
+// Method returns credentials of the Postgres image env vars
+func (dtuc *databaseTesterUsecase) getPostgresCredentials(tc *domain.TestCase) (string, string, error) {
+	const (
+		POSTGRES_USER_ENV_VAR     = "POSTGRES_USER"
+		POSTGRES_PASSWORD_ENV_VAR = "POSTGRES_PASSWORD"
+	)
+
+	// Get user from env vars
+	user, ok := tc.EnvVars[POSTGRES_USER_ENV_VAR]
+	if !ok {
+		err := domain.NewRequiredEnvVarError(POSTGRES_USER_ENV_VAR)
+		logrus.WithError(err).Error("couldn't create database repository")
+		return "", "", err
+	}
+	// Get password from env vars
+	password, ok := tc.EnvVars[POSTGRES_PASSWORD_ENV_VAR]
+	if !ok {
+		err := domain.NewRequiredEnvVarError(POSTGRES_PASSWORD_ENV_VAR)
+		logrus.WithError(err).Error("couldn't create database repository")
+		return "", "", err
+	}
+
+	return user, password, nil
+}
+
+func (dtuc *databaseTesterUsecase) testTable(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase, containerId string) {
 	var (
-		tableName           = "test_table"
-		keyValueTableFields = []string{
-			"id BIGSERIAL PRIMARY KEY",
-			"f1 BIGINT",
-			"f2 BIGSERIAL",
-			"f3 BOOLEAN",
-			"f4 DATE",
-			"f5 FLOAT",
-			"f6 REAL",
-			"f7 INTEGER",
-			"f8 NUMERIC",
-			"f9 SMALLINT",
-			"f10 SMALLSERIAL",
-			"f11 SERIAL",
-		}
-		tableColumns     = []string{"f1", "f2", "f3", "f4", "f5", "f6", "f7", "f8", "f9", "f10", "f11"}
-		selectConditions = "f1>1 AND f2>1 AND f3 AND F5>0.5 AND f6>0.5 AND f7>1 AND f8>1 AND f9>1 AND f10>1 AND f11>1"
+		tableName        = dtuc.tableName("test_table")
+		tableFields      = tc.GetTableFields()
+		selectConditions = tc.GetTableSelectConditions()
 	)
 
-	step := &domain.TestCaseStep{Name: "createTable", StepFunc: func() error { return r.CreateTable(tableName, keyValueTableFields) }}
+	columns, hasColumns := dtuc.findWorkloadColumns(tableFields)
+	if !hasColumns {
+		logrus.Warn("join, aggregation, index and update steps are skipped, because table fields have no integer column")
+	}
+	// Default conditions use the columns of the default schema, so they are derived from the custom one
+	if len(tc.TableFields) != 0 && tc.TableSelectConditions == "" {
+		selectConditions = ""
+		if hasColumns {
+			selectConditions = columns.halfRowsConditions()
+		}
+	}
+
+	step := &domain.TestCaseStep{Name: "createTable", StepFunc: func() error { return r.CreateTable(tableName, tableFields) }}
 	if err := mcuc.CollectStepMetrics(step); err != nil {
 		return
 	}
@@ -158,8 +581,17 @@ func (dtuc *databaseTesterUsecase) testTable(mcuc metrics_collector.MetricsColle
 		return
 	}
 
-	for i := 1; i <= 10000000; i *= 10 {
-		if err := dtuc.testTableInsertSelect(mcuc, r, tableName, tableColumns, selectConditions, i); err != nil {
+	if err := dtuc.createRelatedTable(mcuc, r); err != nil {
+		logrus.WithError(err).Warn("couldn't create related table")
+	}
+	defer func() {
+		if err := dtuc.dropRelatedTable(mcuc, r); err != nil {
+			logrus.WithError(err).Warn("couldn't drop related table")
+		}
+	}()
+
+	for _, dataCount := range tc.GetDataCounts() {
+		if err := dtuc.testTableInsertSelect(mcuc, r, tc, containerId, tableName, tableFields, columns, selectConditions, int(dataCount)); err != nil {
 			return
 		}
 	}
@@ -175,24 +607,10 @@ func (dtuc *databaseTesterUsecase) testTable(mcuc metrics_collector.MetricsColle
 	}
 }
 
-func (dtuc *databaseTesterUsecase) testTableInsertSelect(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tableName string, tableColumns []string, selectConditions string, dataCount int) error {
+func (dtuc *databaseTesterUsecase) testTableInsertSelect(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DatabaseTesterRepository, tc *domain.TestCase, containerId string, tableName string, tableFields []string, columns *workloadColumns, selectConditions string, dataCount int) error {
 	testPrefix := strconv.FormatInt(int64(dataCount), 10) + "x"
 
-	step := &domain.TestCaseStep{Name: testPrefix + "InsertEmptyTable", StepFunc: func() error {
-		if dataCount > 1000 {
-			// Postgres bulk insert support max 65536 params
-			// Split insert by 1000 rows
-			for i := dataCount / 1000; i > 0; i-- {
-				if err := r.Insert(tableName, tableColumns, dtuc.generateTableData(1000)); err != nil {
-					return err
-				}
-			}
-		} else {
-			return r.Insert(tableName, tableColumns, dtuc.generateTableData(dataCount))
-		}
-
-		return nil
-	}}
+	step := &domain.TestCaseStep{Name: testPrefix + "InsertEmptyTable", StepFunc: func() error { return dtuc.insertTableData(r, tableName, tableFields, dataCount) }}
 	if err := mcuc.CollectStepMetrics(step); err != nil {
 		return err
 	}
@@ -202,9 +620,25 @@ func (dtuc *databaseTesterUsecase) testTableInsertSelect(mcuc metrics_collector.
 		return err
 	}
 
-	step = &domain.TestCaseStep{Name: "selectByConditions" + testPrefix + "Table", StepFunc: func() error { return r.SelectByConditions(tableName, selectConditions) }}
-	if err := mcuc.CollectStepMetrics(step); err != nil {
-		return err
+	if selectConditions != "" {
+		step = &domain.TestCaseStep{Name: "selectByConditions" + testPrefix + "Table", StepFunc: func() error { return r.SelectByConditions(tableName, selectConditions) }}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+	}
+
+	if columns != nil {
+		dtuc.testTableJoin(mcuc, r, tableName, columns, testPrefix)
+
+		dtuc.testTableAggregation(mcuc, r, tableName, columns, testPrefix)
+	}
+
+	dtuc.testTablePagination(mcuc, r, tableName, testPrefix, dataCount)
+
+	if columns != nil {
+		dtuc.testTableIndex(mcuc, r, tableName, columns, testPrefix)
+
+		dtuc.testTableUpdate(mcuc, r, tableName, tableFields, columns, testPrefix, dataCount)
 	}
 
 	// Inserts into full table
@@ -212,13 +646,27 @@ func (dtuc *databaseTesterUsecase) testTableInsertSelect(mcuc metrics_collector.
 		for i := 1000; i >= 1; i /= 10 {
 			insertTestPrefix := strconv.FormatInt(int64(i), 10) + "x"
 
-			step = &domain.TestCaseStep{Name: insertTestPrefix + "Insert" + testPrefix + "Table", StepFunc: func() error { return r.Insert(tableName, tableColumns, dtuc.generateTableData(i)) }}
+			step = &domain.TestCaseStep{Name: insertTestPrefix + "Insert" + testPrefix + "Table", StepFunc: func() error { return dtuc.insertTableData(r, tableName, tableFields, i) }}
 			if err := mcuc.CollectStepMetrics(step); err != nil {
 				return err
 			}
 		}
 	}
 
+	if tc.HasWorkload(domain.Workload_SchemaMigrations) {
+		if err := dtuc.testSchemaMigrations(mcuc, r, tableName, tableFields, testPrefix); err != nil {
+			logrus.WithError(err).Warn("couldn't test schema migrations")
+		}
+	}
+
+	if tc.HasWorkload(domain.Workload_BackupRestore) {
+		if err := dtuc.testBackupRestore(mcuc, r, containerId, testPrefix); err != nil {
+			logrus.WithError(err).Warn("couldn't test backup and restore")
+		}
+	}
+
+	dtuc.testTableDelete(mcuc, r, tableName, tableFields, columns, testPrefix, dataCount)
+
 	step = &domain.TestCaseStep{Name: "truncate" + testPrefix + "Table", StepFunc: func() error { return r.TruncateTable(tableName) }}
 	if err := mcuc.CollectStepMetrics(step); err != nil {
 		return err
@@ -227,53 +675,74 @@ func (dtuc *databaseTesterUsecase) testTableInsertSelect(mcuc metrics_collector.
 	return nil
 }
 
-// Method geerates data set for:
-/*
-keyValueTableFields = []string{
-	"f1 BIGINT",
-	"f2 BIGSERIAL",
-	"f3 BOOLEAN",
-	"f4 DATE",
-	"f5 FLOAT",
-	"f6 REAL",
-	"f7 INTEGER",
-	"f8 NUMERIC",
-	"f9 SMALLINT",
-	"f10 SMALLSERIAL",
-	"f11 SERIAL",
+// Method generates row of the table fields, which values are generated by the column types.
+// Serial primary key is skipped, because it's filled by the database.
+func (dtuc *databaseTesterUsecase) generateTableRow(fields []string) map[string]interface{} {
+	valuesSet := make(map[string]interface{}, len(fields))
+
+	for _, field := range fields {
+		column, columnType, ok := dtuc.splitTableField(field)
+		if !ok {
+			continue
+		}
+		valuesSet[column] = dtuc.generateColumnValue(columnType)
+	}
+
+	return valuesSet
 }
-*/
-func (dtuc *databaseTesterUsecase) generateTableData(count int) []map[string]interface{} {
-	var values []map[string]interface{}
-
-	for i := 0; i < count; i++ {
-		valuesSet := make(map[string]interface{})
-
-		// "f1 BIGINT",
-		valuesSet["f1"] = rand.Intn(255)
-		// "f2 BIGSERIAL",
-		valuesSet["f2"] = rand.Intn(255)
-		// "f3 BOOLEAN",
-		valuesSet["f3"] = rand.Intn(255) > 128
-		// "f4 DATE",
-		valuesSet["f4"] = time.Now()
-		// "f5 FLOAT",
-		valuesSet["f5"] = rand.Float32()
-		// "f6 REAL",
-		valuesSet["f6"] = rand.Float64()
-		// "f7 INTEGER",
-		valuesSet["f7"] = rand.Intn(255)
-		// "f8 NUMERIC",
-		valuesSet["f8"] = rand.Intn(255)
-		// "f9 SMALLINT",
-		valuesSet["f9"] = rand.Intn(255)
-		// "f10 SMALLSERIAL",
-		valuesSet["f10"] = rand.Intn(255)
-		// "f11 SERIAL",
-		valuesSet["f11"] = rand.Intn(255)
-
-		values = append(values, valuesSet)
-	}
-
-	return values
+
+// Method generates value of the Postgres column type. Numbers are random in [0, 255), so conditions of the default schema match about half of the rows.
+func (dtuc *databaseTesterUsecase) generateColumnValue(columnType string) interface{} {
+	// Length and precision of the type don't change the generated value
+	if i := strings.IndexByte(columnType, '('); i >= 0 {
+		columnType = columnType[:i]
+	}
+
+	switch strings.ToUpper(columnType) {
+	case "BOOLEAN", "BOOL":
+		return dtuc.rand.Intn(255) > 128
+	case "DATE":
+		return dtuc.generateDate()
+	case "TIMESTAMP":
+		return dtuc.generateTimestamp()
+	case "TIMESTAMPTZ":
+		return dtuc.generateTimestampTz()
+	case "FLOAT":
+		return dtuc.rand.Float32()
+	case "REAL", "DOUBLE":
+		return dtuc.rand.Float64()
+	case "TEXT", "VARCHAR", "CHAR":
+		return "text" + strconv.Itoa(dtuc.rand.Intn(255))
+	case "BYTEA":
+		return []byte{byte(dtuc.rand.Intn(255))}
+	default:
+		return dtuc.rand.Intn(255)
+	}
+}
+
+// Method returns inserted columns of the table fields
+func (dtuc *databaseTesterUsecase) tableColumns(fields []string) []string {
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if column, _, ok := dtuc.splitTableField(field); ok {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+// Method splits field definition into column name and type. Returns false for the serial primary key, which isn't inserted.
+func (dtuc *databaseTesterUsecase) splitTableField(field string) (column string, columnType string, ok bool) {
+	parts := strings.Fields(field)
+	if len(parts) < 2 {
+		return "", "", false
+	}
+
+	isSerial := strings.HasSuffix(strings.ToUpper(parts[1]), "SERIAL")
+	if isSerial && strings.Contains(strings.ToUpper(field), "PRIMARY KEY") {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
 }
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	REQUEST_TIMEOUT = 60 * time.Second
+	// Time for the services of the connected cluster to become ready
+	READY_TIMEOUT = 5 * time.Second
+	// Services quotas of the single node cluster
+	COUCHBASE_MEMORY_QUOTA_MB       = 512
+	COUCHBASE_INDEX_MEMORY_QUOTA_MB = 256
+	COUCHBASE_BUCKET_RAM_QUOTA_MB   = 256
+	// Forest DB is the only index storage of the community edition
+	COUCHBASE_INDEXER_STORAGE_MODE = "forestdb"
+)
+
+type couchbaseDocumentTesterRepository struct {
+	cluster  *gocb.Cluster
+	port     uint16
+	host     string
+	user     string
+	password string
+}
+
+// NewCouchbaseDocumentTesterRepository creates repository using gocb. Port is the port of the cluster management REST API.
+func NewCouchbaseDocumentTesterRepository(port uint16, host, user, password string) DocumentTesterRepository {
+	r := new(couchbaseDocumentTesterRepository)
+	r.port = port
+	r.host = host
+	r.user = user
+	r.password = password
+	return r
+}
+
+// Image starts uninitialized node, so cluster is initialized with KV, query and index services before connection
+func (r *couchbaseDocumentTesterRepository) Open() error {
+	if err := r.initCluster(); err != nil {
+		return err
+	}
+
+	cluster, err := gocb.Connect("couchbase://"+r.host, gocb.ClusterOptions{
+		Authenticator: gocb.PasswordAuthenticator{Username: r.user, Password: r.password},
+	})
+	if err != nil {
+		return err
+	}
+	if err := cluster.WaitUntilReady(READY_TIMEOUT, &gocb.WaitUntilReadyOptions{
+		ServiceTypes: []gocb.ServiceType{gocb.ServiceTypeManagement, gocb.ServiceTypeQuery},
+	}); err != nil {
+		cluster.Close(nil)
+		return err
+	}
+
+	r.cluster = cluster
+
+	return nil
+}
+
+func (r *couchbaseDocumentTesterRepository) CreateBucket(name string) error {
+	if r.cluster == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.cluster.Buckets().CreateBucket(gocb.CreateBucketSettings{
+		BucketSettings: gocb.BucketSettings{
+			Name:         name,
+			RAMQuotaMB:   COUCHBASE_BUCKET_RAM_QUOTA_MB,
+			BucketType:   gocb.CouchbaseBucketType,
+			FlushEnabled: true,
+		},
+	}, nil); err != nil {
+		return err
+	}
+
+	// Bucket is created asynchronously, so KV service is awaited
+	return r.cluster.Bucket(name).WaitUntilReady(REQUEST_TIMEOUT, &gocb.WaitUntilReadyOptions{
+		ServiceTypes: []gocb.ServiceType{gocb.ServiceTypeKeyValue},
+	})
+}
+
+func (r *couchbaseDocumentTesterRepository) DropBucket(name string) error {
+	if r.cluster == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.cluster.Buckets().DropBucket(name, nil)
+}
+
+func (r *couchbaseDocumentTesterRepository) Upsert(bucket string, key string, document interface{}) error {
+	if r.cluster == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	_, err := r.cluster.Bucket(bucket).DefaultCollection().Upsert(key, document, nil)
+	return err
+}
+
+func (r *couchbaseDocumentTesterRepository) Get(bucket string, key string) error {
+	if r.cluster == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	_, err := r.cluster.Bucket(bucket).DefaultCollection().Get(key, nil)
+	return err
+}
+
+func (r *couchbaseDocumentTesterRepository) CreatePrimaryIndex(bucket string) error {
+	if r.cluster == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.cluster.QueryIndexes().CreatePrimaryIndex(bucket, &gocb.CreatePrimaryQueryIndexOptions{IgnoreIfExists: true})
+}
+
+func (r *couchbaseDocumentTesterRepository) DropPrimaryIndex(bucket string) error {
+	if r.cluster == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.cluster.QueryIndexes().DropPrimaryIndex(bucket, &gocb.DropPrimaryQueryIndexOptions{IgnoreIfNotExists: true})
+}
+
+// Query waits for the index to include all the mutations before the request, so just upserted documents are found
+func (r *couchbaseDocumentTesterRepository) Query(statement string, args ...interface{}) error {
+	if r.cluster == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	rows, err := r.cluster.Query(statement, &gocb.QueryOptions{
+		PositionalParameters: args,
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+	})
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+
+	return rows.Close()
+}
+
+func (r *couchbaseDocumentTesterRepository) Close() error {
+	if r.cluster == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.cluster.Close(nil); err != nil {
+		return err
+	}
+
+	r.cluster = nil
+
+	return nil
+}
+
+// Method initializes single node cluster by the management REST API. Initialized cluster rejects the request, so its status is ignored.
+func (r *couchbaseDocumentTesterRepository) initCluster() error {
+	form := url.Values{}
+	form.Set("hostname", "127.0.0.1")
+	form.Set("services", "kv,n1ql,index")
+	form.Set("memoryQuota", strconv.Itoa(COUCHBASE_MEMORY_QUOTA_MB))
+	form.Set("indexMemoryQuota", strconv.Itoa(COUCHBASE_INDEX_MEMORY_QUOTA_MB))
+	form.Set("indexerStorageMode", COUCHBASE_INDEXER_STORAGE_MODE)
+	form.Set("username", r.user)
+	form.Set("password", r.password)
+	form.Set("port", "SAME")
+
+	client := &http.Client{Timeout: REQUEST_TIMEOUT}
+	resp, err := client.Post("http://"+r.host+":"+strconv.FormatUint(uint64(r.port), 10)+"/clusterInit", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		logrus.WithFields(logrus.Fields{"status": resp.StatusCode, "body": string(respBody)}).Debug("cluster wasn't initialized")
+		return nil
+	}
+
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	return err
+}
@@ -0,0 +1,17 @@
+package repository
+
+type DocumentTesterRepository interface {
+	// Open initializes the server if it's needed and connects to it
+	Open() error
+	CreateBucket(name string) error
+	DropBucket(name string) error
+	// Upsert stores document by the key using KV service
+	Upsert(bucket string, key string, document interface{}) error
+	// Get reads document by the key using KV service
+	Get(bucket string, key string) error
+	CreatePrimaryIndex(bucket string) error
+	DropPrimaryIndex(bucket string) error
+	// Query runs query statement with positional parameters using query service and reads all the rows
+	Query(statement string, args ...interface{}) error
+	Close() error
+}
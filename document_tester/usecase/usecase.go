@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/document_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	BUCKET_NAME    = "cott_bucket"
+	QUERY_REQUESTS = 200
+	// Range queries match 1% of the documents
+	RANGE_QUERY_WIDTH = 0.01
+)
+
+// Multipliers of the documents count of the test case
+var documentsCountMultipliers = []int{1, 10}
+
+type document struct {
+	Id        int       `json:"id"`
+	Value     int64     `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type DocumentTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type documentTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewDocumentTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) DocumentTesterUsecase {
+	dcuc := new(documentTesterUsecase)
+	dcuc.cluc = cluc
+	return dcuc
+}
+
+func (dcuc *documentTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	r, err := dcuc.createDocumentRepository(tcra.TestCase)
+	if err != nil {
+		return err
+	}
+
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, dcuc.cluc, containerId)
+
+	// Await for cluster initialized and its services ready
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return helpers.AwaitConnection(r.Open, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+	defer r.Close()
+
+	// Bucket could be left by the failed run
+	if err := r.DropBucket(BUCKET_NAME); err != nil {
+		logrus.WithError(err).Debug("couldn't drop bucket")
+	}
+
+	step = &domain.TestCaseStep{Name: "createBucket", StepFunc: func() error { return r.CreateBucket(BUCKET_NAME) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	for _, multiplier := range documentsCountMultipliers {
+		documentsCount := multiplier * int(tcra.TestCase.GetDocumentsCount())
+		if err := dcuc.testDocuments(mcuc, r, int(tcra.TestCase.GetConcurrency()), documentsCount); err != nil {
+			break
+		}
+	}
+
+	step = &domain.TestCaseStep{Name: "dropBucket", StepFunc: func() error { return r.DropBucket(BUCKET_NAME) }}
+	mcuc.CollectStepMetrics(step)
+
+	return nil
+}
+
+func (dcuc *documentTesterUsecase) createDocumentRepository(tc *domain.TestCase) (repository.DocumentTesterRepository, error) {
+	switch tc.ComponentType {
+	case domain.ComponentType_Couchbase:
+		user := tc.User
+		if user == "" {
+			user = "Administrator"
+		}
+		return repository.NewCouchbaseDocumentTesterRepository(tc.Port, "localhost", user, tc.Password), nil
+	default:
+		return nil, domain.NewUnknownComponentError(tc.ComponentType)
+	}
+}
+
+// Method upserts and gets documents by KV service and queries them by query service with and without primary index.
+// KV and query steps are separate, so latencies of the services are reported separately.
+func (dcuc *documentTesterUsecase) testDocuments(mcuc metrics_collector.MetricsCollectorUsecase, r repository.DocumentTesterRepository, concurrency int, documentsCount int) error {
+	testPrefix := strconv.FormatInt(int64(documentsCount), 10) + "x"
+
+	// Documents of the previous counts are overwritten, so bucket contains documentsCount documents
+	createdAt := time.Now()
+	var nextId int64 = -1
+	step := dcuc.createLoadStep(testPrefix+"KvUpsert", documentsCount, concurrency, func() error {
+		id := int(atomic.AddInt64(&nextId, 1))
+		return r.Upsert(BUCKET_NAME, documentKey(id), document{
+			Id:        id,
+			Value:     int64(id),
+			CreatedAt: createdAt.Add(time.Duration(id) * time.Millisecond),
+		})
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = dcuc.createLoadStep(testPrefix+"KvGet", documentsCount, concurrency, func() error {
+		return r.Get(BUCKET_NAME, documentKey(rand.Intn(documentsCount)))
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("kv get failed")
+	}
+
+	// Query by keys doesn't need any index
+	step = dcuc.createLoadStep("n1qlQueryByKeys"+testPrefix, QUERY_REQUESTS, concurrency, func() error {
+		return r.Query("SELECT d.* FROM `"+BUCKET_NAME+"` d USE KEYS $1", documentKey(rand.Intn(documentsCount)))
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("query by keys failed")
+	}
+
+	step = &domain.TestCaseStep{Name: "createPrimaryIndex", StepFunc: func() error { return r.CreatePrimaryIndex(BUCKET_NAME) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	rangeWidth := int64(float64(documentsCount) * RANGE_QUERY_WIDTH)
+	step = dcuc.createLoadStep("n1qlQueryByPrimaryIndex"+testPrefix, QUERY_REQUESTS, concurrency, func() error {
+		from := rand.Int63n(int64(documentsCount))
+		return r.Query("SELECT d.* FROM `"+BUCKET_NAME+"` d WHERE d.`value` BETWEEN $1 AND $2", from, from+rangeWidth)
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("query by primary index failed")
+	}
+
+	step = &domain.TestCaseStep{Name: "dropPrimaryIndex", StepFunc: func() error { return r.DropPrimaryIndex(BUCKET_NAME) }}
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Method creates step running request the count times concurrently
+func (dcuc *documentTesterUsecase) createLoadStep(name string, count int, concurrency int, request func() error) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = helpers.RunLoad(count, concurrency, request)
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+}
+
+func documentKey(id int) string {
+	return "doc::" + strconv.Itoa(id)
+}
@@ -0,0 +1,16 @@
+package domain
+
+// Aggregate function of the column values, which are aggregated over the whole table or by the groups
+type Aggregation string
+
+const (
+	Aggregation_Count = "count"
+	Aggregation_Sum   = "sum"
+	Aggregation_Avg   = "avg"
+)
+
+var Aggregations = []Aggregation{
+	Aggregation_Count,
+	Aggregation_Sum,
+	Aggregation_Avg,
+}
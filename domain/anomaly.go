@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"math"
+	"strconv"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// Minimal count of the past values to calculate standard deviation
+const MIN_HISTORY_VALUES = 3
+
+// Anomaly is the metric deviating from its rolling average across past runs
+type Anomaly struct {
+	TestCase string     `json:"test-case"`
+	Step     string     `json:"step"`
+	Metric   MetricMeta `json:"metric"`
+	Value    float64    `json:"value"`
+	Mean     float64    `json:"mean"`
+	StdDev   float64    `json:"std-dev"`
+	// Deviation from the mean in standard deviations
+	Sigmas float64 `json:"sigmas"`
+}
+
+// FlagAnomalies compares report metrics with the same metrics of the history reports
+func (r *Report) FlagAnomalies(history []*Report, sigmas float64) {
+	pastValues := make(map[string][]float64)
+	for _, hr := range history {
+		hr.forEachMetric(func(tc string, step string, m *Metric) {
+			key := tc + "/" + step + "/" + m.Meta.Name
+			pastValues[key] = append(pastValues[key], m.Value)
+		})
+	}
+
+	r.Anomalies = nil
+	r.forEachMetric(func(tc string, step string, m *Metric) {
+		values := pastValues[tc+"/"+step+"/"+m.Meta.Name]
+		if len(values) < MIN_HISTORY_VALUES {
+			return
+		}
+
+		mean, stdDev := stat.MeanStdDev(values, nil)
+		// Deviation from the constant metric can't be measured in sigmas
+		if stdDev == 0 || math.IsNaN(stdDev) || math.IsNaN(m.Value) {
+			return
+		}
+
+		deviation := (m.Value - mean) / stdDev
+		if math.Abs(deviation) > sigmas {
+			r.Anomalies = append(r.Anomalies, &Anomaly{TestCase: tc, Step: step, Metric: m.Meta, Value: m.Value, Mean: mean, StdDev: stdDev, Sigmas: deviation})
+		}
+	})
+}
+
+// Test cases are identified by their index and image, so the same config produces the same keys
+func (r *Report) forEachMetric(f func(tc string, step string, m *Metric)) {
+	for i, tcr := range r.TestCaseResults {
+		tc := testCaseKey(i, tcr)
+		for _, tcsr := range tcr.StepsResults {
+			for j := range tcsr.Metrics {
+				f(tc, tcsr.TestCaseStep.Name, &tcsr.Metrics[j])
+			}
+		}
+	}
+}
+
+func testCaseKey(i int, tcr *TestCaseResults) string {
+	return strconv.FormatInt(int64(i), 10) + "/" + string(tcr.TestCase.ComponentType) + "/" + tcr.TestCase.Image + tcr.TestCase.ComposeFile
+}
+
+// FindAnomaly returns anomaly of the step metric of the test case by its index or nil
+func (r *Report) FindAnomaly(i int, step string, metric string) *Anomaly {
+	key := testCaseKey(i, r.TestCaseResults[i])
+	for _, a := range r.Anomalies {
+		if a.TestCase == key && a.Step == step && a.Metric.Name == metric {
+			return a
+		}
+	}
+	return nil
+}
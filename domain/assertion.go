@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	AssertionOperator_Less         = "<"
+	AssertionOperator_LessEqual    = "<="
+	AssertionOperator_Greater      = ">"
+	AssertionOperator_GreaterEqual = ">="
+)
+
+// Assertion is the threshold of the step metric, e.g. "10000xInsertEmptyTableDuration < 2s".
+// Left side is the step name followed by the capitalized metric name.
+// Right side is the duration for the time metrics or the number in the metric units.
+type Assertion struct {
+	Expression string
+	StepMetric string
+	Operator   string
+	Threshold  *Threshold
+}
+
+// AssertionViolation is the assertion which failed or couldn't be checked by the test case results
+type AssertionViolation struct {
+	ComponentType ComponentType `json:"component-type"`
+	Image         string        `json:"image"`
+	Assertion     string        `json:"assertion"`
+	// Metric value in the metric units, absent if metric wasn't found
+	Value  *float64 `json:"value,omitempty"`
+	Reason string   `json:"reason"`
+}
+
+func ParseAssertion(expression string) (*Assertion, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 3 {
+		return nil, INVALID_ASSERTION
+	}
+
+	a := &Assertion{Expression: expression, StepMetric: fields[0], Operator: fields[1]}
+	switch a.Operator {
+	case AssertionOperator_Less, AssertionOperator_LessEqual, AssertionOperator_Greater, AssertionOperator_GreaterEqual:
+	default:
+		return nil, INVALID_ASSERTION
+	}
+
+	threshold, err := ParseThreshold(fields[2])
+	if err != nil {
+		return nil, INVALID_ASSERTION
+	}
+	a.Threshold = threshold
+
+	return a, nil
+}
+
+// ValidateAssertions checks syntax of the test cases assertions
+func (c *Config) ValidateAssertions() error {
+	for _, tc := range c.TestCases {
+		for _, expression := range tc.Assertions {
+			if _, err := ParseAssertion(expression); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Check returns violation of the assertion by the test case results or nil if it holds
+func (a *Assertion) Check(tcr *TestCaseResults) *AssertionViolation {
+	violation := &AssertionViolation{ComponentType: tcr.TestCase.ComponentType, Image: tcr.TestCase.Image, Assertion: a.Expression}
+
+	m := a.findMetric(tcr)
+	if m == nil {
+		violation.Reason = METRIC_NOT_FOUND.Error()
+		return violation
+	}
+	value := m.Value
+	violation.Value = &value
+
+	threshold, err := a.Threshold.InUnitsOf(&m.Meta)
+	if err != nil {
+		violation.Reason = err.Error()
+		return violation
+	}
+
+	var holds bool
+	switch a.Operator {
+	case AssertionOperator_Less:
+		holds = value < threshold
+	case AssertionOperator_LessEqual:
+		holds = value <= threshold
+	case AssertionOperator_Greater:
+		holds = value > threshold
+	case AssertionOperator_GreaterEqual:
+		holds = value >= threshold
+	}
+	if holds {
+		return nil
+	}
+
+	violation.Reason = "metric value " + strconv.FormatFloat(value, 'f', -1, 64) + " doesn't satisfy " + a.Operator + " " +
+		strconv.FormatFloat(threshold, 'f', -1, 64) + " " + string(m.Meta.UnitOfMeasurePrefix) + string(m.Meta.UnitOfMeasure)
+	return violation
+}
+
+func (a *Assertion) findMetric(tcr *TestCaseResults) *Metric {
+	for _, tcsr := range tcr.StepsResults {
+		if !strings.HasPrefix(a.StepMetric, tcsr.TestCaseStep.Name) {
+			continue
+		}
+		for i, m := range tcsr.Metrics {
+			if tcsr.TestCaseStep.Name+strings.ToUpper(m.Meta.Name[:1])+m.Meta.Name[1:] == a.StepMetric {
+				return &tcsr.Metrics[i]
+			}
+		}
+	}
+	return nil
+}
+
+// CheckAssertions adds violations of the test case assertions into the report
+func (r *Report) CheckAssertions(tcr *TestCaseResults) {
+	for _, expression := range tcr.TestCase.Assertions {
+		a, err := ParseAssertion(expression)
+		if err != nil {
+			r.AssertionViolations = append(r.AssertionViolations, &AssertionViolation{
+				ComponentType: tcr.TestCase.ComponentType, Image: tcr.TestCase.Image, Assertion: expression, Reason: err.Error()})
+			continue
+		}
+
+		if violation := a.Check(tcr); violation != nil {
+			r.AssertionViolations = append(r.AssertionViolations, violation)
+		}
+	}
+}
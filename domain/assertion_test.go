@@ -0,0 +1,80 @@
+package domain
+
+import "testing"
+
+func TestParseThreshold(t *testing.T) {
+	tests := []struct {
+		s          string
+		value      float64
+		isDuration bool
+		err        error
+	}{
+		{s: "0", value: 0},
+		{s: "1000", value: 1000},
+		{s: "0.5", value: 0.5},
+		{s: "2s", value: 2, isDuration: true},
+		{s: "150ms", value: 0.15, isDuration: true},
+		{s: "0s", value: 0, isDuration: true},
+		{s: "fast", err: INVALID_THRESHOLD},
+	}
+
+	for _, tt := range tests {
+		threshold, err := ParseThreshold(tt.s)
+		if err != tt.err {
+			t.Errorf("ParseThreshold(%q) error = %v, want %v", tt.s, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if threshold.Value != tt.value || threshold.IsDuration != tt.isDuration {
+			t.Errorf("ParseThreshold(%q) = %+v, want {Value:%v IsDuration:%v}", tt.s, *threshold, tt.value, tt.isDuration)
+		}
+	}
+}
+
+func TestParseAssertion(t *testing.T) {
+	tests := []struct {
+		expression string
+		stepMetric string
+		operator   string
+		isDuration bool
+		err        error
+	}{
+		{expression: "10000xInsertEmptyTableDuration < 2s", stepMetric: "10000xInsertEmptyTableDuration", operator: AssertionOperator_Less, isDuration: true},
+		{expression: "100xProduceErrors <= 0", stepMetric: "100xProduceErrors", operator: AssertionOperator_LessEqual},
+		{expression: "selectThroughput >= 1000", stepMetric: "selectThroughput", operator: AssertionOperator_GreaterEqual},
+		{expression: "selectThroughput == 1000", err: INVALID_ASSERTION},
+		{expression: "selectThroughput > fast", err: INVALID_ASSERTION},
+		{expression: "selectThroughput >", err: INVALID_ASSERTION},
+	}
+
+	for _, tt := range tests {
+		a, err := ParseAssertion(tt.expression)
+		if err != tt.err {
+			t.Errorf("ParseAssertion(%q) error = %v, want %v", tt.expression, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if a.StepMetric != tt.stepMetric || a.Operator != tt.operator || a.Threshold.IsDuration != tt.isDuration {
+			t.Errorf("ParseAssertion(%q) = %+v, want step metric %q, operator %q, duration %v", tt.expression, *a, tt.stepMetric, tt.operator, tt.isDuration)
+		}
+	}
+}
+
+func TestCheckZeroThreshold(t *testing.T) {
+	a, err := ParseAssertion("100xProduceErrors <= 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tcr := &TestCaseResults{StepsResults: []*TestCaseStepResults{{
+		TestCaseStep: TestCaseStep{Name: "100xProduce"},
+		Metrics:      []Metric{{Meta: *MetricMeta_Errors, Value: 0}},
+	}}}
+	if violation := a.Check(tcr); violation != nil {
+		t.Errorf("Check() = %+v, want nil", *violation)
+	}
+}
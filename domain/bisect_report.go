@@ -0,0 +1,44 @@
+package domain
+
+// BisectReport contains metric values of the measured tags and the first regressed tag
+type BisectReport struct {
+	// Budget breaches of the measured tags
+	Severity      *SeveritySummary `json:"severity"`
+	ComponentType ComponentType    `json:"component-type"`
+	Step          string           `json:"step"`
+	Metric        string           `json:"metric"`
+	// Allowed deviation from the baseline in percents
+	Threshold float64  `json:"threshold"`
+	Tags      []string `json:"tags"`
+	// Metric value of the first tag
+	Baseline float64            `json:"baseline"`
+	Values   map[string]float64 `json:"values"`
+	// Empty if the last tag isn't regressed
+	FirstRegressedTag string `json:"first-regressed-tag,omitempty"`
+}
+
+func NewBisectReport(ct ComponentType, step string, metric string, threshold float64, tags []string) *BisectReport {
+	r := new(BisectReport)
+	r.ComponentType = ct
+	r.Step = step
+	r.Metric = metric
+	r.Threshold = threshold
+	r.Tags = tags
+	r.Values = make(map[string]float64)
+	r.Severity = NewSeveritySummary()
+	return r
+}
+
+func (r *BisectReport) AddValue(tag string, value float64, report *Report) {
+	r.Values[tag] = value
+	r.Severity.Merge(report.Severity)
+}
+
+// IsRegressed returns whether value is worse than the baseline more than threshold
+func (r *BisectReport) IsRegressed(value float64) bool {
+	mm := MetricMeta{Name: r.Metric}
+	if mm.IsHigherBetter() {
+		return value < r.Baseline*(1-r.Threshold/100)
+	}
+	return value > r.Baseline*(1+r.Threshold/100)
+}
@@ -0,0 +1,35 @@
+package domain
+
+type NodeRole string
+
+const (
+	// Node accepting writes, e.g. Patroni leader or Redis master
+	NodeRole_Leader = "leader"
+	// Node replicating the leader and serving reads
+	NodeRole_Follower = "follower"
+	// Kafka broker serving partitions
+	NodeRole_Broker = "broker"
+	// KRaft controller of the Kafka cluster metadata
+	NodeRole_Controller = "controller"
+	// Redis Sentinel monitoring master and replicas
+	NodeRole_Sentinel = "sentinel"
+)
+
+// ClusterNode is the compose service of the cluster test case
+type ClusterNode struct {
+	Service string   `json:"service"`
+	Role    NodeRole `json:"role"`
+	// Host port of the node
+	Port uint16 `json:"port"`
+}
+
+// GetNodes returns cluster nodes with the role
+func (tc *TestCase) GetNodes(role NodeRole) []ClusterNode {
+	var nodes []ClusterNode
+	for _, n := range tc.Nodes {
+		if n.Role == role {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,19 @@
+package domain
+
+type CompressionCodec string
+
+const (
+	CompressionCodec_None   = "none"
+	CompressionCodec_Gzip   = "gzip"
+	CompressionCodec_Snappy = "snappy"
+	CompressionCodec_Lz4    = "lz4"
+	CompressionCodec_Zstd   = "zstd"
+)
+
+var CompressionCodecs = []CompressionCodec{
+	CompressionCodec_None,
+	CompressionCodec_Gzip,
+	CompressionCodec_Snappy,
+	CompressionCodec_Lz4,
+	CompressionCodec_Zstd,
+}
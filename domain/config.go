@@ -5,11 +5,42 @@ import (
 )
 
 type Config struct {
-	Log       LogConfig
-	Report    ReportConfig
+	Log     LogConfig
+	Report  ReportConfig
+	History HistoryConfig
+	CleanUp CleanUpConfig
+	// Hourly costs of the instance types in dollars, e.g. "m5.large: 0.096"
+	InstanceHourlyCosts map[string]float64
+	// Go plugins registering database repositories of the component types
+	RepositoryPlugins []string
+	// External executables testing the component types
+	Plugins   []PluginConfig
 	TestCases []TestCase
 }
 
+// ResolveHourlyCosts sets hourly cost of the test cases declaring instance type only
+func (c *Config) ResolveHourlyCosts() error {
+	for i := range c.TestCases {
+		tc := &c.TestCases[i]
+		if tc.HourlyCost != 0 || tc.InstanceType == "" {
+			continue
+		}
+
+		cost, ok := c.InstanceHourlyCosts[tc.InstanceType]
+		if !ok {
+			return UNKNOWN_INSTANCE_TYPE
+		}
+		tc.HourlyCost = cost
+	}
+	return nil
+}
+
+type PluginConfig struct {
+	ComponentType ComponentType
+	Path          string
+	Args          []string
+}
+
 type LogConfig struct {
 	Level            logrus.Level `default:"info" env:"LOG_LEVEL"`
 	FilePath         string       `default:"/var/log/cott/cott.log" env:"LOG_FILE_PATH"`
@@ -17,8 +48,51 @@ type LogConfig struct {
 	MaxFilesCount    int          `default:"7" env:"LOG_MAX_FILES_COUNT"`
 	MaxFileAgeInDays int          `default:"7" env:"LOG_MAX_FILE_AGE_IN_DAYS"`
 	CompressOldFiles bool         `default:"true" env:"LOG_COMPRESS_OLD_FILES"`
+	// Logs are written into the file only
+	Quiet bool `env:"LOG_QUIET"`
 }
 
 type ReportConfig struct {
 	FilePath string `default:"report.json" env:"REPORT_FILE_PATH"`
+	// Host label of the report, hostname is used if it's empty
+	Host string `env:"REPORT_HOST"`
+	// Format of the report printed into stdout, report isn't printed if it's empty
+	Output OutputFormat `env:"REPORT_OUTPUT"`
+}
+
+type OutputFormat string
+
+const OutputFormat_Json = "json"
+
+type HistoryConfig struct {
+	// Reports of the past runs are stored in the dir. History is disabled if it's empty.
+	DirPath string `env:"HISTORY_DIR_PATH"`
+	// Count of the last runs used for the rolling average
+	Window int `default:"10" env:"HISTORY_WINDOW"`
+	// Metrics deviating from the rolling average more than sigmas are flagged
+	Sigmas float64 `default:"3" env:"HISTORY_SIGMAS"`
+}
+
+type CleanUpConfig struct {
+	// Only containers, networks and volumes older than it are removed, so the runs in progress aren't broken
+	MinAgeInMinutes int `default:"60" env:"CLEANUP_MIN_AGE_IN_MINUTES"`
+}
+
+// ExpandDrivers replaces test cases with drivers list by the test case copy for every driver
+func (c *Config) ExpandDrivers() {
+	var tcs []TestCase
+	for _, tc := range c.TestCases {
+		if len(tc.Drivers) == 0 {
+			tcs = append(tcs, tc)
+			continue
+		}
+
+		for _, d := range tc.Drivers {
+			driverTc := tc
+			driverTc.Driver = d
+			driverTc.Drivers = nil
+			tcs = append(tcs, driverTc)
+		}
+	}
+	c.TestCases = tcs
 }
@@ -0,0 +1,50 @@
+package domain
+
+import "math"
+
+const (
+	MICROSECONDS_IN_HOUR = 3600 * 1000 * 1000
+	BYTES_IN_GB          = 1 << 30
+)
+
+type CostEfficiency struct {
+	HourlyCost float64 `json:"hourly-cost"`
+	// Cost of the single accumulation of all steps
+	Cost float64 `json:"cost"`
+	// Max storage size of the component during the test case in bytes
+	MaxStorageSize float64 `json:"max-storage-size"`
+	// Stored gigabytes paid by the dollar per hour
+	GbStoredPerDollar float64 `json:"gb-stored-per-dollar"`
+}
+
+// CalculateCostEfficiency adds cost and operations per dollar metrics to the steps and summarizes test case cost
+func (tcr *TestCaseResults) CalculateCostEfficiency() {
+	hourlyCost := tcr.TestCase.HourlyCost
+	if hourlyCost <= 0 {
+		return
+	}
+
+	ce := &CostEfficiency{HourlyCost: hourlyCost}
+
+	for _, tcsr := range tcr.StepsResults {
+		var extraMetrics []Metric
+
+		for _, m := range tcsr.Metrics {
+			switch m.Meta.Name {
+			case MetricType_Duration:
+				cost := m.Value / MICROSECONDS_IN_HOUR * hourlyCost
+				ce.Cost += cost
+				extraMetrics = append(extraMetrics, Metric{Meta: *MetricMeta_Cost, Value: cost})
+			case MetricType_Throughput:
+				extraMetrics = append(extraMetrics, Metric{Meta: *MetricMeta_OperationsPerDollar, Value: m.Value * 3600 / hourlyCost})
+			case MetricType_StorageSize:
+				ce.MaxStorageSize = math.Max(ce.MaxStorageSize, m.Value)
+			}
+		}
+
+		tcsr.Metrics = append(tcsr.Metrics, extraMetrics...)
+	}
+	ce.GbStoredPerDollar = ce.MaxStorageSize / BYTES_IN_GB / hourlyCost
+
+	tcr.CostEfficiency = ce
+}
@@ -0,0 +1,12 @@
+package domain
+
+type Driver string
+
+const (
+	// database/sql with lib/pq
+	Driver_LibPq = "lib/pq"
+	// database/sql with pgx stdlib adapter
+	Driver_PgxStdlib = "pgx/stdlib"
+	// pgx native interface
+	Driver_Pgx = "pgx"
+)
@@ -0,0 +1,30 @@
+package domain
+
+// DriverOverhead compares step duration of the same component run with different client drivers.
+// Server does the same work for every driver, so difference with the fastest one is the client overhead.
+type DriverOverhead struct {
+	ComponentType ComponentType `json:"component-type"`
+	Image         string        `json:"image"`
+	Step          string        `json:"step"`
+	Fastest       Driver        `json:"fastest"`
+	// Step durations in microseconds
+	Durations map[Driver]float64 `json:"durations"`
+	// Duration difference with the fastest driver in microseconds
+	Overheads map[Driver]float64 `json:"overheads"`
+}
+
+// CompareDrivers compares step durations of the test cases with the same image and different drivers
+func (r *Report) CompareDrivers() {
+	r.DriverOverheads = nil
+	for _, vd := range r.collectVariantDurations(func(tc *TestCase) string { return string(tc.Driver) }) {
+		do := &DriverOverhead{ComponentType: vd.ComponentType, Image: vd.Image, Step: vd.Step, Fastest: Driver(vd.Fastest),
+			Durations: make(map[Driver]float64, len(vd.Durations)), Overheads: make(map[Driver]float64, len(vd.Durations))}
+
+		for d, duration := range vd.Durations {
+			do.Durations[Driver(d)] = duration
+			do.Overheads[Driver(d)] = duration - vd.Durations[vd.Fastest]
+		}
+
+		r.DriverOverheads = append(r.DriverOverheads, do)
+	}
+}
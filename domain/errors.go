@@ -9,4 +9,46 @@ var (
 	UNKNOWN_COMPONENT_FOR_TESTING        = errors.New("unknown component for testing")
 	NO_REQUIRED_ENV_VAR_KEY              = errors.New("couldn't find required env var for container")
 	COULDNT_CLOSE_CONTAINER_STATS_READER = errors.New("couldn't close containers stats reader")
+	DATA_INTEGRITY_VIOLATED              = errors.New("data integrity violated")
+	UNKNOWN_ISOLATION_LEVEL              = errors.New("unknown isolation level")
+	NO_REPLICA_PORT                      = errors.New("replica port is not set for the test case")
+	REPLICATION_LAG_TIMEOUT              = errors.New("replica didn't catch up the primary in time")
+	CONTAINER_COMMAND_FAILED             = errors.New("command in container failed")
+	UNKNOWN_COMMAND                      = errors.New("unknown command")
+	NO_SUCCESSFUL_REQUESTS               = errors.New("no successful requests")
+	UNEXPECTED_RESPONSE_STATUS           = errors.New("unexpected response status")
+	GRPC_METHOD_NOT_FOUND                = errors.New("gRPC method not found")
+	GRPC_REFLECTION_FAILED               = errors.New("gRPC server reflection failed")
+	INVALID_GRPC_METHOD_NAME             = errors.New("invalid gRPC method name, expected \"package.Service/Method\"")
+	COULDNT_MOUNT_FILESYSTEM             = errors.New("couldn't mount filesystem")
+	VECTOR_INDEX_BUILD_TIMEOUT           = errors.New("vector index wasn't built in time")
+	SERVER_IS_SEALED                     = errors.New("server is sealed and unseal key is unknown")
+	NO_TEST_CASES_FOR_COMPONENT          = errors.New("no test cases with image for the component type")
+	METRIC_NOT_FOUND                     = errors.New("metric not found in the step results")
+	UNKNOWN_DRIVER                       = errors.New("unknown database driver")
+	UNKNOWN_INSTANCE_TYPE                = errors.New("unknown instance type, its hourly cost isn't set in the config")
+	INVALID_ASSERTION                    = errors.New("invalid assertion, expected \"<step><Metric> <operator> <threshold>\"")
+	INVALID_THRESHOLD                    = errors.New("invalid threshold, expected duration or number")
+	THRESHOLD_UNITS_MISMATCH             = errors.New("duration threshold is used for the metric not measured in seconds")
+	ASSERTIONS_VIOLATED                  = errors.New("assertions are violated")
+	NO_SCRIPT_ENTRY_FUNCTION             = errors.New("script doesn't define main function")
+	INVALID_SCRIPT_ARGUMENTS             = errors.New("invalid arguments of the script builtin")
+	UNEXPECTED_PLUGIN_EVENT              = errors.New("unexpected plugin event")
+	UNKNOWN_OUTPUT_FORMAT                = errors.New("unknown output format, only json is supported")
+	SERVICE_IS_NOT_READY                 = errors.New("service didn't pass readiness probe in time")
+	UNSUPPORTED_READINESS_PROBE          = errors.New("readiness probe type isn't supported by the component tester")
+	NO_CLUSTER_LEADER                    = errors.New("cluster test case should have exactly one leader node")
+	UNSUPPORTED_PGBENCH_COMMAND          = errors.New("unsupported pgbench meta command or expression")
+	UNSUPPORTED_JMETER_ELEMENT           = errors.New("unsupported JMeter test plan element")
+	UNDEFINED_SQL_VARIABLE               = errors.New("undefined variable is used in the SQL statement")
+	UNSUPPORTED_OPERATION                = errors.New("operation isn't supported by the database")
+	LATENCY_SLO_VIOLATED                 = errors.New("latency SLO is violated even by the single client")
+	KEY_NOT_FOUND                        = errors.New("key not found")
+	WATCH_NOTIFICATION_TIMEOUT           = errors.New("watch notification wasn't received in time")
+	MESSAGE_DELIVERY_TIMEOUT             = errors.New("published message wasn't delivered in time")
+	UNKNOWN_COMPRESSION_CODEC            = errors.New("unknown compression codec")
+	UNKNOWN_PRODUCER_ACKS                = errors.New("unknown producer acks mode")
+	UNKNOWN_JOIN_TYPE                    = errors.New("unknown join type")
+	UNKNOWN_AGGREGATION                  = errors.New("unknown aggregation")
+	REBALANCE_TIMEOUT                    = errors.New("consumer group wasn't rebalanced in time")
 )
@@ -0,0 +1,79 @@
+package domain
+
+// Score penalties of the test case problems
+const (
+	SCORE_PENALTY_CRITICAL  = 10
+	SCORE_PENALTY_WARNING   = 5
+	SCORE_PENALTY_VIOLATION = 10
+	SCORE_PENALTY_ANOMALY   = 5
+	SCORE_PENALTY_ERROR     = 10
+)
+
+type Grade string
+
+const (
+	Grade_A = "A"
+	Grade_B = "B"
+	Grade_C = "C"
+	Grade_D = "D"
+	Grade_F = "F"
+)
+
+// GradeTestCases scores every test case from 100 down by its budget breaches, assertion violations, anomalies and failed steps
+// and assigns the letter grade. It's called after anomalies are flagged.
+func (r *Report) GradeTestCases() {
+	for i, tcr := range r.TestCaseResults {
+		score := 100
+
+		for _, tcsr := range tcr.StepsResults {
+			if len(tcsr.Errors) > 0 {
+				score -= SCORE_PENALTY_ERROR
+			}
+			for _, m := range tcsr.Metrics {
+				if m.Budget == nil {
+					continue
+				}
+				switch m.Budget.Severity {
+				case Severity_Critical:
+					score -= SCORE_PENALTY_CRITICAL
+				case Severity_Warning:
+					score -= SCORE_PENALTY_WARNING
+				}
+			}
+		}
+
+		for _, av := range r.AssertionViolations {
+			if av.ComponentType == tcr.TestCase.ComponentType && av.Image == tcr.TestCase.Image {
+				score -= SCORE_PENALTY_VIOLATION
+			}
+		}
+
+		key := testCaseKey(i, tcr)
+		for _, a := range r.Anomalies {
+			if a.TestCase == key {
+				score -= SCORE_PENALTY_ANOMALY
+			}
+		}
+
+		if score < 0 {
+			score = 0
+		}
+		tcr.Score = float32(score)
+		tcr.Grade = scoreToGrade(score)
+	}
+}
+
+func scoreToGrade(score int) Grade {
+	switch {
+	case score >= 90:
+		return Grade_A
+	case score >= 80:
+		return Grade_B
+	case score >= 70:
+		return Grade_C
+	case score >= 60:
+		return Grade_D
+	default:
+		return Grade_F
+	}
+}
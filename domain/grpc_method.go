@@ -0,0 +1,40 @@
+package domain
+
+import "strings"
+
+type GrpcMethod struct {
+	// Full method name in the package.Service/Method format
+	Name string `json:"name"`
+	// Request message in the protobuf JSON format
+	Request string `json:"request,omitempty"`
+	// Calls count for the load step
+	Requests uint32 `json:"requests,omitempty"`
+	// Messages count sent in one client stream
+	StreamMessages uint32 `json:"stream-messages,omitempty"`
+}
+
+func (m *GrpcMethod) GetRequestsCount() uint32 {
+	if m.Requests == 0 {
+		return 1000
+	} else {
+		return m.Requests
+	}
+}
+
+func (m *GrpcMethod) GetStreamMessagesCount() uint32 {
+	if m.StreamMessages == 0 {
+		return 100
+	} else {
+		return m.StreamMessages
+	}
+}
+
+// Method returns full name of the service declaring the method
+func (m *GrpcMethod) GetServiceName() (string, error) {
+	name := strings.TrimPrefix(m.Name, "/")
+	i := strings.LastIndex(name, "/")
+	if i <= 0 || i == len(name)-1 {
+		return "", INVALID_GRPC_METHOD_NAME
+	}
+	return name[:i], nil
+}
@@ -0,0 +1,18 @@
+package domain
+
+type HttpEndpoint struct {
+	Method string            `json:"method"`
+	Path   string            `json:"path"`
+	Body   string            `json:"body,omitempty"`
+	Header map[string]string `json:"header,omitempty"`
+	// Requests count for the load step
+	Requests uint32 `json:"requests,omitempty"`
+}
+
+func (e *HttpEndpoint) GetRequestsCount() uint32 {
+	if e.Requests == 0 {
+		return 1000
+	} else {
+		return e.Requests
+	}
+}
@@ -0,0 +1,15 @@
+package domain
+
+type IsolationLevel string
+
+const (
+	IsolationLevel_ReadCommitted  = "readCommitted"
+	IsolationLevel_RepeatableRead = "repeatableRead"
+	IsolationLevel_Serializable   = "serializable"
+)
+
+var IsolationLevels = []IsolationLevel{
+	IsolationLevel_ReadCommitted,
+	IsolationLevel_RepeatableRead,
+	IsolationLevel_Serializable,
+}
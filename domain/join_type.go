@@ -0,0 +1,14 @@
+package domain
+
+// Join of the rows of the table with the rows of the related one: only matched rows or all the rows of the table
+type JoinType string
+
+const (
+	JoinType_Inner = "inner"
+	JoinType_Left  = "left"
+)
+
+var JoinTypes = []JoinType{
+	JoinType_Inner,
+	JoinType_Left,
+}
@@ -0,0 +1,84 @@
+package domain
+
+import "encoding/json"
+
+// MergedReport combines reports of several machines or shards of the matrix run.
+// Test cases run by several hosts are stored once and referenced by the results.
+type MergedReport struct {
+	Severity            *SeveritySummary         `json:"severity"`
+	Hosts               []string                 `json:"hosts"`
+	TestCases           []TestCase               `json:"test-cases"`
+	TestCaseResults     []*MergedTestCaseResults `json:"test-case-results"`
+	AssertionViolations []*AssertionViolation    `json:"assertion-violations,omitempty"`
+
+	testCasesIndexes map[string]int
+}
+
+// MergedTestCaseResults are the test case results labeled by the host which produced them
+type MergedTestCaseResults struct {
+	Host string `json:"host"`
+	// Index of the test case in the merged report test cases
+	TestCase              int                    `json:"test-case"`
+	Score                 float32                `json:"score"`
+	Grade                 Grade                  `json:"grade,omitempty"`
+	StepsResults          []*TestCaseStepResults `json:"steps-results,omitempty"`
+	Leftovers             []string               `json:"leftovers,omitempty"`
+	CostEfficiency        *CostEfficiency        `json:"cost-efficiency,omitempty"`
+	SustainableThroughput float64                `json:"sustainable-throughput,omitempty"`
+	Seed                  int64                  `json:"seed,omitempty"`
+}
+
+func NewMergedReport() *MergedReport {
+	r := new(MergedReport)
+	r.Severity = NewSeveritySummary()
+	r.testCasesIndexes = make(map[string]int)
+	return r
+}
+
+// AddReport adds results of the report labeled by the host
+func (r *MergedReport) AddReport(host string, report *Report) error {
+	r.Hosts = append(r.Hosts, host)
+
+	for _, tcr := range report.TestCaseResults {
+		i, err := r.addTestCase(&tcr.TestCase)
+		if err != nil {
+			return err
+		}
+
+		r.TestCaseResults = append(r.TestCaseResults, &MergedTestCaseResults{
+			Host:                  host,
+			TestCase:              i,
+			Score:                 tcr.Score,
+			Grade:                 tcr.Grade,
+			StepsResults:          tcr.StepsResults,
+			Leftovers:             tcr.Leftovers,
+			CostEfficiency:        tcr.CostEfficiency,
+			SustainableThroughput: tcr.SustainableThroughput,
+			Seed:                  tcr.Seed,
+		})
+	}
+
+	if report.Severity != nil {
+		r.Severity.Merge(report.Severity)
+	}
+	r.AssertionViolations = append(r.AssertionViolations, report.AssertionViolations...)
+
+	return nil
+}
+
+// Method returns index of the same test case or adds the new one. Test cases are equal if their JSON is equal.
+func (r *MergedReport) addTestCase(tc *TestCase) (int, error) {
+	tcBytes, err := json.Marshal(tc)
+	if err != nil {
+		return 0, err
+	}
+
+	key := string(tcBytes)
+	if i, ok := r.testCasesIndexes[key]; ok {
+		return i, nil
+	}
+
+	r.TestCases = append(r.TestCases, *tc)
+	r.testCasesIndexes[key] = len(r.TestCases) - 1
+	return len(r.TestCases) - 1, nil
+}
@@ -3,14 +3,57 @@ package domain
 type MetricType string
 
 const (
-	MetricType_Duration            = "duration"
-	MetricType_CpuUsage            = "cpuUsage"
-	MetricType_MemoryUsage         = "memoryUsage"
-	MetricType_MemoryUsageDiff     = "memoryUsageDiff"
-	MetricType_StorageReadUsage    = "storageReadUsage"
-	MetricType_StorageWriteUsage   = "storageWriteUsage"
-	MetricType_NetworkReceiveUsage = "networkReceiveUsage"
-	MetricType_NetworkSendUsage    = "networkSendUsage"
+	MetricType_Duration              = "duration"
+	MetricType_CpuUsage              = "cpuUsage"
+	MetricType_MemoryUsage           = "memoryUsage"
+	MetricType_MemoryUsageDiff       = "memoryUsageDiff"
+	MetricType_StorageReadUsage      = "storageReadUsage"
+	MetricType_StorageWriteUsage     = "storageWriteUsage"
+	MetricType_NetworkReceiveUsage   = "networkReceiveUsage"
+	MetricType_NetworkSendUsage      = "networkSendUsage"
+	MetricType_LostUpdates           = "lostUpdates"
+	MetricType_WriteSkews            = "writeSkews"
+	MetricType_InvariantViolations   = "invariantViolations"
+	MetricType_AbortedTransactions   = "abortedTransactions"
+	MetricType_ReplicationLagP50     = "replicationLagP50"
+	MetricType_ReplicationLagP90     = "replicationLagP90"
+	MetricType_ReplicationLagP99     = "replicationLagP99"
+	MetricType_BackupSize            = "backupSize"
+	MetricType_ProbeLatencyP99       = "probeLatencyP99"
+	MetricType_ProbeLatencyMax       = "probeLatencyMax"
+	MetricType_BlockedProbes         = "blockedProbes"
+	MetricType_RoundTripMismatches   = "roundTripMismatches"
+	MetricType_Throughput            = "throughput"
+	MetricType_Conflicts             = "conflicts"
+	MetricType_LatencyP50            = "latencyP50"
+	MetricType_LatencyP90            = "latencyP90"
+	MetricType_LatencyP99            = "latencyP99"
+	MetricType_Errors                = "errors"
+	MetricType_AddedLatencyP50       = "addedLatencyP50"
+	MetricType_AddedLatencyP99       = "addedLatencyP99"
+	MetricType_MaxThroughput         = "maxThroughput"
+	MetricType_TransferRate          = "transferRate"
+	MetricType_IngestionRate         = "ingestionRate"
+	MetricType_Recall                = "recall"
+	MetricType_StorageSize           = "storageSize"
+	MetricType_Cost                  = "cost"
+	MetricType_OperationsPerDollar   = "operationsPerDollar"
+	MetricType_SustainableThroughput = "sustainableThroughput"
+	MetricType_SaturationConcurrency = "saturationConcurrency"
+	MetricType_ContainerUpTime       = "containerUpTime"
+	MetricType_ServiceReadyTime      = "serviceReadyTime"
+	MetricType_LoadRate              = "loadRate"
+	MetricType_Connections           = "connections"
+	MetricType_ConnectionSetupP50    = "connectionSetupP50"
+	MetricType_ConnectionSetupMax    = "connectionSetupMax"
+	MetricType_Chunks                = "chunks"
+	MetricType_TabletBootstrapTime   = "tabletBootstrapTime"
+	MetricType_RebalanceTime         = "rebalanceTime"
+	MetricType_LostMessages          = "lostMessages"
+	MetricType_StoredBatchSize       = "storedBatchSize"
+	MetricType_ConsumptionPause      = "consumptionPause"
+	MetricType_WorkerLatencyP99Min   = "workerLatencyP99Min"
+	MetricType_WorkerLatencyP99Max   = "workerLatencyP99Max"
 )
 
 type MetricMeta struct {
@@ -20,17 +63,73 @@ type MetricMeta struct {
 }
 
 var (
-	MetricMeta_Duration            = &MetricMeta{Name: "duration", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
-	MetricMeta_CpuUsage            = &MetricMeta{Name: "cpuUsage", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Nano, UnitOfMeasure: UnitOfMeasure_Second}
-	MetricMeta_MemoryUsage         = &MetricMeta{Name: "memoryUsage", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
-	MetricMeta_MemoryUsageDiff     = &MetricMeta{Name: "memoryUsageDiff", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
-	MetricMeta_StorageReadUsage    = &MetricMeta{Name: "storageReadUsage", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
-	MetricMeta_StorageWriteUsage   = &MetricMeta{Name: "storageWriteUsage", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
-	MetricMeta_NetworkReceiveUsage = &MetricMeta{Name: "networkReceiveUsage", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
-	MetricMeta_NetworkSendUsage    = &MetricMeta{Name: "networkSendUsage", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
+	MetricMeta_Duration              = &MetricMeta{Name: "duration", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_CpuUsage              = &MetricMeta{Name: "cpuUsage", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Nano, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_MemoryUsage           = &MetricMeta{Name: "memoryUsage", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
+	MetricMeta_MemoryUsageDiff       = &MetricMeta{Name: "memoryUsageDiff", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
+	MetricMeta_StorageReadUsage      = &MetricMeta{Name: "storageReadUsage", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
+	MetricMeta_StorageWriteUsage     = &MetricMeta{Name: "storageWriteUsage", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
+	MetricMeta_NetworkReceiveUsage   = &MetricMeta{Name: "networkReceiveUsage", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
+	MetricMeta_NetworkSendUsage      = &MetricMeta{Name: "networkSendUsage", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
+	MetricMeta_LostUpdates           = &MetricMeta{Name: "lostUpdates", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Piece}
+	MetricMeta_WriteSkews            = &MetricMeta{Name: "writeSkews", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Piece}
+	MetricMeta_InvariantViolations   = &MetricMeta{Name: "invariantViolations", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Piece}
+	MetricMeta_AbortedTransactions   = &MetricMeta{Name: "abortedTransactions", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Piece}
+	MetricMeta_ReplicationLagP50     = &MetricMeta{Name: "replicationLagP50", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_ReplicationLagP90     = &MetricMeta{Name: "replicationLagP90", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_ReplicationLagP99     = &MetricMeta{Name: "replicationLagP99", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_BackupSize            = &MetricMeta{Name: "backupSize", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
+	MetricMeta_ProbeLatencyP99       = &MetricMeta{Name: "probeLatencyP99", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_ProbeLatencyMax       = &MetricMeta{Name: "probeLatencyMax", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_BlockedProbes         = &MetricMeta{Name: "blockedProbes", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Piece}
+	MetricMeta_RoundTripMismatches   = &MetricMeta{Name: "roundTripMismatches", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Piece}
+	MetricMeta_Throughput            = &MetricMeta{Name: "throughput", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_OperationPerSecond}
+	MetricMeta_Conflicts             = &MetricMeta{Name: "conflicts", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Piece}
+	MetricMeta_LatencyP50            = &MetricMeta{Name: "latencyP50", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_LatencyP90            = &MetricMeta{Name: "latencyP90", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_LatencyP99            = &MetricMeta{Name: "latencyP99", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_Errors                = &MetricMeta{Name: "errors", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Piece}
+	MetricMeta_AddedLatencyP50       = &MetricMeta{Name: "addedLatencyP50", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_AddedLatencyP99       = &MetricMeta{Name: "addedLatencyP99", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_MaxThroughput         = &MetricMeta{Name: "maxThroughput", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_OperationPerSecond}
+	MetricMeta_TransferRate          = &MetricMeta{Name: "transferRate", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_BytePerSecond}
+	MetricMeta_IngestionRate         = &MetricMeta{Name: "ingestionRate", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_SamplePerSecond}
+	MetricMeta_Recall                = &MetricMeta{Name: "recall", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Percent}
+	MetricMeta_StorageSize           = &MetricMeta{Name: "storageSize", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
+	MetricMeta_Cost                  = &MetricMeta{Name: "cost", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Dollar}
+	MetricMeta_OperationsPerDollar   = &MetricMeta{Name: "operationsPerDollar", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_OperationPerDollar}
+	MetricMeta_SustainableThroughput = &MetricMeta{Name: "sustainableThroughput", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_OperationPerSecond}
+	MetricMeta_SaturationConcurrency = &MetricMeta{Name: "saturationConcurrency", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Piece}
+	MetricMeta_ContainerUpTime       = &MetricMeta{Name: "containerUpTime", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_ServiceReadyTime      = &MetricMeta{Name: "serviceReadyTime", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_LoadRate              = &MetricMeta{Name: "loadRate", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_RowPerSecond}
+	MetricMeta_Connections           = &MetricMeta{Name: "connections", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Piece}
+	MetricMeta_ConnectionSetupP50    = &MetricMeta{Name: "connectionSetupP50", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_ConnectionSetupMax    = &MetricMeta{Name: "connectionSetupMax", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_Chunks                = &MetricMeta{Name: "chunks", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Piece}
+	MetricMeta_TabletBootstrapTime   = &MetricMeta{Name: "tabletBootstrapTime", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_RebalanceTime         = &MetricMeta{Name: "rebalanceTime", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_LostMessages          = &MetricMeta{Name: "lostMessages", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Piece}
+	MetricMeta_StoredBatchSize       = &MetricMeta{Name: "storedBatchSize", UnitOfMeasurePrefix: UnitOfMeasurePrefix_None, UnitOfMeasure: UnitOfMeasure_Byte}
+	MetricMeta_ConsumptionPause      = &MetricMeta{Name: "consumptionPause", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_WorkerLatencyP99Min   = &MetricMeta{Name: "workerLatencyP99Min", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
+	MetricMeta_WorkerLatencyP99Max   = &MetricMeta{Name: "workerLatencyP99Max", UnitOfMeasurePrefix: UnitOfMeasurePrefix_Micro, UnitOfMeasure: UnitOfMeasure_Second}
 )
 
+// IsHigherBetter returns whether bigger value of the metric means better result
+func (mm *MetricMeta) IsHigherBetter() bool {
+	switch mm.Name {
+	case MetricType_Throughput, MetricType_MaxThroughput, MetricType_TransferRate, MetricType_IngestionRate, MetricType_Recall, MetricType_OperationsPerDollar,
+		MetricType_SustainableThroughput, MetricType_SaturationConcurrency, MetricType_LoadRate:
+		return true
+	default:
+		return false
+	}
+}
+
 type Metric struct {
 	Meta  MetricMeta `json:"meta"`
 	Value float64    `json:"value"`
+	// Budget is set if the step metric has thresholds in the test case
+	Budget *Budget `json:"budget,omitempty"`
 }
@@ -0,0 +1,156 @@
+package domain
+
+type Severity string
+
+const (
+	Severity_Ok       = "ok"
+	Severity_Warning  = "warning"
+	Severity_Critical = "critical"
+)
+
+// MetricBudget declares warning and critical thresholds of the step metric.
+// Thresholds are durations for the time metrics or numbers in the metric units.
+// Empty threshold isn't checked.
+type MetricBudget struct {
+	Step     string `json:"step"`
+	Metric   string `json:"metric"`
+	Warning  string `json:"warning,omitempty"`
+	Critical string `json:"critical,omitempty"`
+}
+
+// Budget is the metric budget in the metric units with the severity of the metric value
+type Budget struct {
+	Warning  *float64 `json:"warning,omitempty"`
+	Critical *float64 `json:"critical,omitempty"`
+	Severity Severity `json:"severity"`
+}
+
+// BudgetBreach is the metric exceeding its warning or critical threshold
+type BudgetBreach struct {
+	ComponentType ComponentType `json:"component-type"`
+	Image         string        `json:"image"`
+	Step          string        `json:"step"`
+	Metric        MetricMeta    `json:"metric"`
+	Value         float64       `json:"value"`
+	Threshold     float64       `json:"threshold"`
+	Severity      Severity      `json:"severity"`
+}
+
+// SeveritySummary aggregates budget breaches of the report
+type SeveritySummary struct {
+	// The worst severity of the breaches
+	Level     Severity        `json:"level"`
+	Warnings  int             `json:"warnings"`
+	Criticals int             `json:"criticals"`
+	Breaches  []*BudgetBreach `json:"breaches,omitempty"`
+}
+
+// ValidateBudgets checks syntax of the test cases budget thresholds
+func (c *Config) ValidateBudgets() error {
+	for _, tc := range c.TestCases {
+		for _, mb := range tc.Budgets {
+			for _, s := range []string{mb.Warning, mb.Critical} {
+				if s == "" {
+					continue
+				}
+				if _, err := ParseThreshold(s); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// NewBudget converts step budget thresholds into the metric units and checks the metric value against them
+func NewBudget(mb *MetricBudget, meta *MetricMeta, value float64) (*Budget, error) {
+	b := &Budget{Severity: Severity_Ok}
+
+	var err error
+	if b.Warning, err = convertBudgetThreshold(mb.Warning, meta); err != nil {
+		return nil, err
+	}
+	if b.Critical, err = convertBudgetThreshold(mb.Critical, meta); err != nil {
+		return nil, err
+	}
+
+	isBreached := func(threshold *float64) bool {
+		if threshold == nil {
+			return false
+		}
+		if meta.IsHigherBetter() {
+			return value < *threshold
+		}
+		return value > *threshold
+	}
+
+	if isBreached(b.Critical) {
+		b.Severity = Severity_Critical
+	} else if isBreached(b.Warning) {
+		b.Severity = Severity_Warning
+	}
+
+	return b, nil
+}
+
+func convertBudgetThreshold(s string, meta *MetricMeta) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	t, err := ParseThreshold(s)
+	if err != nil {
+		return nil, err
+	}
+	value, err := t.InUnitsOf(meta)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func NewSeveritySummary() *SeveritySummary {
+	s := new(SeveritySummary)
+	s.Level = Severity_Ok
+	return s
+}
+
+// AddTestCaseResults adds metrics of the test case breaching their budgets
+func (s *SeveritySummary) AddTestCaseResults(tcr *TestCaseResults) {
+	for _, tcsr := range tcr.StepsResults {
+		for _, m := range tcsr.Metrics {
+			if m.Budget == nil || m.Budget.Severity == Severity_Ok {
+				continue
+			}
+
+			threshold := m.Budget.Warning
+			if m.Budget.Severity == Severity_Critical {
+				threshold = m.Budget.Critical
+			}
+			s.addBreach(&BudgetBreach{ComponentType: tcr.TestCase.ComponentType, Image: tcr.TestCase.Image, Step: tcsr.TestCaseStep.Name,
+				Metric: m.Meta, Value: m.Value, Threshold: *threshold, Severity: m.Budget.Severity})
+		}
+	}
+}
+
+// Merge adds breaches of the other summary
+func (s *SeveritySummary) Merge(other *SeveritySummary) {
+	for _, bb := range other.Breaches {
+		s.addBreach(bb)
+	}
+}
+
+func (s *SeveritySummary) addBreach(bb *BudgetBreach) {
+	s.Breaches = append(s.Breaches, bb)
+
+	switch bb.Severity {
+	case Severity_Critical:
+		s.Criticals++
+		s.Level = Severity_Critical
+	case Severity_Warning:
+		s.Warnings++
+		if s.Level != Severity_Critical {
+			s.Level = Severity_Warning
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package domain
+
+// Acknowledgements awaited by the producer: none, the leader's one or the ones of all the in-sync replicas
+type ProducerAcks string
+
+const (
+	ProducerAcks_None   = "0"
+	ProducerAcks_Leader = "1"
+	ProducerAcks_All    = "all"
+)
+
+var ProducerAcksModes = []ProducerAcks{
+	ProducerAcks_None,
+	ProducerAcks_Leader,
+	ProducerAcks_All,
+}
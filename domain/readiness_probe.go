@@ -0,0 +1,37 @@
+package domain
+
+type ProbeType string
+
+const (
+	// Component default probe is used
+	ProbeType_NA = ""
+	// Probe query is executed by the component tester client
+	ProbeType_Sql = "sql"
+	// Cluster metadata is fetched from the broker
+	ProbeType_KafkaMetadata = "kafkaMetadata"
+	// Health endpoint responds with 2xx
+	ProbeType_Http = "http"
+	// Port accepts TCP connections
+	ProbeType_Tcp = "tcp"
+	// Readiness isn't probed, component tester awaits the service itself
+	ProbeType_None = "none"
+)
+
+// ReadinessProbe defines how the service is awaited after the container start
+type ReadinessProbe struct {
+	Type ProbeType `json:"type,omitempty"`
+	// Query of the sql probe
+	Query string `json:"query,omitempty"`
+	// Health endpoint path of the http probe. Test case health check path is used if it's not set.
+	Path string `json:"path,omitempty"`
+	// Timeout in seconds to await the service ready. Startup timeout of the component is used if it's not set.
+	Timeout uint16 `json:"timeout,omitempty"`
+}
+
+func (p *ReadinessProbe) GetQuery() string {
+	if p.Query == "" {
+		return "SELECT 1"
+	} else {
+		return p.Query
+	}
+}
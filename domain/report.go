@@ -1,14 +1,42 @@
 package domain
 
 type Report struct {
-	TestCaseResults []*TestCaseResults `json:"test-case-results"`
+	// Severity summary goes first to be seen at the top of the report
+	Severity *SeveritySummary `json:"severity"`
+	// Label of the machine which run the test cases
+	Host                     string                     `json:"host,omitempty"`
+	TestCaseResults          []*TestCaseResults         `json:"test-case-results"`
+	Anomalies                []*Anomaly                 `json:"anomalies,omitempty"`
+	DriverOverheads          []*DriverOverhead          `json:"driver-overheads,omitempty"`
+	StorageEngineComparisons []*StorageEngineComparison `json:"storage-engine-comparisons,omitempty"`
+	// Failed assertions of the test cases, run fails if there is any
+	AssertionViolations []*AssertionViolation `json:"assertion-violations,omitempty"`
 }
 
 func NewReport() *Report {
 	r := new(Report)
+	r.Severity = NewSeveritySummary()
 	return r
 }
 
 func (r *Report) AddTestCaseResults(tcr *TestCaseResults) {
 	r.TestCaseResults = append(r.TestCaseResults, tcr)
+	r.Severity.AddTestCaseResults(tcr)
+}
+
+// FindMetric returns value of the step metric from the first test case which has it
+func (r *Report) FindMetric(step string, metric string) (float64, bool) {
+	for _, tcr := range r.TestCaseResults {
+		for _, tcsr := range tcr.StepsResults {
+			if tcsr.TestCaseStep.Name != step {
+				continue
+			}
+			for _, m := range tcsr.Metrics {
+				if m.Meta.Name == metric {
+					return m.Value, true
+				}
+			}
+		}
+	}
+	return 0, false
 }
@@ -0,0 +1,12 @@
+package domain
+
+// FindSustainableThroughput summarizes max sustainable throughput over all saturation steps of the test case
+func (tcr *TestCaseResults) FindSustainableThroughput() {
+	for _, tcsr := range tcr.StepsResults {
+		for _, m := range tcsr.Metrics {
+			if m.Meta.Name == MetricType_SustainableThroughput && m.Value > tcr.SustainableThroughput {
+				tcr.SustainableThroughput = m.Value
+			}
+		}
+	}
+}
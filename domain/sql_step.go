@@ -0,0 +1,79 @@
+package domain
+
+// SqlStep is run by the clients executing its statements in the single connection, e.g. imported pgbench script or JMeter JDBC sampler
+type SqlStep struct {
+	Name string `json:"name"`
+	// Statements of the single transaction with ":name" placeholders of the variables
+	Statements []string      `json:"statements"`
+	Variables  []SqlVariable `json:"variables,omitempty"`
+	Clients    uint16        `json:"clients,omitempty"`
+	// Executions count of the statements by all clients
+	Transactions uint32 `json:"transactions,omitempty"`
+}
+
+// SqlVariable value is uniformly distributed in [Min, Max] range and generated for every transaction
+type SqlVariable struct {
+	Name string `json:"name"`
+	Min  int64  `json:"min"`
+	Max  int64  `json:"max"`
+}
+
+func (s *SqlStep) GetClients() uint16 {
+	if s.Clients == 0 {
+		return 1
+	} else {
+		return s.Clients
+	}
+}
+
+func (s *SqlStep) GetTransactions() uint32 {
+	if s.Transactions == 0 {
+		return 1
+	} else {
+		return s.Transactions
+	}
+}
+
+// ExpandSqlVariables replaces ":name" placeholders of the statement by the values returned by lookup.
+// Placeholders unknown for the lookup and "::type" casts are left as is.
+func ExpandSqlVariables(statement string, lookup func(name string) (string, bool)) string {
+	var (
+		buf = make([]byte, 0, len(statement))
+		i   = 0
+	)
+	for i < len(statement) {
+		c := statement[i]
+		if c != ':' || (i > 0 && statement[i-1] == ':') {
+			buf = append(buf, c)
+			i++
+			continue
+		}
+
+		end := i + 1
+		for end < len(statement) && isSqlVariableChar(statement[end], end == i+1) {
+			end++
+		}
+		if end > i+1 {
+			if value, ok := lookup(statement[i+1 : end]); ok {
+				buf = append(buf, value...)
+				i = end
+				continue
+			}
+		}
+
+		buf = append(buf, c)
+		i++
+	}
+	return string(buf)
+}
+
+func isSqlVariableChar(c byte, isFirst bool) bool {
+	switch {
+	case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		return true
+	case c >= '0' && c <= '9':
+		return !isFirst
+	default:
+		return false
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+// Durations of the same step of the test cases which differ by the variant only, e.g. driver or storage engine
+type variantDurations struct {
+	ComponentType ComponentType
+	Image         string
+	Step          string
+	// Step durations in microseconds by the variant
+	Durations map[string]float64
+	// Variant with the min duration
+	Fastest string
+}
+
+// Method groups step durations of the test cases with the same image by the variant.
+// Test cases with empty variant are skipped, steps without other variants aren't returned.
+func (r *Report) collectVariantDurations(variant func(tc *TestCase) string) []*variantDurations {
+	durations := make(map[string]*variantDurations)
+	// Keys keep durations in the order of the test cases
+	var keys []string
+
+	for _, tcr := range r.TestCaseResults {
+		tc := &tcr.TestCase
+		v := variant(tc)
+		if v == "" {
+			continue
+		}
+
+		for _, tcsr := range tcr.StepsResults {
+			for _, m := range tcsr.Metrics {
+				if m.Meta.Name != MetricType_Duration {
+					continue
+				}
+
+				key := string(tc.ComponentType) + "/" + tc.Image + tc.ComposeFile + "/" + tcsr.TestCaseStep.Name
+				vd, ok := durations[key]
+				if !ok {
+					vd = &variantDurations{ComponentType: tc.ComponentType, Image: tc.Image, Step: tcsr.TestCaseStep.Name, Durations: make(map[string]float64)}
+					durations[key] = vd
+					keys = append(keys, key)
+				}
+				vd.Durations[v] = m.Value
+			}
+		}
+	}
+
+	var compared []*variantDurations
+	for _, key := range keys {
+		vd := durations[key]
+		// Single variant has nothing to compare with
+		if len(vd.Durations) < 2 {
+			continue
+		}
+
+		for v, duration := range vd.Durations {
+			if vd.Fastest == "" || duration < vd.Durations[vd.Fastest] {
+				vd.Fastest = v
+			}
+		}
+		compared = append(compared, vd)
+	}
+	return compared
+}
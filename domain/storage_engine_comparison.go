@@ -0,0 +1,49 @@
+package domain
+
+// StorageEngineComparison compares step duration of the same component run with different storage engines
+type StorageEngineComparison struct {
+	ComponentType ComponentType `json:"component-type"`
+	Image         string        `json:"image"`
+	Step          string        `json:"step"`
+	Fastest       string        `json:"fastest"`
+	// Step durations in microseconds
+	Durations map[string]float64 `json:"durations"`
+	// Duration of the engine divided by the fastest engine duration
+	Slowdowns map[string]float64 `json:"slowdowns"`
+}
+
+// CompareStorageEngines builds engine vs engine table of the step durations of the test cases with the same image
+func (r *Report) CompareStorageEngines() {
+	r.StorageEngineComparisons = nil
+	for _, vd := range r.collectVariantDurations(func(tc *TestCase) string { return tc.StorageEngine }) {
+		sec := &StorageEngineComparison{ComponentType: vd.ComponentType, Image: vd.Image, Step: vd.Step, Fastest: vd.Fastest,
+			Durations: vd.Durations, Slowdowns: make(map[string]float64, len(vd.Durations))}
+
+		for engine, duration := range vd.Durations {
+			if fastest := vd.Durations[vd.Fastest]; fastest > 0 {
+				sec.Slowdowns[engine] = duration / fastest
+			}
+		}
+
+		r.StorageEngineComparisons = append(r.StorageEngineComparisons, sec)
+	}
+}
+
+// ExpandStorageEngines replaces test cases with storage engines list by the test case copy for every engine
+func (c *Config) ExpandStorageEngines() {
+	var tcs []TestCase
+	for _, tc := range c.TestCases {
+		if len(tc.StorageEngines) == 0 {
+			tcs = append(tcs, tc)
+			continue
+		}
+
+		for _, engine := range tc.StorageEngines {
+			engineTc := tc
+			engineTc.StorageEngine = engine
+			engineTc.StorageEngines = nil
+			tcs = append(tcs, engineTc)
+		}
+	}
+	c.TestCases = tcs
+}
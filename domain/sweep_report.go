@@ -0,0 +1,76 @@
+package domain
+
+import "strconv"
+
+// SweepReport compares results of the same test cases run against different image tags
+type SweepReport struct {
+	// Budget breaches of all tags
+	Severity      *SeveritySummary   `json:"severity"`
+	ComponentType ComponentType      `json:"component-type"`
+	Tags          []string           `json:"tags"`
+	Reports       map[string]*Report `json:"reports"`
+	Winners       []*SweepWinner     `json:"winners"`
+}
+
+// SweepWinner is the tag with the best value of the step metric
+type SweepWinner struct {
+	// Index of the test case in the swept test cases
+	TestCase int                `json:"test-case"`
+	Step     string             `json:"step"`
+	Metric   MetricMeta         `json:"metric"`
+	Tag      string             `json:"tag"`
+	Values   map[string]float64 `json:"values"`
+}
+
+func NewSweepReport(ct ComponentType, tags []string) *SweepReport {
+	r := new(SweepReport)
+	r.ComponentType = ct
+	r.Tags = tags
+	r.Reports = make(map[string]*Report)
+	r.Severity = NewSeveritySummary()
+	return r
+}
+
+func (r *SweepReport) AddReport(tag string, report *Report) {
+	r.Reports[tag] = report
+	r.Severity.Merge(report.Severity)
+}
+
+// ChooseWinners collects values of every step metric across tags and picks the best one
+func (r *SweepReport) ChooseWinners() {
+	winners := make(map[string]*SweepWinner)
+	// Keys keep winners in the order of the first report
+	var keys []string
+
+	for _, tag := range r.Tags {
+		report, ok := r.Reports[tag]
+		if !ok || report == nil {
+			continue
+		}
+
+		for i, tcr := range report.TestCaseResults {
+			for _, tcsr := range tcr.StepsResults {
+				for _, m := range tcsr.Metrics {
+					key := strconv.FormatInt(int64(i), 10) + "/" + tcsr.TestCaseStep.Name + "/" + m.Meta.Name
+					w, ok := winners[key]
+					if !ok {
+						w = &SweepWinner{TestCase: i, Step: tcsr.TestCaseStep.Name, Metric: m.Meta, Values: make(map[string]float64)}
+						winners[key] = w
+						keys = append(keys, key)
+					}
+					w.Values[tag] = m.Value
+
+					best := w.Values[w.Tag]
+					if w.Tag == "" || (m.Meta.IsHigherBetter() && m.Value > best) || (!m.Meta.IsHigherBetter() && m.Value < best) {
+						w.Tag = tag
+					}
+				}
+			}
+		}
+	}
+
+	r.Winners = nil
+	for _, key := range keys {
+		r.Winners = append(r.Winners, winners[key])
+	}
+}
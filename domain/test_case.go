@@ -1,11 +1,53 @@
 package domain
 
+import (
+	"strings"
+	"time"
+)
+
 type ComponentType string
 
 const (
-	ComponentType_NA       = ""
-	ComponentType_Postgres = "postgres"
-	ComponentType_Kafka    = "kafka"
+	ComponentType_NA            = ""
+	ComponentType_Postgres      = "postgres"
+	ComponentType_MySQL         = "mysql"
+	ComponentType_MariaDB       = "mariadb"
+	ComponentType_ClickHouse    = "clickhouse"
+	ComponentType_Mongo         = "mongo"
+	ComponentType_Kafka         = "kafka"
+	ComponentType_Http          = "http"
+	ComponentType_Grpc          = "grpc"
+	ComponentType_Proxy         = "proxy"
+	ComponentType_Sftp          = "sftp"
+	ComponentType_Ftp           = "ftp"
+	ComponentType_Nfs           = "nfs"
+	ComponentType_Smb           = "smb"
+	ComponentType_Tsdb          = "tsdb"
+	ComponentType_Qdrant        = "qdrant"
+	ComponentType_Keycloak      = "keycloak"
+	ComponentType_Vault         = "vault"
+	ComponentType_Temporal      = "temporal"
+	ComponentType_Redis         = "redis"
+	ComponentType_Cassandra     = "cassandra"
+	ComponentType_ScyllaDB      = "scylladb"
+	ComponentType_CockroachDB   = "cockroachdb"
+	ComponentType_SQLite        = "sqlite"
+	ComponentType_MSSQL         = "mssql"
+	ComponentType_Oracle        = "oracle"
+	ComponentType_TimescaleDB   = "timescaledb"
+	ComponentType_InfluxDB      = "influxdb"
+	ComponentType_Elasticsearch = "elasticsearch"
+	ComponentType_OpenSearch    = "opensearch"
+	ComponentType_Couchbase     = "couchbase"
+	ComponentType_Neo4j         = "neo4j"
+	ComponentType_Etcd          = "etcd"
+	ComponentType_YugabyteDB    = "yugabytedb"
+	ComponentType_QuestDB       = "questdb"
+	ComponentType_CrateDB       = "cratedb"
+	ComponentType_Aerospike     = "aerospike"
+	ComponentType_RabbitMQ      = "rabbitmq"
+	ComponentType_Nats          = "nats"
+	ComponentType_Redpanda      = "redpanda"
 )
 
 type TestCase struct {
@@ -13,7 +55,132 @@ type TestCase struct {
 	Image         string            `json:"image"`
 	Port          uint16            `json:"port"`
 	EnvVars       map[string]string `json:"env-vars"`
+	// Container binds in the docker "host-path:container-path[:ro]" format
+	Binds []string `json:"binds,omitempty"`
+	// Container command. Image's default one is used if it's not set.
+	Cmd []string `json:"cmd,omitempty"`
+	// Additional container ports published to the same host ports
+	ExtraPorts []uint16 `json:"extra-ports,omitempty"`
+	// Tester of the component if it's not the one of the component type, e.g. broker for Redis Streams
+	Mode TesterMode `json:"mode,omitempty"`
+	// Credentials for the components which don't take them from env vars
+	User     string `json:"user,omitempty"`
+	Password string `json:"-"`
+	// Compose file for multi container scenarios. Image is ignored if it's set.
+	ComposeFile string `json:"compose-file,omitempty"`
+	// Compose service which container is tested and used for metrics collection
+	ComposeService string `json:"compose-service,omitempty"`
+	// Nodes of the cluster launched by the compose file and their roles
+	Nodes []ClusterNode `json:"nodes,omitempty"`
+	// Port of the replica for replication scenarios
+	ReplicaPort   uint16 `json:"replica-port,omitempty"`
 	Accumulations uint16
+	// Probe awaiting the service ready after the container start. Component type default is used if its type isn't set.
+	ReadinessProbe ReadinessProbe `json:"readiness-probe,omitempty"`
+	// Path which responds with 2xx when HTTP service is ready
+	HealthCheckPath string         `json:"health-check-path,omitempty"`
+	HttpEndpoints   []HttpEndpoint `json:"http-endpoints,omitempty"`
+	// Path to the FileDescriptorSet of the gRPC service. Server reflection is used if it's not set.
+	ProtoDescriptorSetPath string       `json:"proto-descriptor-set-path,omitempty"`
+	GrpcMethods            []GrpcMethod `json:"grpc-methods,omitempty"`
+	// Writable directory on the file transfer server
+	RemoteDir string `json:"remote-dir,omitempty"`
+	// Network filesystem export and mount options passed to the host mount command,
+	// e.g. "localhost:/" with "port=2049,nfsvers=4" or "//localhost/share" with "username=user,password=password"
+	MountSource  string `json:"mount-source,omitempty"`
+	MountOptions string `json:"-"`
+	// Prometheus compatible remote write and query_range API paths of the metrics backend
+	RemoteWritePath string `json:"remote-write-path,omitempty"`
+	QueryRangePath  string `json:"query-range-path,omitempty"`
+	// Path which triggers compaction or merge of the metrics backend storage
+	CompactionPath string `json:"compaction-path,omitempty"`
+	// Path prefix of the identity provider endpoints, e.g. "/auth" for Keycloak before 17
+	ContextPath string `json:"context-path,omitempty"`
+	// Vector search workload parameters. Vector tester collections of 1000, 10x, 100x and more points are tested up to the vectors count,
+	// pgvector workload table has the vectors count rows.
+	VectorDimension uint16 `json:"vector-dimension,omitempty"`
+	VectorsCount    uint32 `json:"vectors-count,omitempty"`
+	TopK            uint16 `json:"top-k,omitempty"`
+	// Documents count of the first ingestion of the search and document workloads, the next ones use 10x and more documents
+	DocumentsCount uint32 `json:"documents-count,omitempty"`
+	// Nodes count of the first graph of the graph workload, the next one has 10x nodes
+	NodesCount uint32 `json:"nodes-count,omitempty"`
+	// Partitions counts of the topics of the broker partitions sweep
+	PartitionsCounts []uint16 `json:"partitions-counts,omitempty"`
+	// Sizes of the messages of the broker message size sweep in bytes
+	MessageSizes []uint32 `json:"message-sizes,omitempty"`
+	// Port of the echo backend started by cott for the proxy tester
+	EchoPort uint16 `json:"echo-port,omitempty"`
+	// Number of concurrent clients for the load steps
+	Concurrency uint16 `json:"concurrency,omitempty"`
+	// p99 latency SLO in milliseconds for the saturation workload
+	SaturationLatencyP99 uint32 `json:"saturation-latency-p99,omitempty"`
+	// Max concurrency of the saturation workload ramp
+	SaturationMaxConcurrency uint16 `json:"saturation-max-concurrency,omitempty"`
+	// Rows inserted by the single transaction of the giantTransaction workload
+	GiantTransactionRowsCount uint32 `json:"giant-transaction-rows-count,omitempty"`
+	// Clients of the concurrentClients workload, each one runs the insert and select mix by its own connection of the pool
+	ConcurrentClients uint16 `json:"concurrent-clients,omitempty"`
+	// Small transactions run by the transactionThroughput workload for the commit and for the rollback
+	TransactionsCount uint32 `json:"transactions-count,omitempty"`
+	// Concurrent connections of the shardedInsert workload, each one inserts its own range of rows
+	InsertShards           uint16 `json:"insert-shards,omitempty"`
+	ShardedInsertRowsCount uint32 `json:"sharded-insert-rows-count,omitempty"`
+	// Shards of the shardedInsert workload insert directly into their range partitions of the table
+	ShardedInsertPartitions bool `json:"sharded-insert-partitions,omitempty"`
+	// Share of operations with already existing key for the keyConflicts workload, it's 0.1 if it isn't set
+	ConflictRate *float64 `json:"conflict-rate,omitempty"`
+	// Postgres field definitions of the table workload. Values of the columns are generated by their types,
+	// and the serial primary key is filled by the database. Index, update, delete, aggregation and join steps use its integer columns
+	// and are skipped if there are none.
+	TableFields []string `json:"table-fields,omitempty"`
+	// Conditions of the select by conditions step of the table workload. For the custom table fields they match about half of the rows
+	// by the first integer column, and the step is skipped if there is none.
+	TableSelectConditions string `json:"table-select-conditions,omitempty"`
+	// Rows counts of the table workload, every count is inserted into the empty table and tested by all the table steps
+	DataCounts []uint32 `json:"data-counts,omitempty"`
+	// Rows count of the single insert of the table workload, which is decreased to the max rows count of the repository
+	InsertBatchSize uint32 `json:"insert-batch-size,omitempty"`
+	// Seed of the table data generation, so two runs with the same seed generate identical datasets.
+	// Random seed is used if it's 0, the effective seed is printed in the test case results.
+	Seed int64 `json:"seed,omitempty"`
+	// Name of the database created by the test case
+	DatabaseName string `json:"database-name,omitempty"`
+	// Prefix of the tables created by the test case
+	TablePrefix string `json:"table-prefix,omitempty"`
+	// Unique ID of the run is appended to the database and table names, so several runs could share the same server.
+	// ORM overhead workload is skipped, because its table name is fixed.
+	UniqueNames bool `json:"unique-names,omitempty"`
+	// Client driver of the database repository
+	Driver Driver `json:"driver,omitempty"`
+	// Test case is run with every driver to compare their overhead
+	Drivers []Driver `json:"-"`
+	// Storage engine of the created tables, e.g. table access method of Postgres 12+
+	StorageEngine string `json:"storage-engine,omitempty"`
+	// Test case is run with every storage engine to compare them
+	StorageEngines []string `json:"-"`
+	// Directory of the embedded database files. Databases are kept in memory if it's not set.
+	DataDir string `json:"data-dir,omitempty"`
+	// Hourly cost of the instance running the component in dollars.
+	// Cost of the instance type from the config is used if it's not set.
+	HourlyCost   float64 `json:"hourly-cost,omitempty"`
+	InstanceType string  `json:"instance-type,omitempty"`
+	// Thresholds of the step metrics, e.g. "10000xInsertEmptyTableDuration < 2s"
+	Assertions []string `json:"assertions,omitempty"`
+	// Steps executing SQL statements by concurrent clients, steps imported from pgbench scripts and JMeter plan are appended to them
+	SqlSteps []SqlStep `json:"sql-steps,omitempty"`
+	// pgbench custom scripts run by concurrency clients with pgbench transactions by every client like "pgbench -c -t"
+	PgbenchScripts      []string `json:"pgbench-scripts,omitempty"`
+	PgbenchTransactions uint32   `json:"pgbench-transactions,omitempty"`
+	// JMeter test plan which JDBC samplers are converted into the SQL steps
+	JmeterPlan string `json:"jmeter-plan,omitempty"`
+	// Starlark script with custom steps run against the component repository
+	ScriptPath   string            `json:"script-path,omitempty"`
+	ScriptParams map[string]string `json:"script-params,omitempty"`
+	// Warning and critical thresholds of the step metrics aggregated into the report severity summary
+	Budgets []MetricBudget `json:"budgets,omitempty"`
+	// Optional workloads which will be run in addition to the default one
+	Workloads     []Workload     `json:"workloads,omitempty"`
 	TestCaseSteps []TestCaseStep `json:"steps"`
 }
 
@@ -24,3 +191,347 @@ func (tc *TestCase) GetAccumulationsCount() uint16 {
 		return tc.Accumulations
 	}
 }
+
+// GetReadinessProbeType returns probe type of the test case or the default one of the component type
+func (tc *TestCase) GetReadinessProbeType() ProbeType {
+	if tc.ReadinessProbe.Type != ProbeType_NA {
+		return tc.ReadinessProbe.Type
+	}
+
+	switch tc.ComponentType {
+	case ComponentType_Postgres, ComponentType_MySQL, ComponentType_MariaDB, ComponentType_ClickHouse, ComponentType_CockroachDB, ComponentType_MSSQL, ComponentType_Oracle,
+		ComponentType_TimescaleDB, ComponentType_YugabyteDB, ComponentType_CrateDB:
+		return ProbeType_Sql
+	case ComponentType_Kafka, ComponentType_Redpanda:
+		return ProbeType_KafkaMetadata
+	case ComponentType_Http:
+		return ProbeType_Http
+	case ComponentType_SQLite:
+		return ProbeType_None
+	default:
+		return ProbeType_Tcp
+	}
+}
+
+// IsEmbedded returns whether the component runs inside the tester process, so there is no container to launch
+func (tc *TestCase) IsEmbedded() bool {
+	return tc.ComponentType == ComponentType_SQLite
+}
+
+// GetStartupTimeout returns time to await the service responding after the container start.
+// CQL databases open CQL port only after the node has joined the ring, which takes about a minute.
+func (tc *TestCase) GetStartupTimeout() time.Duration {
+	switch tc.ComponentType {
+	case ComponentType_Cassandra, ComponentType_ScyllaDB:
+		return 3 * time.Minute
+	// SQL Server upgrades system databases on the first start
+	case ComponentType_MSSQL:
+		return 2 * time.Minute
+	// Express Edition image creates pluggable database on the first start
+	case ComponentType_Oracle:
+		return 5 * time.Minute
+	// Master and tablet server are started and bootstrap their tablets one by one
+	case ComponentType_YugabyteDB:
+		return 2 * time.Minute
+	// JVM broker elects KRaft controller and loads cluster metadata, while Redpanda is the single native process
+	case ComponentType_Kafka:
+		return 2 * time.Minute
+	// Erlang VM boots plugins before the AMQP listener
+	case ComponentType_RabbitMQ:
+		return time.Minute
+	// Search engines start JVM and recover cluster state, which takes longer than databases
+	case ComponentType_Elasticsearch, ComponentType_OpenSearch:
+		return 2 * time.Minute
+	// Neo4j starts JVM and recovers transaction log before opening Bolt port
+	case ComponentType_Neo4j:
+		return 2 * time.Minute
+	// Node is initialized and its services are started after the container start
+	case ComponentType_Couchbase:
+		return 2 * time.Minute
+	// Identity providers usually start slower than databases
+	case ComponentType_Keycloak:
+		return 3 * time.Minute
+	// Auto setup images create schema and namespace on start
+	case ComponentType_Temporal:
+		return 3 * time.Minute
+	// Services, proxies, file servers and stores other than databases
+	case ComponentType_Http, ComponentType_Grpc, ComponentType_Proxy, ComponentType_Tsdb,
+		ComponentType_Sftp, ComponentType_Ftp, ComponentType_Nfs, ComponentType_Smb,
+		ComponentType_Redis, ComponentType_Etcd, ComponentType_Aerospike, ComponentType_Vault,
+		ComponentType_InfluxDB, ComponentType_QuestDB, ComponentType_Qdrant:
+		return time.Minute
+	default:
+		return 30 * time.Second
+	}
+}
+
+func (tc *TestCase) GetReadinessProbeTimeout() time.Duration {
+	if tc.ReadinessProbe.Timeout == 0 {
+		return tc.GetStartupTimeout()
+	} else {
+		return time.Duration(tc.ReadinessProbe.Timeout) * time.Second
+	}
+}
+
+func (tc *TestCase) GetReadinessProbePath() string {
+	if tc.ReadinessProbe.Path == "" {
+		return tc.HealthCheckPath
+	} else {
+		return tc.ReadinessProbe.Path
+	}
+}
+
+func (tc *TestCase) GetEchoPort() uint16 {
+	if tc.EchoPort == 0 {
+		return 8090
+	} else {
+		return tc.EchoPort
+	}
+}
+
+func (tc *TestCase) GetRemoteWritePath() string {
+	if tc.RemoteWritePath == "" {
+		return "/api/v1/write"
+	} else {
+		return tc.RemoteWritePath
+	}
+}
+
+func (tc *TestCase) GetQueryRangePath() string {
+	if tc.QueryRangePath == "" {
+		return "/api/v1/query_range"
+	} else {
+		return tc.QueryRangePath
+	}
+}
+
+func (tc *TestCase) GetVectorDimension() uint16 {
+	if tc.VectorDimension == 0 {
+		return 128
+	} else {
+		return tc.VectorDimension
+	}
+}
+
+func (tc *TestCase) GetVectorsCount() uint32 {
+	if tc.VectorsCount == 0 {
+		return 100000
+	} else {
+		return tc.VectorsCount
+	}
+}
+
+func (tc *TestCase) GetTopK() uint16 {
+	if tc.TopK == 0 {
+		return 10
+	} else {
+		return tc.TopK
+	}
+}
+
+func (tc *TestCase) GetDocumentsCount() uint32 {
+	if tc.DocumentsCount == 0 {
+		return 1000
+	} else {
+		return tc.DocumentsCount
+	}
+}
+
+func (tc *TestCase) GetNodesCount() uint32 {
+	if tc.NodesCount == 0 {
+		return 10000
+	} else {
+		return tc.NodesCount
+	}
+}
+
+func (tc *TestCase) GetPartitionsCounts() []uint16 {
+	if len(tc.PartitionsCounts) == 0 {
+		return []uint16{1, 3, 12, 48}
+	} else {
+		return tc.PartitionsCounts
+	}
+}
+
+func (tc *TestCase) GetMessageSizes() []uint32 {
+	if len(tc.MessageSizes) == 0 {
+		return []uint32{100, 1000, 10000, 1000000}
+	} else {
+		return tc.MessageSizes
+	}
+}
+
+func (tc *TestCase) GetTableFields() []string {
+	if len(tc.TableFields) == 0 {
+		return []string{
+			"id BIGSERIAL PRIMARY KEY",
+			"f1 BIGINT",
+			"f2 BIGSERIAL",
+			"f3 BOOLEAN",
+			"f4 DATE",
+			"f5 FLOAT",
+			"f6 REAL",
+			"f7 INTEGER",
+			"f8 NUMERIC",
+			"f9 SMALLINT",
+			"f10 SMALLSERIAL",
+			"f11 SERIAL",
+		}
+	} else {
+		return tc.TableFields
+	}
+}
+
+func (tc *TestCase) GetTableSelectConditions() string {
+	if tc.TableSelectConditions == "" {
+		return "f1>1 AND f2>1 AND f3 AND F5>0.5 AND f6>0.5 AND f7>1 AND f8>1 AND f9>1 AND f10>1 AND f11>1"
+	} else {
+		return tc.TableSelectConditions
+	}
+}
+
+func (tc *TestCase) GetDataCounts() []uint32 {
+	if len(tc.DataCounts) == 0 {
+		return []uint32{1, 10, 100, 1000, 10000, 100000, 1000000, 10000000}
+	} else {
+		return tc.DataCounts
+	}
+}
+
+func (tc *TestCase) GetInsertBatchSize() uint32 {
+	if tc.InsertBatchSize == 0 {
+		return 1000
+	} else {
+		return tc.InsertBatchSize
+	}
+}
+
+func (tc *TestCase) GetDatabaseName() string {
+	if tc.DatabaseName == "" {
+		return "cott_db"
+	} else {
+		return tc.DatabaseName
+	}
+}
+
+func (tc *TestCase) GetDriver() Driver {
+	if tc.Driver == "" {
+		return Driver_LibPq
+	} else {
+		return tc.Driver
+	}
+}
+
+func (tc *TestCase) GetConcurrency() uint16 {
+	if tc.Concurrency == 0 {
+		return 1
+	} else {
+		return tc.Concurrency
+	}
+}
+
+func (tc *TestCase) GetSaturationLatencyP99() uint32 {
+	if tc.SaturationLatencyP99 == 0 {
+		return 50
+	} else {
+		return tc.SaturationLatencyP99
+	}
+}
+
+func (tc *TestCase) GetSaturationMaxConcurrency() uint16 {
+	if tc.SaturationMaxConcurrency == 0 {
+		return 512
+	} else {
+		return tc.SaturationMaxConcurrency
+	}
+}
+
+func (tc *TestCase) GetGiantTransactionRowsCount() uint32 {
+	if tc.GiantTransactionRowsCount == 0 {
+		return 1000000
+	} else {
+		return tc.GiantTransactionRowsCount
+	}
+}
+
+func (tc *TestCase) GetConcurrentClients() uint16 {
+	if tc.ConcurrentClients == 0 {
+		return 16
+	} else {
+		return tc.ConcurrentClients
+	}
+}
+
+func (tc *TestCase) GetTransactionsCount() uint32 {
+	if tc.TransactionsCount == 0 {
+		return 10000
+	} else {
+		return tc.TransactionsCount
+	}
+}
+
+func (tc *TestCase) GetInsertShards() uint16 {
+	if tc.InsertShards == 0 {
+		return 4
+	} else {
+		return tc.InsertShards
+	}
+}
+
+func (tc *TestCase) GetShardedInsertRowsCount() uint32 {
+	if tc.ShardedInsertRowsCount == 0 {
+		return 1000000
+	} else {
+		return tc.ShardedInsertRowsCount
+	}
+}
+
+func (tc *TestCase) GetPgbenchTransactions() uint32 {
+	if tc.PgbenchTransactions == 0 {
+		return 10
+	} else {
+		return tc.PgbenchTransactions
+	}
+}
+
+func (tc *TestCase) GetConflictRate() float64 {
+	if tc.ConflictRate == nil {
+		return 0.1
+	} else {
+		return *tc.ConflictRate
+	}
+}
+
+// WithImageTag returns copy of the test case with the image tag replaced
+func (tc *TestCase) WithImageTag(tag string) TestCase {
+	tagged := *tc
+
+	image := tc.Image
+	// Colon before the last slash belongs to the registry port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	tagged.Image = image + ":" + tag
+
+	return tagged
+}
+
+// GetStepBudgets returns budgets of the step metrics by the metric name
+func (tc *TestCase) GetStepBudgets(step string) map[string]*MetricBudget {
+	budgets := make(map[string]*MetricBudget)
+	for i, mb := range tc.Budgets {
+		if mb.Step == step {
+			budgets[mb.Metric] = &tc.Budgets[i]
+		}
+	}
+	return budgets
+}
+
+func (tc *TestCase) HasWorkload(w Workload) bool {
+	for _, v := range tc.Workloads {
+		if v == w {
+			return true
+		}
+	}
+	return false
+}
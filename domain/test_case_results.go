@@ -3,5 +3,13 @@ package domain
 type TestCaseResults struct {
 	TestCase     TestCase               `json:"test-case"`
 	Score        float32                `json:"score"`
+	Grade        Grade                  `json:"grade,omitempty"`
 	StepsResults []*TestCaseStepResults `json:"steps-results,omitempty"`
+	// Databases, tables and containers which weren't removed after the test case
+	Leftovers      []string        `json:"leftovers,omitempty"`
+	CostEfficiency *CostEfficiency `json:"cost-efficiency,omitempty"`
+	// Max throughput over the saturation steps which satisfies the latency SLO
+	SustainableThroughput float64 `json:"sustainable-throughput,omitempty"`
+	// Effective seed of the table data generation, which reproduces the dataset of the run
+	Seed int64 `json:"seed,omitempty"`
 }
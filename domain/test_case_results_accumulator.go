@@ -3,6 +3,11 @@ package domain
 type TestCaseResultsAccumulator struct {
 	TestCase                        *TestCase
 	testCaseStepResultsAccumulators []*TestCaseStepResultsAccumulator
+	leftovers                       []string
+	// Container IDs of the cluster nodes by their services
+	nodeContainerIds map[string]string
+	// Effective seed of the table data generation
+	seed int64
 }
 
 func NewTestCaseResultsAccumulator(tc *TestCase) *TestCaseResultsAccumulator {
@@ -15,8 +20,52 @@ func (r *TestCaseResultsAccumulator) AddTestCaseStepResultsAccumulator(tcsra *Te
 	r.testCaseStepResultsAccumulators = append(r.testCaseStepResultsAccumulators, tcsra)
 }
 
+func (r *TestCaseResultsAccumulator) AddLeftover(leftover string) {
+	// The same leftover could be found on every accumulation
+	for _, v := range r.leftovers {
+		if v == leftover {
+			return
+		}
+	}
+	r.leftovers = append(r.leftovers, leftover)
+}
+
+func (r *TestCaseResultsAccumulator) SetNodeContainerId(service string, id string) {
+	if r.nodeContainerIds == nil {
+		r.nodeContainerIds = make(map[string]string)
+	}
+	r.nodeContainerIds[service] = id
+}
+
+// GetNodeContainerId returns container ID of the cluster node, so its resources usage could be collected
+func (r *TestCaseResultsAccumulator) GetNodeContainerId(service string) (string, bool) {
+	id, ok := r.nodeContainerIds[service]
+	return id, ok
+}
+
+func (r *TestCaseResultsAccumulator) SetSeed(seed int64) {
+	r.seed = seed
+}
+
+// GetTestCaseStepResultsAccumulator returns accumulator for the step with the same name or creates a new one
+func (r *TestCaseResultsAccumulator) GetTestCaseStepResultsAccumulator(tcs *TestCaseStep) *TestCaseStepResultsAccumulator {
+	for _, v := range r.testCaseStepResultsAccumulators {
+		if v.testCaseStep.Name == tcs.Name {
+			return v
+		}
+	}
+
+	tcsra := NewTestCaseStepResultsAccumulator(tcs)
+	tcsra.budgets = r.TestCase.GetStepBudgets(tcs.Name)
+	r.AddTestCaseStepResultsAccumulator(tcsra)
+	return tcsra
+}
+
 func (r *TestCaseResultsAccumulator) ToTestCaseResults() *TestCaseResults {
 	tcr := new(TestCaseResults)
+	tcr.TestCase = *r.TestCase
+	tcr.Leftovers = r.leftovers
+	tcr.Seed = r.seed
 
 	for _, v := range r.testCaseStepResultsAccumulators {
 		tcr.StepsResults = append(tcr.StepsResults, v.ToTestCaseStepResults())
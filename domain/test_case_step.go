@@ -3,8 +3,10 @@ package domain
 import "bytes"
 
 type TestCaseStep struct {
-	Name     string
-	StepFunc func() error
+	Name     string       `json:"name"`
+	StepFunc func() error `json:"-"`
+	// MetricsFunc is optional and adds step specific metrics after successful StepFunc execution
+	MetricsFunc func(tcsra *TestCaseStepResultsAccumulator) `json:"-"`
 }
 
 func (s *TestCaseStep) String() string {
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"sort"
+
 	"github.com/sirupsen/logrus"
 	"gonum.org/v1/gonum/stat"
 )
@@ -10,6 +12,8 @@ type TestCaseStepResultsAccumulator struct {
 	// TODO Refactor onto interface
 	metricsMap map[MetricMeta][]float64
 	errors     []string
+	// Budgets of the step metrics by the metric name
+	budgets map[string]*MetricBudget
 }
 
 func NewTestCaseStepResultsAccumulator(tcs *TestCaseStep) *TestCaseStepResultsAccumulator {
@@ -28,6 +32,20 @@ func (r *TestCaseStepResultsAccumulator) AddMetric(meta *MetricMeta, value float
 	}
 }
 
+// AddLatencyMetrics adds p50, p90 and p99 of the latencies in microseconds
+func (r *TestCaseStepResultsAccumulator) AddLatencyMetrics(latencies []float64) {
+	if len(latencies) == 0 {
+		return
+	}
+
+	sorted := append([]float64{}, latencies...)
+	sort.Float64s(sorted)
+
+	r.AddMetric(MetricMeta_LatencyP50, stat.Quantile(0.5, stat.Empirical, sorted, nil))
+	r.AddMetric(MetricMeta_LatencyP90, stat.Quantile(0.9, stat.Empirical, sorted, nil))
+	r.AddMetric(MetricMeta_LatencyP99, stat.Quantile(0.99, stat.Empirical, sorted, nil))
+}
+
 func (r *TestCaseStepResultsAccumulator) AddError(err string) {
 	r.errors = append(r.errors, err)
 }
@@ -36,7 +54,18 @@ func (r *TestCaseStepResultsAccumulator) ToTestCaseStepResults() *TestCaseStepRe
 	var metrics []Metric
 
 	for metricMeta, values := range r.metricsMap {
-		metrics = append(metrics, Metric{Meta: metricMeta, Value: stat.Mean(values, nil)})
+		metricMeta := metricMeta
+		m := Metric{Meta: metricMeta, Value: stat.Mean(values, nil)}
+
+		if mb, ok := r.budgets[metricMeta.Name]; ok {
+			if b, err := NewBudget(mb, &metricMeta, m.Value); err != nil {
+				logrus.WithError(err).WithFields(logrus.Fields{"step": r.testCaseStep.Name, "metric": metricMeta.Name}).Warn("couldn't check metric budget")
+			} else {
+				m.Budget = b
+			}
+		}
+
+		metrics = append(metrics, m)
 	}
 
 	return &TestCaseStepResults{
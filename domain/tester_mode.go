@@ -0,0 +1,10 @@
+package domain
+
+type TesterMode string
+
+const (
+	// Component is tested by the tester of its type
+	TesterMode_NA = ""
+	// Component is tested by the broker tester, e.g. Redis Streams
+	TesterMode_Broker = "broker"
+)
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+// Threshold is the duration for the time metrics or the number in the metric units, e.g. "2s" or "1000"
+type Threshold struct {
+	Value      float64
+	IsDuration bool
+}
+
+// ParseThreshold parses plain number first, because unitless "0" is the valid duration too
+func ParseThreshold(s string) (*Threshold, error) {
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		return &Threshold{Value: v}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return &Threshold{Value: d.Seconds(), IsDuration: true}, nil
+	}
+	return nil, INVALID_THRESHOLD
+}
+
+// InUnitsOf returns threshold value in the units of the metric
+func (t *Threshold) InUnitsOf(mm *MetricMeta) (float64, error) {
+	if !t.IsDuration {
+		return t.Value, nil
+	}
+	if mm.UnitOfMeasure != UnitOfMeasure_Second {
+		return 0, THRESHOLD_UNITS_MISMATCH
+	}
+	return t.Value / mm.UnitOfMeasurePrefix.Factor(), nil
+}
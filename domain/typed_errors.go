@@ -0,0 +1,37 @@
+package domain
+
+// RequiredEnvVarError is returned when env var required by the tester isn't set in the test case.
+// It matches NO_REQUIRED_ENV_VAR_KEY with errors.Is.
+type RequiredEnvVarError struct {
+	Key string
+}
+
+func NewRequiredEnvVarError(key string) *RequiredEnvVarError {
+	return &RequiredEnvVarError{Key: key}
+}
+
+func (e *RequiredEnvVarError) Error() string {
+	return NO_REQUIRED_ENV_VAR_KEY.Error() + ": " + e.Key
+}
+
+func (e *RequiredEnvVarError) Is(target error) bool {
+	return target == NO_REQUIRED_ENV_VAR_KEY
+}
+
+// UnknownComponentError is returned when there is no tester or repository for the component type.
+// It matches UNKNOWN_COMPONENT_FOR_TESTING with errors.Is.
+type UnknownComponentError struct {
+	ComponentType ComponentType
+}
+
+func NewUnknownComponentError(ct ComponentType) *UnknownComponentError {
+	return &UnknownComponentError{ComponentType: ct}
+}
+
+func (e *UnknownComponentError) Error() string {
+	return UNKNOWN_COMPONENT_FOR_TESTING.Error() + ": " + string(e.ComponentType)
+}
+
+func (e *UnknownComponentError) Is(target error) bool {
+	return target == UNKNOWN_COMPONENT_FOR_TESTING
+}
@@ -9,6 +9,7 @@ const (
 	UnitOfMeasurePrefix_None  = ""
 	UnitOfMeasurePrefix_Kilo  = "kilo"
 	UnitOfMeasurePrefix_Mega  = "mega"
+	UnitOfMeasurePrefix_Giga  = "giga"
 	UnitOfMeasurePrefix_Tera  = "tera"
 	UnitOfMeasurePrefix_Peta  = "peta"
 )
@@ -16,7 +17,39 @@ const (
 type UnitOfMeasure string
 
 const (
-	UnitOfMeasure_Byte   = "byte"
-	UnitOfMeasure_Second = "second"
-	UnitOfMeasure_Piece  = "piece"
+	UnitOfMeasure_Byte    = "byte"
+	UnitOfMeasure_Second  = "second"
+	UnitOfMeasure_Piece   = "piece"
+	UnitOfMeasure_Percent = "percent"
+	// Operations per second
+	UnitOfMeasure_OperationPerSecond = "operationPerSecond"
+	UnitOfMeasure_BytePerSecond      = "bytePerSecond"
+	UnitOfMeasure_SamplePerSecond    = "samplePerSecond"
+	UnitOfMeasure_Dollar             = "dollar"
+	UnitOfMeasure_OperationPerDollar = "operationPerDollar"
+	UnitOfMeasure_RowPerSecond       = "rowPerSecond"
 )
+
+// Factor returns multiplier of the prefixed value to the base unit
+func (p UnitOfMeasurePrefix) Factor() float64 {
+	switch p {
+	case UnitOfMeasurePrefix_Nano:
+		return 1e-9
+	case UnitOfMeasurePrefix_Micro:
+		return 1e-6
+	case UnitOfMeasurePrefix_Milli:
+		return 1e-3
+	case UnitOfMeasurePrefix_Kilo:
+		return 1e3
+	case UnitOfMeasurePrefix_Mega:
+		return 1e6
+	case UnitOfMeasurePrefix_Giga:
+		return 1e9
+	case UnitOfMeasurePrefix_Tera:
+		return 1e12
+	case UnitOfMeasurePrefix_Peta:
+		return 1e15
+	default:
+		return 1
+	}
+}
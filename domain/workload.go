@@ -0,0 +1,33 @@
+package domain
+
+type Workload string
+
+const (
+	Workload_CrashRecovery         = "crashRecovery"
+	Workload_TransactionAnomalies  = "transactionAnomalies"
+	Workload_ReplicationLag        = "replicationLag"
+	Workload_BackupRestore         = "backupRestore"
+	Workload_SchemaMigrations      = "schemaMigrations"
+	Workload_UnicodeCorrectness    = "unicodeCorrectness"
+	Workload_BoundaryValues        = "boundaryValues"
+	Workload_TemporalTypes         = "temporalTypes"
+	Workload_NumericPrecision      = "numericPrecision"
+	Workload_KeyConflicts          = "keyConflicts"
+	Workload_OrmOverhead           = "ormOverhead"
+	Workload_Saturation            = "saturation"
+	Workload_MultiDatabase         = "multiDatabase"
+	Workload_MultiSchema           = "multiSchema"
+	Workload_GiantTransaction      = "giantTransaction"
+	Workload_ShardedInsert         = "shardedInsert"
+	Workload_ClusterTopology       = "clusterTopology"
+	Workload_Hypertable            = "hypertable"
+	Workload_PgVector              = "pgvector"
+	Workload_TransactionThroughput = "transactionThroughput"
+	Workload_ConcurrentClients     = "concurrentClients"
+	Workload_PreparedStatements    = "preparedStatements"
+	Workload_CopyFrom              = "copyFrom"
+	Workload_Upsert                = "upsert"
+	Workload_Jsonb                 = "jsonb"
+	Workload_FullTextSearch        = "fullTextSearch"
+	Workload_LargeBinary           = "largeBinary"
+)
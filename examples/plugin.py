@@ -0,0 +1,35 @@
+#!/usr/bin/env python3
+# Example of the cott plugin measuring HTTP GET of the component root path.
+# Plugin reads the test case from stdin and writes JSON events to stdout line by line.
+import json
+import sys
+import time
+import urllib.request
+
+def emit(event):
+    print(json.dumps(event), flush=True)
+
+request = json.loads(sys.stdin.readline())
+url = "http://localhost:%d/" % request["test-case"]["port"]
+
+emit({"type": "stepStart", "step": "startUp"})
+for _ in range(600):
+    try:
+        urllib.request.urlopen(url, timeout=1).read()
+        emit({"type": "stepEnd", "step": "startUp"})
+        break
+    except Exception:
+        time.sleep(0.1)
+else:
+    emit({"type": "stepEnd", "step": "startUp", "error": "service isn't ready"})
+    sys.exit(0)
+
+emit({"type": "stepStart", "step": "100xGet"})
+latencies = []
+for _ in range(100):
+    start = time.monotonic()
+    urllib.request.urlopen(url, timeout=10).read()
+    latencies.append((time.monotonic() - start) * 1e6)
+latencies.sort()
+emit({"type": "metric", "step": "100xGet", "metric": {"name": "latencyP99", "uom-prefix": "micro", "uom": "second"}, "value": latencies[98]})
+emit({"type": "stepEnd", "step": "100xGet"})
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"io"
+	"net"
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/jlaffaye/ftp"
+)
+
+type ftpFileTransferTesterRepository struct {
+	conn     *ftp.ServerConn
+	port     uint16
+	host     string
+	user     string
+	password string
+}
+
+func NewFtpFileTransferTesterRepository(port uint16, host, user, password string) FileTransferTesterRepository {
+	r := new(ftpFileTransferTesterRepository)
+	r.port = port
+	r.host = host
+	r.user = user
+	r.password = password
+	return r
+}
+
+func (r *ftpFileTransferTesterRepository) Open() error {
+	conn, err := ftp.Dial(net.JoinHostPort(r.host, strconv.FormatUint(uint64(r.port), 10)), ftp.DialWithTimeout(DIAL_TIMEOUT))
+	if err != nil {
+		return err
+	}
+
+	if err := conn.Login(r.user, r.password); err != nil {
+		conn.Quit()
+		return err
+	}
+
+	r.conn = conn
+
+	return nil
+}
+
+func (r *ftpFileTransferTesterRepository) Upload(path string, reader io.Reader) error {
+	if r.conn == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.conn.Stor(path, reader)
+}
+
+func (r *ftpFileTransferTesterRepository) Download(path string, w io.Writer) (int64, error) {
+	if r.conn == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	resp, err := r.conn.Retr(path)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Close()
+
+	return io.Copy(w, resp)
+}
+
+func (r *ftpFileTransferTesterRepository) Remove(path string) error {
+	if r.conn == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.conn.Delete(path)
+}
+
+func (r *ftpFileTransferTesterRepository) Close() error {
+	if r.conn == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.conn.Quit(); err != nil {
+		return err
+	}
+
+	r.conn = nil
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "io"
+
+type FileTransferTesterRepository interface {
+	// Open connects and logs in to the server
+	Open() error
+	Upload(path string, r io.Reader) error
+	// Download reads remote file into the writer and returns read bytes count
+	Download(path string, w io.Writer) (int64, error)
+	Remove(path string) error
+	Close() error
+}
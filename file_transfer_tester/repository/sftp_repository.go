@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+const DIAL_TIMEOUT = 5 * time.Second
+
+type sftpFileTransferTesterRepository struct {
+	sshClient  *ssh.Client
+	sftpClient *sftp.Client
+	port       uint16
+	host       string
+	user       string
+	password   string
+}
+
+func NewSftpFileTransferTesterRepository(port uint16, host, user, password string) FileTransferTesterRepository {
+	r := new(sftpFileTransferTesterRepository)
+	r.port = port
+	r.host = host
+	r.user = user
+	r.password = password
+	return r
+}
+
+func (r *sftpFileTransferTesterRepository) Open() error {
+	sshClient, err := ssh.Dial("tcp", net.JoinHostPort(r.host, strconv.FormatUint(uint64(r.port), 10)), &ssh.ClientConfig{
+		User: r.user,
+		Auth: []ssh.AuthMethod{ssh.Password(r.password)},
+		// Test containers generate new host keys on every start
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         DIAL_TIMEOUT,
+	})
+	if err != nil {
+		return err
+	}
+
+	sftpClient, err := sftp.NewClient(sshClient)
+	if err != nil {
+		sshClient.Close()
+		return err
+	}
+
+	r.sshClient = sshClient
+	r.sftpClient = sftpClient
+
+	return nil
+}
+
+func (r *sftpFileTransferTesterRepository) Upload(path string, reader io.Reader) error {
+	if r.sftpClient == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	f, err := r.sftpClient.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.ReadFrom(reader); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func (r *sftpFileTransferTesterRepository) Download(path string, w io.Writer) (int64, error) {
+	if r.sftpClient == nil {
+		return 0, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	f, err := r.sftpClient.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return f.WriteTo(w)
+}
+
+func (r *sftpFileTransferTesterRepository) Remove(path string) error {
+	if r.sftpClient == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	return r.sftpClient.Remove(path)
+}
+
+func (r *sftpFileTransferTesterRepository) Close() error {
+	if r.sftpClient == nil {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	if err := r.sftpClient.Close(); err != nil {
+		return err
+	}
+	if err := r.sshClient.Close(); err != nil {
+		return err
+	}
+
+	r.sftpClient = nil
+	r.sshClient = nil
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"path"
+	"time"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/file_transfer_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+var fileSizes = []struct {
+	name string
+	size int64
+}{
+	{name: "1KB", size: 1 << 10},
+	{name: "1MB", size: 1 << 20},
+	{name: "10MB", size: 10 << 20},
+	{name: "100MB", size: 100 << 20},
+}
+
+type FileTransferTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type fileTransferTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewFileTransferTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) FileTransferTesterUsecase {
+	ftuc := new(fileTransferTesterUsecase)
+	ftuc.cluc = cluc
+	return ftuc
+}
+
+func (ftuc *fileTransferTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	r, err := ftuc.createFileTransferRepository(tcra.TestCase)
+	if err != nil {
+		return err
+	}
+
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, ftuc.cluc, containerId)
+
+	// Await for server ready
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return helpers.AwaitConnection(r.Open, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	if err := r.Close(); err != nil {
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: "login", StepFunc: func() error { return r.Open() }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	for _, fs := range fileSizes {
+		if err := ftuc.testFileTransfer(mcuc, r, path.Join(tcra.TestCase.RemoteDir, "cott_"+fs.name), fs.name, fs.size); err != nil {
+			break
+		}
+	}
+
+	step = &domain.TestCaseStep{Name: "logout", StepFunc: func() error { return r.Close() }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	return nil
+}
+
+func (ftuc *fileTransferTesterUsecase) createFileTransferRepository(tc *domain.TestCase) (repository.FileTransferTesterRepository, error) {
+	switch tc.ComponentType {
+
+	case domain.ComponentType_Sftp:
+		return repository.NewSftpFileTransferTesterRepository(tc.Port, "localhost", tc.User, tc.Password), nil
+
+	case domain.ComponentType_Ftp:
+		return repository.NewFtpFileTransferTesterRepository(tc.Port, "localhost", tc.User, tc.Password), nil
+
+	default:
+		return nil, domain.NewUnknownComponentError(tc.ComponentType)
+	}
+}
+
+// Method uploads generated file, downloads it back and reports transfer rate of both steps
+func (ftuc *fileTransferTesterUsecase) testFileTransfer(mcuc metrics_collector.MetricsCollectorUsecase, r repository.FileTransferTesterRepository, filePath string, sizeName string, size int64) error {
+	var duration time.Duration
+
+	step := &domain.TestCaseStep{Name: "upload" + sizeName + "File", StepFunc: func() error {
+		reader := io.LimitReader(rand.New(rand.NewSource(size)), size)
+		startTime := time.Now()
+		err := r.Upload(filePath, reader)
+		duration = time.Since(startTime)
+		return err
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_TransferRate, float64(size)/duration.Seconds())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	var downloaded int64
+	step = &domain.TestCaseStep{Name: "download" + sizeName + "File", StepFunc: func() error {
+		startTime := time.Now()
+		n, err := r.Download(filePath, ioutil.Discard)
+		duration = time.Since(startTime)
+		downloaded = n
+		if err == nil && n != size {
+			return domain.DATA_INTEGRITY_VIOLATED
+		}
+		return err
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_TransferRate, float64(downloaded)/duration.Seconds())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: "remove" + sizeName + "File", StepFunc: func() error { return r.Remove(filePath) }}
+	return mcuc.CollectStepMetrics(step)
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	SEQUENTIAL_FILE_SIZE  = 256 << 20
+	RANDOM_FILE_SIZE      = 64 << 20
+	RANDOM_BLOCK_SIZE     = 4 << 10
+	RANDOM_IO_OPERATIONS  = 2000
+	METADATA_FILES_COUNT  = 1000
+	SEQUENTIAL_CHUNK_SIZE = 1 << 20
+)
+
+// Method writes and reads back big file sequentially and reports transfer rate
+func (fsuc *filesystemTesterUsecase) testSequentialIo(mcuc metrics_collector.MetricsCollectorUsecase, workDir string) {
+	filePath := filepath.Join(workDir, "sequential")
+	var duration time.Duration
+
+	step := &domain.TestCaseStep{Name: "sequentialWrite", StepFunc: func() error {
+		f, err := os.Create(filePath)
+		if err != nil {
+			return err
+		}
+		startTime := time.Now()
+		if _, err := io.CopyBuffer(f, io.LimitReader(rand.New(rand.NewSource(0)), SEQUENTIAL_FILE_SIZE), make([]byte, SEQUENTIAL_CHUNK_SIZE)); err != nil {
+			f.Close()
+			return err
+		}
+		// Data must reach the server
+		if err := f.Sync(); err != nil {
+			f.Close()
+			return err
+		}
+		duration = time.Since(startTime)
+		return f.Close()
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_TransferRate, SEQUENTIAL_FILE_SIZE/duration.Seconds())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return
+	}
+
+	step = &domain.TestCaseStep{Name: "sequentialRead", StepFunc: func() error {
+		f, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		startTime := time.Now()
+		if _, err := io.CopyBuffer(ioutil.Discard, f, make([]byte, SEQUENTIAL_CHUNK_SIZE)); err != nil {
+			return err
+		}
+		duration = time.Since(startTime)
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_TransferRate, SEQUENTIAL_FILE_SIZE/duration.Seconds())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return
+	}
+
+	if err := os.Remove(filePath); err != nil {
+		logrus.WithError(err).Debug("couldn't remove sequential file")
+	}
+}
+
+// Method writes and reads blocks at random offsets and reports IOPS and latency
+func (fsuc *filesystemTesterUsecase) testRandomIo(mcuc metrics_collector.MetricsCollectorUsecase, workDir string) {
+	filePath := filepath.Join(workDir, "random")
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		logrus.WithError(err).Warn("couldn't create random io file")
+		return
+	}
+	defer os.Remove(filePath)
+	defer f.Close()
+
+	if err := f.Truncate(RANDOM_FILE_SIZE); err != nil {
+		logrus.WithError(err).Warn("couldn't allocate random io file")
+		return
+	}
+
+	block := make([]byte, RANDOM_BLOCK_SIZE)
+	randomOffset := func() int64 { return rand.Int63n(RANDOM_FILE_SIZE/RANDOM_BLOCK_SIZE) * RANDOM_BLOCK_SIZE }
+
+	steps := []*domain.TestCaseStep{
+		fsuc.createLoadStep("randomWrite", RANDOM_IO_OPERATIONS, func(i int) error {
+			if _, err := f.WriteAt(block, randomOffset()); err != nil {
+				return err
+			}
+			return f.Sync()
+		}),
+		fsuc.createLoadStep("randomRead", RANDOM_IO_OPERATIONS, func(i int) error {
+			_, err := f.ReadAt(block, randomOffset())
+			return err
+		}),
+	}
+	for _, step := range steps {
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return
+		}
+	}
+}
+
+// Method creates, stats and unlinks many small files and reports operations rate and latency
+func (fsuc *filesystemTesterUsecase) testMetadata(mcuc metrics_collector.MetricsCollectorUsecase, workDir string) {
+	metadataDir := filepath.Join(workDir, "metadata")
+	if err := os.Mkdir(metadataDir, 0755); err != nil {
+		logrus.WithError(err).Warn("couldn't create metadata dir")
+		return
+	}
+
+	filePath := func(i int) string { return filepath.Join(metadataDir, strconv.FormatInt(int64(i), 10)) }
+
+	steps := []*domain.TestCaseStep{
+		fsuc.createLoadStep("metadataCreate", METADATA_FILES_COUNT, func(i int) error {
+			f, err := os.Create(filePath(i))
+			if err != nil {
+				return err
+			}
+			return f.Close()
+		}),
+		fsuc.createLoadStep("metadataStat", METADATA_FILES_COUNT, func(i int) error {
+			_, err := os.Stat(filePath(i))
+			return err
+		}),
+		fsuc.createLoadStep("metadataUnlink", METADATA_FILES_COUNT, func(i int) error {
+			return os.Remove(filePath(i))
+		}),
+	}
+	for _, step := range steps {
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return
+		}
+	}
+}
+
+// Method creates step running operations sequentially with the operation index
+func (fsuc *filesystemTesterUsecase) createLoadStep(name string, count int, operation func(i int) error) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		i := 0
+		lr = helpers.RunLoad(count, 1, func() error {
+			err := operation(i)
+			i++
+			return err
+		})
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"time"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+type FilesystemTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type filesystemTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewFilesystemTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) FilesystemTesterUsecase {
+	fsuc := new(filesystemTesterUsecase)
+	fsuc.cluc = cluc
+	return fsuc
+}
+
+// Export is mounted on the host with mount command, so cott must have permissions to mount
+func (fsuc *filesystemTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	mountType, err := fsuc.getMountType(tcra.TestCase.ComponentType)
+	if err != nil {
+		return err
+	}
+
+	mountPoint, err := ioutil.TempDir("", "cott_mount_")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(mountPoint)
+
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, fsuc.cluc, containerId)
+
+	// Await for export ready
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		startTime := time.Now()
+		for time.Since(startTime) < tcra.TestCase.GetStartupTimeout() {
+			if err := fsuc.mount(mountType, tcra.TestCase.MountSource, tcra.TestCase.MountOptions, mountPoint); err == nil {
+				return nil
+			}
+			time.Sleep(time.Second)
+		}
+		return domain.COULDNT_MOUNT_FILESYSTEM
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	workDir, err := ioutil.TempDir(mountPoint, "cott_")
+	if err != nil {
+		logrus.WithError(err).Error("couldn't create work dir on the mounted filesystem")
+	} else {
+		fsuc.testSequentialIo(mcuc, workDir)
+		fsuc.testRandomIo(mcuc, workDir)
+		fsuc.testMetadata(mcuc, workDir)
+
+		if err := os.RemoveAll(workDir); err != nil {
+			logrus.WithError(err).Warn("couldn't remove work dir")
+		}
+	}
+
+	step = &domain.TestCaseStep{Name: "unmount", StepFunc: func() error { return fsuc.unmount(mountPoint) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (fsuc *filesystemTesterUsecase) getMountType(ct domain.ComponentType) (string, error) {
+	switch ct {
+	case domain.ComponentType_Nfs:
+		return "nfs", nil
+	case domain.ComponentType_Smb:
+		return "cifs", nil
+	default:
+		return "", domain.NewUnknownComponentError(ct)
+	}
+}
+
+func (fsuc *filesystemTesterUsecase) mount(mountType string, source string, options string, mountPoint string) error {
+	args := []string{"-t", mountType}
+	if options != "" {
+		args = append(args, "-o", options)
+	}
+	args = append(args, source, mountPoint)
+
+	if out, err := exec.Command("mount", args...).CombinedOutput(); err != nil {
+		logrus.WithError(err).WithField("output", string(out)).Debug("mount failed")
+		return err
+	}
+	logrus.WithFields(logrus.Fields{"source": source, "mountPoint": mountPoint}).Debug("filesystem mounted")
+
+	return nil
+}
+
+func (fsuc *filesystemTesterUsecase) unmount(mountPoint string) error {
+	if out, err := exec.Command("umount", mountPoint).CombinedOutput(); err != nil {
+		logrus.WithError(err).WithField("output", string(out)).Warn("umount failed")
+		return err
+	}
+	logrus.WithField("mountPoint", mountPoint).Debug("filesystem unmounted")
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+)
+
+// Nodes are deleted by the batches, so transaction state fits into the default heap
+const NEO4J_DELETE_BATCH_SIZE = 10000
+
+type neo4jGraphTesterRepository struct {
+	driver neo4j.Driver
+}
+
+// NewNeo4jGraphTesterRepository creates repository using Bolt protocol
+func NewNeo4jGraphTesterRepository(port uint16, host, user, password string) (GraphTesterRepository, error) {
+	driver, err := neo4j.NewDriver("bolt://"+host+":"+strconv.FormatUint(uint64(port), 10), neo4j.BasicAuth(user, password, ""))
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(neo4jGraphTesterRepository)
+	r.driver = driver
+	return r, nil
+}
+
+func (r *neo4jGraphTesterRepository) Ping() error {
+	return r.driver.VerifyConnectivity()
+}
+
+// Labels and relationship types can't be parameters, so they are quoted into the queries
+func (r *neo4jGraphTesterRepository) CreateIndex(label string) error {
+	if err := r.run(neo4j.AccessModeWrite, "CREATE INDEX "+quoteNeo4jName(label+"_id")+" IF NOT EXISTS FOR (n:"+quoteNeo4jName(label)+") ON (n.id)", nil); err != nil {
+		return err
+	}
+
+	// Index is populated asynchronously
+	return r.run(neo4j.AccessModeRead, "CALL db.awaitIndexes()", nil)
+}
+
+func (r *neo4jGraphTesterRepository) DropIndex(label string) error {
+	return r.run(neo4j.AccessModeWrite, "DROP INDEX "+quoteNeo4jName(label+"_id")+" IF EXISTS", nil)
+}
+
+func (r *neo4jGraphTesterRepository) CreateNodes(label string, ids []int64) error {
+	return r.write("UNWIND $ids AS id CREATE (:"+quoteNeo4jName(label)+" {id: id})", map[string]interface{}{"ids": ids})
+}
+
+func (r *neo4jGraphTesterRepository) CreateRelationships(label string, relType string, relationships []Relationship) error {
+	rels := make([]interface{}, len(relationships))
+	for i, rel := range relationships {
+		rels[i] = map[string]interface{}{"from": rel.From, "to": rel.To}
+	}
+
+	quotedLabel := quoteNeo4jName(label)
+	return r.write("UNWIND $rels AS rel MATCH (a:"+quotedLabel+" {id: rel.from}), (b:"+quotedLabel+" {id: rel.to}) CREATE (a)-[:"+quoteNeo4jName(relType)+"]->(b)",
+		map[string]interface{}{"rels": rels})
+}
+
+func (r *neo4jGraphTesterRepository) LookupNode(label string, id int64) error {
+	return r.run(neo4j.AccessModeRead, "MATCH (n:"+quoteNeo4jName(label)+" {id: $id}) RETURN n", map[string]interface{}{"id": id})
+}
+
+// Depth is the fixed length of the variable length pattern, because it can't be parameter
+func (r *neo4jGraphTesterRepository) Traverse(label string, relType string, id int64, depth int) error {
+	return r.run(neo4j.AccessModeRead, "MATCH (n:"+quoteNeo4jName(label)+" {id: $id})-[:"+quoteNeo4jName(relType)+"*"+strconv.Itoa(depth)+"]->(m) RETURN count(m)",
+		map[string]interface{}{"id": id})
+}
+
+func (r *neo4jGraphTesterRepository) DeleteNodes(label string) error {
+	session := r.driver.NewSession(neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close()
+
+	for {
+		result, err := session.Run("MATCH (n:"+quoteNeo4jName(label)+") WITH n LIMIT $limit DETACH DELETE n RETURN count(n)",
+			map[string]interface{}{"limit": NEO4J_DELETE_BATCH_SIZE})
+		if err != nil {
+			return err
+		}
+		record, err := result.Single()
+		if err != nil {
+			return err
+		}
+		deleted, ok := record.Values[0].(int64)
+		if !ok {
+			return domain.UNEXPECTED_RESPONSE_STATUS
+		}
+		if deleted == 0 {
+			return nil
+		}
+	}
+}
+
+func (r *neo4jGraphTesterRepository) Close() error {
+	return r.driver.Close()
+}
+
+// Method runs query in the auto commit transaction and discards its records
+func (r *neo4jGraphTesterRepository) run(accessMode neo4j.AccessMode, query string, params map[string]interface{}) error {
+	session := r.driver.NewSession(neo4j.SessionConfig{AccessMode: accessMode})
+	defer session.Close()
+
+	result, err := session.Run(query, params)
+	if err != nil {
+		return err
+	}
+	_, err = result.Consume()
+	return err
+}
+
+// Method runs query in the write transaction, which is retried by the driver on the transient errors
+func (r *neo4jGraphTesterRepository) write(query string, params map[string]interface{}) error {
+	session := r.driver.NewSession(neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close()
+
+	_, err := session.WriteTransaction(func(tx neo4j.Transaction) (interface{}, error) {
+		result, err := tx.Run(query, params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume()
+	})
+	return err
+}
+
+func quoteNeo4jName(name string) string {
+	return "`" + name + "`"
+}
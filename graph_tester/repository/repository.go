@@ -0,0 +1,24 @@
+package repository
+
+// Relationship is directed edge between the nodes of the ids
+type Relationship struct {
+	From int64
+	To   int64
+}
+
+type GraphTesterRepository interface {
+	Ping() error
+	// CreateIndex creates index of the node id property and awaits it online
+	CreateIndex(label string) error
+	DropIndex(label string) error
+	// CreateNodes creates nodes of the label with the id property in the single transaction
+	CreateNodes(label string, ids []int64) error
+	// CreateRelationships creates relationships of the type between the nodes found by the ids in the single transaction
+	CreateRelationships(label string, relType string, relationships []Relationship) error
+	LookupNode(label string, id int64) error
+	// Traverse counts nodes reachable from the node by the paths of the depth
+	Traverse(label string, relType string, id int64, depth int) error
+	// DeleteNodes deletes nodes of the label with their relationships
+	DeleteNodes(label string) error
+	Close() error
+}
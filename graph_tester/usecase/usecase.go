@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/graph_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	NODE_LABEL        = "CottNode"
+	RELATIONSHIP_TYPE = "COTT_LINK"
+	// Nodes and relationships count of the single transaction
+	BATCH_SIZE = 1000
+	// Outgoing relationships of every node, so traversal of the depth reaches up to RELATIONSHIPS_PER_NODE^depth nodes
+	RELATIONSHIPS_PER_NODE = 3
+	QUERY_REQUESTS         = 200
+)
+
+var (
+	// Multipliers of the nodes count of the test case
+	nodesCountMultipliers = []int{1, 10}
+	traversalDepths       = []int{1, 2, 3}
+)
+
+type GraphTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type graphTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewGraphTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) GraphTesterUsecase {
+	gruc := new(graphTesterUsecase)
+	gruc.cluc = cluc
+	return gruc
+}
+
+func (gruc *graphTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	r, err := gruc.createGraphRepository(tcra.TestCase)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, gruc.cluc, containerId)
+
+	// Await for graph database ready
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return helpers.AwaitConnection(r.Ping, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	// Nodes could be left by the failed run
+	if err := r.DeleteNodes(NODE_LABEL); err != nil {
+		logrus.WithError(err).Debug("couldn't delete nodes")
+	}
+
+	for _, multiplier := range nodesCountMultipliers {
+		nodesCount := multiplier * int(tcra.TestCase.GetNodesCount())
+		if err := gruc.testGraph(mcuc, r, int(tcra.TestCase.GetConcurrency()), nodesCount); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (gruc *graphTesterUsecase) createGraphRepository(tc *domain.TestCase) (repository.GraphTesterRepository, error) {
+	switch tc.ComponentType {
+	case domain.ComponentType_Neo4j:
+		user := tc.User
+		if user == "" {
+			user = "neo4j"
+		}
+		return repository.NewNeo4jGraphTesterRepository(tc.Port, "localhost", user, tc.Password)
+	default:
+		return nil, domain.NewUnknownComponentError(tc.ComponentType)
+	}
+}
+
+// Method creates graph by the batches, runs index lookups and traversals of the depths and deletes the graph.
+// Steps are named like the table steps of the database tester.
+func (gruc *graphTesterUsecase) testGraph(mcuc metrics_collector.MetricsCollectorUsecase, r repository.GraphTesterRepository, concurrency int, nodesCount int) error {
+	testPrefix := strconv.FormatInt(int64(nodesCount), 10) + "x"
+
+	// Relationships are created by the node ids, so index is created before the graph
+	step := &domain.TestCaseStep{Name: "createIndex", StepFunc: func() error { return r.CreateIndex(NODE_LABEL) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	ids := make([]int64, nodesCount)
+	for i := range ids {
+		ids[i] = int64(i)
+	}
+	step = gruc.createBatchStep(testPrefix+"CreateNodes", len(ids), concurrency, func(from, to int) error {
+		return r.CreateNodes(NODE_LABEL, ids[from:to])
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		gruc.deleteGraph(r)
+		return err
+	}
+
+	relationships := gruc.generateRelationships(nodesCount)
+	step = gruc.createBatchStep(strconv.FormatInt(int64(len(relationships)), 10)+"xCreateRelationships", len(relationships), concurrency, func(from, to int) error {
+		return r.CreateRelationships(NODE_LABEL, RELATIONSHIP_TYPE, relationships[from:to])
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		gruc.deleteGraph(r)
+		return err
+	}
+
+	step = helpers.NewQueryStep("indexLookup"+testPrefix+"Graph", QUERY_REQUESTS, concurrency, func() error {
+		return r.LookupNode(NODE_LABEL, rand.Int63n(int64(nodesCount)))
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("index lookup failed")
+	}
+
+	for _, depth := range traversalDepths {
+		depth := depth
+		step = helpers.NewQueryStep("traversalDepth"+strconv.Itoa(depth)+testPrefix+"Graph", QUERY_REQUESTS, concurrency, func() error {
+			return r.Traverse(NODE_LABEL, RELATIONSHIP_TYPE, rand.Int63n(int64(nodesCount)), depth)
+		})
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).WithField("depth", depth).Warn("traversal failed")
+		}
+	}
+
+	step = &domain.TestCaseStep{Name: "delete" + testPrefix + "Graph", StepFunc: func() error { return r.DeleteNodes(NODE_LABEL) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: "dropIndex", StepFunc: func() error { return r.DropIndex(NODE_LABEL) }}
+	return mcuc.CollectStepMetrics(step)
+}
+
+func (gruc *graphTesterUsecase) deleteGraph(r repository.GraphTesterRepository) {
+	if err := r.DeleteNodes(NODE_LABEL); err != nil {
+		logrus.WithError(err).Warn("couldn't delete nodes")
+	}
+	if err := r.DropIndex(NODE_LABEL); err != nil {
+		logrus.WithError(err).Warn("couldn't drop index")
+	}
+}
+
+// Method generates RELATIONSHIPS_PER_NODE relationships from every node to the random ones
+func (gruc *graphTesterUsecase) generateRelationships(nodesCount int) []repository.Relationship {
+	relationships := make([]repository.Relationship, 0, nodesCount*RELATIONSHIPS_PER_NODE)
+	for i := 0; i < nodesCount; i++ {
+		for j := 0; j < RELATIONSHIPS_PER_NODE; j++ {
+			relationships = append(relationships, repository.Relationship{From: int64(i), To: rand.Int63n(int64(nodesCount))})
+		}
+	}
+	return relationships
+}
+
+// Method creates step running batches of BATCH_SIZE elements concurrently. Throughput is elements per second.
+func (gruc *graphTesterUsecase) createBatchStep(name string, count int, concurrency int, batch func(from, to int) error) *domain.TestCaseStep {
+	var (
+		lr       *helpers.LoadResult
+		duration time.Duration
+	)
+	batches := (count + BATCH_SIZE - 1) / BATCH_SIZE
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		startTime := time.Now()
+		var nextBatch int64 = -1
+		lr = helpers.RunLoad(batches, concurrency, func() error {
+			from := int(atomic.AddInt64(&nextBatch, 1)) * BATCH_SIZE
+			to := from + BATCH_SIZE
+			if to > count {
+				to = count
+			}
+			return batch(from, to)
+		})
+		duration = time.Since(startTime)
+		if lr.Errors != 0 {
+			return domain.UNEXPECTED_RESPONSE_STATUS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_Throughput, float64(count)/duration.Seconds())
+		tcsra.AddLatencyMetrics(lr.Latencies)
+	}}
+}
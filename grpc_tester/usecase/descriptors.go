@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Method loads files registry from the FileDescriptorSet file
+func (gtuc *grpcTesterUsecase) loadDescriptorSet(filePath string) (*protoregistry.Files, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var fds descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &fds); err != nil {
+		return nil, err
+	}
+
+	return protodesc.NewFiles(&fds)
+}
+
+// Method resolves files which declare services of the methods and their dependencies with server reflection
+func (gtuc *grpcTesterUsecase) loadReflectionDescriptors(conn *grpc.ClientConn, methods []domain.GrpcMethod) (*protoregistry.Files, error) {
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+
+	fdps := make(map[string]*descriptorpb.FileDescriptorProto)
+
+	var requests []*rpb.ServerReflectionRequest
+	for _, m := range methods {
+		service, err := m.GetServiceName()
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, &rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service}})
+	}
+
+	for len(requests) > 0 {
+		req := requests[0]
+		requests = requests[1:]
+
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		fdResp := resp.GetFileDescriptorResponse()
+		if fdResp == nil {
+			return nil, domain.GRPC_REFLECTION_FAILED
+		}
+
+		for _, b := range fdResp.FileDescriptorProto {
+			fdp := new(descriptorpb.FileDescriptorProto)
+			if err := proto.Unmarshal(b, fdp); err != nil {
+				return nil, err
+			}
+			fdps[fdp.GetName()] = fdp
+		}
+
+		// Request missing dependencies which aren't linked into the binary
+		for _, fdp := range fdps {
+			for _, dep := range fdp.GetDependency() {
+				if _, ok := fdps[dep]; ok {
+					continue
+				}
+				if _, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+					continue
+				}
+				fdps[dep] = nil
+				requests = append(requests, &rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep}})
+			}
+		}
+	}
+
+	files := new(protoregistry.Files)
+	for name := range fdps {
+		if err := gtuc.registerFile(files, fdps, name); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// Method registers file after its dependencies
+func (gtuc *grpcTesterUsecase) registerFile(files *protoregistry.Files, fdps map[string]*descriptorpb.FileDescriptorProto, name string) error {
+	if _, err := files.FindFileByPath(name); err == nil {
+		return nil
+	}
+
+	fdp, ok := fdps[name]
+	if !ok || fdp == nil {
+		return domain.GRPC_REFLECTION_FAILED
+	}
+
+	for _, dep := range fdp.Dependency {
+		if _, ok := fdps[dep]; !ok {
+			continue
+		}
+		if err := gtuc.registerFile(files, fdps, dep); err != nil {
+			return err
+		}
+	}
+
+	fd, err := protodesc.NewFile(fdp, &filesResolver{local: files})
+	if err != nil {
+		return err
+	}
+
+	return files.RegisterFile(fd)
+}
+
+// Method finds method descriptor by the full method name
+func (gtuc *grpcTesterUsecase) findMethod(files *protoregistry.Files, name string) (protoreflect.MethodDescriptor, error) {
+	fullName := strings.Replace(strings.TrimPrefix(name, "/"), "/", ".", 1)
+
+	d, err := files.FindDescriptorByName(protoreflect.FullName(fullName))
+	if err != nil {
+		return nil, err
+	}
+
+	md, ok := d.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, domain.GRPC_METHOD_NOT_FOUND
+	}
+
+	return md, nil
+}
+
+// Resolver looks for the files in the local registry and then in the linked ones
+type filesResolver struct {
+	local *protoregistry.Files
+}
+
+func (r *filesResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.local.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r *filesResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.local.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
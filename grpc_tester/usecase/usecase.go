@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	CALL_TIMEOUT = 10 * time.Second
+)
+
+type GrpcTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type grpcTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewGrpcTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) GrpcTesterUsecase {
+	gtuc := new(grpcTesterUsecase)
+	gtuc.cluc = cluc
+	return gtuc
+}
+
+func (gtuc *grpcTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	tc := tcra.TestCase
+	target := "localhost:" + strconv.FormatUint(uint64(tc.Port), 10)
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, gtuc.cluc, containerId)
+
+	var (
+		conn  *grpc.ClientConn
+		files *protoregistry.Files
+	)
+
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		ctx, ctxCancelFunc := context.WithTimeout(context.Background(), tcra.TestCase.GetStartupTimeout())
+		defer ctxCancelFunc()
+
+		var err error
+		conn, err = grpc.DialContext(ctx, target, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+		return err
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+	defer conn.Close()
+
+	step = &domain.TestCaseStep{Name: "loadDescriptors", StepFunc: func() error {
+		var err error
+		if tc.ProtoDescriptorSetPath != "" {
+			files, err = gtuc.loadDescriptorSet(tc.ProtoDescriptorSetPath)
+		} else {
+			files, err = gtuc.loadReflectionDescriptors(conn, tc.GrpcMethods)
+		}
+		return err
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	for _, method := range tc.GrpcMethods {
+		method := method
+
+		md, err := gtuc.findMethod(files, method.Name)
+		if err != nil {
+			logrus.WithError(err).WithField("method", method.Name).Warn("couldn't find gRPC method")
+			continue
+		}
+
+		req := dynamicpb.NewMessage(md.Input())
+		if method.Request != "" {
+			if err := protojson.Unmarshal([]byte(method.Request), req); err != nil {
+				logrus.WithError(err).WithField("method", method.Name).Warn("couldn't parse gRPC request")
+				continue
+			}
+		}
+
+		if err := mcuc.CollectStepMetrics(gtuc.createLoadStep(conn, md, &method, req, tc.GetConcurrency())); err != nil {
+			logrus.WithError(err).WithField("method", method.Name).Warn("gRPC load failed")
+		}
+
+		if tc.HasWorkload(domain.Workload_Saturation) {
+			name, call := gtuc.createCall(conn, md, &method, req)
+			if err := mcuc.CollectStepMetrics(helpers.NewSaturationStep("saturation "+name, tc, call)); err != nil {
+				logrus.WithError(err).WithField("method", method.Name).Warn("saturation point wasn't found")
+			}
+		}
+	}
+
+	return nil
+}
+
+// Method creates step calling method concurrently and reporting latency percentiles and calls per second
+func (gtuc *grpcTesterUsecase) createLoadStep(conn *grpc.ClientConn, md protoreflect.MethodDescriptor, method *domain.GrpcMethod, req *dynamicpb.Message, concurrency uint16) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+	name, call := gtuc.createCall(conn, md, method, req)
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = helpers.RunLoad(int(method.GetRequestsCount()), int(concurrency), call)
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+}
+
+// Method returns name of the method call with its kind and the call function
+func (gtuc *grpcTesterUsecase) createCall(conn *grpc.ClientConn, md protoreflect.MethodDescriptor, method *domain.GrpcMethod, req *dynamicpb.Message) (string, func() error) {
+	var (
+		fullName = "/" + string(md.Parent().FullName()) + "/" + string(md.Name())
+		kind     = "unary"
+		call     = func() error { return gtuc.invokeUnary(conn, fullName, md, req) }
+	)
+
+	switch {
+	case md.IsStreamingClient() && md.IsStreamingServer():
+		kind = "bidiStream"
+	case md.IsStreamingClient():
+		kind = "clientStream"
+	case md.IsStreamingServer():
+		kind = "serverStream"
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		call = func() error { return gtuc.invokeStream(conn, fullName, md, req, method.GetStreamMessagesCount()) }
+	}
+
+	return kind + " " + fullName, call
+}
+
+func (gtuc *grpcTesterUsecase) invokeUnary(conn *grpc.ClientConn, fullName string, md protoreflect.MethodDescriptor, req *dynamicpb.Message) error {
+	ctx, ctxCancelFunc := context.WithTimeout(context.Background(), CALL_TIMEOUT)
+	defer ctxCancelFunc()
+
+	return conn.Invoke(ctx, fullName, req, dynamicpb.NewMessage(md.Output()))
+}
+
+// Method sends messages count requests for client streams or one request otherwise and receives responses till the stream end
+func (gtuc *grpcTesterUsecase) invokeStream(conn *grpc.ClientConn, fullName string, md protoreflect.MethodDescriptor, req *dynamicpb.Message, messagesCount uint32) error {
+	ctx, ctxCancelFunc := context.WithTimeout(context.Background(), CALL_TIMEOUT)
+	defer ctxCancelFunc()
+
+	desc := &grpc.StreamDesc{ServerStreams: md.IsStreamingServer(), ClientStreams: md.IsStreamingClient()}
+	stream, err := conn.NewStream(ctx, desc, fullName)
+	if err != nil {
+		return err
+	}
+
+	if !md.IsStreamingClient() {
+		messagesCount = 1
+	}
+	for i := uint32(0); i < messagesCount; i++ {
+		if err := stream.SendMsg(req); err != nil {
+			return err
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	for {
+		if err := stream.RecvMsg(dynamicpb.NewMessage(md.Output())); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// Names in the format are sorted in the time order
+const REPORT_FILE_NAME_FORMAT = "20060102T150405.000000000Z"
+
+type fileHistoryRepository struct {
+	dirPath string
+}
+
+func NewFileHistoryRepository(dirPath string) HistoryRepository {
+	r := new(fileHistoryRepository)
+	r.dirPath = dirPath
+	return r
+}
+
+func (r *fileHistoryRepository) Save(report *domain.Report) error {
+	if err := os.MkdirAll(r.dirPath, 0755); err != nil {
+		return err
+	}
+
+	reportBytes, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(r.dirPath, time.Now().UTC().Format(REPORT_FILE_NAME_FORMAT)+".json"), reportBytes, 0644)
+}
+
+func (r *fileHistoryRepository) LoadLast(n int) ([]*domain.Report, error) {
+	files, err := ioutil.ReadDir(r.dirPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), ".json") {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+	if len(names) > n {
+		names = names[len(names)-n:]
+	}
+
+	reports := make([]*domain.Report, 0, len(names))
+	for _, name := range names {
+		reportBytes, err := ioutil.ReadFile(filepath.Join(r.dirPath, name))
+		if err != nil {
+			return nil, err
+		}
+
+		report := new(domain.Report)
+		if err := json.Unmarshal(reportBytes, report); err != nil {
+			logrus.WithError(err).WithField("file", name).Warn("couldn't parse history report")
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/iakrevetkho/components-tests/cott/domain"
+
+type HistoryRepository interface {
+	Save(r *domain.Report) error
+	// LoadLast returns up to n last saved reports from the oldest to the newest
+	LoadLast(n int) ([]*domain.Report, error)
+}
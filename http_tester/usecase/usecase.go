@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	REQUEST_TIMEOUT = 10 * time.Second
+)
+
+type HttpTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type httpTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewHttpTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) HttpTesterUsecase {
+	htuc := new(httpTesterUsecase)
+	htuc.cluc = cluc
+	return htuc
+}
+
+func (htuc *httpTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	baseUrl := "http://localhost:" + strconv.FormatUint(uint64(tcra.TestCase.Port), 10)
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, htuc.cluc, containerId)
+
+	keepAliveClient := &http.Client{Timeout: REQUEST_TIMEOUT}
+	newConnectionClient := &http.Client{Timeout: REQUEST_TIMEOUT, Transport: &http.Transport{DisableKeepAlives: true}}
+
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return htuc.awaitServiceReady(keepAliveClient, baseUrl+tcra.TestCase.HealthCheckPath, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	for _, endpoint := range tcra.TestCase.HttpEndpoints {
+		endpoint := endpoint
+		stepSuffix := " " + endpoint.Path
+
+		if err := mcuc.CollectStepMetrics(htuc.createLoadStep(strings.ToLower(endpoint.Method)+"KeepAlive"+stepSuffix, keepAliveClient, baseUrl, &endpoint, tcra.TestCase.GetConcurrency())); err != nil {
+			logrus.WithError(err).WithField("endpoint", endpoint).Warn("keep alive load failed")
+		}
+
+		if err := mcuc.CollectStepMetrics(htuc.createLoadStep(strings.ToLower(endpoint.Method)+"NewConnection"+stepSuffix, newConnectionClient, baseUrl, &endpoint, tcra.TestCase.GetConcurrency())); err != nil {
+			logrus.WithError(err).WithField("endpoint", endpoint).Warn("new connection load failed")
+		}
+
+		if tcra.TestCase.HasWorkload(domain.Workload_Saturation) {
+			step := helpers.NewSaturationStep(strings.ToLower(endpoint.Method)+"Saturation"+stepSuffix, tcra.TestCase, func() error {
+				return htuc.sendRequest(keepAliveClient, baseUrl, &endpoint)
+			})
+			if err := mcuc.CollectStepMetrics(step); err != nil {
+				logrus.WithError(err).WithField("endpoint", endpoint).Warn("saturation point wasn't found")
+			}
+		}
+	}
+
+	return nil
+}
+
+// Method polls health check URL until service responds with 2xx
+func (htuc *httpTesterUsecase) awaitServiceReady(client *http.Client, url string, timeout time.Duration) error {
+	return helpers.AwaitConnection(func() error {
+		resp, err := client.Get(url)
+		if err != nil {
+			return err
+		}
+		htuc.drainBody(resp.Body)
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return domain.UNEXPECTED_RESPONSE_STATUS
+		}
+		return nil
+	}, timeout)
+}
+
+// Method creates step sending endpoint requests concurrently and reporting latency percentiles and throughput
+func (htuc *httpTesterUsecase) createLoadStep(name string, client *http.Client, baseUrl string, endpoint *domain.HttpEndpoint, concurrency uint16) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = helpers.RunLoad(int(endpoint.GetRequestsCount()), int(concurrency), func() error {
+			return htuc.sendRequest(client, baseUrl, endpoint)
+		})
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+}
+
+func (htuc *httpTesterUsecase) sendRequest(client *http.Client, baseUrl string, endpoint *domain.HttpEndpoint) error {
+	var body io.Reader
+	if endpoint.Body != "" {
+		body = strings.NewReader(endpoint.Body)
+	}
+
+	req, err := http.NewRequest(endpoint.Method, baseUrl+endpoint.Path, body)
+	if err != nil {
+		return err
+	}
+	for k, v := range endpoint.Header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	htuc.drainBody(resp.Body)
+
+	if resp.StatusCode >= 400 {
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	return nil
+}
+
+// Body must be read till EOF to reuse keep alive connection
+func (htuc *httpTesterUsecase) drainBody(body io.ReadCloser) {
+	if _, err := io.Copy(ioutil.Discard, body); err != nil {
+		logrus.WithError(err).Trace("couldn't drain response body")
+	}
+	body.Close()
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	REQUEST_TIMEOUT = 30 * time.Second
+	// Idle connections are kept for all concurrent clients
+	MAX_IDLE_CONNS_PER_HOST = 128
+	// Client of the master realm which is allowed to use admin API
+	KEYCLOAK_ADMIN_CLIENT_ID = "admin-cli"
+	KEYCLOAK_MASTER_REALM    = "master"
+)
+
+type keycloakIdpTesterRepository struct {
+	client     *http.Client
+	baseUrl    string
+	adminToken string
+}
+
+// Context path is "/auth" for Keycloak before 17 and empty for the newer ones
+func NewKeycloakIdpTesterRepository(port uint16, host, contextPath string) IdpTesterRepository {
+	r := new(keycloakIdpTesterRepository)
+	r.client = &http.Client{Timeout: REQUEST_TIMEOUT, Transport: &http.Transport{MaxIdleConnsPerHost: MAX_IDLE_CONNS_PER_HOST}}
+	r.baseUrl = "http://" + host + ":" + strconv.FormatUint(uint64(port), 10) + contextPath
+	return r
+}
+
+type keycloakTokenResponse struct {
+	AccessToken string `json:"access_token"`
+}
+
+type keycloakIntrospectionResponse struct {
+	Active bool `json:"active"`
+}
+
+func (r *keycloakIdpTesterRepository) Ping() error {
+	return r.RealmReady(KEYCLOAK_MASTER_REALM)
+}
+
+func (r *keycloakIdpTesterRepository) Login(user, password string) error {
+	token, err := r.PasswordToken(KEYCLOAK_MASTER_REALM, KEYCLOAK_ADMIN_CLIENT_ID, "", user, password)
+	if err != nil {
+		return err
+	}
+	r.adminToken = token
+	return nil
+}
+
+func (r *keycloakIdpTesterRepository) CreateRealm(realm string) error {
+	return r.doAdmin(http.MethodPost, "/admin/realms", map[string]interface{}{
+		"realm":   realm,
+		"enabled": true,
+	})
+}
+
+func (r *keycloakIdpTesterRepository) RealmReady(realm string) error {
+	resp, err := r.client.Get(r.baseUrl + "/realms/" + realm + "/.well-known/openid-configuration")
+	if err != nil {
+		return err
+	}
+	r.drainBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+	return nil
+}
+
+func (r *keycloakIdpTesterRepository) CreateClient(realm, clientId, secret string) error {
+	return r.doAdmin(http.MethodPost, "/admin/realms/"+realm+"/clients", map[string]interface{}{
+		"clientId":                  clientId,
+		"secret":                    secret,
+		"enabled":                   true,
+		"publicClient":              false,
+		"standardFlowEnabled":       false,
+		"directAccessGrantsEnabled": true,
+		"serviceAccountsEnabled":    true,
+	})
+}
+
+func (r *keycloakIdpTesterRepository) CreateUser(realm, user, password string) error {
+	return r.doAdmin(http.MethodPost, "/admin/realms/"+realm+"/users", map[string]interface{}{
+		"username": user,
+		"enabled":  true,
+		"credentials": []map[string]interface{}{
+			{"type": "password", "value": password, "temporary": false},
+		},
+	})
+}
+
+func (r *keycloakIdpTesterRepository) PasswordToken(realm, clientId, secret, user, password string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "password")
+	form.Set("username", user)
+	form.Set("password", password)
+
+	return r.requestToken(realm, clientId, secret, form)
+}
+
+func (r *keycloakIdpTesterRepository) ClientCredentialsToken(realm, clientId, secret string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+
+	return r.requestToken(realm, clientId, secret, form)
+}
+
+func (r *keycloakIdpTesterRepository) Introspect(realm, clientId, secret, token string) (bool, error) {
+	form := url.Values{}
+	form.Set("token", token)
+
+	var ir keycloakIntrospectionResponse
+	if err := r.postForm("/realms/"+realm+"/protocol/openid-connect/token/introspect", clientId, secret, form, &ir); err != nil {
+		return false, err
+	}
+	return ir.Active, nil
+}
+
+func (r *keycloakIdpTesterRepository) DeleteRealm(realm string) error {
+	return r.doAdmin(http.MethodDelete, "/admin/realms/"+realm, nil)
+}
+
+func (r *keycloakIdpTesterRepository) requestToken(realm, clientId, secret string, form url.Values) (string, error) {
+	var tr keycloakTokenResponse
+	if err := r.postForm("/realms/"+realm+"/protocol/openid-connect/token", clientId, secret, form, &tr); err != nil {
+		return "", err
+	}
+	return tr.AccessToken, nil
+}
+
+// Method posts form authenticated with the client credentials and decodes JSON response into result
+func (r *keycloakIdpTesterRepository) postForm(path, clientId, secret string, form url.Values, result interface{}) error {
+	// Public clients are identified by client_id form field
+	if secret == "" {
+		form.Set("client_id", clientId)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.baseUrl+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if secret != "" {
+		req.SetBasicAuth(clientId, secret)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		r.logFailedResponse(resp)
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (r *keycloakIdpTesterRepository) doAdmin(method, path string, body interface{}) error {
+	if r.adminToken == "" {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, r.baseUrl+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+r.adminToken)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 300 {
+		r.logFailedResponse(resp)
+		resp.Body.Close()
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+	r.drainBody(resp.Body)
+
+	return nil
+}
+
+func (r *keycloakIdpTesterRepository) logFailedResponse(resp *http.Response) {
+	body, _ := ioutil.ReadAll(resp.Body)
+	logrus.WithFields(logrus.Fields{"status": resp.StatusCode, "body": string(body)}).Debug("keycloak request failed")
+}
+
+// Body must be read till EOF to reuse keep alive connection
+func (r *keycloakIdpTesterRepository) drainBody(body io.ReadCloser) {
+	if _, err := io.Copy(ioutil.Discard, body); err != nil {
+		logrus.WithError(err).Trace("couldn't drain response body")
+	}
+	body.Close()
+}
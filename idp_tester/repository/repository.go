@@ -0,0 +1,19 @@
+package repository
+
+type IdpTesterRepository interface {
+	Ping() error
+	// Login obtains admin token for the admin API calls
+	Login(user, password string) error
+	CreateRealm(realm string) error
+	// RealmReady returns nil when realm's OIDC discovery document is served
+	RealmReady(realm string) error
+	// CreateClient creates confidential client with password and client credentials grants enabled
+	CreateClient(realm, clientId, secret string) error
+	CreateUser(realm, user, password string) error
+	// PasswordToken issues access token with the resource owner password grant
+	PasswordToken(realm, clientId, secret, user, password string) (string, error)
+	ClientCredentialsToken(realm, clientId, secret string) (string, error)
+	// Introspect returns whether token is active
+	Introspect(realm, clientId, secret, token string) (bool, error)
+	DeleteRealm(realm string) error
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"strconv"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/idp_tester/repository"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TOKEN_REQUESTS = 1000
+	REALM_NAME     = "cott"
+	CLIENT_ID      = "cott"
+	CLIENT_SECRET  = "cott-secret"
+	USER_NAME      = "cott"
+	USER_PASSWORD  = "cott-password"
+)
+
+var concurrencyLevels = []int{1, 8, 32}
+
+type IdpTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type idpTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewIdpTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) IdpTesterUsecase {
+	ituc := new(idpTesterUsecase)
+	ituc.cluc = cluc
+	return ituc
+}
+
+func (ituc *idpTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	r, err := ituc.createIdpRepository(tcra.TestCase)
+	if err != nil {
+		return err
+	}
+
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, ituc.cluc, containerId)
+
+	// Await for provider ready
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return helpers.AwaitConnection(r.Ping, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	step = &domain.TestCaseStep{Name: "adminLogin", StepFunc: func() error { return r.Login(tcra.TestCase.User, tcra.TestCase.Password) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: "createRealm", StepFunc: func() error { return r.CreateRealm(REALM_NAME) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	// Time until realm endpoints are served after creation
+	step = &domain.TestCaseStep{Name: "realmStartUp", StepFunc: func() error {
+		return helpers.AwaitConnection(func() error { return r.RealmReady(REALM_NAME) }, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: "createClient", StepFunc: func() error { return r.CreateClient(REALM_NAME, CLIENT_ID, CLIENT_SECRET) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: "createUser", StepFunc: func() error { return r.CreateUser(REALM_NAME, USER_NAME, USER_PASSWORD) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	ituc.testTokens(mcuc, r)
+
+	step = &domain.TestCaseStep{Name: "deleteRealm", StepFunc: func() error { return r.DeleteRealm(REALM_NAME) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ituc *idpTesterUsecase) createIdpRepository(tc *domain.TestCase) (repository.IdpTesterRepository, error) {
+	switch tc.ComponentType {
+	case domain.ComponentType_Keycloak:
+		return repository.NewKeycloakIdpTesterRepository(tc.Port, "localhost", tc.ContextPath), nil
+	default:
+		return nil, domain.NewUnknownComponentError(tc.ComponentType)
+	}
+}
+
+// Method measures token issuance and introspection at every concurrency level
+func (ituc *idpTesterUsecase) testTokens(mcuc metrics_collector.MetricsCollectorUsecase, r repository.IdpTesterRepository) {
+	token, err := r.ClientCredentialsToken(REALM_NAME, CLIENT_ID, CLIENT_SECRET)
+	if err != nil {
+		logrus.WithError(err).Warn("couldn't issue token for introspection")
+		return
+	}
+
+	for _, concurrency := range concurrencyLevels {
+		testPrefix := strconv.FormatInt(int64(concurrency), 10) + "xConcurrency"
+
+		steps := []*domain.TestCaseStep{
+			ituc.createLoadStep(testPrefix+"PasswordToken", concurrency, func() error {
+				_, err := r.PasswordToken(REALM_NAME, CLIENT_ID, CLIENT_SECRET, USER_NAME, USER_PASSWORD)
+				return err
+			}),
+			ituc.createLoadStep(testPrefix+"ClientCredentialsToken", concurrency, func() error {
+				_, err := r.ClientCredentialsToken(REALM_NAME, CLIENT_ID, CLIENT_SECRET)
+				return err
+			}),
+			ituc.createLoadStep(testPrefix+"Introspection", concurrency, func() error {
+				active, err := r.Introspect(REALM_NAME, CLIENT_ID, CLIENT_SECRET, token)
+				if err != nil {
+					return err
+				}
+				if !active {
+					return domain.UNEXPECTED_RESPONSE_STATUS
+				}
+				return nil
+			}),
+		}
+		for _, step := range steps {
+			if err := mcuc.CollectStepMetrics(step); err != nil {
+				logrus.WithError(err).WithField("concurrency", concurrency).Warn("token load failed")
+			}
+		}
+	}
+}
+
+func (ituc *idpTesterUsecase) createLoadStep(name string, concurrency int, operation func() error) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = helpers.RunLoad(TOKEN_REQUESTS, concurrency, operation)
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"encoding/xml"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// Matches ${__Random(min,max)} and ${__Random(min,max,name)} functions
+var jmeterRandomRegexp = regexp.MustCompile(`\$\{__Random\(\s*(-?\d+)\s*,\s*(-?\d+)\s*(?:,\s*\w+\s*)?\)\}`)
+
+// Element of the JMX file. Children of the element are in the hashTree element following it.
+type jmxElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr   `xml:",any,attr"`
+	Content  string       `xml:",chardata"`
+	Children []jmxElement `xml:",any"`
+}
+
+func (e *jmxElement) attr(name string) string {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// Method returns value of the string, int or bool property
+func (e *jmxElement) prop(name string) string {
+	for _, c := range e.Children {
+		if strings.HasSuffix(c.XMLName.Local, "Prop") && c.attr("name") == name {
+			return strings.TrimSpace(c.Content)
+		}
+	}
+	return ""
+}
+
+func (e *jmxElement) elementProp(name string) *jmxElement {
+	for i, c := range e.Children {
+		if c.XMLName.Local == "elementProp" && c.attr("name") == name {
+			return &e.Children[i]
+		}
+	}
+	return nil
+}
+
+// Method supports thread groups with fixed loops count, loop, transaction and simple controllers and JDBC samplers.
+// Other samplers are skipped, config elements and listeners are ignored.
+func (iuc *importerUsecase) ImportJmeterPlan(path string) ([]domain.SqlStep, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var root jmxElement
+	if err := xml.NewDecoder(f).Decode(&root); err != nil {
+		return nil, err
+	}
+
+	var steps []domain.SqlStep
+	err = walkJmxTree(root.Children, func(e *jmxElement, threads uint16, loops uint32) error {
+		step, err := convertJdbcSampler(e, threads, loops)
+		if err != nil {
+			return err
+		}
+		steps = append(steps, *step)
+		return nil
+	}, 0, 1)
+	return steps, err
+}
+
+// Method walks elements of the hash tree and calls onSampler for JDBC samplers of the thread groups
+// with threads count and loops count of every thread
+func walkJmxTree(elements []jmxElement, onSampler func(e *jmxElement, threads uint16, loops uint32) error, threads uint16, loops uint32) error {
+	for i := 0; i < len(elements); i++ {
+		e := &elements[i]
+		if e.XMLName.Local == "hashTree" {
+			if err := walkJmxTree(e.Children, onSampler, threads, loops); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var children []jmxElement
+		if i+1 < len(elements) && elements[i+1].XMLName.Local == "hashTree" {
+			children = elements[i+1].Children
+			i++
+		}
+
+		if e.attr("enabled") == "false" {
+			continue
+		}
+
+		switch e.XMLName.Local {
+		case "ThreadGroup", "SetupThreadGroup", "PostThreadGroup":
+			groupThreads, err := strconv.ParseUint(e.prop("ThreadGroup.num_threads"), 10, 16)
+			if err != nil {
+				return domain.UNSUPPORTED_JMETER_ELEMENT
+			}
+			groupLoops := uint64(1)
+			if lc := e.elementProp("ThreadGroup.main_controller"); lc != nil {
+				// Infinite loops of the scheduled thread groups are negative
+				if groupLoops, err = strconv.ParseUint(lc.prop("LoopController.loops"), 10, 32); err != nil {
+					return domain.UNSUPPORTED_JMETER_ELEMENT
+				}
+			}
+			if err := walkJmxTree(children, onSampler, uint16(groupThreads), uint32(groupLoops)); err != nil {
+				return err
+			}
+
+		case "LoopController":
+			controllerLoops, err := strconv.ParseUint(e.prop("LoopController.loops"), 10, 32)
+			if err != nil {
+				return domain.UNSUPPORTED_JMETER_ELEMENT
+			}
+			if err := walkJmxTree(children, onSampler, threads, loops*uint32(controllerLoops)); err != nil {
+				return err
+			}
+
+		case "TransactionController", "GenericController":
+			if err := walkJmxTree(children, onSampler, threads, loops); err != nil {
+				return err
+			}
+
+		case "JDBCSampler":
+			if threads == 0 {
+				return domain.UNSUPPORTED_JMETER_ELEMENT
+			}
+			if err := onSampler(e, threads, loops); err != nil {
+				return err
+			}
+
+		case "TestPlan":
+			if err := walkJmxTree(children, onSampler, threads, loops); err != nil {
+				return err
+			}
+
+		default:
+			if strings.HasSuffix(e.XMLName.Local, "Sampler") || strings.HasSuffix(e.XMLName.Local, "SamplerProxy") {
+				logrus.WithFields(logrus.Fields{"element": e.XMLName.Local, "name": e.attr("testname")}).Warn("JMeter sampler isn't JDBC one, it's skipped")
+			}
+		}
+	}
+	return nil
+}
+
+func convertJdbcSampler(e *jmxElement, threads uint16, loops uint32) (*domain.SqlStep, error) {
+	s := &domain.SqlStep{
+		Name:         "jmeter " + e.attr("testname"),
+		Clients:      threads,
+		Transactions: uint32(threads) * loops,
+	}
+
+	// Functions are evaluated before the arguments are split by commas like JMeter does
+	expandRandoms := func(text string) string {
+		return jmeterRandomRegexp.ReplaceAllStringFunc(text, func(f string) string {
+			m := jmeterRandomRegexp.FindStringSubmatch(f)
+			min, _ := strconv.ParseInt(m[1], 10, 64)
+			max, _ := strconv.ParseInt(m[2], 10, 64)
+
+			name := "jmeter_random_" + strconv.FormatInt(int64(len(s.Variables)+1), 10)
+			s.Variables = append(s.Variables, domain.SqlVariable{Name: name, Min: min, Max: max})
+			return ":" + name
+		})
+	}
+
+	query := expandRandoms(e.prop("query"))
+	if args := expandRandoms(e.prop("queryArguments")); args != "" {
+		var err error
+		if query, err = bindJdbcArguments(query, args, e.prop("queryArgumentsTypes")); err != nil {
+			return nil, err
+		}
+	}
+
+	// Other functions and variables are defined by the elements which aren't imported
+	if strings.Contains(query, "${") {
+		return nil, domain.UNSUPPORTED_JMETER_ELEMENT
+	}
+
+	s.Statements = []string{strings.TrimSuffix(strings.TrimSpace(query), ";")}
+	return s, nil
+}
+
+// Method replaces "?" parameters of the prepared statement with the argument literals, strings are quoted
+func bindJdbcArguments(query string, args string, types string) (string, error) {
+	argsList := strings.Split(args, ",")
+	typesList := strings.Split(types, ",")
+	if len(argsList) != len(typesList) || strings.Count(query, "?") != len(argsList) {
+		return "", domain.UNSUPPORTED_JMETER_ELEMENT
+	}
+
+	var buf strings.Builder
+	for i, part := range strings.Split(query, "?") {
+		buf.WriteString(part)
+		if i == len(argsList) {
+			break
+		}
+
+		arg := strings.TrimSpace(argsList[i])
+		switch strings.ToUpper(strings.TrimSpace(typesList[i])) {
+		case "INTEGER", "BIGINT", "SMALLINT", "NUMERIC", "DECIMAL", "DOUBLE", "FLOAT", "REAL":
+			buf.WriteString(arg)
+		default:
+			buf.WriteString("'" + strings.ReplaceAll(arg, "'", "''") + "'")
+		}
+	}
+	return buf.String(), nil
+}
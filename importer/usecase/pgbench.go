@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+// Scale factor of the imported scripts like "pgbench -s 1"
+const PGBENCH_SCALE = 1
+
+// Method supports SQL commands terminated by semicolons or line ends and "\set" meta commands
+// with constant expressions or random(min, max)
+func (iuc *importerUsecase) ImportPgbenchScript(path string, clients uint16, transactions uint32) (*domain.SqlStep, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &domain.SqlStep{
+		Name:         "pgbench " + strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Clients:      clients,
+		Transactions: uint32(clients) * transactions,
+	}
+	// Values of the constant variables used by the next expressions
+	constants := map[string]int64{"scale": PGBENCH_SCALE}
+	// Old pgbench versions accept only single line commands without semicolons
+	isSingleLineCommands := !strings.Contains(string(data), ";")
+
+	var statement strings.Builder
+	flush := func() {
+		if sql := strings.TrimSpace(statement.String()); sql != "" {
+			s.Statements = append(s.Statements, strings.TrimSuffix(sql, ";"))
+		}
+		statement.Reset()
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "\\") {
+			flush()
+			v, err := parsePgbenchMetaCommand(line, constants)
+			if err != nil {
+				return nil, err
+			}
+			s.Variables = append(s.Variables, *v)
+			if v.Min == v.Max {
+				constants[v.Name] = v.Min
+			}
+			continue
+		}
+
+		statement.WriteString(line)
+		statement.WriteByte('\n')
+		if strings.HasSuffix(line, ";") || isSingleLineCommands {
+			flush()
+		}
+	}
+	flush()
+
+	if err := validateSqlVariables(s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Only "\set name expression" is supported, constant variable has the same min and max value
+func parsePgbenchMetaCommand(line string, constants map[string]int64) (*domain.SqlVariable, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || fields[0] != "\\set" {
+		return nil, domain.UNSUPPORTED_PGBENCH_COMMAND
+	}
+	name := fields[1]
+	expr := strings.Join(fields[2:], " ")
+
+	if strings.HasPrefix(expr, "random(") && strings.HasSuffix(expr, ")") {
+		args := splitPgbenchArgs(expr[len("random(") : len(expr)-1])
+		if len(args) != 2 {
+			return nil, domain.UNSUPPORTED_PGBENCH_COMMAND
+		}
+		min, err := evalPgbenchExpression(args[0], constants)
+		if err != nil {
+			return nil, err
+		}
+		max, err := evalPgbenchExpression(args[1], constants)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.SqlVariable{Name: name, Min: min, Max: max}, nil
+	}
+
+	value, err := evalPgbenchExpression(expr, constants)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SqlVariable{Name: name, Min: value, Max: value}, nil
+}
+
+// Splits function arguments by top level commas
+func splitPgbenchArgs(s string) []string {
+	var (
+		args  []string
+		depth int
+		start int
+	)
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(args, s[start:])
+}
+
+// Method evaluates integer expression with + - * / % operators, parentheses and constant variables
+func evalPgbenchExpression(expr string, constants map[string]int64) (int64, error) {
+	p := &pgbenchExpressionParser{tokens: tokenizePgbenchExpression(expr), constants: constants}
+	value, err := p.parseSum()
+	if err != nil {
+		return 0, err
+	}
+	if p.pos != len(p.tokens) {
+		return 0, domain.UNSUPPORTED_PGBENCH_COMMAND
+	}
+	return value, nil
+}
+
+func tokenizePgbenchExpression(expr string) []string {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case strings.IndexByte("+-*/%()", c) >= 0:
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			j := i
+			for j < len(expr) && strings.IndexByte(" \t+-*/%()", expr[j]) < 0 {
+				j++
+			}
+			tokens = append(tokens, expr[i:j])
+			i = j
+		}
+	}
+	return tokens
+}
+
+type pgbenchExpressionParser struct {
+	tokens    []string
+	pos       int
+	constants map[string]int64
+}
+
+func (p *pgbenchExpressionParser) next() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *pgbenchExpressionParser) parseSum() (int64, error) {
+	value, err := p.parseProduct()
+	if err != nil {
+		return 0, err
+	}
+	for op := p.next(); op == "+" || op == "-"; op = p.next() {
+		p.pos++
+		rhs, err := p.parseProduct()
+		if err != nil {
+			return 0, err
+		}
+		if op == "+" {
+			value += rhs
+		} else {
+			value -= rhs
+		}
+	}
+	return value, nil
+}
+
+func (p *pgbenchExpressionParser) parseProduct() (int64, error) {
+	value, err := p.parseOperand()
+	if err != nil {
+		return 0, err
+	}
+	for op := p.next(); op == "*" || op == "/" || op == "%"; op = p.next() {
+		p.pos++
+		rhs, err := p.parseOperand()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case op == "*":
+			value *= rhs
+		case rhs == 0:
+			return 0, domain.UNSUPPORTED_PGBENCH_COMMAND
+		case op == "/":
+			value /= rhs
+		default:
+			value %= rhs
+		}
+	}
+	return value, nil
+}
+
+func (p *pgbenchExpressionParser) parseOperand() (int64, error) {
+	token := p.next()
+	p.pos++
+
+	switch {
+	case token == "(":
+		value, err := p.parseSum()
+		if err != nil {
+			return 0, err
+		}
+		if p.next() != ")" {
+			return 0, domain.UNSUPPORTED_PGBENCH_COMMAND
+		}
+		p.pos++
+		return value, nil
+	case token == "-":
+		value, err := p.parseOperand()
+		return -value, err
+	case strings.HasPrefix(token, ":"):
+		// Random variables could be used only in the statements
+		value, ok := p.constants[token[1:]]
+		if !ok {
+			return 0, domain.UNDEFINED_SQL_VARIABLE
+		}
+		return value, nil
+	default:
+		value, err := strconv.ParseInt(token, 10, 64)
+		if err != nil {
+			return 0, domain.UNSUPPORTED_PGBENCH_COMMAND
+		}
+		return value, nil
+	}
+}
+
+// Method checks that every placeholder of the statements is the defined variable, like pgbench does
+func validateSqlVariables(s *domain.SqlStep) error {
+	defined := make(map[string]bool)
+	for _, v := range s.Variables {
+		defined[v.Name] = true
+	}
+
+	for _, statement := range s.Statements {
+		var undefined bool
+		domain.ExpandSqlVariables(statement, func(name string) (string, bool) {
+			if !defined[name] {
+				undefined = true
+			}
+			return "", false
+		})
+		if undefined {
+			return domain.UNDEFINED_SQL_VARIABLE
+		}
+	}
+	return nil
+}
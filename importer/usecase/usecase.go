@@ -0,0 +1,18 @@
+package usecase
+
+import "github.com/iakrevetkho/components-tests/cott/domain"
+
+// ImporterUsecase converts benchmark definitions of the other tools into the SQL steps
+type ImporterUsecase interface {
+	// ImportPgbenchScript converts pgbench custom script into the step run by clients, every one runs transactions like "pgbench -c -t"
+	ImportPgbenchScript(path string, clients uint16, transactions uint32) (*domain.SqlStep, error)
+	// ImportJmeterPlan converts JDBC samplers of the JMeter test plan thread groups into the steps
+	ImportJmeterPlan(path string) ([]domain.SqlStep, error)
+}
+
+type importerUsecase struct{}
+
+func NewImporterUsecase() ImporterUsecase {
+	iuc := new(importerUsecase)
+	return iuc
+}
@@ -0,0 +1,131 @@
+package helpers
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"gonum.org/v1/gonum/stat"
+)
+
+type LoadResult struct {
+	// Latencies of the successful operations in microseconds
+	Latencies []float64
+	Duration  time.Duration
+	Errors    int64
+}
+
+// RunLoad runs operation requests times by concurrency workers and collects latencies
+func RunLoad(requests int, concurrency int, operation func() error) *LoadResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		lr        = new(LoadResult)
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		remaining = int64(requests)
+	)
+
+	startTime := time.Now()
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for atomic.AddInt64(&remaining, -1) >= 0 {
+				opStartTime := time.Now()
+				if err := operation(); err != nil {
+					atomic.AddInt64(&lr.Errors, 1)
+					continue
+				}
+				latency := float64(time.Since(opStartTime).Microseconds())
+
+				mu.Lock()
+				lr.Latencies = append(lr.Latencies, latency)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	lr.Duration = time.Since(startTime)
+
+	return lr
+}
+
+// NewQueryStep creates step running query requests times by concurrency workers and reporting latencies, throughput and errors
+func NewQueryStep(name string, requests int, concurrency int, query func() error) *domain.TestCaseStep {
+	var lr *LoadResult
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = RunLoad(requests, concurrency, query)
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+}
+
+// RunWorkersLoad runs operation requests times by every worker and collects latencies of every worker and of all of them.
+// Duration of the worker is the time till its last operation, duration of the total result is the time till the last worker.
+func RunWorkersLoad(requests int, workers int, operation func(worker int) error) (*LoadResult, []*LoadResult) {
+	var (
+		total   = new(LoadResult)
+		results = make([]*LoadResult, workers)
+		wg      sync.WaitGroup
+	)
+
+	startTime := time.Now()
+	for w := 0; w < workers; w++ {
+		results[w] = new(LoadResult)
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			lr := results[w]
+			for i := 0; i < requests; i++ {
+				opStartTime := time.Now()
+				if err := operation(w); err != nil {
+					lr.Errors++
+					continue
+				}
+				lr.Latencies = append(lr.Latencies, float64(time.Since(opStartTime).Microseconds()))
+			}
+			lr.Duration = time.Since(startTime)
+		}(w)
+	}
+	wg.Wait()
+	total.Duration = time.Since(startTime)
+
+	for _, lr := range results {
+		total.Latencies = append(total.Latencies, lr.Latencies...)
+		total.Errors += lr.Errors
+	}
+
+	return total, results
+}
+
+// Throughput returns successful operations per second
+func (lr *LoadResult) Throughput() float64 {
+	if lr.Duration == 0 {
+		return 0
+	}
+	return float64(len(lr.Latencies)) / lr.Duration.Seconds()
+}
+
+// Quantile returns latency quantile in microseconds
+func (lr *LoadResult) Quantile(p float64) float64 {
+	if len(lr.Latencies) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64{}, lr.Latencies...)
+	sort.Float64s(sorted)
+
+	return stat.Quantile(p, stat.Empirical, sorted, nil)
+}
@@ -43,8 +43,19 @@ func InitLogger(config *domain.Config) error {
 	}
 	c.Start()
 
+	if config.Log.Quiet {
+		// Stdout is left for the machine readable output
+		logrus.SetOutput(rotatedLog)
+		return nil
+	}
+
 	// Create writer into the console and file simultaneously
-	mw := io.MultiWriter(os.Stdout, rotatedLog)
+	console := os.Stdout
+	if config.Report.Output != "" {
+		// Stdout is left for the machine readable output
+		console = os.Stderr
+	}
+	mw := io.MultiWriter(console, rotatedLog)
 	logrus.SetOutput(mw)
 
 	return nil
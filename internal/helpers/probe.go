@@ -0,0 +1,95 @@
+package helpers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	PROBE_INTERVAL = 100 * time.Millisecond
+	// Timeout of the single probe attempt
+	PROBE_ATTEMPT_TIMEOUT = 5 * time.Second
+)
+
+// Probe returns nil if the service is ready
+type Probe func() error
+
+// AwaitProbe runs probe every PROBE_INTERVAL until it passes or timeout is exceeded
+func AwaitProbe(probe Probe, timeout time.Duration) error {
+	if !poll(probe, timeout) {
+		return domain.SERVICE_IS_NOT_READY
+	}
+	return nil
+}
+
+// AwaitConnection runs ping every PROBE_INTERVAL until component responds or timeout is exceeded
+func AwaitConnection(ping Probe, timeout time.Duration) error {
+	if !poll(ping, timeout) {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+	return nil
+}
+
+func poll(probe Probe, timeout time.Duration) bool {
+	startTime := time.Now()
+	for time.Since(startTime) < timeout {
+		if err := probe(); err == nil {
+			return true
+		}
+		time.Sleep(PROBE_INTERVAL)
+	}
+	return false
+}
+
+// NewTcpProbe checks that the address accepts connections
+func NewTcpProbe(address string) Probe {
+	return func() error {
+		conn, err := net.DialTimeout("tcp", address, PROBE_ATTEMPT_TIMEOUT)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// NewHttpProbe checks that the url responds with 2xx status
+func NewHttpProbe(url string) Probe {
+	client := &http.Client{Timeout: PROBE_ATTEMPT_TIMEOUT}
+	return func() error {
+		resp, err := client.Get(url)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return domain.UNEXPECTED_RESPONSE_STATUS
+		}
+		return nil
+	}
+}
+
+// NewKafkaMetadataProbe checks that the broker returns cluster metadata, so it has joined the cluster
+func NewKafkaMetadataProbe(address string) Probe {
+	return func() error {
+		ctx, ctxCancelFunc := context.WithTimeout(context.Background(), PROBE_ATTEMPT_TIMEOUT)
+		defer ctxCancelFunc()
+
+		conn, err := kafka.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		if err := conn.SetDeadline(time.Now().Add(PROBE_ATTEMPT_TIMEOUT)); err != nil {
+			return err
+		}
+		_, err = conn.Brokers()
+		return err
+	}
+}
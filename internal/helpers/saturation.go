@@ -0,0 +1,60 @@
+package helpers
+
+import "github.com/iakrevetkho/components-tests/cott/domain"
+
+// Operations count of every client on each concurrency level of the saturation ramp
+const SATURATION_REQUESTS_PER_CLIENT = 100
+
+type SaturationPoint struct {
+	// Max concurrency which satisfies the latency SLO, 0 if even the single client violates it
+	Concurrency int
+	// Load of the max sustainable concurrency
+	Load *LoadResult
+}
+
+// Throughput returns max sustainable successful operations per second
+func (sp *SaturationPoint) Throughput() float64 {
+	if sp.Load == nil {
+		return 0
+	}
+	return sp.Load.Throughput()
+}
+
+// FindSaturationPoint doubles concurrency from 1 to maxConcurrency till p99 latency exceeds slo microseconds or operation fails.
+// Every level runs requestsPerClient operations by each client, so all clients are loaded for most of the level.
+func FindSaturationPoint(requestsPerClient int, maxConcurrency int, slo float64, operation func() error) *SaturationPoint {
+	sp := new(SaturationPoint)
+
+	for concurrency := 1; concurrency <= maxConcurrency; concurrency *= 2 {
+		lr := RunLoad(requestsPerClient*concurrency, concurrency, operation)
+		if lr.Errors > 0 || len(lr.Latencies) == 0 || lr.Quantile(0.99) > slo {
+			break
+		}
+
+		// Throughput could stop growing before latency SLO is violated
+		if lr.Throughput() > sp.Throughput() {
+			sp.Concurrency = concurrency
+			sp.Load = lr
+		}
+	}
+
+	return sp
+}
+
+// NewSaturationStep creates step ramping concurrency of the operation till the test case latency SLO is violated
+// and reporting max sustainable throughput with its concurrency and latencies
+func NewSaturationStep(name string, tc *domain.TestCase, operation func() error) *domain.TestCaseStep {
+	var sp *SaturationPoint
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		sp = FindSaturationPoint(SATURATION_REQUESTS_PER_CLIENT, int(tc.GetSaturationMaxConcurrency()), float64(tc.GetSaturationLatencyP99())*1000, operation)
+		if sp.Load == nil {
+			return domain.LATENCY_SLO_VIOLATED
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(sp.Load.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_SustainableThroughput, sp.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_SaturationConcurrency, float64(sp.Concurrency))
+	}}
+}
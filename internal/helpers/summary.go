@@ -0,0 +1,140 @@
+package helpers
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+const (
+	COLOR_RESET   = "\033[0m"
+	COLOR_RED     = "\033[31m"
+	COLOR_GREEN   = "\033[32m"
+	COLOR_YELLOW  = "\033[33m"
+	COLOR_MAGENTA = "\033[35m"
+	COLOR_BOLD    = "\033[1m"
+)
+
+// Metrics shown for every step, other metrics are shown only if they have budget or anomaly
+var summaryMetrics = map[string]bool{
+	domain.MetricType_Duration:   true,
+	domain.MetricType_Throughput: true,
+	domain.MetricType_LatencyP99: true,
+	domain.MetricType_Errors:     true,
+}
+
+// WriteSummary writes human readable report with the test cases grades and metrics colorized by their budgets.
+// Metrics deviating from the history are marked by the deviation in sigmas.
+func WriteSummary(w io.Writer, r *domain.Report) error {
+	var buf bytes.Buffer
+
+	for i, tcr := range r.TestCaseResults {
+		tc := &tcr.TestCase
+		name := string(tc.ComponentType) + " " + tc.Image + tc.ComposeFile
+		if tc.Driver != "" {
+			name += " " + string(tc.Driver)
+		}
+		if tc.StorageEngine != "" {
+			name += " " + tc.StorageEngine
+		}
+
+		buf.WriteString(COLOR_BOLD + name + COLOR_RESET + " ")
+		buf.WriteString(colorizeGrade(tcr.Grade) + " (" + strconv.FormatFloat(float64(tcr.Score), 'f', 0, 32) + ")\n")
+
+		for _, tcsr := range tcr.StepsResults {
+			buf.WriteString("  " + tcsr.TestCaseStep.Name)
+			if len(tcsr.Errors) > 0 {
+				buf.WriteString(" " + COLOR_RED + "failed: " + tcsr.Errors[0] + COLOR_RESET)
+			}
+
+			for _, m := range tcsr.Metrics {
+				a := r.FindAnomaly(i, tcsr.TestCaseStep.Name, m.Meta.Name)
+				if !summaryMetrics[m.Meta.Name] && m.Budget == nil && a == nil {
+					continue
+				}
+
+				buf.WriteString(" " + m.Meta.Name + "=" + colorizeMetric(&m, formatMetric(&m)))
+				if a != nil {
+					buf.WriteString(COLOR_MAGENTA + "(" + strconv.FormatFloat(a.Sigmas, 'f', 1, 64) + "σ)" + COLOR_RESET)
+				}
+			}
+			buf.WriteByte('\n')
+		}
+	}
+
+	if len(r.AssertionViolations) > 0 {
+		buf.WriteString(COLOR_RED + COLOR_BOLD + "Assertions violated:" + COLOR_RESET + "\n")
+		for _, av := range r.AssertionViolations {
+			buf.WriteString("  " + string(av.ComponentType) + " " + av.Image + ": " + av.Assertion + " - " + av.Reason + "\n")
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func colorizeGrade(g domain.Grade) string {
+	switch g {
+	case domain.Grade_A, domain.Grade_B:
+		return COLOR_GREEN + string(g) + COLOR_RESET
+	case domain.Grade_C:
+		return COLOR_YELLOW + string(g) + COLOR_RESET
+	default:
+		return COLOR_RED + string(g) + COLOR_RESET
+	}
+}
+
+func colorizeMetric(m *domain.Metric, s string) string {
+	if m.Budget == nil {
+		return s
+	}
+
+	switch m.Budget.Severity {
+	case domain.Severity_Critical:
+		return COLOR_RED + s + COLOR_RESET
+	case domain.Severity_Warning:
+		return COLOR_YELLOW + s + COLOR_RESET
+	default:
+		return COLOR_GREEN + s + COLOR_RESET
+	}
+}
+
+// Time metrics are formatted as durations, the others as numbers with the units
+func formatMetric(m *domain.Metric) string {
+	value := m.Value * m.Meta.UnitOfMeasurePrefix.Factor()
+
+	switch m.Meta.UnitOfMeasure {
+	case domain.UnitOfMeasure_Second:
+		return time.Duration(value * float64(time.Second)).Round(time.Microsecond).String()
+	case domain.UnitOfMeasure_Piece:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return strconv.FormatFloat(value, 'f', 2, 64) + unitAbbreviation(m.Meta.UnitOfMeasure)
+	}
+}
+
+func unitAbbreviation(uom domain.UnitOfMeasure) string {
+	switch uom {
+	case domain.UnitOfMeasure_Byte:
+		return "B"
+	case domain.UnitOfMeasure_Percent:
+		return "%"
+	case domain.UnitOfMeasure_OperationPerSecond:
+		return "op/s"
+	case domain.UnitOfMeasure_BytePerSecond:
+		return "B/s"
+	case domain.UnitOfMeasure_SamplePerSecond:
+		return "samples/s"
+	case domain.UnitOfMeasure_Dollar:
+		return "$"
+	case domain.UnitOfMeasure_OperationPerDollar:
+		return "op/$"
+	case domain.UnitOfMeasure_RowPerSecond:
+		return "rows/s"
+	default:
+		return string(uom)
+	}
+}
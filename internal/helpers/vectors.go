@@ -0,0 +1,73 @@
+package helpers
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// GenerateVectors returns count random unit vectors of the dimension
+func GenerateVectors(count int, dimension int) [][]float32 {
+	vectors := make([][]float32, count)
+	for i := range vectors {
+		v := make([]float32, dimension)
+		var norm float64
+		for j := range v {
+			v[j] = float32(rand.NormFloat64())
+			norm += float64(v[j]) * float64(v[j])
+		}
+		norm = math.Sqrt(norm)
+		for j := range v {
+			v[j] = float32(float64(v[j]) / norm)
+		}
+		vectors[i] = v
+	}
+	return vectors
+}
+
+// ExactTopK returns indexes of k vectors with the highest cosine similarity to the unit query vector
+func ExactTopK(vectors [][]float32, query []float32, k int) []int {
+	type scored struct {
+		index int
+		score float64
+	}
+
+	scores := make([]scored, len(vectors))
+	for i, v := range vectors {
+		var dot float64
+		for j := range v {
+			dot += float64(v[j]) * float64(query[j])
+		}
+		scores[i] = scored{index: i, score: dot}
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].score > scores[j].score })
+
+	if k > len(scores) {
+		k = len(scores)
+	}
+	indexes := make([]int, k)
+	for i := range indexes {
+		indexes[i] = scores[i].index
+	}
+	return indexes
+}
+
+// Recall returns share of the expected results found in the actual ones
+func Recall(expected []int, actual []int) float64 {
+	if len(expected) == 0 {
+		return 1
+	}
+
+	found := make(map[int]bool, len(actual))
+	for _, v := range actual {
+		found[v] = true
+	}
+
+	var hits int
+	for _, v := range expected {
+		if found[v] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(expected))
+}
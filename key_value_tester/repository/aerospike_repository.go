@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	as "github.com/aerospike/aerospike-client-go/v5"
+	"github.com/aerospike/aerospike-client-go/v5/types"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+)
+
+const (
+	// Set of the namespace keeping keys of the tests
+	AEROSPIKE_SET        = "cott"
+	AEROSPIKE_VALUE_BIN  = "value"
+	AEROSPIKE_INDEX_BIN  = "index"
+	AEROSPIKE_INDEX_NAME = "cott_index"
+)
+
+type aerospikeKeyValueTesterRepository struct {
+	host      string
+	port      int
+	namespace string
+	policy    *as.ClientPolicy
+	// Client connects to the cluster by its creation, so it's created by the first successful ping
+	mu     sync.Mutex
+	client *as.Client
+}
+
+// NewAerospikeKeyValueTesterRepository creates repository of the keys of the namespace. User is empty if security is disabled.
+func NewAerospikeKeyValueTesterRepository(port uint16, host, user, password, namespace string, poolSize int) KeyValueTesterRepository {
+	r := new(aerospikeKeyValueTesterRepository)
+	r.host = host
+	r.port = int(port)
+	r.namespace = namespace
+	r.policy = as.NewClientPolicy()
+	r.policy.User = user
+	r.policy.Password = password
+	r.policy.Timeout = REQUEST_TIMEOUT
+	r.policy.ConnectionQueueSize = poolSize
+	return r
+}
+
+func (r *aerospikeKeyValueTesterRepository) Ping() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.client == nil {
+		client, err := as.NewClientWithPolicy(r.policy, r.host, r.port)
+		if err != nil {
+			return err
+		}
+		r.client = client
+	}
+
+	if !r.client.IsConnected() {
+		return domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+	return nil
+}
+
+func (r *aerospikeKeyValueTesterRepository) Set(key string, value []byte) error {
+	return r.put(nil, key, as.BinMap{AEROSPIKE_VALUE_BIN: value})
+}
+
+func (r *aerospikeKeyValueTesterRepository) Get(key string) ([]byte, error) {
+	k, err := as.NewKey(r.namespace, AEROSPIKE_SET, key)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := r.client.Get(nil, k, AEROSPIKE_VALUE_BIN)
+	if err != nil {
+		return nil, r.convertError(err)
+	}
+	return r.recordValue(record), nil
+}
+
+// There is no multi record write command, so puts of the batch are sent concurrently like pipelined ones
+func (r *aerospikeKeyValueTesterRepository) MSet(keys []string, values [][]byte) error {
+	return r.SetPipelined(keys, values)
+}
+
+// Every connection runs the single command, so puts are sent by the concurrent connections and awaited together
+func (r *aerospikeKeyValueTesterRepository) SetPipelined(keys []string, values [][]byte) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i, key := range keys {
+		wg.Add(1)
+		go func(key string, value []byte) {
+			defer wg.Done()
+			if err := r.Set(key, value); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(key, values[i])
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// Set is truncated, so records written before the call aren't read even before they are removed from the storage
+func (r *aerospikeKeyValueTesterRepository) FlushDB() error {
+	return r.client.Truncate(nil, r.namespace, AEROSPIKE_SET, nil)
+}
+
+func (r *aerospikeKeyValueTesterRepository) BatchGet(keys []string) ([][]byte, error) {
+	ks := make([]*as.Key, len(keys))
+	for i, key := range keys {
+		k, err := as.NewKey(r.namespace, AEROSPIKE_SET, key)
+		if err != nil {
+			return nil, err
+		}
+		ks[i] = k
+	}
+
+	records, err := r.client.BatchGet(nil, ks, AEROSPIKE_VALUE_BIN)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([][]byte, len(records))
+	for i, record := range records {
+		// Records of the missing keys are nil
+		if record == nil {
+			return nil, domain.KEY_NOT_FOUND
+		}
+		values[i] = r.recordValue(record)
+	}
+	return values, nil
+}
+
+func (r *aerospikeKeyValueTesterRepository) SetIndexed(key string, value []byte, indexValue int64) error {
+	return r.put(nil, key, as.BinMap{AEROSPIKE_VALUE_BIN: value, AEROSPIKE_INDEX_BIN: indexValue})
+}
+
+// Index is built by the background scan of the set, so method waits for the task completion
+func (r *aerospikeKeyValueTesterRepository) CreateIndex() error {
+	task, err := r.client.CreateIndex(nil, r.namespace, AEROSPIKE_SET, AEROSPIKE_INDEX_NAME, AEROSPIKE_INDEX_BIN, as.NUMERIC)
+	if err != nil {
+		return err
+	}
+	if err := <-task.OnComplete(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *aerospikeKeyValueTesterRepository) DropIndex() error {
+	if err := r.client.DropIndex(nil, r.namespace, AEROSPIKE_SET, AEROSPIKE_INDEX_NAME); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *aerospikeKeyValueTesterRepository) QueryIndex(from int64, to int64) (int, error) {
+	stmt := as.NewStatement(r.namespace, AEROSPIKE_SET, AEROSPIKE_INDEX_BIN)
+	if err := stmt.SetFilter(as.NewRangeFilter(AEROSPIKE_INDEX_BIN, from, to)); err != nil {
+		return 0, err
+	}
+
+	rs, err := r.client.Query(nil, stmt)
+	if err != nil {
+		return 0, err
+	}
+	defer rs.Close()
+
+	var count int
+	for res := range rs.Results() {
+		if res.Err != nil {
+			return 0, res.Err
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (r *aerospikeKeyValueTesterRepository) SetWithTTL(key string, value []byte, ttl time.Duration) error {
+	return r.put(as.NewWritePolicy(0, uint32(ttl/time.Second)), key, as.BinMap{AEROSPIKE_VALUE_BIN: value})
+}
+
+// Only the record header is read, because it contains the expiration
+func (r *aerospikeKeyValueTesterRepository) TTL(key string) (time.Duration, error) {
+	k, err := as.NewKey(r.namespace, AEROSPIKE_SET, key)
+	if err != nil {
+		return 0, err
+	}
+
+	record, err := r.client.GetHeader(nil, k)
+	if err != nil {
+		return 0, r.convertError(err)
+	}
+	return time.Duration(record.Expiration) * time.Second, nil
+}
+
+func (r *aerospikeKeyValueTesterRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.client != nil {
+		r.client.Close()
+	}
+	return nil
+}
+
+func (r *aerospikeKeyValueTesterRepository) put(policy *as.WritePolicy, key string, bins as.BinMap) error {
+	k, err := as.NewKey(r.namespace, AEROSPIKE_SET, key)
+	if err != nil {
+		return err
+	}
+
+	if err := r.client.Put(policy, k, bins); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *aerospikeKeyValueTesterRepository) recordValue(record *as.Record) []byte {
+	value, _ := record.Bins[AEROSPIKE_VALUE_BIN].([]byte)
+	return value
+}
+
+// Method converts missing and expired records error into the KEY_NOT_FOUND error
+func (r *aerospikeKeyValueTesterRepository) convertError(err as.Error) error {
+	if err.Matches(types.KEY_NOT_FOUND_ERROR) {
+		return domain.KEY_NOT_FOUND
+	}
+	return err
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type etcdKeyValueTesterRepository struct {
+	client *clientv3.Client
+}
+
+// NewEtcdKeyValueTesterRepository creates repository using gRPC client v3. User is empty if authentication is disabled.
+func NewEtcdKeyValueTesterRepository(port uint16, host, user, password string) (KeyValueTesterRepository, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{host + ":" + strconv.FormatUint(uint64(port), 10)},
+		Username:    user,
+		Password:    password,
+		DialTimeout: REQUEST_TIMEOUT,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(etcdKeyValueTesterRepository)
+	r.client = client
+	return r, nil
+}
+
+// Status of the member is requested, because client connects lazily
+func (r *etcdKeyValueTesterRepository) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+	defer cancel()
+
+	_, err := r.client.Status(ctx, r.client.Endpoints()[0])
+	return err
+}
+
+func (r *etcdKeyValueTesterRepository) Set(key string, value []byte) error {
+	_, err := r.client.Put(context.Background(), key, string(value))
+	return err
+}
+
+func (r *etcdKeyValueTesterRepository) Get(key string) ([]byte, error) {
+	resp, err := r.client.Get(context.Background(), key)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, domain.KEY_NOT_FOUND
+	}
+	return resp.Kvs[0].Value, nil
+}
+
+// Keys are put by the single transaction, which is limited by the --max-txn-ops of the server
+func (r *etcdKeyValueTesterRepository) MSet(keys []string, values [][]byte) error {
+	ops := make([]clientv3.Op, len(keys))
+	for i, key := range keys {
+		ops[i] = clientv3.OpPut(key, string(values[i]))
+	}
+
+	_, err := r.client.Txn(context.Background()).Then(ops...).Commit()
+	return err
+}
+
+// Requests are multiplexed by the single gRPC connection, so puts are sent concurrently and awaited together
+func (r *etcdKeyValueTesterRepository) SetPipelined(keys []string, values [][]byte) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i, key := range keys {
+		wg.Add(1)
+		go func(key string, value []byte) {
+			defer wg.Done()
+			if _, err := r.client.Put(context.Background(), key, string(value)); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(key, values[i])
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// All the keys are deleted by the range from the empty key
+func (r *etcdKeyValueTesterRepository) FlushDB() error {
+	_, err := r.client.Delete(context.Background(), "", clientv3.WithFromKey())
+	return err
+}
+
+func (r *etcdKeyValueTesterRepository) Range(prefix string) (int, error) {
+	resp, err := r.client.Get(context.Background(), prefix, clientv3.WithPrefix())
+	if err != nil {
+		return 0, err
+	}
+	return len(resp.Kvs), nil
+}
+
+func (r *etcdKeyValueTesterRepository) Delete(key string) error {
+	_, err := r.client.Delete(context.Background(), key)
+	return err
+}
+
+func (r *etcdKeyValueTesterRepository) Watch(prefix string) (<-chan string, func(), error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	watchChan := r.client.Watch(ctx, prefix, clientv3.WithPrefix(), clientv3.WithFilterDelete(), clientv3.WithCreatedNotify())
+
+	// First response notifies about the created watch
+	created, ok := <-watchChan
+	if !ok {
+		cancel()
+		return nil, nil, domain.CONNECTION_WAS_NOT_ESTABLISHED
+	}
+	if err := created.Err(); err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	keys := make(chan string)
+	go func() {
+		defer close(keys)
+		for resp := range watchChan {
+			for _, event := range resp.Events {
+				select {
+				case keys <- string(event.Kv.Key):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return keys, cancel, nil
+}
+
+func (r *etcdKeyValueTesterRepository) Compact() error {
+	ctx := context.Background()
+
+	// Any response header contains the current revision
+	resp, err := r.client.Get(ctx, "", clientv3.WithCountOnly())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.Compact(ctx, resp.Header.Revision, clientv3.WithCompactPhysical())
+	return err
+}
+
+func (r *etcdKeyValueTesterRepository) Close() error {
+	return r.client.Close()
+}
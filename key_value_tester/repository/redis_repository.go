@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const REQUEST_TIMEOUT = 60 * time.Second
+
+type redisKeyValueTesterRepository struct {
+	client *redis.Client
+}
+
+// NewRedisKeyValueTesterRepository creates repository of the database number of the server. Password is empty if authentication is disabled.
+func NewRedisKeyValueTesterRepository(port uint16, host, password string, db int, poolSize int) KeyValueTesterRepository {
+	r := new(redisKeyValueTesterRepository)
+	r.client = redis.NewClient(&redis.Options{
+		Addr:         host + ":" + strconv.FormatUint(uint64(port), 10),
+		Password:     password,
+		DB:           db,
+		PoolSize:     poolSize,
+		ReadTimeout:  REQUEST_TIMEOUT,
+		WriteTimeout: REQUEST_TIMEOUT,
+	})
+	return r
+}
+
+func (r *redisKeyValueTesterRepository) Ping() error {
+	return r.client.Ping(context.Background()).Err()
+}
+
+func (r *redisKeyValueTesterRepository) Set(key string, value []byte) error {
+	return r.client.Set(context.Background(), key, value, 0).Err()
+}
+
+func (r *redisKeyValueTesterRepository) Get(key string) ([]byte, error) {
+	return r.client.Get(context.Background(), key).Bytes()
+}
+
+func (r *redisKeyValueTesterRepository) MSet(keys []string, values [][]byte) error {
+	pairs := make([]interface{}, 0, 2*len(keys))
+	for i, key := range keys {
+		pairs = append(pairs, key, values[i])
+	}
+
+	return r.client.MSet(context.Background(), pairs...).Err()
+}
+
+func (r *redisKeyValueTesterRepository) SetPipelined(keys []string, values [][]byte) error {
+	ctx := context.Background()
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			pipe.Set(ctx, key, values[i], 0)
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *redisKeyValueTesterRepository) FlushDB() error {
+	return r.client.FlushDB(context.Background()).Err()
+}
+
+func (r *redisKeyValueTesterRepository) Close() error {
+	return r.client.Close()
+}
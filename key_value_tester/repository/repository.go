@@ -0,0 +1,46 @@
+package repository
+
+import "time"
+
+type KeyValueTesterRepository interface {
+	Ping() error
+	Set(key string, value []byte) error
+	Get(key string) ([]byte, error)
+	// MSet sets all the keys by the single command
+	MSet(keys []string, values [][]byte) error
+	// SetPipelined sends set commands of all the keys without waiting for the replies of the previous ones
+	SetPipelined(keys []string, values [][]byte) error
+	// FlushDB removes all the keys of the current database
+	FlushDB() error
+	Close() error
+}
+
+// RevisionedKeyValueTesterRepository is implemented by the stores keeping revisions of the keys, which are watched and compacted
+type RevisionedKeyValueTesterRepository interface {
+	// Range reads all the keys of the prefix by the single request and returns their count
+	Range(prefix string) (int, error)
+	Delete(key string) error
+	// Watch sends keys of the put events of the prefix to the channel until stop is called.
+	// Method returns after the watch is created, so events of the next puts aren't missed.
+	Watch(prefix string) (keys <-chan string, stop func(), err error)
+	// Compact removes revisions before the current one and awaits them removed from the backend
+	Compact() error
+}
+
+// RecordKeyValueTesterRepository is implemented by the stores keeping values in the records with the bins, which are
+// read by the batches, queried by the secondary indexes and expired by their TTL
+type RecordKeyValueTesterRepository interface {
+	// BatchGet reads all the keys by the single request
+	BatchGet(keys []string) ([][]byte, error)
+	// SetIndexed sets the key with the integer bin of the secondary index
+	SetIndexed(key string, value []byte, indexValue int64) error
+	// CreateIndex creates secondary index of the integer bin and awaits it built
+	CreateIndex() error
+	DropIndex() error
+	// QueryIndex returns count of the keys which integer bin values are in [from, to] range
+	QueryIndex(from int64, to int64) (int, error)
+	// SetWithTTL sets the key which expires after the TTL rounded to seconds
+	SetWithTTL(key string, value []byte, ttl time.Duration) error
+	// TTL returns remaining time to live of the key
+	TTL(key string) (time.Duration, error)
+}
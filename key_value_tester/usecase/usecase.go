@@ -0,0 +1,364 @@
+package usecase
+
+import (
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	"github.com/iakrevetkho/components-tests/cott/key_value_tester/repository"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	KEY_PREFIX     = "cott:"
+	VALUE_SIZE     = 100
+	MAX_KEYS_COUNT = 100000
+	// Keys count of the single MSET command or pipeline
+	BATCH_SIZE = 100
+	// Revisions are compacted only at the key counts which history takes noticeable time to compact
+	MIN_COMPACTION_KEYS_COUNT = 1000
+	WATCH_KEY_PREFIX          = "cott-watch:"
+	WATCH_EVENTS_COUNT        = 1000
+	WATCH_TIMEOUT             = 10 * time.Second
+	RANGE_REQUESTS            = 10
+	// Keys are spread evenly between the values of the indexed bin
+	INDEX_VALUES_COUNT   = 100
+	INDEX_QUERY_REQUESTS = 100
+	KEY_TTL              = 2 * time.Second
+	// Expiration time is stored in seconds, so keys are read after the next second of their expiration
+	EXPIRATION_MARGIN = time.Second
+)
+
+type KeyValueTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type keyValueTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewKeyValueTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) KeyValueTesterUsecase {
+	kvuc := new(keyValueTesterUsecase)
+	kvuc.cluc = cluc
+	return kvuc
+}
+
+func (kvuc *keyValueTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	r, err := kvuc.createKeyValueRepository(tcra.TestCase)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, kvuc.cluc, containerId)
+
+	// Await for server ready
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return helpers.AwaitConnection(r.Ping, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	if rr, ok := r.(repository.RevisionedKeyValueTesterRepository); ok {
+		step = kvuc.createWatchStep(r, rr)
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).Warn("watch failed")
+		}
+	}
+
+	for i := 1; i <= MAX_KEYS_COUNT; i *= 10 {
+		if err := kvuc.testKeys(mcuc, r, int(tcra.TestCase.GetConcurrency()), i); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (kvuc *keyValueTesterUsecase) createKeyValueRepository(tc *domain.TestCase) (repository.KeyValueTesterRepository, error) {
+	switch tc.ComponentType {
+	case domain.ComponentType_Redis:
+		return repository.NewRedisKeyValueTesterRepository(tc.Port, "localhost", tc.Password, 0, int(tc.GetConcurrency())), nil
+	case domain.ComponentType_Etcd:
+		return repository.NewEtcdKeyValueTesterRepository(tc.Port, "localhost", tc.User, tc.Password)
+	case domain.ComponentType_Aerospike:
+		// Namespaces are configured by the server config, the default one is "test"
+		namespace := tc.DatabaseName
+		if namespace == "" {
+			namespace = "test"
+		}
+		return repository.NewAerospikeKeyValueTesterRepository(tc.Port, "localhost", tc.User, tc.Password, namespace, int(tc.GetConcurrency())), nil
+	default:
+		return nil, domain.NewUnknownComponentError(tc.ComponentType)
+	}
+}
+
+// Method sets keys one by one, by pipelines and by MSET commands, reads them and removes them all with FLUSHDB.
+// Revisioned stores also range and delete the keys and compact their history.
+// Record stores also read the keys by batches, query them by the secondary index and expire them.
+func (kvuc *keyValueTesterUsecase) testKeys(mcuc metrics_collector.MetricsCollectorUsecase, r repository.KeyValueTesterRepository, concurrency int, keysCount int) error {
+	testPrefix := strconv.FormatInt(int64(keysCount), 10) + "x"
+
+	keys := make([]string, keysCount)
+	values := make([][]byte, keysCount)
+	for i := range keys {
+		keys[i] = KEY_PREFIX + strconv.FormatInt(int64(i), 10)
+		values[i] = make([]byte, VALUE_SIZE)
+		rand.Read(values[i])
+	}
+
+	step := kvuc.createLoadStep(testPrefix+"Set", keysCount, 1, concurrency, func(i int) error { return r.Set(keys[i], values[i]) })
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = kvuc.createLoadStep(testPrefix+"Get", keysCount, 1, concurrency, func(i int) error {
+		_, err := r.Get(keys[i])
+		return err
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	rec, recorded := r.(repository.RecordKeyValueTesterRepository)
+	if recorded {
+		step = kvuc.createLoadStep(testPrefix+"BatchGet", keysCount, BATCH_SIZE, concurrency, func(i int) error {
+			to := kvuc.batchEnd(i, keysCount)
+			got, err := rec.BatchGet(keys[i:to])
+			if err != nil {
+				return err
+			}
+			if len(got) != to-i {
+				return domain.DATA_INTEGRITY_VIOLATED
+			}
+			return nil
+		})
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+	}
+
+	rr, revisioned := r.(repository.RevisionedKeyValueTesterRepository)
+	if revisioned {
+		step = kvuc.createLoadStep("range"+testPrefix+"Keys", RANGE_REQUESTS, 1, concurrency, func(i int) error {
+			count, err := rr.Range(KEY_PREFIX)
+			if err != nil {
+				return err
+			}
+			if count != keysCount {
+				return domain.DATA_INTEGRITY_VIOLATED
+			}
+			return nil
+		})
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+
+		step = kvuc.createLoadStep(testPrefix+"Delete", keysCount, 1, concurrency, func(i int) error { return rr.Delete(keys[i]) })
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+	}
+
+	if err := r.FlushDB(); err != nil {
+		return err
+	}
+
+	step = kvuc.createLoadStep(testPrefix+"SetPipelined", keysCount, BATCH_SIZE, concurrency, func(i int) error {
+		to := kvuc.batchEnd(i, keysCount)
+		return r.SetPipelined(keys[i:to], values[i:to])
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	if err := r.FlushDB(); err != nil {
+		return err
+	}
+
+	step = kvuc.createLoadStep(testPrefix+"MSet", keysCount, BATCH_SIZE, concurrency, func(i int) error {
+		to := kvuc.batchEnd(i, keysCount)
+		return r.MSet(keys[i:to], values[i:to])
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	// History contains revisions of all the sets and deletes of the keys
+	if revisioned && keysCount >= MIN_COMPACTION_KEYS_COUNT {
+		step = &domain.TestCaseStep{Name: "compact" + testPrefix + "Keys", StepFunc: func() error { return rr.Compact() }}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+	}
+
+	if recorded {
+		if err := kvuc.testRecords(mcuc, r, rec, concurrency, keys, values); err != nil {
+			return err
+		}
+	}
+
+	step = &domain.TestCaseStep{Name: "flush" + testPrefix + "Keys", StepFunc: func() error { return r.FlushDB() }}
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Method sets keys with the indexed bin and queries them by the secondary index, then sets keys with TTL and reads them after
+// their expiration. Keys are left set with TTL, so the flush removes the expired ones.
+func (kvuc *keyValueTesterUsecase) testRecords(mcuc metrics_collector.MetricsCollectorUsecase, r repository.KeyValueTesterRepository, rec repository.RecordKeyValueTesterRepository, concurrency int, keys []string, values [][]byte) error {
+	keysCount := len(keys)
+	testPrefix := strconv.FormatInt(int64(keysCount), 10) + "x"
+
+	if err := r.FlushDB(); err != nil {
+		return err
+	}
+
+	step := kvuc.createLoadStep(testPrefix+"SetIndexed", keysCount, 1, concurrency, func(i int) error {
+		return rec.SetIndexed(keys[i], values[i], int64(i%INDEX_VALUES_COUNT))
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	// Index is built by the existing keys
+	step = &domain.TestCaseStep{Name: "createIndex" + testPrefix + "Keys", StepFunc: func() error { return rec.CreateIndex() }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	step = kvuc.createLoadStep("indexQuery"+testPrefix+"Keys", INDEX_QUERY_REQUESTS, 1, concurrency, func(i int) error {
+		value := rand.Intn(INDEX_VALUES_COUNT)
+		count, err := rec.QueryIndex(int64(value), int64(value))
+		if err != nil {
+			return err
+		}
+		expected := keysCount / INDEX_VALUES_COUNT
+		if value < keysCount%INDEX_VALUES_COUNT {
+			expected++
+		}
+		if count != expected {
+			return domain.DATA_INTEGRITY_VIOLATED
+		}
+		return nil
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		rec.DropIndex()
+		return err
+	}
+
+	if err := rec.DropIndex(); err != nil {
+		return err
+	}
+
+	if err := r.FlushDB(); err != nil {
+		return err
+	}
+
+	step = kvuc.createLoadStep(testPrefix+"SetWithTTL", keysCount, 1, concurrency, func(i int) error {
+		return rec.SetWithTTL(keys[i], values[i], KEY_TTL)
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+	expirationTime := time.Now().Add(KEY_TTL + EXPIRATION_MARGIN)
+
+	step = kvuc.createLoadStep(testPrefix+"TTL", keysCount, 1, concurrency, func(i int) error {
+		ttl, err := rec.TTL(keys[i])
+		if err != nil {
+			return err
+		}
+		if ttl <= 0 || ttl > KEY_TTL {
+			return domain.DATA_INTEGRITY_VIOLATED
+		}
+		return nil
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	time.Sleep(time.Until(expirationTime))
+
+	// Expired keys aren't read even before they are removed by the server, so every found key is the error
+	step = kvuc.createLoadStep(testPrefix+"GetExpired", keysCount, 1, concurrency, func(i int) error {
+		if _, err := r.Get(keys[i]); err != domain.KEY_NOT_FOUND {
+			if err == nil {
+				return domain.DATA_INTEGRITY_VIOLATED
+			}
+			return err
+		}
+		return nil
+	})
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Method creates step running operation for every batch of the keys concurrently.
+// Operation gets index of the first key of the batch. Throughput is reported in keys per second.
+func (kvuc *keyValueTesterUsecase) createLoadStep(name string, keysCount int, batchSize int, concurrency int, operation func(i int) error) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+
+	batches := (keysCount + batchSize - 1) / batchSize
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		var nextBatch int64 = -1
+		lr = helpers.RunLoad(batches, concurrency, func() error {
+			return operation(int(atomic.AddInt64(&nextBatch, 1)) * batchSize)
+		})
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, float64(keysCount)/lr.Duration.Seconds())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+}
+
+// Method creates step putting watched keys one by one. Latency is time from the put request till the notification of the watch.
+func (kvuc *keyValueTesterUsecase) createWatchStep(r repository.KeyValueTesterRepository, rr repository.RevisionedKeyValueTesterRepository) *domain.TestCaseStep {
+	var latencies []float64
+
+	return &domain.TestCaseStep{Name: "watchNotification", StepFunc: func() error {
+		keys, stop, err := rr.Watch(WATCH_KEY_PREFIX)
+		if err != nil {
+			return err
+		}
+		defer stop()
+
+		value := make([]byte, VALUE_SIZE)
+		rand.Read(value)
+		for i := 0; i < WATCH_EVENTS_COUNT; i++ {
+			key := WATCH_KEY_PREFIX + strconv.FormatInt(int64(i), 10)
+			startTime := time.Now()
+			if err := r.Set(key, value); err != nil {
+				return err
+			}
+			select {
+			case event := <-keys:
+				if event != key {
+					return domain.DATA_INTEGRITY_VIOLATED
+				}
+			case <-time.After(WATCH_TIMEOUT):
+				return domain.WATCH_NOTIFICATION_TIMEOUT
+			}
+			latencies = append(latencies, float64(time.Since(startTime).Microseconds()))
+		}
+
+		return r.FlushDB()
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(latencies)
+	}}
+}
+
+func (kvuc *keyValueTesterUsecase) batchEnd(from int, keysCount int) int {
+	if from+BATCH_SIZE > keysCount {
+		return keysCount
+	}
+	return from + BATCH_SIZE
+}
@@ -3,29 +3,98 @@ package main
 import (
 	"encoding/json"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/iakrevetkho/components-tests/cott/domain"
 	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
 
+	br_usecase "github.com/iakrevetkho/components-tests/cott/broker_tester/usecase"
 	cl_usecase "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	dt_repository "github.com/iakrevetkho/components-tests/cott/database_tester/repository"
+	_ "github.com/iakrevetkho/components-tests/cott/database_tester/repository/extensions"
 	dt_usecase "github.com/iakrevetkho/components-tests/cott/database_tester/usecase"
+	dc_usecase "github.com/iakrevetkho/components-tests/cott/document_tester/usecase"
+	ft_usecase "github.com/iakrevetkho/components-tests/cott/file_transfer_tester/usecase"
+	fs_usecase "github.com/iakrevetkho/components-tests/cott/filesystem_tester/usecase"
+	gr_usecase "github.com/iakrevetkho/components-tests/cott/graph_tester/usecase"
+	gt_usecase "github.com/iakrevetkho/components-tests/cott/grpc_tester/usecase"
+	history_repository "github.com/iakrevetkho/components-tests/cott/history/repository"
+	ht_usecase "github.com/iakrevetkho/components-tests/cott/http_tester/usecase"
+	it_usecase "github.com/iakrevetkho/components-tests/cott/idp_tester/usecase"
+	importer_usecase "github.com/iakrevetkho/components-tests/cott/importer/usecase"
+	kv_usecase "github.com/iakrevetkho/components-tests/cott/key_value_tester/usecase"
+	plt_usecase "github.com/iakrevetkho/components-tests/cott/plugin_tester/usecase"
+	pt_usecase "github.com/iakrevetkho/components-tests/cott/proxy_tester/usecase"
+	se_usecase "github.com/iakrevetkho/components-tests/cott/search_tester/usecase"
+	st_usecase "github.com/iakrevetkho/components-tests/cott/secrets_tester/usecase"
 	tester_usecase "github.com/iakrevetkho/components-tests/cott/tester/usecase"
+	ts_usecase "github.com/iakrevetkho/components-tests/cott/time_series_tester/usecase"
+	tt_usecase "github.com/iakrevetkho/components-tests/cott/tsdb_tester/usecase"
+	vt_usecase "github.com/iakrevetkho/components-tests/cott/vector_tester/usecase"
+	wt_usecase "github.com/iakrevetkho/components-tests/cott/workflow_tester/usecase"
 
 	"github.com/jinzhu/configor"
 	"github.com/sirupsen/logrus"
 )
 
-var cfg domain.Config
+const (
+	COMMAND_RUN     = "run"
+	COMMAND_CLEANUP = "cleanup"
+	COMMAND_SWEEP   = "sweep"
+	COMMAND_BISECT  = "bisect"
+	COMMAND_MERGE   = "merge"
+)
+
+var (
+	cfg domain.Config
+	// Command line arguments without options
+	args []string
+)
 
 func init() {
 	if err := configor.Load(&cfg, "config.yaml"); err != nil {
 		logrus.WithError(err).Fatal("Can't parse conf")
 	}
 
+	var err error
+	if args, err = parseOptions(os.Args[1:]); err != nil {
+		logrus.WithError(err).Fatal("Couldn't parse options")
+	}
+
 	if err := helpers.InitLogger(&cfg); err != nil {
 		logrus.WithError(err).Fatal("Couldn't init logger")
 	}
 
+	// Steps are imported before expansions to be copied into the expanded test cases
+	if err := importSqlSteps(); err != nil {
+		logrus.WithError(err).Fatal("Couldn't import SQL steps")
+	}
+
+	cfg.ExpandDrivers()
+	cfg.ExpandStorageEngines()
+
+	for _, path := range cfg.RepositoryPlugins {
+		if err := dt_repository.LoadRepositoryPlugin(path); err != nil {
+			logrus.WithError(err).WithField("path", path).Fatal("Couldn't load repository plugin")
+		}
+	}
+
+	if err := cfg.ResolveHourlyCosts(); err != nil {
+		logrus.WithError(err).Fatal("Couldn't resolve test cases costs")
+	}
+
+	if err := cfg.ValidateAssertions(); err != nil {
+		logrus.WithError(err).Fatal("Couldn't parse test cases assertions")
+	}
+
+	if err := cfg.ValidateBudgets(); err != nil {
+		logrus.WithError(err).Fatal("Couldn't parse test cases budgets")
+	}
+
 	if cfgJson, err := json.Marshal(cfg); err != nil {
 		logrus.WithError(err).Fatal("Couldn't serialize config to JSON")
 	} else {
@@ -41,15 +110,301 @@ func main() {
 	}
 
 	dtuc := dt_usecase.NewDatabaseTesterUsecase(cluc)
+	htuc := ht_usecase.NewHttpTesterUsecase(cluc)
+	gtuc := gt_usecase.NewGrpcTesterUsecase(cluc)
+	ptuc := pt_usecase.NewProxyTesterUsecase(cluc)
+	ftuc := ft_usecase.NewFileTransferTesterUsecase(cluc)
+	fsuc := fs_usecase.NewFilesystemTesterUsecase(cluc)
+	ttuc := tt_usecase.NewTsdbTesterUsecase(cluc)
+	vtuc := vt_usecase.NewVectorTesterUsecase(cluc)
+	ituc := it_usecase.NewIdpTesterUsecase(cluc)
+	stuc := st_usecase.NewSecretsTesterUsecase(cluc)
+	wtuc := wt_usecase.NewWorkflowTesterUsecase(cluc)
+	kvuc := kv_usecase.NewKeyValueTesterUsecase(cluc)
+	tsuc := ts_usecase.NewTimeSeriesTesterUsecase(cluc)
+	seuc := se_usecase.NewSearchTesterUsecase(cluc)
+	dcuc := dc_usecase.NewDocumentTesterUsecase(cluc)
+	gruc := gr_usecase.NewGraphTesterUsecase(cluc)
+	bruc := br_usecase.NewBrokerTesterUsecase(cluc)
+
+	componentTesters := map[domain.ComponentType]tester_usecase.ComponentTesterUsecase{
+		domain.ComponentType_Postgres:      dtuc,
+		domain.ComponentType_MySQL:         dtuc,
+		domain.ComponentType_MariaDB:       dtuc,
+		domain.ComponentType_ClickHouse:    dtuc,
+		domain.ComponentType_Mongo:         dtuc,
+		domain.ComponentType_Cassandra:     dtuc,
+		domain.ComponentType_ScyllaDB:      dtuc,
+		domain.ComponentType_CockroachDB:   dtuc,
+		domain.ComponentType_SQLite:        dtuc,
+		domain.ComponentType_MSSQL:         dtuc,
+		domain.ComponentType_Oracle:        dtuc,
+		domain.ComponentType_TimescaleDB:   dtuc,
+		domain.ComponentType_YugabyteDB:    dtuc,
+		domain.ComponentType_CrateDB:       dtuc,
+		domain.ComponentType_Http:          htuc,
+		domain.ComponentType_Grpc:          gtuc,
+		domain.ComponentType_Proxy:         ptuc,
+		domain.ComponentType_Sftp:          ftuc,
+		domain.ComponentType_Ftp:           ftuc,
+		domain.ComponentType_Nfs:           fsuc,
+		domain.ComponentType_Smb:           fsuc,
+		domain.ComponentType_Tsdb:          ttuc,
+		domain.ComponentType_Qdrant:        vtuc,
+		domain.ComponentType_Keycloak:      ituc,
+		domain.ComponentType_Vault:         stuc,
+		domain.ComponentType_Temporal:      wtuc,
+		domain.ComponentType_Redis:         kvuc,
+		domain.ComponentType_Etcd:          kvuc,
+		domain.ComponentType_Aerospike:     kvuc,
+		domain.ComponentType_InfluxDB:      tsuc,
+		domain.ComponentType_QuestDB:       tsuc,
+		domain.ComponentType_Elasticsearch: seuc,
+		domain.ComponentType_OpenSearch:    seuc,
+		domain.ComponentType_Couchbase:     dcuc,
+		domain.ComponentType_Neo4j:         gruc,
+		domain.ComponentType_Kafka:         bruc,
+		domain.ComponentType_Redpanda:      bruc,
+		domain.ComponentType_RabbitMQ:      bruc,
+		domain.ComponentType_Nats:          bruc,
+	}
+	// Extensions and plugins are registered before main
+	for _, ct := range dt_repository.RegisteredComponentTypes() {
+		componentTesters[ct] = dtuc
+	}
+	for _, pc := range cfg.Plugins {
+		componentTesters[pc.ComponentType] = plt_usecase.NewPluginTesterUsecase(cluc, pc.Path, pc.Args)
+	}
+
+	modeTesters := map[domain.TesterMode]tester_usecase.ComponentTesterUsecase{
+		domain.TesterMode_Broker: bruc,
+	}
+
+	tuc := tester_usecase.NewTesterUsecase(cluc, componentTesters, modeTesters)
+
+	command := COMMAND_RUN
+	if len(args) > 0 {
+		command = args[0]
+	}
 
-	tuc := tester_usecase.NewTesterUsecase(cluc, dtuc)
+	switch command {
+	case COMMAND_RUN:
+		runCases(tuc)
+	case COMMAND_CLEANUP:
+		cleanUp(tuc)
+	case COMMAND_SWEEP:
+		sweep(tuc, args[1:])
+	case COMMAND_BISECT:
+		bisect(tuc, args[1:])
+	case COMMAND_MERGE:
+		merge(args[1:])
+	default:
+		logrus.WithField("command", command).Fatal(domain.UNKNOWN_COMMAND)
+	}
+}
+
+// Convert pgbench scripts and JMeter plans of the test cases into their SQL steps
+func importSqlSteps() error {
+	iuc := importer_usecase.NewImporterUsecase()
+
+	for i := range cfg.TestCases {
+		tc := &cfg.TestCases[i]
+
+		for _, path := range tc.PgbenchScripts {
+			step, err := iuc.ImportPgbenchScript(path, tc.GetConcurrency(), tc.GetPgbenchTransactions())
+			if err != nil {
+				logrus.WithField("path", path).Error("couldn't import pgbench script")
+				return err
+			}
+			tc.SqlSteps = append(tc.SqlSteps, *step)
+		}
+
+		if tc.JmeterPlan != "" {
+			steps, err := iuc.ImportJmeterPlan(tc.JmeterPlan)
+			if err != nil {
+				logrus.WithField("path", tc.JmeterPlan).Error("couldn't import JMeter plan")
+				return err
+			}
+			tc.SqlSteps = append(tc.SqlSteps, steps...)
+		}
+	}
 
+	return nil
+}
+
+func runCases(tuc tester_usecase.TesterUsecase) {
 	report, err := tuc.RunCases(cfg.TestCases)
 	if err != nil {
 		logrus.WithError(err).Error("test case error")
 	}
 	logrus.WithField("report", report).Info("test cases done")
 
+	if report != nil {
+		report.Host = reportHost()
+	}
+
+	if report != nil && cfg.History.DirPath != "" {
+		analyzeTrend(report)
+	}
+
+	if report != nil {
+		report.GradeTestCases()
+
+		// Summary is for humans only, it would break JSON output
+		if !cfg.Log.Quiet && cfg.Report.Output == "" {
+			if err := helpers.WriteSummary(os.Stdout, report); err != nil {
+				logrus.WithError(err).Error("couldn't write summary")
+			}
+		}
+
+		writeReport(report)
+	}
+
+	if report != nil && report.Severity.Level != domain.Severity_Ok {
+		logrus.WithFields(logrus.Fields{"warnings": report.Severity.Warnings, "criticals": report.Severity.Criticals}).Warn("metric budgets are breached")
+	}
+
+	if report != nil && len(report.AssertionViolations) > 0 {
+		logrus.WithField("violations", report.AssertionViolations).Fatal(domain.ASSERTIONS_VIOLATED)
+	}
+}
+
+// Flag metrics deviating from the past runs and save report into the history
+func analyzeTrend(report *domain.Report) {
+	hr := history_repository.NewFileHistoryRepository(cfg.History.DirPath)
+
+	if history, err := hr.LoadLast(cfg.History.Window); err != nil {
+		logrus.WithError(err).Warn("couldn't load history")
+	} else {
+		report.FlagAnomalies(history, cfg.History.Sigmas)
+		logrus.WithFields(logrus.Fields{"runs": len(history), "anomalies": len(report.Anomalies)}).Info("trend analysis done")
+	}
+
+	if err := hr.Save(report); err != nil {
+		logrus.WithError(err).Warn("couldn't save report into history")
+	}
+}
+
+// Compare test cases of the component across image tags: cott sweep <component type> <tag>...
+func sweep(tuc tester_usecase.TesterUsecase, args []string) {
+	if len(args) < 2 {
+		logrus.Fatal("usage: cott sweep <component type> <tag> [<tag>...]")
+	}
+
+	report, err := tuc.Sweep(cfg.TestCases, domain.ComponentType(args[0]), args[1:])
+	if err != nil {
+		logrus.WithError(err).Fatal("couldn't sweep")
+	}
+	logrus.WithField("winners", len(report.Winners)).Info("sweep done")
+
+	writeReport(report)
+}
+
+// Find the first image tag where the step metric regressed:
+// cott bisect <component type> <step> <metric> <threshold percent> <tag>...
+func bisect(tuc tester_usecase.TesterUsecase, args []string) {
+	if len(args) < 6 {
+		logrus.Fatal("usage: cott bisect <component type> <step> <metric> <threshold percent> <good tag> [<tag>...] <bad tag>")
+	}
+
+	threshold, err := strconv.ParseFloat(args[3], 64)
+	if err != nil {
+		logrus.WithError(err).Fatal("couldn't parse threshold")
+	}
+
+	report, err := tuc.Bisect(cfg.TestCases, domain.ComponentType(args[0]), args[1], args[2], threshold, args[4:])
+	if err != nil {
+		logrus.WithError(err).Fatal("couldn't bisect")
+	}
+	if report.FirstRegressedTag == "" {
+		logrus.Info("bisect done, no regression found")
+	} else {
+		logrus.WithField("tag", report.FirstRegressedTag).Info("bisect done, found first regressed tag")
+	}
+
+	writeReport(report)
+}
+
+// Combine reports of several machines into one: cott merge <report file> [<host>=]<report file>...
+// Host label of the file is taken from the argument, the report or the file name.
+func merge(args []string) {
+	if len(args) < 2 {
+		logrus.Fatal("usage: cott merge [<host>=]<report file> [<host>=]<report file>...")
+	}
+
+	merged := domain.NewMergedReport()
+	for _, arg := range args {
+		host, path := "", arg
+		if i := strings.Index(arg, "="); i >= 0 {
+			host, path = arg[:i], arg[i+1:]
+		}
+
+		reportBytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			logrus.WithError(err).WithField("file", path).Fatal("couldn't read report")
+		}
+		report := new(domain.Report)
+		if err := json.Unmarshal(reportBytes, report); err != nil {
+			logrus.WithError(err).WithField("file", path).Fatal("couldn't parse report")
+		}
+
+		if host == "" {
+			host = report.Host
+		}
+		if host == "" {
+			host = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+
+		if err := merged.AddReport(host, report); err != nil {
+			logrus.WithError(err).WithField("file", path).Fatal("couldn't merge report")
+		}
+	}
+	logrus.WithFields(logrus.Fields{"hosts": merged.Hosts, "testCases": len(merged.TestCases)}).Info("merge done")
+
+	writeReport(merged)
+}
+
+func reportHost() string {
+	if cfg.Report.Host != "" {
+		return cfg.Report.Host
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		logrus.WithError(err).Warn("couldn't get hostname")
+	}
+	return host
+}
+
+// Options could be anywhere in the arguments: --quiet writes logs into the file only, --output json prints report to stdout and logs to stderr
+func parseOptions(rawArgs []string) ([]string, error) {
+	var positional []string
+	for i := 0; i < len(rawArgs); i++ {
+		switch arg := rawArgs[i]; {
+		case arg == "--quiet" || arg == "-q":
+			cfg.Log.Quiet = true
+		case arg == "--output":
+			if i+1 == len(rawArgs) {
+				return nil, domain.UNKNOWN_OUTPUT_FORMAT
+			}
+			i++
+			cfg.Report.Output = domain.OutputFormat(rawArgs[i])
+		case strings.HasPrefix(arg, "--output="):
+			cfg.Report.Output = domain.OutputFormat(strings.TrimPrefix(arg, "--output="))
+		default:
+			positional = append(positional, arg)
+		}
+	}
+
+	switch cfg.Report.Output {
+	case "", domain.OutputFormat_Json:
+		return positional, nil
+	default:
+		return nil, domain.UNKNOWN_OUTPUT_FORMAT
+	}
+}
+
+func writeReport(report interface{}) {
 	reportBytes, err := json.Marshal(report)
 	if err != nil {
 		logrus.WithError(err).Fatal("couldn't serialise report")
@@ -58,4 +413,19 @@ func main() {
 	if err := ioutil.WriteFile(cfg.Report.FilePath, reportBytes, 0644); err != nil {
 		logrus.WithError(err).Fatal("couldn't write report")
 	}
+
+	if cfg.Report.Output == domain.OutputFormat_Json {
+		if _, err := os.Stdout.Write(append(reportBytes, '\n')); err != nil {
+			logrus.WithError(err).Fatal("couldn't print report")
+		}
+	}
+}
+
+// Remove orphans left by crashed runs
+func cleanUp(tuc tester_usecase.TesterUsecase) {
+	removed, err := tuc.CleanUp(time.Duration(cfg.CleanUp.MinAgeInMinutes) * time.Minute)
+	if err != nil {
+		logrus.WithError(err).Fatal("couldn't clean up")
+	}
+	logrus.WithField("count", len(removed)).Info("clean up done")
 }
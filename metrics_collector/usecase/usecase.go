@@ -32,7 +32,12 @@ func NewMetricsCollectorUsecase(tcra *domain.TestCaseResultsAccumulator, cluc co
 
 // TODO Refactor float64 onto interface{}
 func (mcuc *metricsCollectorUsecase) CollectStepMetrics(step *domain.TestCaseStep) error {
-	tcsra := domain.NewTestCaseStepResultsAccumulator(step)
+	tcsra := mcuc.tcra.GetTestCaseStepResultsAccumulator(step)
+
+	// Embedded component shares the tester process, so its resources usage can't be measured separately
+	if mcuc.containerId == "" {
+		return mcuc.collectEmbeddedStepMetrics(step, tcsra)
+	}
 
 	stats, err := mcuc.cluc.GetContainerStats(mcuc.containerId)
 	if err != nil {
@@ -89,5 +94,34 @@ func (mcuc *metricsCollectorUsecase) CollectStepMetrics(step *domain.TestCaseSte
 	tcsra.AddMetric(domain.MetricMeta_NetworkReceiveUsage, float64(stats.Networks[DEFAULT_NETWORK].RxBytes)-float64(startNetworkRxUsage))
 	tcsra.AddMetric(domain.MetricMeta_NetworkSendUsage, float64(stats.Networks[DEFAULT_NETWORK].TxBytes)-float64(startNetworkTxUsage))
 
+	// Storage size is needed for the cost efficiency only, because du could take a while
+	if mcuc.tcra.TestCase.HourlyCost > 0 {
+		if size, err := mcuc.cluc.GetContainerStorageSize(mcuc.containerId); err != nil {
+			logrus.WithError(err).WithField("step", step).Warn("couldn't get container storage size")
+		} else {
+			tcsra.AddMetric(domain.MetricMeta_StorageSize, float64(size))
+		}
+	}
+
+	if step.MetricsFunc != nil {
+		step.MetricsFunc(tcsra)
+	}
+
+	return nil
+}
+
+func (mcuc *metricsCollectorUsecase) collectEmbeddedStepMetrics(step *domain.TestCaseStep, tcsra *domain.TestCaseStepResultsAccumulator) error {
+	startTime := time.Now()
+	if err := step.StepFunc(); err != nil {
+		logrus.WithError(err).WithField("step", step).Warn("error on step execution")
+		tcsra.AddError(err.Error())
+		return err
+	}
+	tcsra.AddMetric(domain.MetricMeta_Duration, float64(time.Since(startTime).Microseconds()))
+
+	if step.MetricsFunc != nil {
+		step.MetricsFunc(tcsra)
+	}
+
 	return nil
 }
@@ -0,0 +1,33 @@
+package usecase
+
+import "github.com/iakrevetkho/components-tests/cott/domain"
+
+// Plugin receives the single request line on stdin and writes event lines on stdout till it exits
+type pluginRequest struct {
+	TestCase    *domain.TestCase `json:"test-case"`
+	ContainerId string           `json:"container-id"`
+}
+
+type pluginEventType string
+
+const (
+	// Step measurement starts, every step has to be ended before the next one starts
+	pluginEventType_StepStart = "stepStart"
+	// Step measurement ends, error is set if the step failed
+	pluginEventType_StepEnd = "stepEnd"
+	// Metric of the current step measured by the plugin
+	pluginEventType_Metric = "metric"
+	// Resource created by the plugin which wasn't removed
+	pluginEventType_Leftover = "leftover"
+	pluginEventType_Log      = "log"
+)
+
+type pluginEvent struct {
+	Type     pluginEventType    `json:"type"`
+	Step     string             `json:"step,omitempty"`
+	Metric   *domain.MetricMeta `json:"metric,omitempty"`
+	Value    float64            `json:"value,omitempty"`
+	Error    string             `json:"error,omitempty"`
+	Leftover string             `json:"leftover,omitempty"`
+	Message  string             `json:"message,omitempty"`
+}
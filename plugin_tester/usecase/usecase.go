@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os/exec"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// Max length of the single event line
+const MAX_EVENT_SIZE = 1 << 20
+
+type PluginTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type pluginTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+	path string
+	args []string
+}
+
+// NewPluginTesterUsecase creates tester running external executable which talks JSON lines over stdio
+func NewPluginTesterUsecase(cluc container_launcher.ContainerLauncherUsecase, path string, args []string) PluginTesterUsecase {
+	ptuc := new(pluginTesterUsecase)
+	ptuc.cluc = cluc
+	ptuc.path = path
+	ptuc.args = args
+	return ptuc
+}
+
+func (ptuc *pluginTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	log := logrus.WithField("plugin", ptuc.path)
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, ptuc.cluc, containerId)
+
+	cmd := exec.Command(ptuc.path, ptuc.args...)
+	stderr := log.WriterLevel(logrus.WarnLevel)
+	defer stderr.Close()
+	cmd.Stderr = stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		log.WithError(err).Warn("couldn't start plugin")
+		return nil
+	}
+
+	if err := json.NewEncoder(stdin).Encode(&pluginRequest{TestCase: tcra.TestCase, ContainerId: containerId}); err != nil {
+		log.WithError(err).Warn("couldn't send test case to plugin")
+	}
+	stdin.Close()
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), MAX_EVENT_SIZE)
+	if err := ptuc.handleEvents(tcra, mcuc, scanner); err != nil {
+		log.WithError(err).Warn("couldn't handle plugin events")
+		cmd.Process.Kill()
+	}
+
+	if err := cmd.Wait(); err != nil {
+		log.WithError(err).Warn("plugin failed")
+	}
+
+	return nil
+}
+
+// Method reads events till the plugin closes stdout
+func (ptuc *pluginTesterUsecase) handleEvents(tcra *domain.TestCaseResultsAccumulator, mcuc metrics_collector.MetricsCollectorUsecase, scanner *bufio.Scanner) error {
+	for scanner.Scan() {
+		e, err := ptuc.parseEvent(scanner.Bytes())
+		if err != nil {
+			return err
+		}
+
+		switch e.Type {
+		case pluginEventType_StepStart:
+			if err := ptuc.collectStep(mcuc, scanner, e.Step); err != nil {
+				return err
+			}
+		case pluginEventType_Leftover:
+			tcra.AddLeftover(e.Leftover)
+		case pluginEventType_Log:
+			logrus.WithField("plugin", ptuc.path).Info(e.Message)
+		default:
+			return domain.UNEXPECTED_PLUGIN_EVENT
+		}
+	}
+	return scanner.Err()
+}
+
+// Method measures step till its end event and adds metrics reported by the plugin
+func (ptuc *pluginTesterUsecase) collectStep(mcuc metrics_collector.MetricsCollectorUsecase, scanner *bufio.Scanner, name string) error {
+	var (
+		metrics   []*pluginEvent
+		isEnded   bool
+		streamErr error
+	)
+
+	awaitEnd := func() error {
+		for scanner.Scan() {
+			e, err := ptuc.parseEvent(scanner.Bytes())
+			if err != nil {
+				streamErr = err
+				return err
+			}
+
+			switch {
+			case e.Type == pluginEventType_Metric && e.Metric != nil:
+				metrics = append(metrics, e)
+			case e.Type == pluginEventType_Log:
+				logrus.WithFields(logrus.Fields{"plugin": ptuc.path, "step": name}).Info(e.Message)
+			case e.Type == pluginEventType_StepEnd && e.Step == name:
+				isEnded = true
+				if e.Error != "" {
+					return errors.New(e.Error)
+				}
+				return nil
+			default:
+				streamErr = domain.UNEXPECTED_PLUGIN_EVENT
+				return streamErr
+			}
+		}
+
+		if streamErr = scanner.Err(); streamErr == nil {
+			streamErr = domain.UNEXPECTED_PLUGIN_EVENT
+		}
+		return streamErr
+	}
+
+	step := &domain.TestCaseStep{Name: name, StepFunc: awaitEnd, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		for _, e := range metrics {
+			tcsra.AddMetric(e.Metric, e.Value)
+		}
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{"plugin": ptuc.path, "step": name}).Debug("plugin step failed")
+	}
+
+	// Step isn't run if container stats weren't collected, but the plugin still runs it
+	if !isEnded && streamErr == nil {
+		awaitEnd()
+	}
+	return streamErr
+}
+
+func (ptuc *pluginTesterUsecase) parseEvent(line []byte) (*pluginEvent, error) {
+	e := new(pluginEvent)
+	if err := json.Unmarshal(line, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Method starts HTTP backend on the host which responds with the request body
+func (ptuc *proxyTesterUsecase) startEchoBackend(port uint16) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.Copy(w, r.Body); err != nil {
+			logrus.WithError(err).Trace("couldn't echo request body")
+		}
+	})
+
+	server := &http.Server{Addr: ":" + strconv.FormatUint(uint64(port), 10), Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logrus.WithError(err).Error("echo backend failed")
+		}
+	}()
+	logrus.WithField("port", port).Debug("echo backend started")
+
+	return server
+}
+
+func (ptuc *proxyTesterUsecase) stopEchoBackend(server *http.Server) {
+	if err := server.Shutdown(context.Background()); err != nil {
+		logrus.WithError(err).Warn("couldn't stop echo backend")
+	}
+	logrus.Debug("echo backend stopped")
+}
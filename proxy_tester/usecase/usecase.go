@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	REQUEST_TIMEOUT = 10 * time.Second
+	// Requests count for every concurrency level
+	REQUESTS_COUNT = 10000
+	REQUEST_BODY   = "cott echo request"
+)
+
+var concurrencyLevels = []int{1, 8, 32, 128}
+
+type ProxyTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type proxyTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewProxyTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) ProxyTesterUsecase {
+	ptuc := new(proxyTesterUsecase)
+	ptuc.cluc = cluc
+	return ptuc
+}
+
+// Proxy must be configured to forward requests to the echo backend at host.docker.internal:<echo port>
+func (ptuc *proxyTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	tc := tcra.TestCase
+	proxyUrl := "http://localhost:" + strconv.FormatUint(uint64(tc.Port), 10) + "/"
+	backendUrl := "http://localhost:" + strconv.FormatUint(uint64(tc.GetEchoPort()), 10) + "/"
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, ptuc.cluc, containerId)
+	client := &http.Client{Timeout: REQUEST_TIMEOUT, Transport: &http.Transport{MaxIdleConnsPerHost: concurrencyLevels[len(concurrencyLevels)-1]}}
+
+	server := ptuc.startEchoBackend(tc.GetEchoPort())
+	defer ptuc.stopEchoBackend(server)
+
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error { return ptuc.awaitProxyReady(client, proxyUrl, tc.GetStartupTimeout()) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	var maxThroughput float64
+	for i, concurrency := range concurrencyLevels {
+		var direct, proxied *helpers.LoadResult
+		testPrefix := strconv.FormatInt(int64(concurrency), 10) + "x"
+
+		// Baseline without proxy under the same concurrency
+		direct = helpers.RunLoad(REQUESTS_COUNT, concurrency, func() error { return ptuc.sendRequest(client, backendUrl) })
+
+		isLast := i == len(concurrencyLevels)-1
+		step := &domain.TestCaseStep{Name: testPrefix + "ConcurrencyProxied", StepFunc: func() error {
+			proxied = helpers.RunLoad(REQUESTS_COUNT, concurrency, func() error { return ptuc.sendRequest(client, proxyUrl) })
+			if len(proxied.Latencies) == 0 {
+				return domain.NO_SUCCESSFUL_REQUESTS
+			}
+			return nil
+		}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+			tcsra.AddLatencyMetrics(proxied.Latencies)
+			tcsra.AddMetric(domain.MetricMeta_AddedLatencyP50, proxied.Quantile(0.5)-direct.Quantile(0.5))
+			tcsra.AddMetric(domain.MetricMeta_AddedLatencyP99, proxied.Quantile(0.99)-direct.Quantile(0.99))
+			tcsra.AddMetric(domain.MetricMeta_Throughput, proxied.Throughput())
+			tcsra.AddMetric(domain.MetricMeta_Errors, float64(proxied.Errors))
+
+			if proxied.Throughput() > maxThroughput {
+				maxThroughput = proxied.Throughput()
+			}
+			// Max throughput over all concurrency levels is reported with the last one
+			if isLast {
+				tcsra.AddMetric(domain.MetricMeta_MaxThroughput, maxThroughput)
+			}
+		}}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).WithField("concurrency", concurrency).Warn("proxied load failed")
+		}
+	}
+
+	return nil
+}
+
+// Method polls proxy until it responds with 2xx from the echo backend
+func (ptuc *proxyTesterUsecase) awaitProxyReady(client *http.Client, url string, timeout time.Duration) error {
+	return helpers.AwaitConnection(func() error { return ptuc.sendRequest(client, url) }, timeout)
+}
+
+func (ptuc *proxyTesterUsecase) sendRequest(client *http.Client, url string) error {
+	resp, err := client.Post(url, "text/plain", strings.NewReader(REQUEST_BODY))
+	if err != nil {
+		return err
+	}
+	// Body must be read till EOF to reuse keep alive connection
+	if _, err := io.Copy(ioutil.Discard, resp.Body); err != nil {
+		logrus.WithError(err).Trace("couldn't drain response body")
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	return nil
+}
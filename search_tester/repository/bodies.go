@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// Bodies of the requests are the same for Elasticsearch and OpenSearch, because OpenSearch is fork of Elasticsearch 7.10
+
+var indexMapping = map[string]interface{}{
+	"mappings": map[string]interface{}{
+		"properties": map[string]interface{}{
+			"id":         map[string]interface{}{"type": "long"},
+			"title":      map[string]interface{}{"type": "text"},
+			"value":      map[string]interface{}{"type": "long"},
+			"created_at": map[string]interface{}{"type": "date"},
+		},
+	},
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+}
+
+func createJsonBody(v interface{}) (io.Reader, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(b), nil
+}
+
+// Bulk body is NDJSON of the action and document lines
+func createBulkBody(documents []Document) (io.Reader, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, d := range documents {
+		buf.WriteString(`{"index":{"_id":"`)
+		buf.WriteString(strconv.Itoa(d.Id))
+		buf.WriteString("\"}}\n")
+		if err := enc.Encode(d); err != nil {
+			return nil, err
+		}
+	}
+	return &buf, nil
+}
+
+func createMatchQueryBody(text string) (io.Reader, error) {
+	return createJsonBody(map[string]interface{}{
+		"query": map[string]interface{}{
+			"match": map[string]interface{}{"title": text},
+		},
+	})
+}
+
+func createRangeQueryBody(from int64, to int64) (io.Reader, error) {
+	return createJsonBody(map[string]interface{}{
+		"query": map[string]interface{}{
+			"range": map[string]interface{}{
+				"value": map[string]interface{}{"gte": from, "lte": to},
+			},
+		},
+	})
+}
+
+// Method closes response body and decodes it into result if it's not nil. Otherwise body is drained.
+func readResponse(isError bool, body io.ReadCloser, result interface{}) error {
+	defer body.Close()
+
+	if isError {
+		respBody, _ := ioutil.ReadAll(body)
+		logrus.WithField("body", string(respBody)).Debug("search engine request failed")
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	if result == nil {
+		_, err := io.Copy(ioutil.Discard, body)
+		return err
+	}
+
+	return json.NewDecoder(body).Decode(result)
+}
+
+// Bulk request succeeds even if some documents were rejected, so errors flag of the response is checked
+func readBulkResponse(isError bool, body io.ReadCloser) error {
+	var resp bulkResponse
+	if err := readResponse(isError, body, &resp); err != nil {
+		return err
+	}
+	if resp.Errors {
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+	return nil
+}
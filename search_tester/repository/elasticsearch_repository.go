@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+)
+
+const REQUEST_TIMEOUT = 60 * time.Second
+
+type elasticsearchSearchTesterRepository struct {
+	client *elasticsearch.Client
+}
+
+// NewElasticsearchSearchTesterRepository creates repository using go-elasticsearch. User is empty if security is disabled.
+func NewElasticsearchSearchTesterRepository(port uint16, host, user, password string) (SearchTesterRepository, error) {
+	client, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: []string{"http://" + host + ":" + strconv.FormatUint(uint64(port), 10)},
+		Username:  user,
+		Password:  password,
+		Transport: &http.Transport{ResponseHeaderTimeout: REQUEST_TIMEOUT},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(elasticsearchSearchTesterRepository)
+	r.client = client
+	return r, nil
+}
+
+func (r *elasticsearchSearchTesterRepository) Ping() error {
+	return r.readResponse(r.client.Info())
+}
+
+func (r *elasticsearchSearchTesterRepository) CreateIndex(name string) error {
+	body, err := createJsonBody(indexMapping)
+	if err != nil {
+		return err
+	}
+
+	return r.readResponse(r.client.Indices.Create(name, r.client.Indices.Create.WithBody(body)))
+}
+
+func (r *elasticsearchSearchTesterRepository) BulkIndex(name string, documents []Document) error {
+	body, err := createBulkBody(documents)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Bulk(body, r.client.Bulk.WithIndex(name))
+	if err != nil {
+		return err
+	}
+	return readBulkResponse(res.IsError(), res.Body)
+}
+
+func (r *elasticsearchSearchTesterRepository) Refresh(name string) error {
+	return r.readResponse(r.client.Indices.Refresh(r.client.Indices.Refresh.WithIndex(name)))
+}
+
+func (r *elasticsearchSearchTesterRepository) SearchMatch(name string, text string) error {
+	body, err := createMatchQueryBody(text)
+	if err != nil {
+		return err
+	}
+
+	return r.readResponse(r.client.Search(r.client.Search.WithIndex(name), r.client.Search.WithBody(body)))
+}
+
+func (r *elasticsearchSearchTesterRepository) SearchRange(name string, from int64, to int64) error {
+	body, err := createRangeQueryBody(from, to)
+	if err != nil {
+		return err
+	}
+
+	return r.readResponse(r.client.Search(r.client.Search.WithIndex(name), r.client.Search.WithBody(body)))
+}
+
+func (r *elasticsearchSearchTesterRepository) DeleteIndex(name string) error {
+	return r.readResponse(r.client.Indices.Delete([]string{name}))
+}
+
+func (r *elasticsearchSearchTesterRepository) readResponse(res *esapi.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	return readResponse(res.IsError(), res.Body, nil)
+}
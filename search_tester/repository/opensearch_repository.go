@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"crypto/tls"
+	"net/http"
+	"strconv"
+
+	"github.com/opensearch-project/opensearch-go"
+	"github.com/opensearch-project/opensearch-go/opensearchapi"
+)
+
+type openSearchSearchTesterRepository struct {
+	client *opensearch.Client
+}
+
+// NewOpenSearchSearchTesterRepository creates repository using opensearch-go.
+// Security plugin serves HTTPS by the self-signed demo certificate, so certificate isn't verified. User is empty if plugin is disabled.
+func NewOpenSearchSearchTesterRepository(port uint16, host, user, password string, secure bool) (SearchTesterRepository, error) {
+	scheme := "http://"
+	if secure {
+		scheme = "https://"
+	}
+
+	client, err := opensearch.NewClient(opensearch.Config{
+		Addresses: []string{scheme + host + ":" + strconv.FormatUint(uint64(port), 10)},
+		Username:  user,
+		Password:  password,
+		Transport: &http.Transport{
+			ResponseHeaderTimeout: REQUEST_TIMEOUT,
+			TLSClientConfig:       &tls.Config{InsecureSkipVerify: true},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(openSearchSearchTesterRepository)
+	r.client = client
+	return r, nil
+}
+
+func (r *openSearchSearchTesterRepository) Ping() error {
+	return r.readResponse(r.client.Info())
+}
+
+func (r *openSearchSearchTesterRepository) CreateIndex(name string) error {
+	body, err := createJsonBody(indexMapping)
+	if err != nil {
+		return err
+	}
+
+	return r.readResponse(r.client.Indices.Create(name, r.client.Indices.Create.WithBody(body)))
+}
+
+func (r *openSearchSearchTesterRepository) BulkIndex(name string, documents []Document) error {
+	body, err := createBulkBody(documents)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Bulk(body, r.client.Bulk.WithIndex(name))
+	if err != nil {
+		return err
+	}
+	return readBulkResponse(res.IsError(), res.Body)
+}
+
+func (r *openSearchSearchTesterRepository) Refresh(name string) error {
+	return r.readResponse(r.client.Indices.Refresh(r.client.Indices.Refresh.WithIndex(name)))
+}
+
+func (r *openSearchSearchTesterRepository) SearchMatch(name string, text string) error {
+	body, err := createMatchQueryBody(text)
+	if err != nil {
+		return err
+	}
+
+	return r.readResponse(r.client.Search(r.client.Search.WithIndex(name), r.client.Search.WithBody(body)))
+}
+
+func (r *openSearchSearchTesterRepository) SearchRange(name string, from int64, to int64) error {
+	body, err := createRangeQueryBody(from, to)
+	if err != nil {
+		return err
+	}
+
+	return r.readResponse(r.client.Search(r.client.Search.WithIndex(name), r.client.Search.WithBody(body)))
+}
+
+func (r *openSearchSearchTesterRepository) DeleteIndex(name string) error {
+	return r.readResponse(r.client.Indices.Delete([]string{name}))
+}
+
+func (r *openSearchSearchTesterRepository) readResponse(res *opensearchapi.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	return readResponse(res.IsError(), res.Body, nil)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import "time"
+
+type Document struct {
+	Id        int       `json:"id"`
+	Title     string    `json:"title"`
+	Value     int64     `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SearchTesterRepository interface {
+	Ping() error
+	// CreateIndex creates index with the mapping of the document fields
+	CreateIndex(name string) error
+	// BulkIndex indexes documents by the single bulk request
+	BulkIndex(name string, documents []Document) error
+	// Refresh makes indexed documents visible for search
+	Refresh(name string) error
+	// SearchMatch runs full text match query of the title field
+	SearchMatch(name string, text string) error
+	// SearchRange runs range query of the value field in [from, to] range
+	SearchRange(name string, from int64, to int64) error
+	DeleteIndex(name string) error
+}
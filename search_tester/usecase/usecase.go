@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/iakrevetkho/components-tests/cott/search_tester/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	INDEX_NAME = "cott_index"
+	// Documents count of the single bulk request
+	BULK_BATCH_SIZE = 1000
+	QUERY_REQUESTS  = 200
+	TITLE_WORDS     = 5
+	// Range queries match 1% of the documents
+	RANGE_QUERY_WIDTH = 0.01
+)
+
+// Multipliers of the documents count of the test case
+var documentsCountMultipliers = []int{1, 10, 100}
+
+// Vocabulary of the titles, so match queries find documents of every word
+var titleVocabulary = []string{
+	"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliett",
+	"kilo", "lima", "mike", "november", "oscar", "papa", "quebec", "romeo", "sierra", "tango",
+	"uniform", "victor", "whiskey", "xray", "yankee", "zulu",
+}
+
+type SearchTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type searchTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewSearchTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) SearchTesterUsecase {
+	seuc := new(searchTesterUsecase)
+	seuc.cluc = cluc
+	return seuc
+}
+
+func (seuc *searchTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	r, err := seuc.createSearchRepository(tcra.TestCase)
+	if err != nil {
+		return err
+	}
+
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, seuc.cluc, containerId)
+
+	// Await for search engine ready
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return helpers.AwaitConnection(r.Ping, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	// Index could be left by the failed run
+	if err := r.DeleteIndex(INDEX_NAME); err != nil {
+		logrus.WithError(err).Debug("couldn't delete index")
+	}
+
+	for _, multiplier := range documentsCountMultipliers {
+		documentsCount := multiplier * int(tcra.TestCase.GetDocumentsCount())
+		if err := seuc.testIndex(mcuc, r, int(tcra.TestCase.GetConcurrency()), documentsCount); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (seuc *searchTesterUsecase) createSearchRepository(tc *domain.TestCase) (repository.SearchTesterRepository, error) {
+	switch tc.ComponentType {
+	case domain.ComponentType_Elasticsearch:
+		const ELASTIC_PASSWORD_ENV_VAR = "ELASTIC_PASSWORD"
+
+		// Image sets password of the built-in superuser if security is enabled
+		var user string
+		password, ok := tc.EnvVars[ELASTIC_PASSWORD_ENV_VAR]
+		if ok {
+			user = "elastic"
+		}
+
+		return repository.NewElasticsearchSearchTesterRepository(tc.Port, "localhost", user, password)
+	case domain.ComponentType_OpenSearch:
+		const (
+			DISABLE_SECURITY_PLUGIN_ENV_VAR = "DISABLE_SECURITY_PLUGIN"
+			ADMIN_PASSWORD_ENV_VAR          = "OPENSEARCH_INITIAL_ADMIN_PASSWORD"
+		)
+
+		if tc.EnvVars[DISABLE_SECURITY_PLUGIN_ENV_VAR] == "true" {
+			return repository.NewOpenSearchSearchTesterRepository(tc.Port, "localhost", "", "", false)
+		}
+
+		// Images before 2.12 have the demo admin user with the default password
+		password, ok := tc.EnvVars[ADMIN_PASSWORD_ENV_VAR]
+		if !ok {
+			password = "admin"
+		}
+
+		return repository.NewOpenSearchSearchTesterRepository(tc.Port, "localhost", "admin", password, true)
+	default:
+		return nil, domain.NewUnknownComponentError(tc.ComponentType)
+	}
+}
+
+// Method creates index, ingests documents by bulk requests, runs match and range queries and deletes the index.
+// Steps are named like the table steps of the database tester.
+func (seuc *searchTesterUsecase) testIndex(mcuc metrics_collector.MetricsCollectorUsecase, r repository.SearchTesterRepository, concurrency int, documentsCount int) error {
+	testPrefix := strconv.FormatInt(int64(documentsCount), 10) + "x"
+
+	step := &domain.TestCaseStep{Name: "createIndex", StepFunc: func() error { return r.CreateIndex(INDEX_NAME) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	documents := seuc.generateDocuments(documentsCount)
+
+	step = seuc.createBulkIndexStep(testPrefix+"BulkIndexEmptyIndex", r, documents, concurrency)
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		r.DeleteIndex(INDEX_NAME)
+		return err
+	}
+
+	step = helpers.NewQueryStep("matchQuery"+testPrefix+"Index", QUERY_REQUESTS, concurrency, func() error {
+		return r.SearchMatch(INDEX_NAME, titleVocabulary[rand.Intn(len(titleVocabulary))])
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("match query failed")
+	}
+
+	rangeWidth := int64(float64(documentsCount) * RANGE_QUERY_WIDTH)
+	step = helpers.NewQueryStep("rangeQuery"+testPrefix+"Index", QUERY_REQUESTS, concurrency, func() error {
+		from := rand.Int63n(int64(documentsCount))
+		return r.SearchRange(INDEX_NAME, from, from+rangeWidth)
+	})
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("range query failed")
+	}
+
+	step = &domain.TestCaseStep{Name: "delete" + testPrefix + "Index", StepFunc: func() error { return r.DeleteIndex(INDEX_NAME) }}
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Method generates documents which values are their ids, so range queries select the known share of documents
+func (seuc *searchTesterUsecase) generateDocuments(documentsCount int) []repository.Document {
+	createdAt := time.Now()
+	documents := make([]repository.Document, documentsCount)
+	for i := range documents {
+		words := make([]string, TITLE_WORDS)
+		for j := range words {
+			words[j] = titleVocabulary[rand.Intn(len(titleVocabulary))]
+		}
+		documents[i] = repository.Document{
+			Id:        i,
+			Title:     strings.Join(words, " "),
+			Value:     int64(i),
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Millisecond),
+		}
+	}
+	return documents
+}
+
+// Method creates step indexing documents by bulk requests concurrently. Index is refreshed by the step,
+// so the documents are searchable by the next steps and refresh time is included into the ingestion time.
+func (seuc *searchTesterUsecase) createBulkIndexStep(name string, r repository.SearchTesterRepository, documents []repository.Document, concurrency int) *domain.TestCaseStep {
+	var (
+		lr       *helpers.LoadResult
+		duration time.Duration
+	)
+	batches := (len(documents) + BULK_BATCH_SIZE - 1) / BULK_BATCH_SIZE
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		startTime := time.Now()
+		var nextBatch int64 = -1
+		lr = helpers.RunLoad(batches, concurrency, func() error {
+			from := int(atomic.AddInt64(&nextBatch, 1)) * BULK_BATCH_SIZE
+			to := from + BULK_BATCH_SIZE
+			if to > len(documents) {
+				to = len(documents)
+			}
+			return r.BulkIndex(INDEX_NAME, documents[from:to])
+		})
+		if lr.Errors != 0 {
+			return domain.UNEXPECTED_RESPONSE_STATUS
+		}
+		if err := r.Refresh(INDEX_NAME); err != nil {
+			return err
+		}
+		duration = time.Since(startTime)
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_Throughput, float64(len(documents))/duration.Seconds())
+		tcsra.AddLatencyMetrics(lr.Latencies)
+	}}
+}
@@ -0,0 +1,18 @@
+package repository
+
+type SecretsTesterRepository interface {
+	// Health returns initialization and seal state once server responds
+	Health() (initialized bool, sealed bool, err error)
+	// Init initializes server with single unseal key and returns it with the root token
+	Init() (unsealKey string, rootToken string, err error)
+	Unseal(unsealKey string) error
+	SetToken(token string)
+	// EnableEngine mounts secrets engine of the type at the path
+	EnableEngine(path, engineType string, options map[string]string) error
+	DisableEngine(path string) error
+	KvWrite(mount, key string, data map[string]string) error
+	KvRead(mount, key string) (map[string]string, error)
+	CreateTransitKey(mount, name string) error
+	Encrypt(mount, key string, plaintext []byte) (string, error)
+	Decrypt(mount, key string, ciphertext string) ([]byte, error)
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	REQUEST_TIMEOUT = 30 * time.Second
+	// Idle connections are kept for all concurrent clients
+	MAX_IDLE_CONNS_PER_HOST = 128
+)
+
+type vaultSecretsTesterRepository struct {
+	client  *http.Client
+	baseUrl string
+	token   string
+}
+
+func NewVaultSecretsTesterRepository(port uint16, host string) SecretsTesterRepository {
+	r := new(vaultSecretsTesterRepository)
+	r.client = &http.Client{Timeout: REQUEST_TIMEOUT, Transport: &http.Transport{MaxIdleConnsPerHost: MAX_IDLE_CONNS_PER_HOST}}
+	r.baseUrl = "http://" + host + ":" + strconv.FormatUint(uint64(port), 10) + "/v1"
+	return r
+}
+
+type vaultHealthResponse struct {
+	Initialized bool `json:"initialized"`
+	Sealed      bool `json:"sealed"`
+}
+
+type vaultInitResponse struct {
+	Keys      []string `json:"keys"`
+	RootToken string   `json:"root_token"`
+}
+
+type vaultKvReadResponse struct {
+	Data struct {
+		Data map[string]string `json:"data"`
+	} `json:"data"`
+}
+
+type vaultTransitResponse struct {
+	Data struct {
+		Ciphertext string `json:"ciphertext"`
+		Plaintext  string `json:"plaintext"`
+	} `json:"data"`
+}
+
+func (r *vaultSecretsTesterRepository) Health() (bool, bool, error) {
+	// Health endpoint responds with non 2xx codes for uninitialized and sealed servers
+	resp, err := r.client.Get(r.baseUrl + "/sys/health?uninitcode=200&sealedcode=200&standbyok=true")
+	if err != nil {
+		return false, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, false, domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	var hr vaultHealthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&hr); err != nil {
+		return false, false, err
+	}
+	return hr.Initialized, hr.Sealed, nil
+}
+
+func (r *vaultSecretsTesterRepository) Init() (string, string, error) {
+	var ir vaultInitResponse
+	if err := r.do(http.MethodPut, "/sys/init", map[string]interface{}{"secret_shares": 1, "secret_threshold": 1}, &ir); err != nil {
+		return "", "", err
+	}
+	if len(ir.Keys) == 0 {
+		return "", "", domain.UNEXPECTED_RESPONSE_STATUS
+	}
+	return ir.Keys[0], ir.RootToken, nil
+}
+
+func (r *vaultSecretsTesterRepository) Unseal(unsealKey string) error {
+	return r.do(http.MethodPut, "/sys/unseal", map[string]interface{}{"key": unsealKey}, nil)
+}
+
+func (r *vaultSecretsTesterRepository) SetToken(token string) {
+	r.token = token
+}
+
+func (r *vaultSecretsTesterRepository) EnableEngine(path, engineType string, options map[string]string) error {
+	return r.do(http.MethodPost, "/sys/mounts/"+path, map[string]interface{}{"type": engineType, "options": options}, nil)
+}
+
+func (r *vaultSecretsTesterRepository) DisableEngine(path string) error {
+	return r.do(http.MethodDelete, "/sys/mounts/"+path, nil, nil)
+}
+
+func (r *vaultSecretsTesterRepository) KvWrite(mount, key string, data map[string]string) error {
+	return r.do(http.MethodPost, "/"+mount+"/data/"+key, map[string]interface{}{"data": data}, nil)
+}
+
+func (r *vaultSecretsTesterRepository) KvRead(mount, key string) (map[string]string, error) {
+	var kr vaultKvReadResponse
+	if err := r.do(http.MethodGet, "/"+mount+"/data/"+key, nil, &kr); err != nil {
+		return nil, err
+	}
+	return kr.Data.Data, nil
+}
+
+func (r *vaultSecretsTesterRepository) CreateTransitKey(mount, name string) error {
+	return r.do(http.MethodPost, "/"+mount+"/keys/"+name, nil, nil)
+}
+
+func (r *vaultSecretsTesterRepository) Encrypt(mount, key string, plaintext []byte) (string, error) {
+	var tr vaultTransitResponse
+	if err := r.do(http.MethodPost, "/"+mount+"/encrypt/"+key, map[string]interface{}{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}, &tr); err != nil {
+		return "", err
+	}
+	return tr.Data.Ciphertext, nil
+}
+
+func (r *vaultSecretsTesterRepository) Decrypt(mount, key string, ciphertext string) ([]byte, error) {
+	var tr vaultTransitResponse
+	if err := r.do(http.MethodPost, "/"+mount+"/decrypt/"+key, map[string]interface{}{"ciphertext": ciphertext}, &tr); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(tr.Data.Plaintext)
+}
+
+// Method sends JSON request with the token and decodes response into result if it's not nil
+func (r *vaultSecretsTesterRepository) do(method, path string, body interface{}, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, r.baseUrl+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.token != "" {
+		req.Header.Set("X-Vault-Token", r.token)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		logrus.WithFields(logrus.Fields{"status": resp.StatusCode, "body": string(respBody)}).Debug("vault request failed")
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	if result == nil {
+		_, err := io.Copy(ioutil.Discard, resp.Body)
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
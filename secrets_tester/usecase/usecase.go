@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"bytes"
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/iakrevetkho/components-tests/cott/secrets_tester/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	REQUESTS       = 1000
+	KV_MOUNT       = "cott-kv"
+	TRANSIT_MOUNT  = "cott-transit"
+	TRANSIT_KEY    = "cott"
+	PLAINTEXT_SIZE = 1 << 10
+)
+
+type SecretsTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type vaultCredentials struct {
+	unsealKey string
+	rootToken string
+}
+
+type secretsTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+	// Credentials of the servers initialized by cott, so server can be unsealed again in the next accumulations
+	credentials map[string]*vaultCredentials
+}
+
+func NewSecretsTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) SecretsTesterUsecase {
+	stuc := new(secretsTesterUsecase)
+	stuc.cluc = cluc
+	stuc.credentials = make(map[string]*vaultCredentials)
+	return stuc
+}
+
+func (stuc *secretsTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	r, err := stuc.createSecretsRepository(tcra.TestCase)
+	if err != nil {
+		return err
+	}
+
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, stuc.cluc, containerId)
+
+	var initialized, sealed bool
+	// Await for server responds
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return helpers.AwaitConnection(func() error {
+			initialized, sealed, err = r.Health()
+			return err
+		}, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	if err := stuc.unseal(mcuc, r, tcra.TestCase, containerId, initialized, sealed); err != nil {
+		return err
+	}
+
+	steps := []*domain.TestCaseStep{
+		{Name: "enableKvEngine", StepFunc: func() error { return r.EnableEngine(KV_MOUNT, "kv", map[string]string{"version": "2"}) }},
+		{Name: "enableTransitEngine", StepFunc: func() error { return r.EnableEngine(TRANSIT_MOUNT, "transit", nil) }},
+		{Name: "createTransitKey", StepFunc: func() error { return r.CreateTransitKey(TRANSIT_MOUNT, TRANSIT_KEY) }},
+	}
+	for _, step := range steps {
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+	}
+
+	stuc.testKv(mcuc, r, int(tcra.TestCase.GetConcurrency()))
+	stuc.testTransit(mcuc, r, int(tcra.TestCase.GetConcurrency()))
+
+	steps = []*domain.TestCaseStep{
+		{Name: "disableTransitEngine", StepFunc: func() error { return r.DisableEngine(TRANSIT_MOUNT) }},
+		{Name: "disableKvEngine", StepFunc: func() error { return r.DisableEngine(KV_MOUNT) }},
+	}
+	for _, step := range steps {
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (stuc *secretsTesterUsecase) createSecretsRepository(tc *domain.TestCase) (repository.SecretsTesterRepository, error) {
+	switch tc.ComponentType {
+	case domain.ComponentType_Vault:
+		return repository.NewVaultSecretsTesterRepository(tc.Port, "localhost"), nil
+	default:
+		return nil, domain.NewUnknownComponentError(tc.ComponentType)
+	}
+}
+
+// Method initializes and unseals server if it's needed and sets token for the next calls.
+// Dev servers are already unsealed, so test case password is used as their root token.
+func (stuc *secretsTesterUsecase) unseal(mcuc metrics_collector.MetricsCollectorUsecase, r repository.SecretsTesterRepository, tc *domain.TestCase, containerId string, initialized bool, sealed bool) error {
+	if !initialized {
+		step := &domain.TestCaseStep{Name: "init", StepFunc: func() error {
+			unsealKey, rootToken, err := r.Init()
+			if err != nil {
+				return err
+			}
+			stuc.credentials[containerId] = &vaultCredentials{unsealKey: unsealKey, rootToken: rootToken}
+			return nil
+		}}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+		sealed = true
+	}
+
+	credentials, ok := stuc.credentials[containerId]
+
+	if sealed {
+		if !ok {
+			return domain.SERVER_IS_SEALED
+		}
+
+		step := &domain.TestCaseStep{Name: "unseal", StepFunc: func() error { return r.Unseal(credentials.unsealKey) }}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			return err
+		}
+	}
+
+	if ok {
+		r.SetToken(credentials.rootToken)
+	} else {
+		r.SetToken(tc.Password)
+	}
+
+	return nil
+}
+
+func (stuc *secretsTesterUsecase) testKv(mcuc metrics_collector.MetricsCollectorUsecase, r repository.SecretsTesterRepository, concurrency int) {
+	var nextWrite, nextRead int64 = -1, -1
+	prefix := strconv.FormatInt(REQUESTS, 10) + "x"
+
+	steps := []*domain.TestCaseStep{
+		stuc.createLoadStep(prefix+"KvWrite", concurrency, func() error {
+			i := strconv.FormatInt(atomic.AddInt64(&nextWrite, 1), 10)
+			return r.KvWrite(KV_MOUNT, "cott-"+i, map[string]string{"value": i})
+		}),
+		stuc.createLoadStep(prefix+"KvRead", concurrency, func() error {
+			i := strconv.FormatInt(atomic.AddInt64(&nextRead, 1), 10)
+			data, err := r.KvRead(KV_MOUNT, "cott-"+i)
+			if err != nil {
+				return err
+			}
+			if data["value"] != i {
+				return domain.DATA_INTEGRITY_VIOLATED
+			}
+			return nil
+		}),
+	}
+	for _, step := range steps {
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).Warn("kv load failed")
+		}
+	}
+}
+
+func (stuc *secretsTesterUsecase) testTransit(mcuc metrics_collector.MetricsCollectorUsecase, r repository.SecretsTesterRepository, concurrency int) {
+	plaintext := make([]byte, PLAINTEXT_SIZE)
+	rand.Read(plaintext)
+
+	ciphertext, err := r.Encrypt(TRANSIT_MOUNT, TRANSIT_KEY, plaintext)
+	if err != nil {
+		logrus.WithError(err).Warn("couldn't encrypt plaintext for decryption")
+		return
+	}
+
+	prefix := strconv.FormatInt(REQUESTS, 10) + "x"
+
+	steps := []*domain.TestCaseStep{
+		stuc.createLoadStep(prefix+"Encrypt", concurrency, func() error {
+			_, err := r.Encrypt(TRANSIT_MOUNT, TRANSIT_KEY, plaintext)
+			return err
+		}),
+		stuc.createLoadStep(prefix+"Decrypt", concurrency, func() error {
+			decrypted, err := r.Decrypt(TRANSIT_MOUNT, TRANSIT_KEY, ciphertext)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(decrypted, plaintext) {
+				return domain.DATA_INTEGRITY_VIOLATED
+			}
+			return nil
+		}),
+	}
+	for _, step := range steps {
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).Warn("transit load failed")
+		}
+	}
+}
+
+func (stuc *secretsTesterUsecase) createLoadStep(name string, concurrency int, operation func() error) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = helpers.RunLoad(REQUESTS, concurrency, operation)
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+)
+
+// ServiceProber is implemented by the component testers which probe readiness with their own clients, e.g. by SQL query
+type ServiceProber interface {
+	// ProbeService returns nil if the service is ready
+	ProbeService(tc *domain.TestCase) error
+}
+
+// Method awaits the service passes readiness probe and reports container up time separately from the service ready time,
+// so slow image start isn't mixed with slow service initialization
+func (tuc *testerUsecase) awaitServiceReady(tcra *domain.TestCaseResultsAccumulator, ctuc ComponentTesterUsecase, containerId string) error {
+	tc := tcra.TestCase
+	if tc.GetReadinessProbeType() == domain.ProbeType_None || tc.Port == 0 {
+		return nil
+	}
+
+	probe, err := tuc.createProbe(tc, ctuc)
+	if err != nil {
+		return err
+	}
+
+	var readyTime time.Time
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, tuc.cluc, containerId)
+	step := &domain.TestCaseStep{Name: "readiness", StepFunc: func() error {
+		err := helpers.AwaitProbe(probe, tc.GetReadinessProbeTimeout())
+		readyTime = time.Now()
+		return err
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		created, started, err := tuc.cluc.GetContainerStartTimes(containerId)
+		if err != nil {
+			tcsra.AddError(err.Error())
+			return
+		}
+		tcsra.AddMetric(domain.MetricMeta_ContainerUpTime, float64(started.Sub(created).Microseconds()))
+		tcsra.AddMetric(domain.MetricMeta_ServiceReadyTime, float64(readyTime.Sub(started).Microseconds()))
+	}}
+
+	return mcuc.CollectStepMetrics(step)
+}
+
+func (tuc *testerUsecase) createProbe(tc *domain.TestCase, ctuc ComponentTesterUsecase) (helpers.Probe, error) {
+	address := net.JoinHostPort("localhost", strconv.FormatUint(uint64(tc.Port), 10))
+
+	switch tc.GetReadinessProbeType() {
+
+	case domain.ProbeType_Sql:
+		sp, ok := ctuc.(ServiceProber)
+		if !ok {
+			return nil, domain.UNSUPPORTED_READINESS_PROBE
+		}
+		return func() error { return sp.ProbeService(tc) }, nil
+
+	case domain.ProbeType_KafkaMetadata:
+		return helpers.NewKafkaMetadataProbe(address), nil
+
+	case domain.ProbeType_Http:
+		return helpers.NewHttpProbe("http://" + address + tc.GetReadinessProbePath()), nil
+
+	case domain.ProbeType_Tcp:
+		return helpers.NewTcpProbe(address), nil
+
+	default:
+		return nil, domain.UNSUPPORTED_READINESS_PROBE
+	}
+}
@@ -1,25 +1,42 @@
 package usecase
 
 import (
+	"strconv"
+	"time"
+
 	cl_usecase "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
-	dt_usecase "github.com/iakrevetkho/components-tests/cott/database_tester/usecase"
 	"github.com/iakrevetkho/components-tests/cott/domain"
 	"github.com/sirupsen/logrus"
 )
 
 type TesterUsecase interface {
 	RunCases(tcs []domain.TestCase) (*domain.Report, error)
+	// Sweep runs test cases of the component type against every image tag and compares results
+	Sweep(tcs []domain.TestCase, ct domain.ComponentType, tags []string) (*domain.SweepReport, error)
+	// Bisect finds the first image tag where the step metric regressed more than threshold percent from the first tag
+	Bisect(tcs []domain.TestCase, ct domain.ComponentType, step string, metric string, threshold float64, tags []string) (*domain.BisectReport, error)
+	// CleanUp removes containers, compose networks and volumes older than min age, which are left by crashed runs, and returns them.
+	// Younger ones are kept, because they could belong to the run in progress.
+	CleanUp(minAge time.Duration) ([]string, error)
+}
+
+// ComponentTesterUsecase runs test case against the launched component container
+type ComponentTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
 }
 
 type testerUsecase struct {
-	cluc cl_usecase.ContainerLauncherUsecase
-	dtuc dt_usecase.DatabaseTesterUsecase
+	cluc             cl_usecase.ContainerLauncherUsecase
+	componentTesters map[domain.ComponentType]ComponentTesterUsecase
+	// Testers of the modes, which take precedence over the testers of the component types
+	modeTesters map[domain.TesterMode]ComponentTesterUsecase
 }
 
-func NewTesterUsecase(cluc cl_usecase.ContainerLauncherUsecase, dtuc dt_usecase.DatabaseTesterUsecase) TesterUsecase {
+func NewTesterUsecase(cluc cl_usecase.ContainerLauncherUsecase, componentTesters map[domain.ComponentType]ComponentTesterUsecase, modeTesters map[domain.TesterMode]ComponentTesterUsecase) TesterUsecase {
 	tuc := new(testerUsecase)
 	tuc.cluc = cluc
-	tuc.dtuc = dtuc
+	tuc.componentTesters = componentTesters
+	tuc.modeTesters = modeTesters
 	return tuc
 }
 
@@ -27,41 +44,282 @@ func (tuc *testerUsecase) RunCases(tcs []domain.TestCase) (*domain.Report, error
 	r := domain.NewReport()
 
 	for _, tc := range tcs {
+		ctuc, ok := tuc.getComponentTester(&tc)
+		if !ok {
+			return nil, domain.NewUnknownComponentError(tc.ComponentType)
+		}
 
-		switch tc.ComponentType {
+		composeProjectName := cl_usecase.COTT_COMPOSE_PROJECT_PREFIX + strconv.FormatInt(time.Now().Unix(), 10)
+		containerId, err := tuc.launchTestCase(&tc, composeProjectName)
+		if err != nil {
+			return nil, err
+		}
+
+		tcra := domain.NewTestCaseResultsAccumulator(&tc)
 
-		case domain.ComponentType_Postgres:
-			containerId, err := tuc.cluc.LaunchContainer(tc.Image, tc.EnvVars, tc.Port)
-			if err != nil {
+		if err := tuc.findNodesContainers(tcra, composeProjectName); err != nil {
+			return nil, err
+		}
+
+		if err := tuc.awaitServiceReady(tcra, ctuc, *containerId); err != nil {
+			logrus.WithError(err).WithField("testCase", tc).Warn("service didn't pass readiness probe")
+		}
+
+		// Accumulations loop
+		for i := 0; i < int(tc.GetAccumulationsCount()); i++ {
+			if err := ctuc.RunCase(tcra, *containerId); err != nil {
 				return nil, err
 			}
+		}
 
-			tcra := domain.NewTestCaseResultsAccumulator(&tc)
+		if err := tuc.removeTestCase(&tc, *containerId, composeProjectName); err != nil {
+			return nil, err
+		}
 
-			// Accumulations loop
-			for i := 0; i < int(tc.GetAccumulationsCount()); i++ {
-				if err := tuc.dtuc.RunCase(tcra, *containerId); err != nil {
-					return nil, err
-				}
-			}
+		if err := tuc.checkContainerLeftovers(tcra, *containerId); err != nil {
+			return nil, err
+		}
 
-			tcr := tcra.ToTestCaseResults()
+		tcr := tcra.ToTestCaseResults()
+		tcr.CalculateCostEfficiency()
+		tcr.FindSustainableThroughput()
+		r.CheckAssertions(tcr)
 
-			r.AddTestCaseResults(tcr)
-			logrus.WithField("testResults", tcr).Debug("added test results")
+		r.AddTestCaseResults(tcr)
+		logrus.WithField("testResults", tcr).Debug("added test results")
+	}
 
-			if err := tuc.cluc.StopContainer(*containerId); err != nil {
-				return nil, err
-			}
+	r.CompareDrivers()
+	r.CompareStorageEngines()
 
-			if err := tuc.cluc.RemoveContainer(*containerId); err != nil {
-				return nil, err
-			}
+	return r, nil
+}
+
+func (tuc *testerUsecase) Sweep(tcs []domain.TestCase, ct domain.ComponentType, tags []string) (*domain.SweepReport, error) {
+	sweepTcs, err := tuc.filterTaggableTestCases(tcs, ct)
+	if err != nil {
+		return nil, err
+	}
 
-		default:
-			return nil, domain.UNKNOWN_COMPONENT_FOR_TESTING
+	sr := domain.NewSweepReport(ct, tags)
+
+	for _, tag := range tags {
+		r, err := tuc.runTaggedCases(sweepTcs, tag)
+		if err != nil {
+			return nil, err
 		}
+		sr.AddReport(tag, r)
 	}
 
-	return r, nil
+	sr.ChooseWinners()
+
+	return sr, nil
+}
+
+// Bisect assumes that the first tag isn't regressed and binary searches for the first regressed one
+func (tuc *testerUsecase) Bisect(tcs []domain.TestCase, ct domain.ComponentType, step string, metric string, threshold float64, tags []string) (*domain.BisectReport, error) {
+	bisectTcs, err := tuc.filterTaggableTestCases(tcs, ct)
+	if err != nil {
+		return nil, err
+	}
+
+	br := domain.NewBisectReport(ct, step, metric, threshold, tags)
+	measure := func(i int) (float64, error) {
+		r, err := tuc.runTaggedCases(bisectTcs, tags[i])
+		if err != nil {
+			return 0, err
+		}
+
+		value, ok := r.FindMetric(step, metric)
+		if !ok {
+			return 0, domain.METRIC_NOT_FOUND
+		}
+		br.AddValue(tags[i], value, r)
+		logrus.WithFields(logrus.Fields{"tag": tags[i], "value": value}).Info("bisect tag measured")
+		return value, nil
+	}
+
+	if br.Baseline, err = measure(0); err != nil {
+		return nil, err
+	}
+
+	last := len(tags) - 1
+	value, err := measure(last)
+	if err != nil {
+		return nil, err
+	}
+	if !br.IsRegressed(value) {
+		return br, nil
+	}
+
+	// Invariant: tags[good] isn't regressed, tags[bad] is regressed
+	good, bad := 0, last
+	for bad-good > 1 {
+		mid := (good + bad) / 2
+		value, err := measure(mid)
+		if err != nil {
+			return nil, err
+		}
+
+		if br.IsRegressed(value) {
+			bad = mid
+		} else {
+			good = mid
+		}
+	}
+	br.FirstRegressedTag = tags[bad]
+
+	return br, nil
+}
+
+// Compose test cases are skipped, because their images are defined in the compose file
+func (tuc *testerUsecase) filterTaggableTestCases(tcs []domain.TestCase, ct domain.ComponentType) ([]domain.TestCase, error) {
+	var filtered []domain.TestCase
+	for _, tc := range tcs {
+		if tc.ComponentType == ct && tc.Image != "" && tc.ComposeFile == "" {
+			filtered = append(filtered, tc)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, domain.NO_TEST_CASES_FOR_COMPONENT
+	}
+	return filtered, nil
+}
+
+func (tuc *testerUsecase) runTaggedCases(tcs []domain.TestCase, tag string) (*domain.Report, error) {
+	taggedTcs := make([]domain.TestCase, 0, len(tcs))
+	for _, tc := range tcs {
+		taggedTcs = append(taggedTcs, tc.WithImageTag(tag))
+	}
+
+	logrus.WithField("tag", tag).Info("run test cases for tag")
+	return tuc.RunCases(taggedTcs)
+}
+
+// Method returns tester of the test case mode or of its component type if mode isn't set
+func (tuc *testerUsecase) getComponentTester(tc *domain.TestCase) (ComponentTesterUsecase, bool) {
+	if tc.Mode != domain.TesterMode_NA {
+		ctuc, ok := tuc.modeTesters[tc.Mode]
+		return ctuc, ok
+	}
+
+	ctuc, ok := tuc.componentTesters[tc.ComponentType]
+	return ctuc, ok
+}
+
+// Method launches compose project or single container for the test case and returns ID of the tested container.
+// ID is empty for the embedded components.
+func (tuc *testerUsecase) launchTestCase(tc *domain.TestCase, composeProjectName string) (*string, error) {
+	if tc.IsEmbedded() {
+		id := ""
+		return &id, nil
+	}
+	if tc.ComposeFile != "" {
+		return tuc.cluc.LaunchCompose(tc.ComposeFile, composeProjectName, tc.ComposeService)
+	}
+	return tuc.cluc.LaunchContainer(tc)
+}
+
+// Method finds containers of the cluster nodes, so component testers could collect metrics of every node
+func (tuc *testerUsecase) findNodesContainers(tcra *domain.TestCaseResultsAccumulator, composeProjectName string) error {
+	if tcra.TestCase.ComposeFile == "" {
+		return nil
+	}
+
+	for _, n := range tcra.TestCase.Nodes {
+		id, err := tuc.cluc.GetComposeServiceContainer(tcra.TestCase.ComposeFile, composeProjectName, n.Service)
+		if err != nil {
+			return err
+		}
+		tcra.SetNodeContainerId(n.Service, *id)
+	}
+	return nil
+}
+
+func (tuc *testerUsecase) removeTestCase(tc *domain.TestCase, containerId string, composeProjectName string) error {
+	if tc.IsEmbedded() {
+		return nil
+	}
+	if tc.ComposeFile != "" {
+		return tuc.cluc.RemoveCompose(tc.ComposeFile, composeProjectName)
+	}
+
+	if err := tuc.cluc.StopContainer(containerId); err != nil {
+		return err
+	}
+
+	return tuc.cluc.RemoveContainer(containerId)
+}
+
+func (tuc *testerUsecase) CleanUp(minAge time.Duration) ([]string, error) {
+	createdBefore := time.Now().Add(-minAge)
+
+	ids, err := tuc.cluc.ListContainersCreatedBefore(createdBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, id := range ids {
+		// Container could be already stopped
+		if err := tuc.cluc.StopContainer(id); err != nil {
+			logrus.WithError(err).WithField("id", id).Debug("couldn't stop orphan container")
+		}
+		if err := tuc.cluc.RemoveContainer(id); err != nil {
+			return nil, err
+		}
+		logrus.WithField("id", id).Info("orphan container removed")
+		removed = append(removed, "container "+id)
+	}
+
+	// Networks and volumes are removed after their containers
+	ids, err = tuc.cluc.ListComposeNetworks(createdBefore)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		// Removal fails if the network is still used by the younger containers of the same project
+		if err := tuc.cluc.RemoveNetwork(id); err != nil {
+			logrus.WithError(err).WithField("id", id).Warn("couldn't remove orphan network")
+			continue
+		}
+		logrus.WithField("id", id).Info("orphan network removed")
+		removed = append(removed, "network "+id)
+	}
+
+	names, err := tuc.cluc.ListComposeVolumes(createdBefore)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		// Removal fails if the volume is still used by the younger containers of the same project
+		if err := tuc.cluc.RemoveVolume(name); err != nil {
+			logrus.WithError(err).WithField("name", name).Warn("couldn't remove orphan volume")
+			continue
+		}
+		logrus.WithField("name", name).Info("orphan volume removed")
+		removed = append(removed, "volume "+name)
+	}
+
+	return removed, nil
+}
+
+// Method checks that test case container was removed
+func (tuc *testerUsecase) checkContainerLeftovers(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	if containerId == "" {
+		return nil
+	}
+
+	ids, err := tuc.cluc.ListContainers()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if id == containerId {
+			tcra.AddLeftover("container " + id)
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	REQUEST_TIMEOUT = 60 * time.Second
+	// Retention policy of the InfluxQL mapping of the buckets
+	INFLUXDB_RETENTION_POLICY = "autogen"
+)
+
+type influxDBTimeSeriesTesterRepository struct {
+	client  *http.Client
+	baseUrl string
+	token   string
+	org     string
+	// IDs are resolved once, because API addresses organizations and buckets by them
+	mu        sync.Mutex
+	orgId     string
+	bucketIds map[string]string
+}
+
+// NewInfluxDBTimeSeriesTesterRepository creates repository of the InfluxDB 2 API authorized by the token of the organization
+func NewInfluxDBTimeSeriesTesterRepository(port uint16, host, token, org string) TimeSeriesTesterRepository {
+	r := new(influxDBTimeSeriesTesterRepository)
+	r.client = &http.Client{Timeout: REQUEST_TIMEOUT}
+	r.baseUrl = "http://" + host + ":" + strconv.FormatUint(uint64(port), 10)
+	r.token = token
+	r.org = org
+	r.bucketIds = make(map[string]string)
+	return r
+}
+
+type influxDBResource struct {
+	Id string `json:"id"`
+}
+
+func (r *influxDBTimeSeriesTesterRepository) Ping() error {
+	return r.do(http.MethodGet, "/health", "", nil, nil)
+}
+
+// InfluxQL queries address databases, so bucket is mapped to the database of the same name
+func (r *influxDBTimeSeriesTesterRepository) CreateBucket(name string) error {
+	orgId, err := r.getOrgId()
+	if err != nil {
+		return err
+	}
+
+	var bucket influxDBResource
+	if err := r.doJson(http.MethodPost, "/api/v2/buckets", map[string]interface{}{
+		"orgID":          orgId,
+		"name":           name,
+		"retentionRules": []interface{}{},
+	}, &bucket); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.bucketIds[name] = bucket.Id
+	r.mu.Unlock()
+
+	return r.doJson(http.MethodPost, "/api/v2/dbrps", map[string]interface{}{
+		"orgID":            orgId,
+		"bucketID":         bucket.Id,
+		"database":         name,
+		"retention_policy": INFLUXDB_RETENTION_POLICY,
+		"default":          true,
+	}, nil)
+}
+
+// Mapping of the bucket is deleted with it
+func (r *influxDBTimeSeriesTesterRepository) DeleteBucket(name string) error {
+	bucketId, err := r.getBucketId(name)
+	if err != nil {
+		return err
+	}
+
+	if err := r.do(http.MethodDelete, "/api/v2/buckets/"+bucketId, "", nil, nil); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	delete(r.bucketIds, name)
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *influxDBTimeSeriesTesterRepository) WriteLines(bucket string, lines []string) error {
+	params := url.Values{}
+	params.Set("org", r.org)
+	params.Set("bucket", bucket)
+	params.Set("precision", "ns")
+
+	return r.do(http.MethodPost, "/api/v2/write?"+params.Encode(), "text/plain; charset=utf-8", strings.NewReader(strings.Join(lines, "\n")), nil)
+}
+
+func (r *influxDBTimeSeriesTesterRepository) QueryFlux(query string) error {
+	params := url.Values{}
+	params.Set("org", r.org)
+
+	return r.do(http.MethodPost, "/api/v2/query?"+params.Encode(), "application/vnd.flux", strings.NewReader(query), nil)
+}
+
+func (r *influxDBTimeSeriesTesterRepository) QueryInfluxQL(bucket string, query string) error {
+	params := url.Values{}
+	params.Set("db", bucket)
+	params.Set("q", query)
+
+	return r.do(http.MethodGet, "/query?"+params.Encode(), "", nil, nil)
+}
+
+func (r *influxDBTimeSeriesTesterRepository) Close() error {
+	r.client.CloseIdleConnections()
+	return nil
+}
+
+func (r *influxDBTimeSeriesTesterRepository) getOrgId() (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.orgId != "" {
+		return r.orgId, nil
+	}
+
+	var orgs struct {
+		Orgs []influxDBResource `json:"orgs"`
+	}
+	if err := r.doJson(http.MethodGet, "/api/v2/orgs?org="+url.QueryEscape(r.org), nil, &orgs); err != nil {
+		return "", err
+	}
+	if len(orgs.Orgs) == 0 {
+		return "", domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	r.orgId = orgs.Orgs[0].Id
+	return r.orgId, nil
+}
+
+// Buckets of the previous runs aren't known by the repository, so they are found by the name
+func (r *influxDBTimeSeriesTesterRepository) getBucketId(name string) (string, error) {
+	r.mu.Lock()
+	bucketId, ok := r.bucketIds[name]
+	r.mu.Unlock()
+	if ok {
+		return bucketId, nil
+	}
+
+	params := url.Values{}
+	params.Set("org", r.org)
+	params.Set("name", name)
+
+	var buckets struct {
+		Buckets []influxDBResource `json:"buckets"`
+	}
+	if err := r.doJson(http.MethodGet, "/api/v2/buckets?"+params.Encode(), nil, &buckets); err != nil {
+		return "", err
+	}
+	if len(buckets.Buckets) == 0 {
+		return "", domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	return buckets.Buckets[0].Id, nil
+}
+
+func (r *influxDBTimeSeriesTesterRepository) doJson(method string, path string, body interface{}, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	return r.do(method, path, "application/json", reqBody, result)
+}
+
+// Method sends authorized request and decodes JSON response into result if it's not nil. Otherwise response is drained.
+func (r *influxDBTimeSeriesTesterRepository) do(method string, path string, contentType string, body io.Reader, result interface{}) error {
+	req, err := http.NewRequest(method, r.baseUrl+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Token "+r.token)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		logrus.WithFields(logrus.Fields{"status": resp.StatusCode, "body": string(respBody)}).Debug("influxdb request failed")
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	if result == nil {
+		_, err := io.Copy(ioutil.Discard, resp.Body)
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	_ "github.com/jackc/pgx/v4/stdlib"
+	"github.com/jmoiron/sqlx"
+)
+
+// Database of the PG wire port, which is the only one of the server
+const QUESTDB_DATABASE = "qdb"
+
+type questDBTimeSeriesTesterRepository struct {
+	db      *sqlx.DB
+	ilpAddr string
+	// Idle ILP connections, so concurrent writers don't share the stream
+	conns chan net.Conn
+}
+
+// NewQuestDBTimeSeriesTesterRepository creates repository writing lines by ILP over TCP and querying them by PG wire protocol.
+// Connections of the ILP port are pooled up to poolSize.
+func NewQuestDBTimeSeriesTesterRepository(pgPort uint16, ilpPort uint16, host, user, password string, poolSize int) (TimeSeriesTesterRepository, error) {
+	db, err := sqlx.Open("pgx", "host="+host+" port="+strconv.FormatUint(uint64(pgPort), 10)+" user="+user+" password="+password+" dbname="+QUESTDB_DATABASE+" sslmode=disable")
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(questDBTimeSeriesTesterRepository)
+	r.db = db
+	r.ilpAddr = host + ":" + strconv.FormatUint(uint64(ilpPort), 10)
+	r.conns = make(chan net.Conn, poolSize)
+	return r, nil
+}
+
+func (r *questDBTimeSeriesTesterRepository) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+	defer cancel()
+
+	return r.db.PingContext(ctx)
+}
+
+// Table is created with the schema of the generated lines, so ILP doesn't alter it by the first write
+func (r *questDBTimeSeriesTesterRepository) CreateBucket(name string) error {
+	_, err := r.db.Exec("CREATE TABLE " + name + " (series SYMBOL, f1 LONG, f2 DOUBLE, timestamp TIMESTAMP) TIMESTAMP(timestamp) PARTITION BY DAY")
+	return err
+}
+
+func (r *questDBTimeSeriesTesterRepository) DeleteBucket(name string) error {
+	_, err := r.db.Exec("DROP TABLE IF EXISTS " + name)
+	return err
+}
+
+// ILP over TCP doesn't acknowledge lines, so write completes when lines are sent. Rows are committed asynchronously.
+func (r *questDBTimeSeriesTesterRepository) WriteLines(bucket string, lines []string) error {
+	conn, err := r.getConn()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+
+	if err := conn.SetWriteDeadline(time.Now().Add(REQUEST_TIMEOUT)); err != nil {
+		conn.Close()
+		return err
+	}
+	if _, err := conn.Write(buf.Bytes()); err != nil {
+		// Stream could be cut in the middle of the line, so connection isn't reused
+		conn.Close()
+		return err
+	}
+
+	r.putConn(conn)
+	return nil
+}
+
+func (r *questDBTimeSeriesTesterRepository) QuerySQL(query string) error {
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func (r *questDBTimeSeriesTesterRepository) CountRows(bucket string) (int64, error) {
+	var count int64
+	if err := r.db.Get(&count, "SELECT count() FROM "+bucket); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *questDBTimeSeriesTesterRepository) TruncateBucket(name string) error {
+	_, err := r.db.Exec("TRUNCATE TABLE " + name)
+	return err
+}
+
+func (r *questDBTimeSeriesTesterRepository) Close() error {
+	close(r.conns)
+	for conn := range r.conns {
+		conn.Close()
+	}
+	return r.db.Close()
+}
+
+func (r *questDBTimeSeriesTesterRepository) getConn() (net.Conn, error) {
+	select {
+	case conn := <-r.conns:
+		return conn, nil
+	default:
+		return net.DialTimeout("tcp", r.ilpAddr, REQUEST_TIMEOUT)
+	}
+}
+
+// Connection is closed if the pool is full
+func (r *questDBTimeSeriesTesterRepository) putConn(conn net.Conn) {
+	select {
+	case r.conns <- conn:
+	default:
+		conn.Close()
+	}
+}
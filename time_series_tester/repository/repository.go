@@ -0,0 +1,29 @@
+package repository
+
+type TimeSeriesTesterRepository interface {
+	Ping() error
+	// CreateBucket creates bucket with infinite retention
+	CreateBucket(name string) error
+	DeleteBucket(name string) error
+	// WriteLines writes points of the line protocol by the single request
+	WriteLines(bucket string, lines []string) error
+	Close() error
+}
+
+// InfluxTimeSeriesTesterRepository is implemented by the stores serving InfluxDB query languages
+type InfluxTimeSeriesTesterRepository interface {
+	// QueryFlux runs Flux query and reads the whole result
+	QueryFlux(query string) error
+	// QueryInfluxQL runs InfluxQL query of the bucket and reads the whole result
+	QueryInfluxQL(bucket string, query string) error
+}
+
+// SQLTimeSeriesTesterRepository is implemented by the stores keeping buckets in the tables queried by SQL.
+// Lines are written into the table of their measurement, so measurement of the lines should be the bucket name.
+type SQLTimeSeriesTesterRepository interface {
+	// QuerySQL runs SQL query and reads the whole result
+	QuerySQL(query string) error
+	// CountRows returns count of the rows of the bucket visible to the queries
+	CountRows(bucket string) (int64, error)
+	TruncateBucket(name string) error
+}
@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/iakrevetkho/components-tests/cott/time_series_tester/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	BUCKET_NAME      = "cott_bucket"
+	MEASUREMENT_NAME = "cott_test"
+	MAX_POINTS_COUNT = 1000000
+	// Points count of the single write request
+	WRITE_BATCH_SIZE = 5000
+	SERIES_COUNT     = 100
+	QUERY_REQUESTS   = 100
+	// Points are written with 1ms interval, so queries aggregate them by windows of 1000 points
+	POINTS_INTERVAL = time.Millisecond
+	QUERY_WINDOW    = time.Second
+	// Time to await rows of the unacknowledged writes visible to the queries
+	COMMIT_TIMEOUT = 60 * time.Second
+	// Timestamp literals of the SQL queries have microseconds precision
+	SQL_TIMESTAMP_LAYOUT = "2006-01-02T15:04:05.000000Z"
+)
+
+type TimeSeriesTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type timeSeriesTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewTimeSeriesTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) TimeSeriesTesterUsecase {
+	tsuc := new(timeSeriesTesterUsecase)
+	tsuc.cluc = cluc
+	return tsuc
+}
+
+func (tsuc *timeSeriesTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	r, err := tsuc.createTimeSeriesRepository(tcra.TestCase)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, tsuc.cluc, containerId)
+
+	// Await for database ready
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return helpers.AwaitConnection(r.Ping, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	// Bucket of the previous run could be left by the failed run
+	if err := r.DeleteBucket(BUCKET_NAME); err != nil {
+		logrus.WithError(err).Debug("couldn't delete bucket")
+	}
+
+	for i := 1; i <= MAX_POINTS_COUNT; i *= 10 {
+		if err := tsuc.testBucket(mcuc, r, int(tcra.TestCase.GetConcurrency()), i); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (tsuc *timeSeriesTesterUsecase) createTimeSeriesRepository(tc *domain.TestCase) (repository.TimeSeriesTesterRepository, error) {
+	switch tc.ComponentType {
+	case domain.ComponentType_InfluxDB:
+		const (
+			INFLUXDB_ORG_ENV_VAR   = "DOCKER_INFLUXDB_INIT_ORG"
+			INFLUXDB_TOKEN_ENV_VAR = "DOCKER_INFLUXDB_INIT_ADMIN_TOKEN"
+		)
+
+		// Image creates organization and token of the admin user by the initial setup
+		org, ok := tc.EnvVars[INFLUXDB_ORG_ENV_VAR]
+		if !ok {
+			err := domain.NewRequiredEnvVarError(INFLUXDB_ORG_ENV_VAR)
+			logrus.WithError(err).Error("couldn't create time series repository")
+			return nil, err
+		}
+		token, ok := tc.EnvVars[INFLUXDB_TOKEN_ENV_VAR]
+		if !ok {
+			err := domain.NewRequiredEnvVarError(INFLUXDB_TOKEN_ENV_VAR)
+			logrus.WithError(err).Error("couldn't create time series repository")
+			return nil, err
+		}
+
+		return repository.NewInfluxDBTimeSeriesTesterRepository(tc.Port, "localhost", token, org), nil
+	case domain.ComponentType_QuestDB:
+		// Port is the PG wire one, ILP port should be published by the extra ports
+		const QUESTDB_ILP_PORT = 9009
+
+		// Image has the default admin user
+		user := tc.User
+		password := tc.Password
+		if user == "" {
+			user = "admin"
+			password = "quest"
+		}
+
+		return repository.NewQuestDBTimeSeriesTesterRepository(tc.Port, QUESTDB_ILP_PORT, "localhost", user, password, int(tc.GetConcurrency()))
+	default:
+		return nil, domain.NewUnknownComponentError(tc.ComponentType)
+	}
+}
+
+// Method writes points into the new bucket, queries their range by the query languages of the store and deletes the bucket.
+// Steps are named like the table steps of the database tester.
+func (tsuc *timeSeriesTesterUsecase) testBucket(mcuc metrics_collector.MetricsCollectorUsecase, r repository.TimeSeriesTesterRepository, concurrency int, pointsCount int) error {
+	testPrefix := strconv.FormatInt(int64(pointsCount), 10) + "x"
+
+	step := &domain.TestCaseStep{Name: "createBucket", StepFunc: func() error { return r.CreateBucket(BUCKET_NAME) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	// Tables of the SQL stores are addressed by the measurement of the lines
+	sqlr, isSql := r.(repository.SQLTimeSeriesTesterRepository)
+	measurement := MEASUREMENT_NAME
+	if isSql {
+		measurement = BUCKET_NAME
+	}
+
+	endTime := time.Now().Truncate(QUERY_WINDOW)
+	startTime := endTime.Add(-time.Duration(pointsCount) * POINTS_INTERVAL)
+	lines := tsuc.generateLines(measurement, startTime, pointsCount)
+
+	if isSql {
+		step = tsuc.createSQLWriteStep(testPrefix+"WriteEmptyBucket", r, sqlr, lines, concurrency)
+	} else {
+		step = tsuc.createWriteStep(testPrefix+"WriteEmptyBucket", r, lines, concurrency)
+	}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		r.DeleteBucket(BUCKET_NAME)
+		return err
+	}
+
+	if ir, ok := r.(repository.InfluxTimeSeriesTesterRepository); ok {
+		tsuc.testInfluxQueries(mcuc, ir, concurrency, testPrefix, startTime, endTime)
+	}
+
+	if isSql {
+		sqlQuery := "SELECT timestamp, avg(f2) FROM " + BUCKET_NAME +
+			" WHERE timestamp >= '" + startTime.UTC().Format(SQL_TIMESTAMP_LAYOUT) + "' AND timestamp < '" + endTime.UTC().Format(SQL_TIMESTAMP_LAYOUT) + "'" +
+			" SAMPLE BY " + strconv.FormatInt(int64(QUERY_WINDOW/time.Second), 10) + "s"
+		step = helpers.NewQueryStep("sqlRangeQuery"+testPrefix+"Bucket", QUERY_REQUESTS, concurrency, func() error { return sqlr.QuerySQL(sqlQuery) })
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).Warn("SQL range query failed")
+		}
+
+		step = &domain.TestCaseStep{Name: "truncate" + testPrefix + "Bucket", StepFunc: func() error { return sqlr.TruncateBucket(BUCKET_NAME) }}
+		if err := mcuc.CollectStepMetrics(step); err != nil {
+			logrus.WithError(err).Warn("couldn't truncate bucket")
+		}
+	}
+
+	step = &domain.TestCaseStep{Name: "delete" + testPrefix + "Bucket", StepFunc: func() error { return r.DeleteBucket(BUCKET_NAME) }}
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Method queries range of the points by Flux and InfluxQL
+func (tsuc *timeSeriesTesterUsecase) testInfluxQueries(mcuc metrics_collector.MetricsCollectorUsecase, ir repository.InfluxTimeSeriesTesterRepository, concurrency int, testPrefix string, startTime time.Time, endTime time.Time) {
+	fluxQuery := `from(bucket: "` + BUCKET_NAME + `")` +
+		` |> range(start: ` + startTime.Format(time.RFC3339Nano) + `, stop: ` + endTime.Format(time.RFC3339Nano) + `)` +
+		` |> filter(fn: (r) => r._measurement == "` + MEASUREMENT_NAME + `" and r._field == "f2")` +
+		` |> aggregateWindow(every: ` + QUERY_WINDOW.String() + `, fn: mean)`
+	step := helpers.NewQueryStep("fluxRangeQuery"+testPrefix+"Bucket", QUERY_REQUESTS, concurrency, func() error { return ir.QueryFlux(fluxQuery) })
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("flux range query failed")
+	}
+
+	influxQLQuery := "SELECT MEAN(f2) FROM " + MEASUREMENT_NAME +
+		" WHERE time >= '" + startTime.Format(time.RFC3339Nano) + "' AND time < '" + endTime.Format(time.RFC3339Nano) + "'" +
+		" GROUP BY time(" + QUERY_WINDOW.String() + ")"
+	step = helpers.NewQueryStep("influxQLRangeQuery"+testPrefix+"Bucket", QUERY_REQUESTS, concurrency, func() error { return ir.QueryInfluxQL(BUCKET_NAME, influxQLQuery) })
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("InfluxQL range query failed")
+	}
+}
+
+// Method generates line protocol points of the series with POINTS_INTERVAL interval starting at startTime
+func (tsuc *timeSeriesTesterUsecase) generateLines(measurement string, startTime time.Time, pointsCount int) []string {
+	lines := make([]string, pointsCount)
+	for i := range lines {
+		var sb strings.Builder
+		sb.WriteString(measurement)
+		sb.WriteString(",series=")
+		sb.WriteString(strconv.Itoa(i % SERIES_COUNT))
+		sb.WriteString(" f1=")
+		sb.WriteString(strconv.FormatInt(rand.Int63(), 10))
+		sb.WriteString("i,f2=")
+		sb.WriteString(strconv.FormatFloat(rand.Float64(), 'f', -1, 64))
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.FormatInt(startTime.Add(time.Duration(i)*POINTS_INTERVAL).UnixNano(), 10))
+		lines[i] = sb.String()
+	}
+	return lines
+}
+
+// Method creates step writing lines by batches concurrently
+func (tsuc *timeSeriesTesterUsecase) createWriteStep(name string, r repository.TimeSeriesTesterRepository, lines []string, concurrency int) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+	batches := (len(lines) + WRITE_BATCH_SIZE - 1) / WRITE_BATCH_SIZE
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		var nextBatch int64 = -1
+		lr = helpers.RunLoad(batches, concurrency, func() error {
+			from := int(atomic.AddInt64(&nextBatch, 1)) * WRITE_BATCH_SIZE
+			to := from + WRITE_BATCH_SIZE
+			if to > len(lines) {
+				to = len(lines)
+			}
+			return r.WriteLines(BUCKET_NAME, lines[from:to])
+		})
+		if lr.Errors != 0 {
+			return domain.UNEXPECTED_RESPONSE_STATUS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_IngestionRate, float64(len(lines))/lr.Duration.Seconds())
+		tcsra.AddLatencyMetrics(lr.Latencies)
+	}}
+}
+
+// Method creates step writing lines by batches concurrently and awaiting the rows visible to the queries,
+// because SQL stores commit rows of the line protocol asynchronously. Load rate is reported in rows per second.
+func (tsuc *timeSeriesTesterUsecase) createSQLWriteStep(name string, r repository.TimeSeriesTesterRepository, sqlr repository.SQLTimeSeriesTesterRepository, lines []string, concurrency int) *domain.TestCaseStep {
+	var duration time.Duration
+	writeStep := tsuc.createWriteStep(name, r, lines, concurrency)
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		startTime := time.Now()
+		if err := writeStep.StepFunc(); err != nil {
+			return err
+		}
+
+		for {
+			count, err := sqlr.CountRows(BUCKET_NAME)
+			if err != nil {
+				return err
+			}
+			if count >= int64(len(lines)) {
+				break
+			}
+			if time.Since(startTime) > COMMIT_TIMEOUT {
+				return domain.DATA_INTEGRITY_VIOLATED
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		duration = time.Since(startTime)
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_LoadRate, float64(len(lines))/duration.Seconds())
+		writeStep.MetricsFunc(tcsra)
+	}}
+}
@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/snappy"
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	REQUEST_TIMEOUT = 30 * time.Second
+	// Samples are written with 1s interval, so series cover last minute
+	SAMPLES_PER_SERIES = 60
+	// Series count in one remote write request
+	REMOTE_WRITE_BATCH_SIZE = 500
+	QUERY_REQUESTS          = 200
+	METRIC_NAME             = "cott_test_metric"
+)
+
+var seriesCardinalities = []int{100, 1000, 10000, 100000}
+
+type TsdbTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type tsdbTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewTsdbTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) TsdbTesterUsecase {
+	ttuc := new(tsdbTesterUsecase)
+	ttuc.cluc = cluc
+	return ttuc
+}
+
+func (ttuc *tsdbTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	baseUrl := "http://localhost:" + strconv.FormatUint(uint64(tcra.TestCase.Port), 10)
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, ttuc.cluc, containerId)
+	client := &http.Client{Timeout: REQUEST_TIMEOUT}
+
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return ttuc.awaitBackendReady(client, baseUrl+tcra.TestCase.HealthCheckPath, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	// Container is reused between accumulations, so every run writes its own series
+	// to avoid out of order samples rejection
+	run := strconv.FormatInt(time.Now().UnixNano(), 10)
+	writeUrl := baseUrl + tcra.TestCase.GetRemoteWritePath()
+	queryRangeUrl := baseUrl + tcra.TestCase.GetQueryRangePath()
+	concurrency := int(tcra.TestCase.GetConcurrency())
+
+	var lastCardinality int
+	for _, cardinality := range seriesCardinalities {
+		cardinalityPrefix := strconv.FormatInt(int64(cardinality), 10) + "Series"
+		endTime := time.Now()
+
+		if err := mcuc.CollectStepMetrics(ttuc.createRemoteWriteStep(cardinalityPrefix+"RemoteWrite", client, writeUrl, run, cardinality, endTime, concurrency)); err != nil {
+			logrus.WithError(err).WithField("cardinality", cardinality).Warn("remote write failed")
+			break
+		}
+		lastCardinality = cardinality
+
+		query := ttuc.createQuery(run, cardinality)
+		if err := mcuc.CollectStepMetrics(ttuc.createQueryRangeStep(cardinalityPrefix+"QueryRange", client, queryRangeUrl, query, endTime, concurrency)); err != nil {
+			logrus.WithError(err).WithField("cardinality", cardinality).Warn("query range failed")
+		}
+	}
+
+	if tcra.TestCase.CompactionPath != "" && lastCardinality != 0 {
+		ttuc.testCompactionImpact(mcuc, client, baseUrl+tcra.TestCase.CompactionPath, queryRangeUrl, ttuc.createQuery(run, lastCardinality), concurrency)
+	}
+
+	return nil
+}
+
+// Method polls health check URL until backend responds with 2xx
+func (ttuc *tsdbTesterUsecase) awaitBackendReady(client *http.Client, url string, timeout time.Duration) error {
+	return helpers.AwaitConnection(func() error {
+		resp, err := client.Get(url)
+		if err != nil {
+			return err
+		}
+		ttuc.drainBody(resp.Body)
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return domain.UNEXPECTED_RESPONSE_STATUS
+		}
+		return nil
+	}, timeout)
+}
+
+// Method creates step writing cardinality series with SAMPLES_PER_SERIES samples ending at endTime
+func (ttuc *tsdbTesterUsecase) createRemoteWriteStep(name string, client *http.Client, writeUrl string, run string, cardinality int, endTime time.Time, concurrency int) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+	cardinalityLabel := strconv.FormatInt(int64(cardinality), 10)
+	batches := (cardinality + REMOTE_WRITE_BATCH_SIZE - 1) / REMOTE_WRITE_BATCH_SIZE
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		var nextBatch int64 = -1
+		lr = helpers.RunLoad(batches, concurrency, func() error {
+			batch := int(atomic.AddInt64(&nextBatch, 1))
+
+			series := make([]timeSeries, 0, REMOTE_WRITE_BATCH_SIZE)
+			for i := batch * REMOTE_WRITE_BATCH_SIZE; i < cardinality && i < (batch+1)*REMOTE_WRITE_BATCH_SIZE; i++ {
+				series = append(series, timeSeries{
+					Labels: []label{
+						{Name: "__name__", Value: METRIC_NAME},
+						{Name: "cardinality", Value: cardinalityLabel},
+						{Name: "run", Value: run},
+						{Name: "series", Value: strconv.FormatInt(int64(i), 10)},
+					},
+					Samples: ttuc.generateSamples(endTime),
+				})
+			}
+
+			return ttuc.remoteWrite(client, writeUrl, series)
+		})
+		if lr.Errors != 0 {
+			return domain.UNEXPECTED_RESPONSE_STATUS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_IngestionRate, float64(cardinality*SAMPLES_PER_SERIES)/lr.Duration.Seconds())
+		tcsra.AddLatencyMetrics(lr.Latencies)
+	}}
+}
+
+func (ttuc *tsdbTesterUsecase) generateSamples(endTime time.Time) []sample {
+	samples := make([]sample, SAMPLES_PER_SERIES)
+	for i := range samples {
+		samples[i] = sample{
+			Value:     float64(i),
+			Timestamp: endTime.Add(time.Duration(i-SAMPLES_PER_SERIES+1)*time.Second).UnixNano() / int64(time.Millisecond),
+		}
+	}
+	return samples
+}
+
+func (ttuc *tsdbTesterUsecase) remoteWrite(client *http.Client, writeUrl string, series []timeSeries) error {
+	req, err := http.NewRequest(http.MethodPost, writeUrl, bytes.NewReader(snappy.Encode(nil, encodeWriteRequest(series))))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	ttuc.drainBody(resp.Body)
+
+	if resp.StatusCode >= 300 {
+		logrus.WithField("status", resp.StatusCode).Debug("remote write rejected")
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	return nil
+}
+
+// Method creates query aggregating all series of the cardinality, so query touches every series
+func (ttuc *tsdbTesterUsecase) createQuery(run string, cardinality int) string {
+	return "sum(rate(" + METRIC_NAME + `{run="` + run + `",cardinality="` + strconv.FormatInt(int64(cardinality), 10) + `"}[30s]))`
+}
+
+// Method creates step querying range of the written samples concurrently
+func (ttuc *tsdbTesterUsecase) createQueryRangeStep(name string, client *http.Client, queryRangeUrl string, query string, endTime time.Time, concurrency int) *domain.TestCaseStep {
+	var lr *helpers.LoadResult
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(endTime.Add(-SAMPLES_PER_SERIES*time.Second).Unix(), 10))
+	params.Set("end", strconv.FormatInt(endTime.Unix(), 10))
+	params.Set("step", "1")
+	fullUrl := queryRangeUrl + "?" + params.Encode()
+
+	return &domain.TestCaseStep{Name: name, StepFunc: func() error {
+		lr = helpers.RunLoad(QUERY_REQUESTS, concurrency, func() error {
+			return ttuc.queryRange(client, fullUrl)
+		})
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+}
+
+func (ttuc *tsdbTesterUsecase) queryRange(client *http.Client, fullUrl string) error {
+	resp, err := client.Get(fullUrl)
+	if err != nil {
+		return err
+	}
+	ttuc.drainBody(resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	return nil
+}
+
+// Method triggers storage compaction and measures query latency while it's running
+func (ttuc *tsdbTesterUsecase) testCompactionImpact(mcuc metrics_collector.MetricsCollectorUsecase, client *http.Client, compactionUrl string, queryRangeUrl string, query string, concurrency int) {
+	queryStep := ttuc.createQueryRangeStep("queryRangeDuringCompaction", client, queryRangeUrl, query, time.Now(), concurrency)
+	queryStepFunc := queryStep.StepFunc
+
+	step := &domain.TestCaseStep{Name: "compaction", StepFunc: func() error {
+		return ttuc.compact(client, compactionUrl)
+	}}
+
+	// Compaction is run in background of the query step
+	queryStep.StepFunc = func() error {
+		var (
+			wg            sync.WaitGroup
+			compactionErr error
+		)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			compactionErr = mcuc.CollectStepMetrics(step)
+		}()
+
+		err := queryStepFunc()
+		wg.Wait()
+
+		if err != nil {
+			return err
+		}
+		return compactionErr
+	}
+
+	if err := mcuc.CollectStepMetrics(queryStep); err != nil {
+		logrus.WithError(err).Warn("compaction impact test failed")
+	}
+}
+
+func (ttuc *tsdbTesterUsecase) compact(client *http.Client, compactionUrl string) error {
+	resp, err := client.Post(compactionUrl, "", nil)
+	if err != nil {
+		return err
+	}
+	ttuc.drainBody(resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	return nil
+}
+
+// Body must be read till EOF to reuse keep alive connection
+func (ttuc *tsdbTesterUsecase) drainBody(body io.ReadCloser) {
+	if _, err := io.Copy(ioutil.Discard, body); err != nil {
+		logrus.WithError(err).Trace("couldn't drain response body")
+	}
+	body.Close()
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	REQUEST_TIMEOUT = 60 * time.Second
+	// Time for HNSW index to be built by optimizers
+	INDEX_BUILD_TIMEOUT = 10 * time.Minute
+	// Segments with more vectors are indexed, so small collections are indexed too
+	QDRANT_INDEXING_THRESHOLD = 1000
+	QDRANT_STATUS_GREEN       = "green"
+)
+
+type qdrantVectorTesterRepository struct {
+	client  *http.Client
+	baseUrl string
+}
+
+func NewQdrantVectorTesterRepository(port uint16, host string) VectorTesterRepository {
+	r := new(qdrantVectorTesterRepository)
+	r.client = &http.Client{Timeout: REQUEST_TIMEOUT}
+	r.baseUrl = "http://" + host + ":" + strconv.FormatUint(uint64(port), 10)
+	return r
+}
+
+type qdrantPoint struct {
+	Id     int       `json:"id"`
+	Vector []float32 `json:"vector"`
+}
+
+type qdrantScoredPoint struct {
+	Id    int     `json:"id"`
+	Score float64 `json:"score"`
+}
+
+type qdrantCollectionInfo struct {
+	Status string `json:"status"`
+}
+
+func (r *qdrantVectorTesterRepository) Ping() error {
+	return r.do(http.MethodGet, "/collections", nil, nil)
+}
+
+func (r *qdrantVectorTesterRepository) CreateCollection(name string, dimension uint16) error {
+	return r.do(http.MethodPut, "/collections/"+name, map[string]interface{}{
+		"vector_size": dimension,
+		"distance":    "Cosine",
+		"optimizers_config": map[string]interface{}{
+			"indexing_threshold": QDRANT_INDEXING_THRESHOLD,
+		},
+	}, nil)
+}
+
+func (r *qdrantVectorTesterRepository) Upsert(name string, ids []int, vectors [][]float32) error {
+	points := make([]qdrantPoint, len(ids))
+	for i := range ids {
+		points[i] = qdrantPoint{Id: ids[i], Vector: vectors[i]}
+	}
+
+	return r.do(http.MethodPut, "/collections/"+name+"/points?wait=true", map[string]interface{}{"points": points}, nil)
+}
+
+// Qdrant builds HNSW index in background, so method waits for optimizers to finish
+func (r *qdrantVectorTesterRepository) BuildIndex(name string) error {
+	startTime := time.Now()
+	for time.Since(startTime) < INDEX_BUILD_TIMEOUT {
+		var info qdrantCollectionInfo
+		if err := r.do(http.MethodGet, "/collections/"+name, nil, &info); err != nil {
+			return err
+		}
+		if info.Status == QDRANT_STATUS_GREEN {
+			return nil
+		}
+		logrus.WithField("status", info.Status).Trace("collection is being optimized")
+		time.Sleep(100 * time.Millisecond)
+	}
+	return domain.VECTOR_INDEX_BUILD_TIMEOUT
+}
+
+func (r *qdrantVectorTesterRepository) Search(name string, vector []float32, k int) ([]int, error) {
+	var points []qdrantScoredPoint
+	if err := r.do(http.MethodPost, "/collections/"+name+"/points/search", map[string]interface{}{
+		"vector": vector,
+		"top":    k,
+	}, &points); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(points))
+	for i, p := range points {
+		ids[i] = p.Id
+	}
+	return ids, nil
+}
+
+func (r *qdrantVectorTesterRepository) DropCollection(name string) error {
+	return r.do(http.MethodDelete, "/collections/"+name, nil, nil)
+}
+
+// Method sends JSON request and decodes "result" field of the response into result if it's not nil
+func (r *qdrantVectorTesterRepository) do(method string, path string, body interface{}, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, r.baseUrl+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		logrus.WithFields(logrus.Fields{"status": resp.StatusCode, "body": string(respBody)}).Debug("qdrant request failed")
+		return domain.UNEXPECTED_RESPONSE_STATUS
+	}
+
+	if result == nil {
+		_, err := io.Copy(ioutil.Discard, resp.Body)
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(&struct {
+		Result interface{} `json:"result"`
+	}{Result: result})
+}
@@ -0,0 +1,13 @@
+package repository
+
+type VectorTesterRepository interface {
+	Ping() error
+	// CreateCollection creates collection of the cosine distance vectors of the dimension
+	CreateCollection(name string, dimension uint16) error
+	Upsert(name string, ids []int, vectors [][]float32) error
+	// BuildIndex builds ANN index and waits until it's ready
+	BuildIndex(name string) error
+	// Search returns IDs of the k nearest vectors
+	Search(name string, vector []float32, k int) ([]int, error)
+	DropCollection(name string) error
+}
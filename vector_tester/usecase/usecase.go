@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/iakrevetkho/components-tests/cott/vector_tester/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	COLLECTION_NAME   = "cott_vectors"
+	UPSERT_BATCH_SIZE = 500
+	SEARCH_QUERIES    = 200
+	// Points count of the first collection of the sweep
+	MIN_VECTORS_COUNT = 1000
+)
+
+type VectorTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type vectorTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewVectorTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) VectorTesterUsecase {
+	vtuc := new(vectorTesterUsecase)
+	vtuc.cluc = cluc
+	return vtuc
+}
+
+func (vtuc *vectorTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	r, err := vtuc.createVectorRepository(tcra.TestCase)
+	if err != nil {
+		return err
+	}
+
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, vtuc.cluc, containerId)
+
+	// Await for database ready
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return helpers.AwaitConnection(r.Ping, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	// Collection could be left by the failed run
+	if err := r.DropCollection(COLLECTION_NAME); err != nil {
+		logrus.WithError(err).Debug("couldn't drop collection")
+	}
+
+	dimension := tcra.TestCase.GetVectorDimension()
+	for i := MIN_VECTORS_COUNT; i <= int(tcra.TestCase.GetVectorsCount()); i *= 10 {
+		vectors := helpers.GenerateVectors(i, int(dimension))
+		if err := vtuc.testCollection(mcuc, r, tcra.TestCase, vectors); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (vtuc *vectorTesterUsecase) createVectorRepository(tc *domain.TestCase) (repository.VectorTesterRepository, error) {
+	switch tc.ComponentType {
+	case domain.ComponentType_Qdrant:
+		return repository.NewQdrantVectorTesterRepository(tc.Port, "localhost"), nil
+	default:
+		return nil, domain.NewUnknownComponentError(tc.ComponentType)
+	}
+}
+
+// Method creates collection, upserts vectors by batches, builds index, measures ANN search latency and recall against exact search
+// and drops the collection. Steps are named like the table steps of the database tester.
+func (vtuc *vectorTesterUsecase) testCollection(mcuc metrics_collector.MetricsCollectorUsecase, r repository.VectorTesterRepository, tc *domain.TestCase, vectors [][]float32) error {
+	concurrency := int(tc.GetConcurrency())
+	testPrefix := strconv.FormatInt(int64(len(vectors)), 10) + "x"
+	dimension := tc.GetVectorDimension()
+
+	step := &domain.TestCaseStep{Name: "createCollection", StepFunc: func() error { return r.CreateCollection(COLLECTION_NAME, dimension) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+
+	var lr *helpers.LoadResult
+	batches := (len(vectors) + UPSERT_BATCH_SIZE - 1) / UPSERT_BATCH_SIZE
+	step = &domain.TestCaseStep{Name: testPrefix + "Upsert", StepFunc: func() error {
+		var nextBatch int64 = -1
+		lr = helpers.RunLoad(batches, concurrency, func() error {
+			from := int(atomic.AddInt64(&nextBatch, 1)) * UPSERT_BATCH_SIZE
+			to := from + UPSERT_BATCH_SIZE
+			if to > len(vectors) {
+				to = len(vectors)
+			}
+
+			ids := make([]int, 0, to-from)
+			for i := from; i < to; i++ {
+				ids = append(ids, i)
+			}
+			return r.Upsert(COLLECTION_NAME, ids, vectors[from:to])
+		})
+		if lr.Errors != 0 {
+			return domain.UNEXPECTED_RESPONSE_STATUS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddMetric(domain.MetricMeta_Throughput, float64(len(vectors))/lr.Duration.Seconds())
+		tcsra.AddLatencyMetrics(lr.Latencies)
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		r.DropCollection(COLLECTION_NAME)
+		return err
+	}
+
+	step = &domain.TestCaseStep{Name: "buildIndex" + testPrefix + "Collection", StepFunc: func() error { return r.BuildIndex(COLLECTION_NAME) }}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		r.DropCollection(COLLECTION_NAME)
+		return err
+	}
+
+	if err := mcuc.CollectStepMetrics(vtuc.createSearchStep(r, testPrefix, vectors, int(tc.GetTopK()), concurrency)); err != nil {
+		logrus.WithError(err).Warn("vector search failed")
+	}
+
+	step = &domain.TestCaseStep{Name: "drop" + testPrefix + "Collection", StepFunc: func() error { return r.DropCollection(COLLECTION_NAME) }}
+	return mcuc.CollectStepMetrics(step)
+}
+
+// Method creates step running ANN queries concurrently and reporting latency and mean recall
+func (vtuc *vectorTesterUsecase) createSearchStep(r repository.VectorTesterRepository, testPrefix string, vectors [][]float32, k int, concurrency int) *domain.TestCaseStep {
+	queries := helpers.GenerateVectors(SEARCH_QUERIES, len(vectors[0]))
+
+	// Exact results are calculated before the step not to affect its duration
+	expected := make([][]int, len(queries))
+	for i, q := range queries {
+		expected[i] = helpers.ExactTopK(vectors, q, k)
+	}
+
+	var (
+		lr     *helpers.LoadResult
+		mu     sync.Mutex
+		recall float64
+		found  int
+	)
+
+	return &domain.TestCaseStep{Name: "top" + strconv.FormatInt(int64(k), 10) + "Search" + testPrefix + "Collection", StepFunc: func() error {
+		var nextQuery int64 = -1
+		lr = helpers.RunLoad(len(queries), concurrency, func() error {
+			i := int(atomic.AddInt64(&nextQuery, 1))
+			ids, err := r.Search(COLLECTION_NAME, queries[i], k)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			recall += helpers.Recall(expected[i], ids)
+			found++
+			mu.Unlock()
+			return nil
+		})
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+		tcsra.AddMetric(domain.MetricMeta_Recall, 100*recall/float64(found))
+	}}
+}
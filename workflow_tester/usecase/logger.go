@@ -0,0 +1,32 @@
+package usecase
+
+import "github.com/sirupsen/logrus"
+
+// SDK logs every worker state change with info level, so it's lowered to debug
+type temporalLogger struct{}
+
+func (l *temporalLogger) Debug(msg string, keyvals ...interface{}) {
+	l.entry(keyvals).Trace(msg)
+}
+
+func (l *temporalLogger) Info(msg string, keyvals ...interface{}) {
+	l.entry(keyvals).Debug(msg)
+}
+
+func (l *temporalLogger) Warn(msg string, keyvals ...interface{}) {
+	l.entry(keyvals).Warn(msg)
+}
+
+func (l *temporalLogger) Error(msg string, keyvals ...interface{}) {
+	l.entry(keyvals).Error(msg)
+}
+
+func (l *temporalLogger) entry(keyvals []interface{}) *logrus.Entry {
+	fields := logrus.Fields{}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if k, ok := keyvals[i].(string); ok {
+			fields[k] = keyvals[i+1]
+		}
+	}
+	return logrus.WithFields(fields)
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	container_launcher "github.com/iakrevetkho/components-tests/cott/container_launcher/usecase"
+	"github.com/iakrevetkho/components-tests/cott/domain"
+	"github.com/iakrevetkho/components-tests/cott/internal/helpers"
+	metrics_collector "github.com/iakrevetkho/components-tests/cott/metrics_collector/usecase"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+)
+
+const (
+	REQUEST_TIMEOUT = 30 * time.Second
+	NAMESPACE       = "default"
+	TASK_QUEUE      = "cott"
+	WORKFLOWS       = 500
+	// Sequential activities in workflow for the activity round trip measurement
+	ACTIVITIES_PER_WORKFLOW = 20
+	ACTIVITY_WORKFLOWS      = 20
+)
+
+type WorkflowTesterUsecase interface {
+	RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error
+}
+
+type workflowTesterUsecase struct {
+	cluc container_launcher.ContainerLauncherUsecase
+}
+
+func NewWorkflowTesterUsecase(cluc container_launcher.ContainerLauncherUsecase) WorkflowTesterUsecase {
+	wtuc := new(workflowTesterUsecase)
+	wtuc.cluc = cluc
+	return wtuc
+}
+
+func (wtuc *workflowTesterUsecase) RunCase(tcra *domain.TestCaseResultsAccumulator, containerId string) error {
+	logrus.WithField("testCase", *tcra.TestCase).Debug("run test case")
+
+	options := client.Options{
+		HostPort:  "localhost:" + strconv.FormatUint(uint64(tcra.TestCase.Port), 10),
+		Namespace: NAMESPACE,
+		Logger:    new(temporalLogger),
+	}
+	mcuc := metrics_collector.NewMetricsCollectorUsecase(tcra, wtuc.cluc, containerId)
+
+	// Await for server and namespace ready
+	step := &domain.TestCaseStep{Name: "startUp", StepFunc: func() error {
+		return helpers.AwaitConnection(func() error { return wtuc.checkNamespace(options) }, tcra.TestCase.GetStartupTimeout())
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return nil
+	}
+
+	c, err := client.NewClient(options)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	w := worker.New(c, TASK_QUEUE, worker.Options{})
+	w.RegisterWorkflow(cottWorkflow)
+	w.RegisterActivity(cottActivity)
+
+	step = &domain.TestCaseStep{Name: "startWorker", StepFunc: w.Start}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	// Workflow IDs must be unique within namespace
+	run := strconv.FormatInt(time.Now().UnixNano(), 10)
+	concurrency := int(tcra.TestCase.GetConcurrency())
+
+	wtuc.testWorkflowStart(mcuc, c, run, concurrency)
+	wtuc.testWorkflowRoundTrip(mcuc, c, run, concurrency)
+	wtuc.testActivityRoundTrip(mcuc, c, run)
+
+	return nil
+}
+
+func (wtuc *workflowTesterUsecase) checkNamespace(options client.Options) error {
+	nc, err := client.NewNamespaceClient(options)
+	if err != nil {
+		return err
+	}
+	defer nc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+	defer cancel()
+
+	_, err = nc.Describe(ctx, NAMESPACE)
+	return err
+}
+
+func (wtuc *workflowTesterUsecase) executeWorkflow(c client.Client, id string, activities int) (client.WorkflowRun, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+	defer cancel()
+
+	return c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{ID: id, TaskQueue: TASK_QUEUE}, cottWorkflow, activities)
+}
+
+func (wtuc *workflowTesterUsecase) awaitWorkflow(wr client.WorkflowRun) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+	defer cancel()
+
+	var times []int64
+	err := wr.Get(ctx, &times)
+	return times, err
+}
+
+// Method measures latency of the workflow start request only and awaits started workflows in the separate step
+func (wtuc *workflowTesterUsecase) testWorkflowStart(mcuc metrics_collector.MetricsCollectorUsecase, c client.Client, run string, concurrency int) {
+	var (
+		lr   *helpers.LoadResult
+		mu   sync.Mutex
+		runs []client.WorkflowRun
+	)
+
+	step := &domain.TestCaseStep{Name: strconv.FormatInt(WORKFLOWS, 10) + "xStartWorkflow", StepFunc: func() error {
+		var next int64 = -1
+		lr = helpers.RunLoad(WORKFLOWS, concurrency, func() error {
+			wr, err := wtuc.executeWorkflow(c, "cott-start-"+run+"-"+strconv.FormatInt(atomic.AddInt64(&next, 1), 10), 1)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			runs = append(runs, wr)
+			mu.Unlock()
+			return nil
+		})
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("workflows start failed")
+	}
+
+	step = &domain.TestCaseStep{Name: "awaitStartedWorkflows", StepFunc: func() error {
+		for _, wr := range runs {
+			if _, err := wtuc.awaitWorkflow(wr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("started workflows weren't completed")
+	}
+}
+
+// Method measures latency from the workflow start till its result with single activity and completed workflows per second
+func (wtuc *workflowTesterUsecase) testWorkflowRoundTrip(mcuc metrics_collector.MetricsCollectorUsecase, c client.Client, run string, concurrency int) {
+	var lr *helpers.LoadResult
+
+	step := &domain.TestCaseStep{Name: strconv.FormatInt(WORKFLOWS, 10) + "xWorkflowRoundTrip", StepFunc: func() error {
+		var next int64 = -1
+		lr = helpers.RunLoad(WORKFLOWS, concurrency, func() error {
+			wr, err := wtuc.executeWorkflow(c, "cott-round-trip-"+run+"-"+strconv.FormatInt(atomic.AddInt64(&next, 1), 10), 1)
+			if err != nil {
+				return err
+			}
+			_, err = wtuc.awaitWorkflow(wr)
+			return err
+		})
+		if len(lr.Latencies) == 0 {
+			return domain.NO_SUCCESSFUL_REQUESTS
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(lr.Latencies)
+		tcsra.AddMetric(domain.MetricMeta_Throughput, lr.Throughput())
+		tcsra.AddMetric(domain.MetricMeta_Errors, float64(lr.Errors))
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("workflows round trip failed")
+	}
+}
+
+// Method measures time between sequential activities executions,
+// i.e. activity completion, workflow task and next activity scheduling
+func (wtuc *workflowTesterUsecase) testActivityRoundTrip(mcuc metrics_collector.MetricsCollectorUsecase, c client.Client, run string) {
+	var latencies []float64
+
+	step := &domain.TestCaseStep{Name: "activityRoundTrip", StepFunc: func() error {
+		for i := 0; i < ACTIVITY_WORKFLOWS; i++ {
+			wr, err := wtuc.executeWorkflow(c, "cott-activities-"+run+"-"+strconv.FormatInt(int64(i), 10), ACTIVITIES_PER_WORKFLOW)
+			if err != nil {
+				return err
+			}
+			times, err := wtuc.awaitWorkflow(wr)
+			if err != nil {
+				return err
+			}
+
+			for j := 1; j < len(times); j++ {
+				latencies = append(latencies, float64(time.Duration(times[j]-times[j-1]).Microseconds()))
+			}
+		}
+		return nil
+	}, MetricsFunc: func(tcsra *domain.TestCaseStepResultsAccumulator) {
+		tcsra.AddLatencyMetrics(latencies)
+	}}
+	if err := mcuc.CollectStepMetrics(step); err != nil {
+		logrus.WithError(err).Warn("activities round trip failed")
+	}
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+const ACTIVITY_TIMEOUT = 10 * time.Second
+
+// Workflow executes activities sequentially and returns their execution times.
+// Times are activity results, so they're recorded in the history and workflow stays deterministic.
+func cottWorkflow(ctx workflow.Context, activities int) ([]int64, error) {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: ACTIVITY_TIMEOUT})
+
+	times := make([]int64, 0, activities)
+	for i := 0; i < activities; i++ {
+		var t int64
+		if err := workflow.ExecuteActivity(ctx, cottActivity).Get(ctx, &t); err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+
+	return times, nil
+}
+
+// Activity returns its execution time in nanoseconds
+func cottActivity(ctx context.Context) (int64, error) {
+	return time.Now().UnixNano(), nil
+}